@@ -0,0 +1,9 @@
+// Package assets embeds the default catalog logos so they can be used whatever the working directory
+package assets
+
+import "embed"
+
+// FS holds the default catalog SVG logos, e.g. catalog-model.svg
+//
+//go:embed *.svg
+var FS embed.FS
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/report"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func main() {
@@ -15,6 +15,9 @@ func main() {
 		catalogPath = flag.String("catalog", "data/models-catalog.yaml", "Path to the models catalog YAML file")
 		outputDir   = flag.String("output-dir", "output", "Directory containing model extraction output")
 		reportDir   = flag.String("report-dir", "", "Directory to write reports (defaults to output-dir)")
+		format      = flag.String("format", report.FormatAll, "Report format: md, yaml, json, or all")
+		csvPath     = flag.String("csv", "", "Also write field completeness as CSV to this path")
+		compareTo   = flag.String("compare-to", "", "Compare -catalog against this older catalog and write catalog-diff.md/.yaml instead of the completeness report")
 		help        = flag.Bool("help", false, "Show help message")
 	)
 	flag.Parse()
@@ -24,10 +27,19 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Diff mode only needs the two catalogs
+	if *compareTo != "" {
+		runCompare(*compareTo, *catalogPath, *outputDir, *reportDir)
+		return
+	}
+
 	// Validate inputs
 	if err := validateInputs(*catalogPath, *outputDir); err != nil {
 		log.Fatalf("Error: %v", err)
 	}
+	if err := report.ValidateFormat(*format); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
 
 	// Set default report directory
 	if *reportDir == "" {
@@ -44,15 +56,44 @@ func main() {
 	fmt.Printf("  Catalog: %s\n", *catalogPath)
 	fmt.Printf("  Output dir: %s\n", *outputDir)
 	fmt.Printf("  Report dir: %s\n", *reportDir)
+	fmt.Printf("  Format: %s\n", *format)
 	fmt.Println()
 
-	if err := report.GenerateMetadataReport(*catalogPath, *outputDir, *reportDir); err != nil {
+	if err := report.GenerateMetadataReport(*catalogPath, *outputDir, *reportDir, report.ReportOptions{Format: *format, CSVPath: *csvPath}); err != nil {
 		log.Fatalf("Failed to generate report: %v", err)
 	}
 
 	fmt.Println("✅ Metadata report generation completed successfully!")
 }
 
+// runCompare writes a diff between an old and a new catalog snapshot
+func runCompare(oldCatalogPath, newCatalogPath, outputDir, reportDir string) {
+	for _, path := range []string{oldCatalogPath, newCatalogPath} {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			log.Fatalf("Error: catalog file does not exist: %s", path)
+		}
+	}
+
+	if reportDir == "" {
+		reportDir = outputDir
+	}
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		log.Fatalf("Failed to create report directory: %v", err)
+	}
+
+	fmt.Printf("Comparing catalogs...\n")
+	fmt.Printf("  Old catalog: %s\n", oldCatalogPath)
+	fmt.Printf("  New catalog: %s\n", newCatalogPath)
+	fmt.Printf("  Report dir: %s\n", reportDir)
+	fmt.Println()
+
+	if err := report.GenerateCatalogDiff(oldCatalogPath, newCatalogPath, reportDir); err != nil {
+		log.Fatalf("Failed to generate catalog diff: %v", err)
+	}
+
+	fmt.Println("✅ Catalog diff generation completed successfully!")
+}
+
 func printUsage() {
 	fmt.Println("Metadata Report Generator")
 	fmt.Println()
@@ -75,9 +116,20 @@ func printUsage() {
 	fmt.Println("  # Write reports to specific directory")
 	fmt.Println("  metadata-report -report-dir=reports")
 	fmt.Println()
+	fmt.Println("  # Only write the JSON report")
+	fmt.Println("  metadata-report -format=json")
+	fmt.Println()
+	fmt.Println("  # Also export field completeness for spreadsheets")
+	fmt.Println("  metadata-report -csv=reports/completeness.csv")
+	fmt.Println()
+	fmt.Println("  # Show what changed since a previous catalog snapshot")
+	fmt.Println("  metadata-report -compare-to=old-catalog.yaml -report-dir=reports")
+	fmt.Println()
 	fmt.Println("Output:")
 	fmt.Println("  - metadata-report.md  (Human-readable markdown report)")
 	fmt.Println("  - metadata-report.yaml (Machine-readable detailed data)")
+	fmt.Println("  - metadata-report.json (Machine-readable detailed data for dashboards)")
+	fmt.Println("  - catalog-diff.md / catalog-diff.yaml (with -compare-to)")
 }
 
 func validateInputs(catalogPath, outputDir string) error {
@@ -92,23 +144,11 @@ func validateInputs(catalogPath, outputDir string) error {
 	}
 
 	// Check if output directory contains model data
-	entries, err := os.ReadDir(outputDir)
+	modelDirs, err := utils.ModelOutputDirs(outputDir)
 	if err != nil {
 		return fmt.Errorf("cannot read output directory: %v", err)
 	}
-
-	hasModelData := false
-	for _, entry := range entries {
-		if entry.IsDir() {
-			modelsDir := filepath.Join(outputDir, entry.Name(), "models")
-			if _, err := os.Stat(modelsDir); err == nil {
-				hasModelData = true
-				break
-			}
-		}
-	}
-
-	if !hasModelData {
+	if len(modelDirs) == 0 {
 		return fmt.Errorf("output directory does not contain model extraction data: %s", outputDir)
 	}
 
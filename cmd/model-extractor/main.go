@@ -5,28 +5,40 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/image"
+	imgmanifest "github.com/containers/image/v5/manifest"
 	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
 	containertypes "github.com/containers/image/v5/types"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
 	"github.com/opendatahub-io/model-metadata-collection/internal/config"
 	"github.com/opendatahub-io/model-metadata-collection/internal/enrichment"
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/logging"
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
 	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
@@ -35,14 +47,53 @@ import (
 
 // Command line flags
 var (
-	modelsIndexPath          = flag.String("input", "data/models-index.yaml", "Path to models index YAML file")
+	configPath               = flag.String("config", "", "YAML file of run settings keyed by flag name; flags given on the command line override it")
+	modelsIndexPath          = flag.String("input", "data/models-index.yaml", "Path or http(s) URL of the models index YAML file")
+	modelSelection           = modelPatternsVar("models", "Only process index models whose reference matches this glob (matched against the full reference and its last path element) or, with a \"re:\" prefix, regular expression; repeat to select several (default: every model)")
+	indexTimeout             = flag.Duration("index-timeout", 30*time.Second, "Timeout for fetching the models index when --input is a URL")
+	registryTemplate         = flag.String("registry-template", config.DefaultRegistryTemplate, "Go template mapping HuggingFace model IDs from version index files to registry references (fields: .ID, .Org, .Name, .Version)")
 	inputDir                 = flag.String("input-dir", "input", "Base directory for supplemental input files (supplemental-catalog.yaml, models/vllm-config/)")
 	outputDir                = flag.String("output-dir", "output", "Output directory for extracted metadata")
 	catalogOutputPath        = flag.String("catalog-output", "data/models-catalog.yaml", "Path for the generated models catalog")
 	maxConcurrent            = flag.Int("max-concurrent", 5, "Maximum number of concurrent model processing jobs")
+	maxRetries               = flag.Int("max-retries", 3, "Maximum retries for transient registry errors (timeouts, dropped connections, 429, 5xx)")
+	metadataFormat           = flag.String("metadata-format", metadata.FormatYAML, "Per-model metadata output format: yaml, json, or both (metadata.yaml is always kept for later pipeline stages)")
+	platform                 = flag.String("platform", "linux/amd64", "Platform (os/arch[/variant]) to select when a model image is a multi-arch index")
+	maxModelcardBytes        = flag.Int64("max-modelcard-bytes", 10<<20, "Maximum size in bytes of a modelcard file read from an image layer; larger modelcards are skipped (0 disables the limit)")
+	fetchTimeout             = flag.Duration("fetch-timeout", 120*time.Second, "Maximum time allowed for fetching a single model image from the registry")
+	since                    = flag.String("since", "", "Only fully reprocess models whose image was created or updated at or after this RFC3339 time; older models reuse their existing metadata.yaml (default: process every model)")
+	modelcardExtensions      = flag.String("modelcard-extensions", strings.Join(defaultModelCardExtensions, ","), "Comma-separated file extensions recognized as the modelcard in a modelcard layer, most preferred first")
+	extractFiles             = flag.String("extract-files", "", "Comma-separated file names or globs (e.g. config.json,LICENSE) to extract from the modelcard layer next to modelcard.md; config.json also supplies the model architectures (default: only the modelcard)")
+	showProgress             = flag.Bool("progress", false, "Show completed/total models and a rough ETA while models are processed (only on a terminal, and not with --log-format=json)")
+	progressJSON             = flag.String("progress-json", "", "Stream one JSON object per completed model (ref, success, modelcard found, fields extracted, duration) to this file, or \"-\" for stdout")
+	force                    = flag.Bool("force", false, "Re-parse every modelcard, even when its sha256 matches the checksum stored by a previous run")
+	pinDigests               = flag.Bool("pin-digests", false, "Rewrite each model's primary artifact URI from its tag to the resolved manifest digest (@sha256:...); the digest is always recorded as a custom property")
 	skipHuggingFace          = flag.Bool("skip-huggingface", false, "Skip HuggingFace collection processing and enrichment")
 	skipEnrichment           = flag.Bool("skip-enrichment", false, "Skip metadata enrichment from HuggingFace")
+	writeAggregateEnrich     = flag.Bool("write-aggregate-enrichment", false, "Write data/enriched-model-metadata.yaml with every model's source-tracked enrichment instead of deleting it")
+	preferModelcardName      = flag.Bool("prefer-modelcard-name", false, "Keep modelcard model names, replacing them with HuggingFace names only when empty or low quality, even over higher-priority sources")
+	sourcePriority           = flag.String("source-priority", "", "Comma-separated enrichment sources, highest priority first, deciding which source wins each metadata field (default: "+strings.Join(enrichment.DefaultSourcePriority, ",")+")")
+	minEnrichmentRate        = flag.Float64("min-enrichment-rate", 0, "Fail the run when fewer than this percentage (0-100) of registry models are matched to HuggingFace during enrichment (0 never fails)")
+	maxConcurrentEnrich      = flag.Int("max-concurrent-enrich", enrichment.DefaultMaxConcurrent, "Maximum number of models enriched from HuggingFace in parallel (requests still share the API rate limit)")
+	matchThreshold           = flag.Float64("match-threshold", enrichment.DefaultMatchOptions().Threshold, "Minimum similarity score (0-1) for a HuggingFace match; raise it to reduce false-positive matches")
+	highConfidenceThreshold  = flag.Float64("high-confidence-threshold", enrichment.DefaultMatchOptions().HighConfidenceThreshold, "Similarity score (0-1) at or above which a HuggingFace match is recorded as high confidence (match_confidence in enrichment.yaml)")
+	ambiguityMargin          = flag.Float64("ambiguity-margin", enrichment.DefaultMatchOptions().AmbiguityMargin, "Minimum score lead over the second-best HuggingFace match; closer matches are low confidence and never override modelcard values (0 disables)")
+	hfMappingPath            = flag.String("hf-mapping", "", "Path to a YAML file mapping registry references to HuggingFace model IDs; mapped models skip fuzzy matching during enrichment")
+	allowTags                = flag.String("allow-tags", "", "Comma-separated HuggingFace tags to always keep, even ones the default filter drops (e.g. language codes)")
+	denyTags                 = flag.String("deny-tags", "", "Comma-separated tags to always drop from enriched tag lists (e.g. autotrain,endpoints_compatible)")
+	descriptionOverridesPath = flag.String("description-overrides", "", "Path to a YAML file mapping registry references or model names to curated descriptions that replace extracted, enriched and generated ones")
+	taskMapPath              = flag.String("task-map", "", "Path to a YAML file of task normalization mappings merged over the built-in defaults (e.g. embedding: feature-extraction)")
+	catalogOnly              = flag.Bool("catalog-only", false, "Only rebuild the models catalog from the metadata.yaml files already in --output-dir, skipping image fetching, HuggingFace and enrichment (MCP and agent catalogs are not generated)")
 	skipCatalog              = flag.Bool("skip-catalog", false, "Skip catalog generation")
+	includeLabels            = flag.String("include-label", "", "Comma-separated labels; only models with at least one of them are written to the catalog (default: all models)")
+	excludeLabels            = flag.String("exclude-label", "", "Comma-separated labels; models with any of them, including static catalog models, are left out of the catalog")
+	strictCatalog            = flag.Bool("strict", false, "Fail catalog generation when the generated catalog fails validation (by default problems are logged as warnings)")
+	verifyCatalog            = flag.Bool("verify", false, "After catalog generation, check every catalog model traces back to an output metadata.yaml (or static catalog) and every output appears in the catalog; fails on mismatches")
+	logoMapPath              = flag.String("logo-map", "", "YAML file mapping model tags to catalog logo SVGs, evaluated in order (defaults to validated and generic model logos)")
+	dedupStrategy            = flag.String("dedup-strategy", catalog.DedupByName, "How duplicate catalog models are detected: name (case-insensitive display name) or artifact (same image repositories, ignoring tags)")
+	catalogSort              = flag.String("catalog-sort", catalog.SortByName, "Catalog model order: name (ascending, static models last), created or updated (newest first), or downloads (most downloaded first)")
+	facetsOutputPath         = flag.String("facets-output", "", "Also write the distinct providers, tasks, languages and licenses of the models catalog, with model counts, to this YAML file (e.g. data/catalog-facets.yaml)")
+	splitByLabel             = flag.String("split-by-label", "", "Comma-separated labels; besides the combined catalog, write one catalog per label (e.g. models-catalog-validated.yaml) plus models-catalog-other.yaml for the remaining models")
 	staticCatalogFiles       = flag.String("static-catalog-files", "", "Comma-separated list of static catalog files to include")
 	skipDefaultStaticCatalog = flag.Bool("skip-default-static-catalog", false, "Skip processing the default supplemental-catalog.yaml from the input directory")
 	mcpIndexPath             = flag.String("mcp-index", "", "Path to MCP servers index YAML file (if set, generates MCP catalog)")
@@ -52,14 +103,32 @@ var (
 	agentCatalogOutputPath   = flag.String("agent-catalog-output", "data/redhat-agents-catalog.yaml", "Path for the generated agents catalog")
 	agentBranch              = flag.String("agent-branch", "", "Override the GitHub branch for agent metadata fetching (defaults to branch in index file)")
 	skipAgentEnrichment      = flag.Bool("skip-agent-enrichment", false, "Skip fetching agent metadata and READMEs from GitHub")
+	hfCacheDir               = flag.String("cache-dir", ".hf-cache", "Directory for caching HuggingFace README and model-details responses")
+	hfCacheTTL               = flag.Duration("cache-ttl", 24*time.Hour, "How long cached HuggingFace responses remain valid")
+	noCache                  = flag.Bool("no-cache", false, "Bypass the HuggingFace response cache")
+	hfToken                  = flag.String("hf-token", "", "HuggingFace API token for gated models (overrides the HF_TOKEN environment variable)")
+	hfRequestTimeout         = flag.Duration("hf-request-timeout", huggingface.DefaultRequestTimeout, "Timeout for each HuggingFace API or README request, including reading the response (0 disables it)")
+	hfMaxResponseBytes       = flag.Int64("hf-max-response-bytes", huggingface.DefaultMaxResponseBytes, "Maximum size in bytes of a HuggingFace response body; larger READMEs, model details or collection pages fail with an error (0 disables the limit)")
+	logLevel                 = flag.String("log-level", "info", "Minimum log level: debug, info, warn, or error (debug adds per-layer digest and annotation dumps)")
+	logFormat                = flag.String("log-format", logging.FormatText, "Log output format: text or json")
 	help                     = flag.Bool("help", false, "Show help message")
 )
 
 // ModelResult represents the result of processing a single model
 type ModelResult struct {
-	Ref            string
-	ModelCardFound bool
-	Metadata       types.ModelMetadata
+	Ref             string
+	ModelCardFound  bool
+	ModelCardPath   string // Path of the modelcard inside its image layer
+	ModelCard       []byte // Raw modelcard content
+	Metadata        types.ModelMetadata
+	Extracted       types.ExtractedMetadata  // Values written to metadata.yaml (skeleton when no modelcard was found)
+	Extraction      *types.ExtractedMetadata // Extracted before labels were added, cached for reuse; nil when the same as Extracted
+	MetadataWritten bool                     // Whether metadata.yaml was written to the output directory
+	ModelCardEmpty  bool                     // Whether the modelcard file was present but empty or whitespace-only
+	LayerEmpty      bool                     // Whether the modelcard layer was present but held no files
+	Reused          bool                     // Whether existing output was reused because the image predates --since or its modelcard is unchanged
+	ExtraFiles      map[string][]byte        // Files matching --extract-files, keyed by their path in the modelcard layer
+	Err             error                    // Non-nil when the model could not be fetched or scanned
 }
 
 // loadDotEnv reads a .env file and sets any unset environment variables from it.
@@ -102,6 +171,23 @@ func loadDotEnv(path string) {
 func main() {
 	loadDotEnv(".env")
 
+	if len(os.Args) > 1 && os.Args[1] == "inspect" {
+		runInspect(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint-index" {
+		runLintIndex(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "merge-index" {
+		runMergeIndex(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
 
 	if *help {
@@ -109,18 +195,82 @@ func main() {
 		return
 	}
 
-	if os.Getenv("HF_TOKEN") != "" {
+	if *configPath != "" {
+		runConfig, err := config.LoadRunConfig(*configPath)
+		if err != nil {
+			logging.Fatalf("Invalid --config: %v", err)
+		}
+		if err := applyRunConfig(flag.CommandLine, runConfig); err != nil {
+			logging.Fatalf("Invalid --config: %v", err)
+		}
+	}
+
+	if err := logging.Setup(*logLevel, *logFormat); err != nil {
+		logging.Fatalf("Invalid logging options: %v", err)
+	}
+
+	if *hfToken != "" {
+		huggingface.SetToken(*hfToken)
+	}
+	if huggingface.HasToken() {
 		log.Println("HuggingFace token detected: authenticated requests enabled")
+	} else {
+		log.Println("No HuggingFace token set: gated models will be skipped during enrichment")
 	}
 
 	log.Printf("Starting model metadata collection with configuration:")
+	if *configPath != "" {
+		log.Printf("  Config File: %s", *configPath)
+	}
 	log.Printf("  Models Index: %s", *modelsIndexPath)
+	if len(*modelSelection) > 0 {
+		log.Printf("  Models: %s", modelSelection)
+	}
+	if config.IsRemotePath(*modelsIndexPath) {
+		log.Printf("  Index Timeout: %v", *indexTimeout)
+	}
+	log.Printf("  Registry Template: %s", *registryTemplate)
 	log.Printf("  Output Directory: %s", *outputDir)
 	log.Printf("  Catalog Output: %s", *catalogOutputPath)
 	log.Printf("  Max Concurrent: %d", *maxConcurrent)
+	log.Printf("  Max Retries: %d", *maxRetries)
+	log.Printf("  Fetch Timeout: %v", *fetchTimeout)
+	log.Printf("  Max Modelcard Bytes: %d", *maxModelcardBytes)
+	log.Printf("  Platform: %s", *platform)
+	log.Printf("  Pin Digests: %v", *pinDigests)
+	log.Printf("  Since: %s", *since)
+	log.Printf("  Force: %v", *force)
+	log.Printf("  Progress: %v", *showProgress)
+	log.Printf("  Progress JSON: %s", *progressJSON)
+	log.Printf("  Modelcard Extensions: %s", *modelcardExtensions)
+	log.Printf("  Extract Files: %s", *extractFiles)
+	log.Printf("  Metadata Format: %s", *metadataFormat)
 	log.Printf("  Skip HuggingFace: %v", *skipHuggingFace)
 	log.Printf("  Skip Enrichment: %v", *skipEnrichment)
+	log.Printf("  Max Concurrent Enrich: %d", *maxConcurrentEnrich)
+	log.Printf("  Min Enrichment Rate: %v%%", *minEnrichmentRate)
+	log.Printf("  Write Aggregate Enrichment: %v", *writeAggregateEnrich)
+	log.Printf("  Prefer Modelcard Name: %v", *preferModelcardName)
+	log.Printf("  Source Priority: %s", *sourcePriority)
+	log.Printf("  Match Threshold: %v (high confidence: %v, ambiguity margin: %v)", *matchThreshold, *highConfidenceThreshold, *ambiguityMargin)
+	log.Printf("  HuggingFace Mapping: %s", *hfMappingPath)
+	log.Printf("  Allow Tags: %s", *allowTags)
+	log.Printf("  Deny Tags: %s", *denyTags)
+	log.Printf("  Task Map: %s", *taskMapPath)
+	log.Printf("  Description Overrides: %s", *descriptionOverridesPath)
 	log.Printf("  Skip Catalog: %v", *skipCatalog)
+	log.Printf("  Catalog Only: %v", *catalogOnly)
+	log.Printf("  Include Labels: %s", *includeLabels)
+	log.Printf("  Exclude Labels: %s", *excludeLabels)
+	log.Printf("  Dedup Strategy: %s", *dedupStrategy)
+	log.Printf("  Catalog Sort: %s", *catalogSort)
+	log.Printf("  Split By Label: %s", *splitByLabel)
+	log.Printf("  Facets Output: %s", *facetsOutputPath)
+	log.Printf("  Logo Map: %s", *logoMapPath)
+	log.Printf("  Strict Catalog Validation: %v", *strictCatalog)
+	log.Printf("  Verify Catalog: %v", *verifyCatalog)
+	log.Printf("  HuggingFace Cache: %s (ttl %v, disabled: %v)", *hfCacheDir, *hfCacheTTL, *noCache)
+	log.Printf("  HuggingFace Request Timeout: %v (max response bytes: %d)", *hfRequestTimeout, *hfMaxResponseBytes)
 	log.Printf("  Static Catalog Files: %s", *staticCatalogFiles)
 	log.Printf("  Skip Default Static Catalog: %v", *skipDefaultStaticCatalog)
 	log.Printf("  MCP Index: %s", *mcpIndexPath)
@@ -130,21 +280,136 @@ func main() {
 	log.Printf("  Agent Catalog Output: %s", *agentCatalogOutputPath)
 	log.Printf("  Agent Branch Override: %s", *agentBranch)
 	log.Printf("  Skip Agent Enrichment: %v", *skipAgentEnrichment)
+	log.Printf("  Log Level: %s (format: %s)", *logLevel, *logFormat)
+
+	matchOpts := enrichment.MatchOptions{
+		Threshold:               *matchThreshold,
+		HighConfidenceThreshold: *highConfidenceThreshold,
+		AmbiguityMargin:         *ambiguityMargin,
+	}
+	if err := matchOpts.Validate(); err != nil {
+		logging.Fatalf("Invalid match thresholds: %v", err)
+	}
+
+	if *maxConcurrent < 1 {
+		logging.Fatalf("Invalid --max-concurrent: must be at least 1, got %d", *maxConcurrent)
+	}
+	if err := enrichment.SetMaxConcurrent(*maxConcurrentEnrich); err != nil {
+		logging.Fatalf("Invalid --max-concurrent-enrich: %v", err)
+	}
+	if err := enrichment.SetMinEnrichmentRate(*minEnrichmentRate); err != nil {
+		logging.Fatalf("Invalid --min-enrichment-rate: %v", err)
+	}
+	enrichment.SetWriteAggregate(*writeAggregateEnrich)
+	enrichment.SetPreferModelcardName(*preferModelcardName)
+	if err := enrichment.SetSourcePriority(splitCommaList(*sourcePriority)); err != nil {
+		logging.Fatalf("Invalid --source-priority: %v", err)
+	}
+	if *hfMappingPath != "" {
+		mapping, err := config.LoadHFMapping(*hfMappingPath)
+		if err != nil {
+			logging.Fatalf("Invalid --hf-mapping: %v", err)
+		}
+		enrichment.SetHFMapping(mapping)
+		log.Printf("Loaded %d HuggingFace mappings from %s", len(mapping), *hfMappingPath)
+	}
+	huggingface.SetTagFilter(splitCommaList(*allowTags), splitCommaList(*denyTags))
+
+	if *descriptionOverridesPath != "" {
+		overrides, err := config.LoadDescriptionOverrides(*descriptionOverridesPath)
+		if err != nil {
+			logging.Fatalf("Invalid --description-overrides: %v", err)
+		}
+		enrichment.SetDescriptionOverrides(overrides)
+		log.Printf("Loaded %d description overrides from %s", len(overrides), *descriptionOverridesPath)
+	}
+
+	if *taskMapPath != "" {
+		taskMap, err := config.LoadTaskMap(*taskMapPath)
+		if err != nil {
+			logging.Fatalf("Invalid --task-map: %v", err)
+		}
+		utils.SetTaskMapOverrides(taskMap)
+		log.Printf("Loaded %d task mappings from %s", len(taskMap), *taskMapPath)
+	}
+
+	config.SetHTTPTimeout(*indexTimeout)
+	if err := config.SetRegistryTemplate(*registryTemplate); err != nil {
+		logging.Fatalf("Invalid --registry-template: %v", err)
+	}
+
+	if !*noCache {
+		huggingface.EnableCache(*hfCacheDir, *hfCacheTTL)
+	}
+	huggingface.SetRequestTimeout(*hfRequestTimeout)
+	huggingface.SetMaxResponseBytes(*hfMaxResponseBytes)
+
+	if err := setModelCardExtensions(splitCommaList(*modelcardExtensions)); err != nil {
+		logging.Fatalf("Invalid --modelcard-extensions: %v", err)
+	}
+
+	extractFilePatterns = splitCommaList(*extractFiles)
+	for _, pattern := range extractFilePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			logging.Fatalf("Invalid --extract-files pattern %q: %v", pattern, err)
+		}
+	}
+
+	if *since != "" {
+		cutoff, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			logging.Fatalf("Invalid --since: %v", err)
+		}
+		sinceCutoff = cutoff
+	}
+
+	if err := metadata.SetOutputFormat(*metadataFormat); err != nil {
+		logging.Fatalf("Invalid --metadata-format: %v", err)
+	}
+
+	if err := catalog.SetDedupStrategy(*dedupStrategy); err != nil {
+		logging.Fatalf("Invalid --dedup-strategy: %v", err)
+	}
+
+	if err := catalog.SetCatalogSort(*catalogSort); err != nil {
+		logging.Fatalf("Invalid --catalog-sort: %v", err)
+	}
+
+	if err := catalog.SetSplitLabels(splitCommaList(*splitByLabel)); err != nil {
+		logging.Fatalf("Invalid --split-by-label: %v", err)
+	}
+
+	catalog.SetStrictValidation(*strictCatalog)
+
+	if *logoMapPath != "" {
+		logos, err := catalog.LoadLogoMap(*logoMapPath)
+		if err != nil {
+			logging.Fatalf("Invalid --logo-map: %v", err)
+		}
+		catalog.SetLogoMap(logos)
+	}
+
+	if *catalogOnly {
+		runCatalogOnly()
+		return
+	}
 
 	// Determine if model processing should run.
 	// Skip when all model pipeline steps are disabled, regardless of MCP processing.
 	skipModels := *skipHuggingFace && *skipEnrichment && *skipCatalog
 
 	if !skipModels {
-		// Ensure output directory exists
-		if err := os.MkdirAll(*outputDir, 0755); err != nil {
-			log.Fatalf("Failed to create output directory: %v", err)
+		// Fail before any network work if results could not be written at the end of a long run
+		if err := ensureWritableDir(*outputDir); err != nil {
+			logging.Fatalf("Output directory (--output-dir) is not usable: %v", err)
 		}
-
-		// Ensure catalog output directory exists
-		catalogDir := filepath.Dir(*catalogOutputPath)
-		if err := os.MkdirAll(catalogDir, 0755); err != nil {
-			log.Fatalf("Failed to create catalog output directory: %v", err)
+		if err := ensureWritableDir(filepath.Dir(*catalogOutputPath)); err != nil {
+			logging.Fatalf("Catalog output directory (--catalog-output) is not usable: %v", err)
+		}
+		if *facetsOutputPath != "" && !*skipCatalog {
+			if err := ensureWritableDir(filepath.Dir(*facetsOutputPath)); err != nil {
+				logging.Fatalf("Facets output directory (--facets-output) is not usable: %v", err)
+			}
 		}
 
 		// Process HuggingFace collections (unless skipped)
@@ -158,20 +423,59 @@ func main() {
 		}
 
 		// Load models from configuration file
-		modelEntries, err := loadModelsWithMetadata(*modelsIndexPath)
+		indexEntries, err := loadModelsWithMetadata(*modelsIndexPath)
 		if err != nil {
-			log.Fatalf("Failed to load models: %v", err)
+			logging.Fatalf("Failed to load models: %v", err)
+		}
+		if len(*modelSelection) > 0 {
+			selected := selectModelEntries(indexEntries, *modelSelection)
+			log.Printf("Selected %d of %d indexed models matching --models %s", len(selected), len(indexEntries), modelSelection)
+			if len(selected) == 0 {
+				logging.Fatalf("No models in %s match --models %s", *modelsIndexPath, modelSelection)
+			}
+		}
+
+		if *progressJSON != "" {
+			reporter, err := newProgressReporter(*progressJSON)
+			if err != nil {
+				logging.Fatalf("Invalid --progress-json: %v", err)
+			}
+			progress = reporter
+			defer func() { _ = reporter.Close() }()
+		}
+
+		// Invalid references are reported as failed models instead of aborting the run
+		indexEntries, invalidResults := validateModelEntries(indexEntries)
+		for _, result := range invalidResults {
+			progress.Report(result, 0)
+		}
+
+		// --models narrows extraction and enrichment only; manifests.yaml and the catalog keep
+		// covering the whole index, with the previous results of the unselected models
+		modelEntries, retainedRefs := splitModelSelection(indexEntries, *modelSelection)
+		if len(*modelSelection) > 0 {
+			selectedRefs := make([]string, 0, len(modelEntries))
+			for _, entry := range modelEntries {
+				selectedRefs = append(selectedRefs, entry.URI)
+			}
+			enrichment.SetModelSelection(selectedRefs)
 		}
 
 		log.Printf("Processing %d models...", len(modelEntries))
 
+		sys, err := newPlatformSystemContext(*platform)
+		if err != nil {
+			logging.Fatalf("Invalid --platform: %v", err)
+		}
+
 		// Process models in parallel
-		modelResults := processModelsInParallelWithMetadata(modelEntries, *maxConcurrent)
+		modelResults := processModelsInParallelWithMetadata(modelEntries, sys, *maxConcurrent)
+		modelResults = append(modelResults, invalidResults...)
 
 		// Generate manifests.yaml
-		err = generateManifestsYAML(modelResults, *outputDir)
+		err = generateManifestsYAML(modelResults, retainedRefs, *outputDir)
 		if err != nil {
-			log.Fatalf("Failed to generate manifests.yaml: %v", err)
+			logging.Fatalf("Failed to generate manifests.yaml: %v", err)
 		}
 
 		log.Printf("All manifest processing completed")
@@ -181,24 +485,16 @@ func main() {
 		if !*skipEnrichment {
 			log.Println("Enriching extracted metadata with HuggingFace data...")
 
-			// Determine HuggingFace index file to use
-			// Prefer merged index file to ensure all models from all collections are available for matching
-			hfIndexFile := huggingface.MergedFilePath()
-			if _, err := os.Stat(hfIndexFile); err != nil {
-				if !errors.Is(err, os.ErrNotExist) {
-					log.Fatalf("Failed to access merged index file %s: %v", hfIndexFile, err)
-				}
-				// Fallback to latest version-specific file if merged doesn't exist
-				log.Printf("Warning: Merged index file not found, falling back to latest version file")
-				hfIndexFile, err = huggingface.GetLatestVersionIndexFile()
-				if err != nil {
-					log.Fatalf("Could not find any HuggingFace index file: %v", err)
-				}
+			hfIndexFile, err := resolveHuggingFaceIndexFile()
+			if err != nil {
+				logging.Fatalf("Could not find any HuggingFace index file: %v", err)
 			}
 
 			log.Printf("Using HuggingFace index file: %s", hfIndexFile)
-			err := enrichment.EnrichMetadataFromHuggingFace(hfIndexFile, *modelsIndexPath, *outputDir, filepath.Join(*inputDir, "models", "vllm-config"))
-			if err != nil {
+			err = enrichment.EnrichMetadataFromHuggingFace(hfIndexFile, *modelsIndexPath, *outputDir, filepath.Join(*inputDir, "models", "vllm-config"), matchOpts)
+			if errors.Is(err, enrichment.ErrEnrichmentRateTooLow) {
+				logging.Fatalf("Enrichment failed: %v", err)
+			} else if err != nil {
 				log.Printf("Warning: Failed to enrich metadata: %v", err)
 			}
 
@@ -209,38 +505,27 @@ func main() {
 			}
 		}
 
+		// Curated descriptions win over every extracted or enriched one, whatever the enrichment outcome
+		applyDescriptionOverrides(modelOutputDirs(catalogModelRefs(indexEntries)))
+
 		// Create the models catalog (unless skipped)
 		if !*skipCatalog {
-			// Load static catalogs
-			staticCatalogPaths := getStaticCatalogPaths(*staticCatalogFiles, *skipDefaultStaticCatalog)
-
-			var staticModels []types.CatalogMetadata
-			if len(staticCatalogPaths) > 0 {
-				log.Printf("Loading static catalogs...")
-				loadedStaticModels, err := catalog.LoadStaticCatalogs(staticCatalogPaths)
-				if err != nil {
-					log.Printf("Warning: Failed to load static catalogs: %v", err)
-					staticModels = []types.CatalogMetadata{} // Continue with empty static models
-				} else {
-					staticModels = loadedStaticModels
-				}
-			} else {
-				log.Printf("No static catalog files to process")
-				staticModels = []types.CatalogMetadata{}
-			}
+			staticModels := loadStaticModels()
 
 			// Create the models catalog with both dynamic and static models
 			log.Printf("Creating models catalog...")
 
-			// Extract model references from the entries that were processed in this run
-			var processedModelRefs []string
-			for _, entry := range modelEntries {
-				processedModelRefs = append(processedModelRefs, entry.URI)
+			labelFilter := labelFilterFromFlags()
+			err = catalog.CreateModelsCatalogWithStaticFromResults(*outputDir, *catalogOutputPath, catalogModelRefs(indexEntries), staticModels, labelFilter)
+			if err != nil {
+				logging.Fatalf("Failed to create models catalog: %v", err)
 			}
 
-			err = catalog.CreateModelsCatalogWithStaticFromResults(*outputDir, *catalogOutputPath, processedModelRefs, staticModels)
-			if err != nil {
-				log.Fatalf("Failed to create models catalog: %v", err)
+			if *verifyCatalog {
+				verifyCatalogOutputs(catalogModelRefs(indexEntries), staticModels, labelFilter)
+			}
+			if *facetsOutputPath != "" {
+				writeCatalogFacets()
 			}
 		}
 	} else {
@@ -253,7 +538,7 @@ func main() {
 		if !*skipMCPEnrichment {
 			log.Printf("Enriching MCP servers from OCI registry...")
 			if err := catalog.EnrichMCPServersFromRegistry(*mcpIndexPath); err != nil {
-				log.Fatalf("MCP server enrichment failed: %v", err)
+				logging.Fatalf("MCP server enrichment failed: %v", err)
 			}
 		}
 
@@ -261,7 +546,7 @@ func main() {
 		log.Printf("Processing MCP servers catalog from: %s", *mcpIndexPath)
 		err := catalog.CreateMCPServersCatalog(*mcpIndexPath, *mcpCatalogOutputPath)
 		if err != nil {
-			log.Fatalf("Failed to create MCP servers catalog: %v", err)
+			logging.Fatalf("Failed to create MCP servers catalog: %v", err)
 		}
 	}
 
@@ -269,13 +554,155 @@ func main() {
 	if *agentIndexPath != "" {
 		log.Printf("Processing agents catalog from: %s", *agentIndexPath)
 		if err := catalog.CreateAgentsCatalog(*agentIndexPath, *agentCatalogOutputPath, *agentBranch, *skipAgentEnrichment); err != nil {
-			log.Fatalf("Failed to create agents catalog: %v", err)
+			logging.Fatalf("Failed to create agents catalog: %v", err)
 		}
 	}
 
 	log.Println("Model metadata collection completed successfully!")
 }
 
+// applyRunConfig sets every flag present in the run config file, except flags given
+// explicitly on the command line
+func applyRunConfig(fs *flag.FlagSet, runConfig *types.Config) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for name, value := range config.RunConfigFlagValues(runConfig) {
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		}
+	}
+	return nil
+}
+
+// runInspect extracts a single image and prints its metadata as YAML to stdout without
+// touching the output directory, the catalog or HuggingFace
+func runInspect(args []string) {
+	if err := flag.CommandLine.Parse(args); err != nil {
+		logging.Fatalf("Invalid inspect options: %v", err)
+	}
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s inspect [options] <image-ref>\n", os.Args[0])
+		os.Exit(2)
+	}
+	ref := flag.Arg(0)
+	// Always parse the image's own modelcard rather than output left by a previous run
+	*force = true
+
+	if err := logging.Setup(*logLevel, *logFormat); err != nil {
+		logging.Fatalf("Invalid logging options: %v", err)
+	}
+
+	sys, err := newPlatformSystemContext(*platform)
+	if err != nil {
+		logging.Fatalf("Invalid --platform: %v", err)
+	}
+
+	result, err := ExtractModel(ref, sys)
+	if err != nil {
+		logging.Fatalf("Failed to inspect %s: %v", ref, err)
+	}
+	if !result.ModelCardFound {
+		logging.Fatalf("No modelcard found in %s", ref)
+	}
+
+	data, err := yaml.Marshal(&result.Extracted)
+	if err != nil {
+		logging.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if _, err := os.Stdout.Write(data); err != nil {
+		logging.Fatalf("Failed to write metadata: %v", err)
+	}
+}
+
+// runValidate checks an existing models catalog file without regenerating it, printing
+// every problem found and exiting non-zero when the catalog is invalid
+func runValidate(args []string) {
+	if err := flag.CommandLine.Parse(args); err != nil {
+		logging.Fatalf("Invalid validate options: %v", err)
+	}
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s validate <catalog-path>\n", os.Args[0])
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	errs, err := catalog.ValidateCatalogFile(path)
+	if err != nil {
+		logging.Fatalf("Failed to validate catalog: %v", err)
+	}
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "  %v\n", err)
+		}
+		logging.Fatalf("Catalog %s failed validation with %d errors", path, len(errs))
+	}
+	fmt.Printf("Catalog %s is valid\n", path)
+}
+
+// runLintIndex checks a models index file before extraction, printing every issue with its
+// line and exiting non-zero when any is an error
+func runLintIndex(args []string) {
+	if err := flag.CommandLine.Parse(args); err != nil {
+		logging.Fatalf("Invalid lint-index options: %v", err)
+	}
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s lint-index <models-index-path>\n", os.Args[0])
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	issues, err := config.LintModelsIndex(path, modelEntryError)
+	if err != nil {
+		logging.Fatalf("Failed to lint models index: %v", err)
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		fmt.Printf("%s:%d: %s: %s\n", path, issue.Line, issue.Severity, issue.Message)
+		if issue.Severity == config.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		logging.Fatalf("Models index %s has %d errors and %d warnings", path, errorCount, len(issues)-errorCount)
+	}
+	fmt.Printf("Models index %s passed with %d warnings\n", path, len(issues))
+}
+
+// runMergeIndex merges two HuggingFace version index files into a third, carrying forward
+// models the overlay (newer) index dropped
+func runMergeIndex(args []string) {
+	if err := flag.CommandLine.Parse(args); err != nil {
+		logging.Fatalf("Invalid merge-index options: %v", err)
+	}
+	if flag.NArg() != 3 {
+		fmt.Fprintf(os.Stderr, "Usage: %s merge-index <base-index> <overlay-index> <output-path>\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	base, err := huggingface.LoadVersionIndex(flag.Arg(0))
+	if err != nil {
+		logging.Fatalf("Failed to load base index: %v", err)
+	}
+	overlay, err := huggingface.LoadVersionIndex(flag.Arg(1))
+	if err != nil {
+		logging.Fatalf("Failed to load overlay index: %v", err)
+	}
+
+	merged := huggingface.MergeVersionIndexes(base, overlay)
+	if err := huggingface.SaveVersionIndex(flag.Arg(2), merged); err != nil {
+		logging.Fatalf("Failed to write merged index: %v", err)
+	}
+	fmt.Printf("Merged %d + %d models into %s with %d models (version: %s)\n",
+		len(base.Models), len(overlay.Models), flag.Arg(2), len(merged.Models), merged.Version)
+}
+
 func printHelp() {
 	fmt.Println("Model Metadata Collection Tool")
 	fmt.Println("")
@@ -283,6 +710,10 @@ func printHelp() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Printf("  %s [options]\n", os.Args[0])
+	fmt.Printf("  %s inspect [options] <image-ref>   Print the extracted metadata of one image as YAML\n", os.Args[0])
+	fmt.Printf("  %s validate <catalog-path>         Check an existing models catalog and exit non-zero if it is invalid\n", os.Args[0])
+	fmt.Printf("  %s lint-index <models-index-path>  Check a models index for problems before extraction\n", os.Args[0])
+	fmt.Printf("  %s merge-index <base> <overlay> <output>  Merge two HuggingFace version index files, keeping models dropped from the overlay\n", os.Args[0])
 	fmt.Println("")
 	fmt.Println("Options:")
 	flag.PrintDefaults()
@@ -294,6 +725,9 @@ func printHelp() {
 	fmt.Println("  # Custom input and output paths")
 	fmt.Printf("  %s --input custom-models.yaml --output-dir /tmp/output --catalog-output /tmp/catalog.yaml\n", os.Args[0])
 	fmt.Println("")
+	fmt.Println("  # Load settings from a file, overriding the output directory")
+	fmt.Printf("  %s --config run-config.yaml --output-dir /tmp/output\n", os.Args[0])
+	fmt.Println("")
 	fmt.Println("  # Skip HuggingFace processing and enrichment")
 	fmt.Printf("  %s --skip-huggingface --skip-enrichment\n", os.Args[0])
 	fmt.Println("")
@@ -317,358 +751,1417 @@ func printHelp() {
 	fmt.Println("")
 	fmt.Println("  # Generate agents catalog without GitHub fetching (offline)")
 	fmt.Printf("  %s --agent-index data/redhat-agents-index.yaml --skip-huggingface --skip-enrichment --skip-catalog --skip-agent-enrichment\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Debug extraction for a single model image")
+	fmt.Printf("  %s inspect registry.redhat.io/rhai/modelcar-granite-4-0-h-tiny:3.0\n", os.Args[0])
+}
+
+// resolveHuggingFaceIndexFile picks the HuggingFace index used for enrichment. The merged index is
+// preferred so models from all collections are available for matching; otherwise the latest
+// version-specific index discovered on disk is used, so new collection versions are never ignored.
+func resolveHuggingFaceIndexFile() (string, error) {
+	mergedFile := huggingface.MergedFilePath()
+	if _, err := os.Stat(mergedFile); err == nil {
+		return mergedFile, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to access merged index file %s: %v", mergedFile, err)
+	}
+
+	// Fallback to latest version-specific file if merged doesn't exist
+	log.Printf("Warning: Merged index file not found, falling back to latest version file")
+	return huggingface.GetLatestVersionIndexFile()
+}
+
+// verifyCatalogOutputs cross-references the generated catalog with the outputs of modelRefs (every
+// output when nil) and exits when catalog models have no source or extracted models are missing
+// from the catalog
+func verifyCatalogOutputs(modelRefs []string, staticModels []types.CatalogMetadata, filter catalog.LabelFilter) {
+	log.Printf("Verifying catalog against %s...", *outputDir)
+	report, err := catalog.VerifyCatalogOutputs(*catalogOutputPath, *outputDir, modelRefs, staticModels, filter)
+	if err != nil {
+		logging.Fatalf("Failed to verify models catalog: %v", err)
+	}
+	for _, name := range report.Orphans {
+		log.Printf("  Orphan catalog model (no output or static source): %s", name)
+	}
+	for _, path := range report.Unmatched {
+		log.Printf("  Extracted model missing from catalog: %s", path)
+	}
+	if report.HasProblems() {
+		logging.Fatalf("Catalog verification failed: %d orphan catalog models, %d extracted models missing from the catalog",
+			len(report.Orphans), len(report.Unmatched))
+	}
+	log.Printf("Catalog verification passed")
+}
+
+// writeCatalogFacets writes the provider, task, language and license facets of the generated
+// models catalog to --facets-output
+func writeCatalogFacets() {
+	generated, err := catalog.ReadModelsCatalog(*catalogOutputPath)
+	if err != nil {
+		logging.Fatalf("Failed to read models catalog for facets: %v", err)
+	}
+	if err := catalog.WriteFacets(generated, *facetsOutputPath); err != nil {
+		logging.Fatalf("Failed to write catalog facets: %v", err)
+	}
+	log.Printf("Successfully created %s", *facetsOutputPath)
+}
+
+// loadStaticModels loads the static catalogs selected by --static-catalog-files and
+// --skip-default-static-catalog; load failures are logged and yield no static models
+func loadStaticModels() []types.CatalogMetadata {
+	staticCatalogPaths := getStaticCatalogPaths(*staticCatalogFiles, *skipDefaultStaticCatalog)
+	if len(staticCatalogPaths) == 0 {
+		log.Printf("No static catalog files to process")
+		return []types.CatalogMetadata{}
+	}
+
+	log.Printf("Loading static catalogs...")
+	staticModels, err := catalog.LoadStaticCatalogs(staticCatalogPaths)
+	if err != nil {
+		log.Printf("Warning: Failed to load static catalogs: %v", err)
+		return []types.CatalogMetadata{} // Continue with empty static models
+	}
+	return staticModels
+}
+
+// labelFilterFromFlags builds the catalog label filter from --include-label and --exclude-label
+func labelFilterFromFlags() catalog.LabelFilter {
+	return catalog.LabelFilter{
+		Include: splitCommaList(*includeLabels),
+		Exclude: splitCommaList(*excludeLabels),
+	}
+}
+
+// runCatalogOnly rebuilds the models catalog from the metadata.yaml files a previous run left in
+// --output-dir, without contacting registries or HuggingFace
+func runCatalogOnly() {
+	if err := requireDir(*outputDir); err != nil {
+		logging.Fatalf("Output directory (--output-dir) cannot be used with --catalog-only: %v", err)
+	}
+	if err := ensureWritableDir(filepath.Dir(*catalogOutputPath)); err != nil {
+		logging.Fatalf("Catalog output directory (--catalog-output) is not usable: %v", err)
+	}
+	if *facetsOutputPath != "" {
+		if err := ensureWritableDir(filepath.Dir(*facetsOutputPath)); err != nil {
+			logging.Fatalf("Facets output directory (--facets-output) is not usable: %v", err)
+		}
+	}
+
+	staticModels := loadStaticModels()
+	labelFilter := labelFilterFromFlags()
+
+	if *descriptionOverridesPath != "" {
+		modelDirs, err := utils.ModelOutputDirs(*outputDir)
+		if err != nil {
+			logging.Fatalf("Failed to list model metadata: %v", err)
+		}
+		applyDescriptionOverrides(modelDirs)
+	}
+
+	log.Printf("Rebuilding models catalog from %s...", *outputDir)
+	if err := catalog.CreateModelsCatalogFromOutput(*outputDir, *catalogOutputPath, staticModels, labelFilter); err != nil {
+		logging.Fatalf("Failed to create models catalog: %v", err)
+	}
+	if *verifyCatalog {
+		verifyCatalogOutputs(nil, staticModels, labelFilter)
+	}
+	if *facetsOutputPath != "" {
+		writeCatalogFacets()
+	}
+
+	log.Println("Catalog regeneration completed successfully!")
+}
+
+// requireDir checks that dir exists and is a directory
+func requireDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// ensureWritableDir creates dir if needed and checks files can be created in it by writing and
+// removing a temporary file, since MkdirAll succeeds on existing read-only directories
+func ensureWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %v", dir, err)
+	}
+	_, writeErr := probe.Write([]byte("ok\n"))
+	closeErr := probe.Close()
+	_ = os.Remove(probe.Name())
+	if writeErr != nil {
+		return fmt.Errorf("%s is not writable: %v", dir, writeErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("%s is not writable: %v", dir, closeErr)
+	}
+	return nil
+}
+
+// getStaticCatalogPaths returns the list of static catalog files to process
+func getStaticCatalogPaths(staticCatalogFiles string, skipDefaultStaticCatalog bool) []string {
+	// Add custom static catalog files if specified
+	paths := splitCommaList(staticCatalogFiles)
+
+	// Add default static catalog file if not skipped and exists
+	if !skipDefaultStaticCatalog {
+		defaultPath := filepath.Join(*inputDir, "supplemental-catalog.yaml")
+		if _, err := os.Stat(defaultPath); err == nil {
+			paths = append(paths, defaultPath)
+		}
+	}
+
+	return paths
+}
+
+// splitCommaList splits a comma-separated flag value, dropping empty entries
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadModelsWithMetadata loads models with their metadata from various sources with fallback logic
+func loadModelsWithMetadata(modelsIndexPath string) ([]types.ModelEntry, error) {
+	// Remote index URLs are fetched directly; there is no local fallback for them
+	if config.IsRemotePath(modelsIndexPath) {
+		log.Printf("Loading models from URL: %s", modelsIndexPath)
+		return config.LoadModelsConfigFromYAML(modelsIndexPath)
+	}
+
+	// First try to load from specified models index file
+	if _, err := os.Stat(modelsIndexPath); err == nil {
+		log.Printf("Loading models from: %s", modelsIndexPath)
+		return config.LoadModelsConfigFromYAML(modelsIndexPath)
+	}
+
+	// Try to load from latest version index file as fallback
+	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
+	if err == nil {
+		log.Printf("Using latest version index file: %s", latestIndexFile)
+		// Convert version index to model entries (all validated=true, featured=false by default)
+		modelURIs, err := config.LoadModelsFromVersionIndex(latestIndexFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var modelEntries []types.ModelEntry
+		for _, uri := range modelURIs {
+			modelEntries = append(modelEntries, types.ModelEntry{
+				Type:   "oci",
+				URI:    uri,
+				Labels: []string{"validated"},
+			})
+		}
+		return modelEntries, nil
+	}
+
+	return nil, fmt.Errorf("no valid models index file found at %s and no version index files available", modelsIndexPath)
+}
+
+// modelPattern is one --models pattern: a glob, or a regular expression when given with a "re:" prefix
+type modelPattern struct {
+	raw  string
+	glob string
+	re   *regexp.Regexp
+}
+
+// modelPatterns is the repeatable --models flag
+type modelPatterns []modelPattern
+
+// modelPatternsVar defines a repeatable model pattern flag on the command line
+func modelPatternsVar(name, usage string) *modelPatterns {
+	patterns := &modelPatterns{}
+	flag.Var(patterns, name, usage)
+	return patterns
+}
+
+// String implements flag.Value
+func (p *modelPatterns) String() string {
+	if p == nil {
+		return ""
+	}
+	raw := make([]string, len(*p))
+	for i, pattern := range *p {
+		raw[i] = pattern.raw
+	}
+	return strings.Join(raw, ", ")
+}
+
+// Set implements flag.Value, adding one pattern per use of the flag
+func (p *modelPatterns) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("empty pattern")
+	}
+	pattern := modelPattern{raw: value}
+	if expr, ok := strings.CutPrefix(value, "re:"); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid regular expression %q: %v", expr, err)
+		}
+		pattern.re = re
+	} else {
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %v", value, err)
+		}
+		pattern.glob = value
+	}
+	*p = append(*p, pattern)
+	return nil
+}
+
+// matches reports whether ref matches any of the patterns. Globs are matched against the whole
+// reference and its last path element (e.g. "modelcar-granite-*"); regular expressions may match
+// any part of the reference.
+func (p modelPatterns) matches(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	for _, pattern := range p {
+		if pattern.re != nil {
+			if pattern.re.MatchString(ref) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern.glob, ref); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern.glob, path.Base(ref)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// selectModelEntries returns the index entries whose URI matches one of the --models patterns
+func selectModelEntries(entries []types.ModelEntry, patterns modelPatterns) []types.ModelEntry {
+	var selected []types.ModelEntry
+	for _, entry := range entries {
+		if patterns.matches(entry.URI) {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
+
+// splitModelSelection splits index entries into the ones to process, those matching the
+// --models patterns (all entries when there are none), and the references of the others, whose
+// results from earlier runs are kept
+func splitModelSelection(entries []types.ModelEntry, patterns modelPatterns) ([]types.ModelEntry, []string) {
+	if len(patterns) == 0 {
+		return entries, nil
+	}
+	var retained []string
+	for _, entry := range entries {
+		if !patterns.matches(entry.URI) {
+			retained = append(retained, entry.URI)
+		}
+	}
+	return selectModelEntries(entries, patterns), retained
+}
+
+// catalogModelRefs returns the references of the index entries that make up the models catalog
+func catalogModelRefs(entries []types.ModelEntry) []string {
+	refs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		refs = append(refs, entry.URI)
+	}
+	return refs
+}
+
+// modelOutputDirs returns the output directory names of model references
+func modelOutputDirs(refs []string) []string {
+	dirs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		dirs = append(dirs, utils.SanitizeManifestRef(ref))
+	}
+	return dirs
+}
+
+// applyDescriptionOverrides writes --description-overrides into the metadata of the given model
+// output directories; failures are logged so the catalog is still built
+func applyDescriptionOverrides(modelDirs []string) {
+	if *descriptionOverridesPath == "" {
+		return
+	}
+	log.Println("Applying description overrides...")
+	if err := enrichment.ApplyDescriptionOverrides(*outputDir, modelDirs); err != nil {
+		log.Printf("Warning: Failed to apply description overrides: %v", err)
+	}
+}
+
+// validateModelEntries trims whitespace from each model URI and checks that it can be fetched:
+// oci entries must parse as registry references and hf entries must name a HuggingFace repo.
+// Invalid entries are returned as failed results so they are reported in manifests.yaml.
+func validateModelEntries(entries []types.ModelEntry) ([]types.ModelEntry, []ModelResult) {
+	var valid []types.ModelEntry
+	var invalid []ModelResult
+
+	for _, entry := range entries {
+		entry.URI = strings.TrimSpace(entry.URI)
+
+		if err := modelEntryError(entry); err != nil {
+			log.Printf("  Warning: Skipping model %q: %v", entry.URI, err)
+			invalid = append(invalid, ModelResult{Ref: entry.URI, Err: err})
+			continue
+		}
+		valid = append(valid, entry)
+	}
+
+	if len(invalid) > 0 {
+		log.Printf("Skipping %d of %d models with invalid references", len(invalid), len(entries))
+	}
+	return valid, invalid
+}
+
+// modelEntryError reports why a models index entry cannot be fetched, or nil when its URI is
+// usable: oci entries must parse as registry references and hf entries must name a repo
+func modelEntryError(entry types.ModelEntry) error {
+	switch {
+	case entry.URI == "":
+		return errors.New("empty model URI")
+	case entry.Type == types.ModelEntryTypeHF:
+		if huggingface.RepoIDFromURI(entry.URI) == "" {
+			return fmt.Errorf("invalid HuggingFace model URI: %q", entry.URI)
+		}
+	case registry.IsOCILayoutRef(entry.URI):
+		if _, err := registry.ParseImageReference(entry.URI); err != nil {
+			return fmt.Errorf("invalid OCI layout reference: %v", err)
+		}
+	default:
+		if _, err := docker.ParseReference("//" + entry.URI); err != nil {
+			return fmt.Errorf("invalid registry reference: %v", err)
+		}
+		if err := registry.ValidateImageRef(entry.URI); err != nil {
+			return fmt.Errorf("invalid registry reference: %v", err)
+		}
+	}
+	return nil
+}
+
+// newPlatformSystemContext builds a registry system context that selects the given
+// "os/arch[/variant]" platform when resolving multi-arch image indexes
+func newPlatformSystemContext(platform string) (*containertypes.SystemContext, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected os/arch[/variant], got %q", platform)
+	}
+
+	sys := &containertypes.SystemContext{
+		OSChoice:           parts[0],
+		ArchitectureChoice: parts[1],
+	}
+	if len(parts) == 3 {
+		sys.VariantChoice = parts[2]
+	}
+	return sys, nil
+}
+
+// processModelsInParallelWithMetadata processes multiple models concurrently with metadata support
+func processModelsInParallelWithMetadata(modelEntries []types.ModelEntry, sys *containertypes.SystemContext, maxConcurrent int) []ModelResult {
+	// Extract URIs for processing
+	var manifestRefs []string
+	uriToEntry := make(map[string]types.ModelEntry)
+
+	for _, entry := range modelEntries {
+		manifestRefs = append(manifestRefs, entry.URI)
+		uriToEntry[entry.URI] = entry
+	}
+
+	return processModelsInParallelWithEntryMap(manifestRefs, uriToEntry, sys, maxConcurrent)
+}
+
+// processModelsInParallelWithEntryMap processes multiple models concurrently with entry metadata
+func processModelsInParallelWithEntryMap(manifestRefs []string, uriToEntry map[string]types.ModelEntry, sys *containertypes.SystemContext, maxConcurrent int) []ModelResult {
+	bar := newProgressBar(len(manifestRefs))
+	defer bar.captureLogs()()
+
+	modelResults := runModelWorkers(manifestRefs, maxConcurrent, func(ref string) ModelResult {
+		return processModelRef(ref, uriToEntry[ref], sys)
+	}, func(ModelResult) { bar.Increment() })
+	bar.Finish()
+
+	return modelResults
+}
+
+// runModelWorkers runs process for every ref on a pool of at most maxConcurrent workers fed from a
+// jobs channel. Results are collected on the calling goroutine as each model finishes, in
+// completion order, with onResult called for each. Every worker exits once the jobs run out, so no
+// goroutine outlives the call.
+func runModelWorkers(manifestRefs []string, maxConcurrent int, process func(ref string) ModelResult, onResult func(ModelResult)) []ModelResult {
+	workers := min(max(maxConcurrent, 1), len(manifestRefs))
+
+	jobs := make(chan string)
+	results := make(chan ModelResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range jobs {
+				results <- process(ref)
+			}
+		}()
+	}
+
+	// Feed the jobs and close results once every worker has drained them, so the collection
+	// loop below ends exactly when the last model finishes
+	go func() {
+		for _, ref := range manifestRefs {
+			jobs <- ref
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	modelResults := make([]ModelResult, 0, len(manifestRefs))
+	for result := range results {
+		modelResults = append(modelResults, result)
+		onResult(result)
+	}
+	return modelResults
+}
+
+// processModelRef extracts one model, tags it with its index entry labels and writes its metadata
+func processModelRef(ref string, entry types.ModelEntry, sys *containertypes.SystemContext) ModelResult {
+	log.Printf("Starting processing for: %s", ref)
+	start := time.Now()
+	var result ModelResult
+	var err error
+	if entry.Type == types.ModelEntryTypeHF {
+		result, err = ExtractHuggingFaceModel(ref)
+	} else {
+		result, err = ExtractModel(ref, sys)
+	}
+	if err != nil {
+		log.Printf("Failed processing for %s: %v", ref, err)
+		progress.Report(result, time.Since(start))
+		return result
+	}
+
+	// Add labels from the model entry as tags before metadata.yaml is written
+	// This works for both successful extractions and skeleton metadata
+	extraction := result.Extracted
+	extraction.Tags = slices.Clone(result.Extracted.Tags)
+	result.Extraction = &extraction
+	addModelLabelTags(&result.Extracted, ref, entry)
+
+	result.MetadataWritten = writeModelResult(result)
+	log.Printf("Completed processing for: %s", ref)
+	progress.Report(result, time.Since(start))
+	return result
+}
+
+// progress streams per-model results for --progress-json; nil when disabled
+var progress *progressReporter
+
+// progressReporter writes one JSON object per completed model. Models complete on concurrent
+// goroutines, so writes are serialized to keep every line intact.
+type progressReporter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// newProgressReporter opens the --progress-json target: "-" writes to stdout, anything else is
+// created (or truncated) as a file
+func newProgressReporter(target string) (*progressReporter, error) {
+	if target == "-" {
+		return &progressReporter{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create progress file: %v", err)
+	}
+	return &progressReporter{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+// Report writes the progress event for a completed model. It is a no-op on a nil reporter.
+func (p *progressReporter) Report(result ModelResult, duration time.Duration) {
+	if p == nil {
+		return
+	}
+	event := types.ModelProgressEvent{
+		Ref:             result.Ref,
+		Success:         result.Err == nil && (result.MetadataWritten || result.ModelCardEmpty),
+		ModelCardFound:  result.ModelCardFound,
+		Reused:          result.Reused,
+		FieldsExtracted: populatedMetadataFields(&result.Extracted),
+		DurationMs:      duration.Milliseconds(),
+	}
+	if result.Err != nil {
+		event.Error = result.Err.Error()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.enc.Encode(&event); err != nil {
+		log.Printf("  Warning: Failed to write progress for %s: %v", result.Ref, err)
+	}
+}
+
+// Close closes the progress file; stdout is left open
+func (p *progressReporter) Close() error {
+	if p == nil || p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
+
+// progressBar shows completed/total models and a rough ETA on the last terminal line (--progress).
+// Its methods are no-ops on a nil bar.
+type progressBar struct {
+	mu    sync.Mutex
+	out   io.Writer
+	total int
+	done  int
+	start time.Time
+}
+
+// newProgressBar returns a progress bar for total models on stderr, or nil when --progress is
+// off, logs are JSON or stderr is not a terminal
+func newProgressBar(total int) *progressBar {
+	if !*showProgress || strings.EqualFold(strings.TrimSpace(*logFormat), logging.FormatJSON) || !isTerminal(os.Stderr) {
+		return nil
+	}
+	return &progressBar{out: os.Stderr, total: total, start: time.Now()}
+}
+
+// isTerminal reports whether f is a character device such as an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// captureLogs routes log output through the bar, so log lines are written above it rather than
+// into it, and returns a function restoring the previous logger
+func (b *progressBar) captureLogs() (restore func()) {
+	if b == nil {
+		return func() {}
+	}
+	handler, err := logging.NewHandler(b, *logLevel, *logFormat)
+	if err != nil {
+		return func() {}
+	}
+	previous, previousOutput, previousFlags := slog.Default(), log.Writer(), log.Flags()
+	logging.SetDefault(handler)
+	return func() {
+		slog.SetDefault(previous)
+		log.SetOutput(previousOutput)
+		log.SetFlags(previousFlags)
+	}
+}
+
+// Write writes log output on the bar's line and redraws the bar below it
+func (b *progressBar) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, _ = fmt.Fprint(b.out, "\r\033[K")
+	n, err := b.out.Write(p)
+	b.draw()
+	return n, err
+}
+
+// Increment counts one more completed model and redraws the bar
+func (b *progressBar) Increment() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done++
+	b.draw()
+}
+
+// Finish ends the bar's line so later output starts on a fresh one
+func (b *progressBar) Finish() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, _ = fmt.Fprintln(b.out)
+}
+
+// draw rewrites the bar's line; callers hold mu
+func (b *progressBar) draw() {
+	_, _ = fmt.Fprintf(b.out, "\r\033[K%s", b.line(time.Now()))
+}
+
+// line formats the progress at now; the ETA assumes the remaining models take as long on average
+// as the completed ones
+func (b *progressBar) line(now time.Time) string {
+	percent := 100
+	if b.total > 0 {
+		percent = b.done * 100 / b.total
+	}
+	text := fmt.Sprintf("Processed %d/%d models (%d%%)", b.done, b.total, percent)
+	if b.done > 0 && b.done < b.total {
+		eta := now.Sub(b.start) / time.Duration(b.done) * time.Duration(b.total-b.done)
+		text += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+	return text
+}
+
+// populatedMetadataFields returns the metadata.yaml keys that hold a value, in declared order
+func populatedMetadataFields(extracted *types.ExtractedMetadata) []string {
+	fields := []string{}
+	v := reflect.ValueOf(extracted).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.IsZero() || (field.Kind() == reflect.Slice && field.Len() == 0) {
+			continue
+		}
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// ExtractModel fetches a single model image and extracts its modelcard metadata without writing
+// anything to disk. Registry operations are bounded by --fetch-timeout so a hung connection cannot
+// stall the caller. When the image has no modelcard, Extracted holds skeleton metadata.
+func ExtractModel(ref string, sys *containertypes.SystemContext) (ModelResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *fetchTimeout)
+	defer cancel()
+
+	result := ModelResult{Ref: ref}
+
+	src, layers, configBlob, manifestDigest, err := fetchManifestSrcAndLayers(ctx, ref, sys)
+	if err != nil {
+		result.Err = err
+		return result, err
+	}
+	defer func() { _ = src.Close() }()
+
+	// The config blob is already fetched, so image timestamps are known before any layer is downloaded
+	createTime, updateTime := extractTimestampsFromConfig(configBlob)
+	imageOS, imageArch := extractPlatformFromConfig(configBlob)
+	if (imageOS != "" && sys.OSChoice != "" && imageOS != sys.OSChoice) ||
+		(imageArch != "" && sys.ArchitectureChoice != "" && imageArch != sys.ArchitectureChoice) {
+		log.Printf("  Warning: image for %s is %s/%s, not the requested platform %s/%s",
+			ref, imageOS, imageArch, sys.OSChoice, sys.ArchitectureChoice)
+	}
+	if !sinceCutoff.IsZero() && imageOlderThan(createTime, updateTime, sinceCutoff) {
+		if reused, ok := loadExistingModelResult(ref); ok {
+			log.Printf("  Image for %s predates --since, reusing existing metadata", ref)
+			return reused, nil
+		}
+		log.Printf("  Image for %s predates --since but has no existing metadata, processing it", ref)
+	}
+
+	// A modelcard attached as an OCI referrer takes precedence over the modelcard layer
+	var extraFiles map[string][]byte
+	modelCardPath, modelCard, found := scanReferrersForModelCard(ctx, src, ref, manifestDigest, sys)
+	if !found {
+		modelCardPath, modelCard, extraFiles, found, err = scanLayersForModelCard(ctx, layers, src, ref)
+	}
+	if errors.Is(err, errModelCardEmpty) {
+		// Not an error, but nothing to parse either; writeModelResult skips its metadata.yaml
+		result.ModelCardEmpty = true
+		err = nil
+	}
+	if errors.Is(err, errModelCardLayerEmpty) {
+		// A packaging bug rather than a missing modelcard; treated like a missing one so enrichment
+		// fills skeleton metadata, but reported separately
+		result.LayerEmpty = true
+		err = nil
+	}
+	if err != nil {
+		result.Err = err
+		return result, err
+	}
+
+	if found && !*force {
+		if reused, ok := loadUnchangedModelResult(ref, modelCard); ok {
+			// Registry artifacts and timestamps below are still refreshed
+			log.Printf("  Modelcard for %s is unchanged, reusing existing metadata", ref)
+			result = reused
+		}
+	}
+
+	if found && !result.Reused {
+		result.ModelCardFound = true
+		result.ModelCardPath = modelCardPath
+		result.ModelCard = modelCard
+
+		// Parse metadata from the modelcard content
+		result.Metadata = metadata.ParseModelCardMetadata(modelCard)
+
+		// Extract actual metadata values
+		result.Extracted = metadata.ExtractMetadataValues(modelCard)
+		result.ExtraFiles = extraFiles
+		applyExtraFiles(&result.Extracted, extraFiles, ref)
+	} else if !found {
+		// Create basic metadata with minimal information for enrichment to populate
+		result.Extracted = types.ExtractedMetadata{
+			Tags:     []string{},
+			Language: []string{},
+			Tasks:    []string{},
+		}
+	}
+
+	// Populate artifacts with OCI registry metadata and real timestamps. A reference the artifact
+	// parser rejects fails the model rather than producing a catalog entry without artifacts.
+	artifacts, err := registry.ExtractOCIArtifactsFromRegistryE(ref)
+	if err != nil {
+		log.Printf("  Warning: no OCI artifacts for %s: %v", ref, err)
+		result.Err = err
+		return result, err
+	}
+	result.Extracted.Artifacts = artifacts
+
+	// The primary artifact is the image itself, so it carries the resolved manifest digest and size
+	if len(result.Extracted.Artifacts) > 0 {
+		registry.AddDigestToArtifact(&result.Extracted.Artifacts[0], manifestDigest, *pinDigests)
+		registry.AddImageSizeToArtifact(&result.Extracted.Artifacts[0], layers)
+		registry.AddPlatformToArtifact(&result.Extracted.Artifacts[0], imageOS, imageArch)
+	}
+
+	// Update artifacts with the real timestamps from the config blob
+	for i := range result.Extracted.Artifacts {
+		if result.Extracted.Artifacts[i].CreateTimeSinceEpoch == nil {
+			result.Extracted.Artifacts[i].CreateTimeSinceEpoch = createTime
+		}
+		if result.Extracted.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
+			result.Extracted.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
+		}
+	}
+
+	return result, nil
+}
+
+// sinceCutoff is the parsed --since time; the zero value processes every model
+var sinceCutoff time.Time
+
+// imageOlderThan reports whether the image's latest known timestamp (epoch milliseconds) is before
+// the cutoff. Images without timestamps are never considered older.
+func imageOlderThan(createTime, updateTime *int64, cutoff time.Time) bool {
+	latest := updateTime
+	if latest == nil {
+		latest = createTime
+	}
+	return latest != nil && *latest < cutoff.UnixMilli()
+}
+
+// extractionCacheFile is written next to metadata.yaml and holds the extraction it was written
+// from, before labels and enrichment changed it
+const extractionCacheFile = "extracted.yaml"
+
+// loadExistingModelResult rebuilds a model result from the extraction and modelcard.md cached by a
+// previous run, so an unchanged image does not need its layers scanned again. metadata.yaml is not
+// used: enrichment has rewritten it, and values it added must not be treated as extracted ones.
+func loadExistingModelResult(ref string) (ModelResult, bool) {
+	data, err := os.ReadFile(filepath.Join(*outputDir, utils.SanitizeManifestRef(ref), "models", extractionCacheFile))
+	if err != nil {
+		return ModelResult{}, false
+	}
+	var existing types.ExtractedMetadata
+	if err := yaml.Unmarshal(data, &existing); err != nil {
+		log.Printf("  Warning: Ignoring unreadable cached extraction for %s: %v", ref, err)
+		return ModelResult{}, false
+	}
+
+	result := ModelResult{Ref: ref, Extracted: existing, Reused: true}
+	modelCardPath := filepath.Join("models", "modelcard.md")
+	if modelCard, err := os.ReadFile(filepath.Join(*outputDir, utils.SanitizeManifestRef(ref), modelCardPath)); err == nil {
+		result.ModelCardFound = true
+		result.ModelCardPath = modelCardPath
+		result.ModelCard = modelCard
+		result.Metadata = metadata.ParseModelCardMetadata(modelCard)
+	}
+	return result, true
+}
+
+// modelCardChecksumFile is written next to metadata.yaml and holds the sha256 of the modelcard
+// the metadata was parsed from
+const modelCardChecksumFile = "modelcard.sha256"
+
+// modelCardChecksum returns the hex-encoded sha256 of modelcard content
+func modelCardChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// loadUnchangedModelResult reuses the output of a previous run when the modelcard's checksum
+// matches the one stored with it, so an unchanged modelcard is not parsed again
+func loadUnchangedModelResult(ref string, modelCard []byte) (ModelResult, bool) {
+	checksumPath := filepath.Join(*outputDir, utils.SanitizeManifestRef(ref), "models", modelCardChecksumFile)
+	stored, err := os.ReadFile(checksumPath)
+	if err != nil || strings.TrimSpace(string(stored)) != modelCardChecksum(modelCard) {
+		return ModelResult{}, false
+	}
+	return loadExistingModelResult(ref)
 }
 
-// getStaticCatalogPaths returns the list of static catalog files to process
-func getStaticCatalogPaths(staticCatalogFiles string, skipDefaultStaticCatalog bool) []string {
-	var paths []string
+// ExtractHuggingFaceModel fetches the README of a HuggingFace-hosted model ("hf" index entry) and
+// extracts its metadata as the modelcard without writing anything to disk. These models have no
+// OCI image, so no artifacts are set.
+func ExtractHuggingFaceModel(uri string) (ModelResult, error) {
+	result := ModelResult{Ref: uri}
 
-	// Add custom static catalog files if specified
-	if staticCatalogFiles != "" {
-		customPaths := strings.Split(staticCatalogFiles, ",")
-		for _, path := range customPaths {
-			path = strings.TrimSpace(path)
-			if path != "" {
-				paths = append(paths, path)
-			}
-		}
+	repoID := huggingface.RepoIDFromURI(uri)
+	if repoID == "" {
+		result.Err = fmt.Errorf("invalid HuggingFace model URI: %q", uri)
+		return result, result.Err
 	}
 
-	// Add default static catalog file if not skipped and exists
-	if !skipDefaultStaticCatalog {
-		defaultPath := filepath.Join(*inputDir, "supplemental-catalog.yaml")
-		if _, err := os.Stat(defaultPath); err == nil {
-			paths = append(paths, defaultPath)
-		}
+	readme, err := huggingface.FetchReadme(repoID)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to fetch HuggingFace README for %s: %w", repoID, err)
+		return result, result.Err
 	}
 
-	return paths
+	modelCard := []byte(readme)
+	result.ModelCardFound = true
+	result.ModelCardPath = filepath.Join("models", "modelcard.md")
+	result.ModelCard = modelCard
+	result.Metadata = metadata.ParseModelCardMetadata(modelCard)
+	result.Extracted = metadata.ExtractMetadataValues(modelCard)
+	result.Extracted.Artifacts = []types.OCIArtifact{}
+
+	return result, nil
 }
 
-// loadModelsWithMetadata loads models with their metadata from various sources with fallback logic
-func loadModelsWithMetadata(modelsIndexPath string) ([]types.ModelEntry, error) {
-	// First try to load from specified models index file
-	if _, err := os.Stat(modelsIndexPath); err == nil {
-		log.Printf("Loading models from: %s", modelsIndexPath)
-		return config.LoadModelsConfigFromYAML(modelsIndexPath)
+// writeModelResult writes the modelcard and metadata.yaml of an extracted model to the output
+// directory and reports whether metadata.yaml was written. Models without a modelcard get
+// skeleton metadata for enrichment processing; models whose modelcard is empty get no
+// metadata.yaml at all, since one would pass the image off as having a usable modelcard.
+func writeModelResult(result ModelResult) bool {
+	// Directory names cannot be reversed, so each one records the ref it belongs to
+	if err := utils.WriteManifestRef(filepath.Join(*outputDir, utils.SanitizeManifestRef(result.Ref)), result.Ref); err != nil {
+		log.Printf("  Warning: Failed to record manifest ref: %v", err)
 	}
 
-	// Try to load from latest version index file as fallback
-	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
-	if err == nil {
-		log.Printf("Using latest version index file: %s", latestIndexFile)
-		// Convert version index to model entries (all validated=true, featured=false by default)
-		modelURIs, err := config.LoadModelsFromVersionIndex(latestIndexFile)
-		if err != nil {
-			return nil, err
+	if result.Reused {
+		// Only metadata.yaml is rewritten, so label changes in the models index still apply
+		metadataFilePath := filepath.Join(*outputDir, utils.SanitizeManifestRef(result.Ref), "models", "metadata.yaml")
+		if err := metadata.WriteMetadataFile(metadataFilePath, &result.Extracted); err != nil {
+			log.Printf("Failed to write metadata: %v", err)
+			return false
 		}
+		writeExtractionCache(filepath.Dir(metadataFilePath), result)
+		return true
+	}
 
-		var modelEntries []types.ModelEntry
-		for _, uri := range modelURIs {
-			modelEntries = append(modelEntries, types.ModelEntry{
-				Type:   "oci",
-				URI:    uri,
-				Labels: []string{"validated"},
-			})
+	if result.ModelCardEmpty {
+		log.Printf("  Modelcard present but empty, skipping metadata.yaml")
+		// Outputs of an earlier run with a non-empty modelcard would keep the model in the catalog
+		if err := os.RemoveAll(filepath.Join(*outputDir, utils.SanitizeManifestRef(result.Ref), "models")); err != nil {
+			log.Printf("  Warning: Failed to remove stale metadata: %v", err)
 		}
-		return modelEntries, nil
+		return false
 	}
 
-	return nil, fmt.Errorf("no valid models index file found at %s and no version index files available", modelsIndexPath)
-}
-
-// processModelsInParallelWithMetadata processes multiple models concurrently with metadata support
-func processModelsInParallelWithMetadata(modelEntries []types.ModelEntry, maxConcurrent int) []ModelResult {
-	// Extract URIs for processing
-	var manifestRefs []string
-	uriToEntry := make(map[string]types.ModelEntry)
-
-	for _, entry := range modelEntries {
-		manifestRefs = append(manifestRefs, entry.URI)
-		uriToEntry[entry.URI] = entry
+	if !result.ModelCardFound {
+		if result.LayerEmpty {
+			log.Printf("  Modelcard layer present but holds no files, creating skeleton metadata for enrichment")
+		} else {
+			log.Printf("  No modelcard layer found, creating skeleton metadata for enrichment")
+		}
+		if !createSkeletonMetadata(result.Ref, &result.Extracted) {
+			return false
+		}
+		writeExtractionCache(filepath.Join(*outputDir, utils.SanitizeManifestRef(result.Ref), "models"), result)
+		return true
 	}
 
-	return processModelsInParallelWithEntryMap(manifestRefs, uriToEntry, maxConcurrent)
-}
+	// The modelcard keeps its path inside the layer (including subdirectories)
+	modelCardPath, ok := safeLayerPath(result.ModelCardPath)
+	if !ok {
+		log.Printf("Failed to write modelcard: unsafe path %q", result.ModelCardPath)
+		return false
+	}
+	modelDir := filepath.Join(*outputDir, utils.SanitizeManifestRef(result.Ref))
+	outputFilePath := filepath.Join(modelDir, filepath.FromSlash(modelCardPath))
+	if err := os.MkdirAll(filepath.Dir(outputFilePath), 0755); err != nil {
+		log.Printf("Failed to create output directory: %v", err)
+		return false
+	}
 
-// processModelsInParallelWithEntryMap processes multiple models concurrently with entry metadata
-func processModelsInParallelWithEntryMap(manifestRefs []string, uriToEntry map[string]types.ModelEntry, maxConcurrent int) []ModelResult {
-	sys := &containertypes.SystemContext{
-		ArchitectureChoice: "amd64",
-		OSChoice:           "linux",
+	// Write modelcard content to file
+	if err := os.WriteFile(outputFilePath, result.ModelCard, 0644); err != nil {
+		log.Printf("Failed to write modelcard content to file: %v", err)
+		return false
 	}
 
-	// Create a WaitGroup to wait for all goroutines to complete
-	var wg sync.WaitGroup
+	log.Printf("  Successfully wrote modelcard content to: %s", outputFilePath)
 
-	// Create a semaphore to limit concurrent goroutines
-	semaphore := make(chan struct{}, maxConcurrent)
+	writeExtraFiles(modelDir, result.ExtraFiles)
 
-	// Channel to collect results from goroutines
-	results := make(chan ModelResult, len(manifestRefs))
+	// metadata.yaml always goes in models/, where enrichment and catalog generation read it,
+	// wherever the modelcard was stored in the layer
+	metadataDir := filepath.Join(modelDir, "models")
+	if err := os.MkdirAll(metadataDir, 0755); err != nil {
+		log.Printf("Failed to create output directory: %v", err)
+		return false
+	}
+	metadataFilePath := filepath.Join(metadataDir, "metadata.yaml")
+	if err := metadata.WriteMetadataFile(metadataFilePath, &result.Extracted); err != nil {
+		log.Printf("Failed to write metadata: %v", err)
+		return false
+	}
+	log.Printf("  Successfully wrote metadata.yaml to: %s", metadataFilePath)
 
-	// Process each manifest reference in parallel with concurrency limit
-	for _, manifestRef := range manifestRefs {
-		// Acquire semaphore (blocks if max goroutines are already running)
-		semaphore <- struct{}{}
+	checksumPath := filepath.Join(metadataDir, modelCardChecksumFile)
+	if err := os.WriteFile(checksumPath, []byte(modelCardChecksum(result.ModelCard)+"\n"), 0644); err != nil {
+		log.Printf("  Warning: Failed to write modelcard checksum: %v", err)
+	}
+	writeExtractionCache(metadataDir, result)
+	return true
+}
 
-		wg.Add(1)
-		go func(ref string, entry types.ModelEntry) {
-			defer wg.Done()
-			defer func() { <-semaphore }() // Release semaphore when done
-
-			log.Printf("Starting processing for: %s", ref)
-			src, layers, configBlob := fetchManifestSrcAndLayers(ref, sys)
-			defer func() { _ = src.Close() }()
-			modelCardFound, metadata := scanLayersForModelCardWithTags(layers, src, ref, configBlob, entry)
-			log.Printf("Completed processing for: %s", ref)
-
-			// Send result to channel
-			results <- ModelResult{
-				Ref:            ref,
-				ModelCardFound: modelCardFound,
-				Metadata:       metadata,
-			}
-		}(manifestRef, uriToEntry[manifestRef])
+// writeExtractionCache stores the extraction of a model in metadataDir so a later run can reuse it
+// (see loadExistingModelResult); failures are logged and only cost the reuse
+func writeExtractionCache(metadataDir string, result ModelResult) {
+	extraction := result.Extraction
+	if extraction == nil {
+		extraction = &result.Extracted
+	}
+	data, err := yaml.Marshal(extraction)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(metadataDir, extractionCacheFile), data, 0644)
+	}
+	if err != nil {
+		log.Printf("  Warning: Failed to write cached extraction: %v", err)
 	}
+}
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-	close(results)
+// defaultModelCardExtensions are the file extensions recognized as a modelcard by default
+var defaultModelCardExtensions = []string{".md", ".markdown", ".mdx"}
 
-	// Collect all results
-	var modelResults []ModelResult
-	for result := range results {
-		modelResults = append(modelResults, result)
+// modelCardExtensions holds the --modelcard-extensions set
+var modelCardExtensions = defaultModelCardExtensions
+
+// setModelCardExtensions sets the modelcard file extensions, adding a missing leading dot; an
+// empty list restores the defaults
+func setModelCardExtensions(extensions []string) error {
+	if len(extensions) == 0 {
+		modelCardExtensions = defaultModelCardExtensions
+		return nil
+	}
+	normalized := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if ext == "." || strings.ContainsAny(ext[1:], "./*?[") {
+			return fmt.Errorf("invalid extension %q", ext)
+		}
+		normalized = append(normalized, ext)
 	}
+	modelCardExtensions = normalized
+	return nil
+}
 
-	return modelResults
+// isModelCardFile reports whether a layer file name has a modelcard extension (case-insensitive)
+func isModelCardFile(name string) bool {
+	return modelCardExtensionRank(name) >= 0
 }
 
-// scanLayersForModelCardWithTags scans container layers for model card content and adds model labels as tags
-func scanLayersForModelCardWithTags(layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte, entry types.ModelEntry) (bool, types.ModelMetadata) {
-	modelCardFound, metadata := scanLayersForModelCard(layers, src, manifestRef, configBlob)
+// modelCardExtensionRank returns the position of a file name's extension in the modelcard
+// extensions (case-insensitive), lower being preferred, or -1 when it is not a modelcard extension
+func modelCardExtensionRank(name string) int {
+	ext := strings.ToLower(path.Ext(name))
+	if ext == "" {
+		return -1
+	}
+	return slices.Index(modelCardExtensions, ext)
+}
 
-	// Add labels from the model entry as tags to the extracted metadata
-	// This works for both successful extractions and skeleton metadata
-	addModelLabelTags(manifestRef, entry)
+// extractFilePatterns holds the --extract-files globs; empty extracts only the modelcard
+var extractFilePatterns []string
 
-	return modelCardFound, metadata
+// matchesExtractFiles reports whether a file in the modelcard layer matches a --extract-files
+// pattern. Patterns are matched against the full path and the base name, so "config.json"
+// matches "models/config.json".
+func matchesExtractFiles(name string) bool {
+	for _, pattern := range extractFilePatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
 }
 
-// addModelLabelTags adds model labels as tags to the extracted metadata
-func addModelLabelTags(manifestRef string, entry types.ModelEntry) {
-	// Create sanitized directory name for the model
-	sanitizedName := utils.SanitizeManifestRef(manifestRef)
-	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", *outputDir, sanitizedName)
+// applyExtraFiles parses known additional files into the extracted metadata; currently
+// config.json provides the model architectures and type
+func applyExtraFiles(extracted *types.ExtractedMetadata, extraFiles map[string][]byte, ref string) {
+	for name, content := range extraFiles {
+		if path.Base(name) != "config.json" {
+			continue
+		}
+		config, err := metadata.ParseModelConfig(content)
+		if err != nil {
+			log.Printf("  Warning: Failed to parse %s for %s: %v", name, ref, err)
+			continue
+		}
+		metadata.ApplyModelConfig(extracted, config)
+	}
+}
 
-	// Read existing metadata
-	data, err := os.ReadFile(metadataPath)
-	if err != nil {
-		log.Printf("Warning: Could not read metadata file %s: %v", metadataPath, err)
-		return
+// writeExtraFiles writes the additional extracted files under modelDir at their layer paths,
+// next to the modelcard. Paths that would escape modelDir are skipped.
+func writeExtraFiles(modelDir string, extraFiles map[string][]byte) {
+	for name, content := range extraFiles {
+		relPath, ok := safeLayerPath(name)
+		if !ok {
+			log.Printf("  Warning: Skipping additional file with unsafe path: %s", name)
+			continue
+		}
+		filePath := filepath.Join(modelDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			log.Printf("  Warning: Failed to create directory for %s: %v", filePath, err)
+			continue
+		}
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			log.Printf("  Warning: Failed to write additional file %s: %v", filePath, err)
+			continue
+		}
+		log.Printf("  Successfully wrote additional file to: %s", filePath)
 	}
+}
 
-	// Parse existing metadata
-	var metadata types.ExtractedMetadata
-	err = yaml.Unmarshal(data, &metadata)
-	if err != nil {
-		log.Printf("Warning: Could not parse metadata file %s: %v", metadataPath, err)
-		return
+// safeLayerPath cleans a tar entry name from an image layer into a relative slash-separated path,
+// e.g. "./models/README.md" -> "models/README.md". Absolute names and names escaping the layer
+// root with ".." are rejected so layer contents can never be written outside the model directory.
+func safeLayerPath(name string) (string, bool) {
+	cleaned := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if cleaned == "." || path.IsAbs(cleaned) || !filepath.IsLocal(filepath.FromSlash(cleaned)) {
+		return "", false
 	}
+	return cleaned, true
+}
 
+// addModelLabelTags adds model labels as tags to the extracted metadata
+func addModelLabelTags(extracted *types.ExtractedMetadata, manifestRef string, entry types.ModelEntry) {
 	// Initialize tags slice if nil
-	if metadata.Tags == nil {
-		metadata.Tags = []string{}
+	if extracted.Tags == nil {
+		extracted.Tags = []string{}
 	}
 
-	// Track if we made changes
-	changed := false
-
 	// Add each label from the model entry as a tag if not already present
 	for _, label := range entry.Labels {
-		if label != "" && !slices.Contains(metadata.Tags, label) {
-			metadata.Tags = append(metadata.Tags, label)
-			changed = true
+		if label != "" && !slices.Contains(extracted.Tags, label) {
+			extracted.Tags = append(extracted.Tags, label)
 			log.Printf("Added '%s' tag to %s", label, manifestRef)
 		}
 	}
-
-	// Write back the metadata if changes were made
-	if changed {
-		updatedData, err := yaml.Marshal(&metadata)
-		if err != nil {
-			log.Printf("Warning: Could not marshal updated metadata for %s: %v", manifestRef, err)
-			return
-		}
-
-		err = os.WriteFile(metadataPath, updatedData, 0644)
-		if err != nil {
-			log.Printf("Warning: Could not write updated metadata file %s: %v", metadataPath, err)
-			return
-		}
-	}
 }
 
-// scanLayersForModelCard scans container layers for model card content
-func scanLayersForModelCard(layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte) (bool, types.ModelMetadata) {
+// scanLayersForModelCard scans container layers for model card content and returns the path and
+// content of the modelcard file, plus any files matching --extract-files keyed by their path in the
+// layer; found is false when no modelcard layer holds exactly one file with the most preferred
+// modelcard extension present (see modelCardExtensionRank). errModelCardLayerEmpty
+// tells a modelcard layer whose tar holds no files apart from an image without a modelcard layer.
+// Returns an error if the modelcard layer blob cannot be fetched or the context expires while reading it.
+func scanLayersForModelCard(ctx context.Context, layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string) (path string, content []byte, extraFiles map[string][]byte, found bool, err error) {
+	var emptyLayer bool
 	for i, layer := range layers {
-		log.Printf("Layer %d:", i+1)
-		log.Printf("  Digest: %s", layer.Digest)
-		log.Printf("  MediaType: %s", layer.MediaType)
-		log.Printf("  Size: %d bytes", layer.Size)
+		slog.Debug("Scanning layer", "ref", manifestRef, "layer", i+1, "digest", layer.Digest,
+			"mediaType", layer.MediaType, "size", layer.Size, "annotations", layer.Annotations)
 		if layer.Annotations != nil {
-			log.Printf("  Annotations: %v", layer.Annotations)
 
 			// Check if this layer has the modelcard annotation
 			if layerType, exists := layer.Annotations["io.opendatahub.modelcar.layer.type"]; exists && layerType == "modelcard" {
-				log.Printf("  Found modelcard layer! Attempting to access modelcard layer blob with digest: %s", layer.Digest)
-
-				var layerBlob io.ReadCloser
-				var err error
-
-				layerBlob, _, err = src.GetBlob(context.Background(), containertypes.BlobInfo{
-					Digest: layer.Digest,
-				}, blobinfocachememory.New())
+				slog.Info("Found modelcard layer", "ref", manifestRef, "digest", layer.Digest)
+
+				layerBlob, err := utils.RetryWithExponentialBackoffContext(ctx, registryRetryConfig(), func() (io.ReadCloser, error) {
+					blob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{
+						Digest: layer.Digest,
+					}, blobinfocachememory.New())
+					return blob, err
+				}, fmt.Sprintf("get modelcard blob %s", layer.Digest))
 				if err != nil {
-					log.Fatalf("Failed to get modelcard layer blob: %v", err)
+					return "", nil, nil, false, fmt.Errorf("failed to get modelcard layer blob: %v", err)
 				}
 
 				if layerBlob == nil {
-					log.Printf("layerBlob is nil for modelcard layer")
+					slog.Warn("Modelcard layer blob is nil", "ref", manifestRef, "digest", layer.Digest)
 				} else {
 					var reader io.Reader = layerBlob
 					defer func() { _ = layerBlob.Close() }()
-					log.Printf("  Successfully fetched modelcard layer blob. Attempting to read as tar...")
+					slog.Debug("Fetched modelcard layer blob, reading as tar", "ref", manifestRef)
 
-					// Check if it's a gzipped tar file
+					// Check if it's a compressed tar file
 					if strings.Contains(layer.MediaType, "+gzip") {
-						log.Printf("  Detected gzipped tar file, decompressing...")
+						slog.Debug("Decompressing gzipped modelcard layer", "ref", manifestRef)
 						gzReader, err := gzip.NewReader(layerBlob)
 						if err != nil {
-							log.Printf("Error creating gzip reader: %v", err)
+							slog.Warn("Failed to create gzip reader", "ref", manifestRef, "error", err)
 							continue
 						}
 						defer func() { _ = gzReader.Close() }()
 						reader = gzReader
+					} else if strings.Contains(layer.MediaType, "+zstd") {
+						slog.Debug("Decompressing zstd-compressed modelcard layer", "ref", manifestRef)
+						zstdReader, err := zstd.NewReader(layerBlob)
+						if err != nil {
+							slog.Warn("Failed to create zstd reader", "ref", manifestRef, "error", err)
+							continue
+						}
+						defer zstdReader.Close()
+						reader = zstdReader
 					}
 
 					tr := tar.NewReader(reader)
-					var mdFileCount int
-					var singleMdFileName string
-					var singleMdContent []byte
+					var fileCount int
+					var tarComplete bool
+					var oversized bool
+					// The modelcard is the file whose extension comes first in --modelcard-extensions,
+					// e.g. README.md over CHANGELOG.mdx; several files sharing that extension are ambiguous
+					var cardName string
+					var cardContent []byte
+					cardRank := -1
+					var cardAmbiguous bool
 
 					for {
 						header, err := tr.Next()
 						if err == io.EOF {
+							tarComplete = true
 							break
 						}
 						if err != nil {
-							log.Printf("Error reading tar: %v", err)
+							slog.Warn("Failed to read modelcard layer tar", "ref", manifestRef, "error", err)
 							break
 						}
-						log.Printf("  Found file in tar: %s (size: %d bytes)", header.Name, header.Size)
-						if strings.HasSuffix(header.Name, ".md") {
-							mdFileCount++
-							if mdFileCount > 1 {
-								log.Printf("  Found multiple .md files, skipping content display")
+						slog.Debug("Found file in modelcard layer", "ref", manifestRef, "file", header.Name, "size", header.Size)
+						if !header.FileInfo().Mode().IsRegular() {
+							continue
+						}
+						fileCount++
+						name, ok := safeLayerPath(header.Name)
+						if !ok {
+							slog.Warn("Skipping layer file with unsafe path", "ref", manifestRef, "file", header.Name)
+							continue
+						}
+						if rank := modelCardExtensionRank(name); rank >= 0 {
+							if cardRank >= 0 && rank >= cardRank {
+								// Not read: either a less preferred extension or a second file with the
+								// chosen one, which makes the modelcard ambiguous
+								cardAmbiguous = cardAmbiguous || rank == cardRank
+								slog.Debug("Skipping additional modelcard candidate", "ref", manifestRef, "file", header.Name, "chosen", cardName)
+								continue
+							}
+							// Read only a candidate preferred over the current one, bounded by
+							// --max-modelcard-bytes so a huge layer can't exhaust memory
+							content, err := readModelCard(tr, header.Size, *maxModelcardBytes)
+							if errors.Is(err, errModelCardTooLarge) {
+								slog.Warn("Skipping oversized modelcard", "ref", manifestRef, "file", header.Name, "error", err)
+								oversized = true
+								cardRank = -1
 								break
 							}
-							singleMdFileName = header.Name
-							// Only read content if this is the first (and potentially only) .md file
-							var content bytes.Buffer
-							_, err := io.Copy(&content, tr)
 							if err != nil {
-								log.Printf("Error reading %s: %v", header.Name, err)
+								slog.Warn("Failed to read modelcard", "ref", manifestRef, "file", header.Name, "error", err)
+								continue
+							}
+							cardName, cardContent, cardRank, cardAmbiguous = name, content, rank, false
+						} else if matchesExtractFiles(name) {
+							content, err := readModelCard(tr, header.Size, *maxModelcardBytes)
+							if err != nil {
+								slog.Warn("Skipping additional file", "ref", manifestRef, "file", header.Name, "error", err)
 								continue
 							}
-							singleMdContent = content.Bytes()
+							if extraFiles == nil {
+								extraFiles = make(map[string][]byte)
+							}
+							extraFiles[name] = content
+							slog.Debug("Extracted additional file", "ref", manifestRef, "file", header.Name, "size", len(content))
 						} else {
-							// Skip non-.md files
+							// Skip files that are neither the modelcard nor requested by --extract-files
 							_, err := io.Copy(io.Discard, tr)
 							if err != nil {
-								log.Printf("Error skipping %s: %v", header.Name, err)
+								slog.Warn("Failed to skip layer file", "ref", manifestRef, "file", header.Name, "error", err)
 								continue
 							}
 						}
 					}
 
-					if mdFileCount == 1 {
-						log.Printf("  Found single .md file: %s (size: %d bytes)", singleMdFileName, len(singleMdContent))
+					// A cancelled or expired context aborts the blob read mid-stream
+					if ctx.Err() != nil {
+						return "", nil, nil, false, fmt.Errorf("reading modelcard layer: %v", ctx.Err())
+					}
 
-						// Create output directory
-						sanitizedDir := utils.SanitizeManifestRef(manifestRef)
-						outputDir := filepath.Join(*outputDir, sanitizedDir)
+					if tarComplete && fileCount == 0 {
+						slog.Warn("Modelcard layer holds no files", "ref", manifestRef, "digest", layer.Digest)
+						emptyLayer = true
+						continue
+					}
+					if cardAmbiguous {
+						slog.Warn("Found multiple modelcard files in modelcard layer, skipping", "ref", manifestRef, "extension", modelCardExtensions[cardRank])
+					} else if cardRank >= 0 && len(bytes.TrimSpace(cardContent)) == 0 {
+						slog.Warn("Modelcard is empty", "ref", manifestRef, "file", cardName)
+						return cardName, nil, extraFiles, false, errModelCardEmpty
+					} else if cardRank >= 0 {
+						slog.Info("Found modelcard", "ref", manifestRef, "file", cardName, "size", len(cardContent))
+						return cardName, cardContent, extraFiles, true, nil
+					} else if !oversized {
+						slog.Warn("No modelcard files found in modelcard layer", "ref", manifestRef, "extensions", modelCardExtensions)
+					}
+				}
+			}
+		}
+	}
 
-						// Create the full directory path for the file (including subdirectories)
-						outputFilePath := filepath.Join(outputDir, singleMdFileName)
-						outputFileDir := filepath.Dir(outputFilePath)
-						err := os.MkdirAll(outputFileDir, 0755)
-						if err != nil {
-							log.Fatalf("Failed to create output directory: %v", err)
-						}
+	if emptyLayer {
+		return "", nil, nil, false, errModelCardLayerEmpty
+	}
+	return "", nil, nil, false, nil
+}
 
-						// Write modelcard content to file
-						err = os.WriteFile(outputFilePath, singleMdContent, 0644)
-						if err != nil {
-							log.Fatalf("Failed to write modelcard content to file: %v", err)
-						}
+// referrerManifest is the part of an OCI artifact manifest that locates its blobs; "blobs" is
+// the pre-1.1 artifact manifest spelling of "layers"
+type referrerManifest struct {
+	Layers []referrerBlob `json:"layers"`
+	Blobs  []referrerBlob `json:"blobs"`
+}
 
-						log.Printf("  Successfully wrote modelcard content to: %s", outputFilePath)
+// referrerBlob is a blob descriptor of an OCI artifact manifest
+type referrerBlob struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
 
-						// Parse metadata from the modelcard content
-						metadataFlags := metadata.ParseModelCardMetadata(singleMdContent)
+// scanReferrersForModelCard looks for a modelcard attached to the image as an OCI referrer
+// (an artifact manifest whose artifactType names a model card) and reads its blob. Any failure,
+// including registries without the referrers API, is logged and reported as not found so the
+// caller falls back to the layer-annotation scan.
+func scanReferrersForModelCard(ctx context.Context, src containertypes.ImageSource, manifestRef, manifestDigest string, sys *containertypes.SystemContext) (path string, content []byte, found bool) {
+	if manifestDigest == "" {
+		return "", nil, false
+	}
+	if registry.IsOCILayoutRef(manifestRef) {
+		// A layout on disk has no registry to ask, so only its layers are scanned
+		slog.Debug("Skipping referrers lookup for OCI layout", "ref", manifestRef)
+		return "", nil, false
+	}
+	referrers, err := registry.ListReferrers(ctx, manifestRef, manifestDigest, sys)
+	if err != nil {
+		slog.Debug("Referrers lookup failed, scanning layers", "ref", manifestRef, "error", err)
+		return "", nil, false
+	}
 
-						// Extract actual metadata values
-						extractedMetadata := metadata.ExtractMetadataValues(singleMdContent)
+	for _, referrer := range referrers {
+		if !registry.IsModelCardArtifactType(referrer.ArtifactType) {
+			continue
+		}
+		slog.Info("Found modelcard referrer", "ref", manifestRef, "digest", referrer.Digest, "artifactType", referrer.ArtifactType)
 
-						// Populate artifacts with OCI registry metadata and real timestamps
-						extractedMetadata.Artifacts = registry.ExtractOCIArtifactsFromRegistry(manifestRef)
+		referrerDigest := digest.Digest(referrer.Digest)
+		manifestBlob, _, err := src.GetManifest(ctx, &referrerDigest)
+		if err != nil {
+			slog.Warn("Failed to get modelcard referrer manifest", "ref", manifestRef, "digest", referrer.Digest, "error", err)
+			continue
+		}
+		var artifact referrerManifest
+		if err := json.Unmarshal(manifestBlob, &artifact); err != nil {
+			slog.Warn("Failed to parse modelcard referrer manifest", "ref", manifestRef, "digest", referrer.Digest, "error", err)
+			continue
+		}
 
-						// Extract real timestamps from config blob and update artifacts
-						createTime, updateTime := extractTimestampsFromConfig(configBlob)
-						for i := range extractedMetadata.Artifacts {
-							if extractedMetadata.Artifacts[i].CreateTimeSinceEpoch == nil {
-								extractedMetadata.Artifacts[i].CreateTimeSinceEpoch = createTime
-							}
-							if extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
-								extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
-							}
-						}
+		blob, ok := modelCardReferrerBlob(append(artifact.Layers, artifact.Blobs...))
+		if !ok {
+			slog.Warn("Modelcard referrer has no blobs", "ref", manifestRef, "digest", referrer.Digest)
+			continue
+		}
+		reader, _, err := src.GetBlob(ctx, containertypes.BlobInfo{Digest: digest.Digest(blob.Digest), Size: blob.Size}, blobinfocachememory.New())
+		if err != nil {
+			slog.Warn("Failed to get modelcard referrer blob", "ref", manifestRef, "digest", blob.Digest, "error", err)
+			continue
+		}
+		content, err := readModelCard(reader, blob.Size, *maxModelcardBytes)
+		_ = reader.Close()
+		if err != nil {
+			slog.Warn("Skipping modelcard referrer", "ref", manifestRef, "digest", blob.Digest, "error", err)
+			continue
+		}
+		if len(bytes.TrimSpace(content)) == 0 {
+			slog.Warn("Modelcard referrer is empty", "ref", manifestRef, "digest", blob.Digest)
+			continue
+		}
 
-						// Generate metadata.yaml file in the same directory
-						metadataFilePath := filepath.Join(outputFileDir, "metadata.yaml")
-						metadataYaml, err := yaml.Marshal(&extractedMetadata)
-						if err != nil {
-							log.Printf("Failed to marshal metadata to YAML: %v", err)
-						} else {
-							err = os.WriteFile(metadataFilePath, metadataYaml, 0644)
-							if err != nil {
-								log.Printf("Failed to write metadata.yaml: %v", err)
-							} else {
-								log.Printf("  Successfully wrote metadata.yaml to: %s", metadataFilePath)
-							}
-						}
+		path = "modelcard.md"
+		if title, ok := safeLayerPath(blob.Annotations["org.opencontainers.image.title"]); ok {
+			path = title
+		}
+		slog.Info("Found modelcard", "ref", manifestRef, "referrer", referrer.Digest, "file", path, "size", len(content))
+		return path, content, true
+	}
+	return "", nil, false
+}
 
-						return true, metadataFlags
-					} else {
-						log.Printf("  No .md files found in the blob")
-					}
-				}
-			}
+// modelCardReferrerBlob picks the modelcard blob of a referrer artifact: the first blob whose
+// title has a modelcard extension, otherwise the first blob
+func modelCardReferrerBlob(blobs []referrerBlob) (referrerBlob, bool) {
+	if len(blobs) == 0 {
+		return referrerBlob{}, false
+	}
+	for _, blob := range blobs {
+		if isModelCardFile(blob.Annotations["org.opencontainers.image.title"]) {
+			return blob, true
 		}
 	}
+	return blobs[0], true
+}
+
+// errModelCardEmpty is returned by scanLayersForModelCard when the modelcard file is present but
+// empty or whitespace-only
+var errModelCardEmpty = errors.New("modelcard present but empty")
 
-	// If no modelcard was found, create a skeleton metadata.yaml for enrichment processing
-	log.Printf("  No modelcard layer found, creating skeleton metadata for enrichment")
-	createSkeletonMetadata(manifestRef, configBlob)
+// errModelCardLayerEmpty is returned by scanLayersForModelCard when a modelcard layer is present
+// but its tar is empty or holds only directories
+var errModelCardLayerEmpty = errors.New("modelcard layer present but holds no files")
 
-	return false, types.ModelMetadata{}
+// errModelCardTooLarge is returned by readModelCard when a modelcard exceeds the size limit
+var errModelCardTooLarge = errors.New("modelcard exceeds size limit")
+
+// readModelCard reads a modelcard file from a tar stream, reading at most limit bytes.
+// declaredSize is the size from the tar header and lets oversized files be rejected
+// before any content is read; limit <= 0 disables the check.
+func readModelCard(r io.Reader, declaredSize, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	if declaredSize > limit {
+		return nil, fmt.Errorf("%w: %d bytes (limit %d)", errModelCardTooLarge, declaredSize, limit)
+	}
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", errModelCardTooLarge, limit)
+	}
+	return content, nil
 }
 
-// createSkeletonMetadata creates a basic metadata.yaml file when modelcard extraction fails
-// and attempts to fetch HuggingFace README as a fallback modelcard
-func createSkeletonMetadata(manifestRef string, configBlob []byte) {
+// createSkeletonMetadata writes skeleton metadata.yaml when modelcard extraction fails
+// and attempts to fetch HuggingFace README as a fallback modelcard. Reports whether
+// metadata.yaml was written.
+func createSkeletonMetadata(manifestRef string, skeleton *types.ExtractedMetadata) bool {
 	// Create output directory
 	sanitizedDir := utils.SanitizeManifestRef(manifestRef)
 	outputDir := filepath.Join(*outputDir, sanitizedDir, "models")
@@ -676,46 +2169,21 @@ func createSkeletonMetadata(manifestRef string, configBlob []byte) {
 	err := os.MkdirAll(outputDir, 0755)
 	if err != nil {
 		log.Printf("  Warning: Failed to create skeleton output directory: %v", err)
-		return
+		return false
 	}
 
 	// Try to find matching HuggingFace model and fetch README as fallback
 	tryHuggingFaceFallback(manifestRef, outputDir)
 
-	// Create basic metadata with minimal information
-	metadata := types.ExtractedMetadata{
-		Tags:      []string{}, // Empty tags slice for enrichment to populate
-		Language:  []string{},
-		Tasks:     []string{},
-		Artifacts: registry.ExtractOCIArtifactsFromRegistry(manifestRef),
-	}
-
-	// Extract timestamps from config blob if available
-	createTime, updateTime := extractTimestampsFromConfig(configBlob)
-	for i := range metadata.Artifacts {
-		if metadata.Artifacts[i].CreateTimeSinceEpoch == nil {
-			metadata.Artifacts[i].CreateTimeSinceEpoch = createTime
-		}
-		if metadata.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
-			metadata.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
-		}
-	}
-
 	// Write skeleton metadata.yaml
 	metadataFilePath := filepath.Join(outputDir, "metadata.yaml")
-	metadataYaml, err := yaml.Marshal(&metadata)
-	if err != nil {
-		log.Printf("  Warning: Failed to marshal skeleton metadata to YAML: %v", err)
-		return
-	}
-
-	err = os.WriteFile(metadataFilePath, metadataYaml, 0644)
-	if err != nil {
-		log.Printf("  Warning: Failed to write skeleton metadata.yaml: %v", err)
-		return
+	if err := metadata.WriteMetadataFile(metadataFilePath, skeleton); err != nil {
+		log.Printf("  Warning: Failed to write skeleton metadata: %v", err)
+		return false
 	}
 
 	log.Printf("  Successfully created skeleton metadata.yaml: %s", metadataFilePath)
+	return true
 }
 
 // tryHuggingFaceFallback attempts to find a matching HuggingFace model and fetch its README as a fallback modelcard
@@ -766,7 +2234,10 @@ func tryHuggingFaceFallback(manifestRef string, outputDir string) {
 
 	// Fetch README content from HuggingFace
 	hfReadme, err := huggingface.FetchReadme(bestMatch.Name)
-	if err != nil {
+	if errors.Is(err, huggingface.ErrGatedModel) {
+		log.Printf("  Gated model skipped for README fallback: %v", err)
+		return
+	} else if err != nil {
 		log.Printf("  Warning: Failed to fetch HuggingFace README for fallback: %v", err)
 		return
 	}
@@ -785,64 +2256,118 @@ func tryHuggingFaceFallback(manifestRef string, outputDir string) {
 	log.Printf("  Successfully created fallback modelcard.md from HuggingFace README: %s", modelcardPath)
 }
 
-// fetchManifestSrcAndLayers fetches manifest, layers, and config blob from container registry
-func fetchManifestSrcAndLayers(manifestRef string, sys *containertypes.SystemContext) (containertypes.ImageSource, []containertypes.BlobInfo, []byte) {
+// registryRetryConfig returns the retry policy for registry pulls.
+// Only transient failures are retried; the per-model fetch timeout bounds the total time.
+func registryRetryConfig() utils.RetryConfig {
+	cfg := utils.DefaultRetryConfig
+	cfg.MaxRetries = *maxRetries
+	cfg.OverallTimeout = 0
+	cfg.Jitter = 0.2
+	cfg.ShouldRetry = registry.IsTransientError
+	return cfg
+}
+
+// fetchManifestSrcAndLayers fetches manifest, layers, config blob and manifest digest from container registry,
+// or from an OCI layout on disk for "oci:<dir>[:<tag>]" references.
+// All registry calls are bound to ctx; the returned image source must be closed by the caller.
+func fetchManifestSrcAndLayers(ctx context.Context, manifestRef string, sys *containertypes.SystemContext) (containertypes.ImageSource, []containertypes.BlobInfo, []byte, string, error) {
 	log.Printf("Parsing reference...")
-	ref, err := docker.ParseReference("//" + manifestRef)
+	ref, err := registry.ParseImageReference(manifestRef)
 	if err != nil {
-		log.Fatalf("Failed to parse reference: %v", err)
+		return nil, nil, nil, "", fmt.Errorf("failed to parse reference: %v", err)
 	}
 
 	// Create a new image source (later will use to get "the" blob)
 	log.Printf("Creating image source...")
-	src, err := ref.NewImageSource(context.Background(), sys)
+	src, err := utils.RetryWithExponentialBackoffContext(ctx, registryRetryConfig(), func() (containertypes.ImageSource, error) {
+		return ref.NewImageSource(ctx, sys)
+	}, fmt.Sprintf("create image source for %s", manifestRef))
 	if err != nil {
-		log.Fatalf("Failed to create image source: %v", err)
+		return nil, nil, nil, "", fmt.Errorf("failed to create image source: %v", err)
 	}
-	// not closing `src` given it is returned to the caller
+	// not closing `src` on success given it is returned to the caller
 
 	// Get the manifest
-	manifest, manifestType, err := src.GetManifest(context.Background(), nil)
+	type manifestResult struct {
+		manifest     []byte
+		manifestType string
+	}
+	fetched, err := utils.RetryWithExponentialBackoffContext(ctx, registryRetryConfig(), func() (manifestResult, error) {
+		manifest, manifestType, err := src.GetManifest(ctx, nil)
+		return manifestResult{manifest, manifestType}, err
+	}, fmt.Sprintf("get manifest for %s", manifestRef))
+	manifest, manifestType := fetched.manifest, fetched.manifestType
 	if err != nil {
-		log.Fatalf("Failed to get manifest: %v", err)
+		_ = src.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to get manifest: %v", err)
 	}
 
 	log.Printf("Manifest type: %s", manifestType)
 	log.Printf("Manifest size: %d bytes", len(manifest))
 
+	// Digest of the manifest the tag resolves to (the index digest for multi-arch images)
+	manifestDigest, err := imgmanifest.Digest(manifest)
+	if err != nil {
+		_ = src.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to compute manifest digest: %v", err)
+	}
+
+	// Resolve multi-arch image indexes to the concrete manifest for the selected platform,
+	// otherwise the layer list would not contain the modelcard layer
+	unparsed := image.UnparsedInstance(src, nil)
+	if imgmanifest.MIMETypeIsMultiImage(manifestType) {
+		list, err := imgmanifest.ListFromBlob(manifest, manifestType)
+		if err != nil {
+			_ = src.Close()
+			return nil, nil, nil, "", fmt.Errorf("failed to parse image index: %v", err)
+		}
+		instanceDigest, err := list.ChooseInstance(sys)
+		if err != nil {
+			_ = src.Close()
+			return nil, nil, nil, "", fmt.Errorf("no image for platform %s/%s in index: %v", sys.OSChoice, sys.ArchitectureChoice, err)
+		}
+		log.Printf("Image index detected, selected %s/%s manifest: %s", sys.OSChoice, sys.ArchitectureChoice, instanceDigest)
+		unparsed = image.UnparsedInstance(src, &instanceDigest)
+	}
+
 	// Get the image
-	img, err := ref.NewImage(context.Background(), sys)
+	img, err := image.FromUnparsedImage(ctx, sys, unparsed)
 	if err != nil {
-		log.Fatalf("Failed to create image: %v", err)
+		_ = src.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to create image: %v", err)
 	}
-	defer func() { _ = img.Close() }()
 
-	// Get the image configuration
+	// Get the image configuration. The layers can still be scanned without it, so a config that
+	// cannot be fetched only costs the image timestamps and platform, which enrichment may fill later.
 	log.Printf("Getting config blob...")
-	configBlob, err := img.ConfigBlob(context.Background())
+	configBlob, err := utils.RetryWithExponentialBackoffContext(ctx, registryRetryConfig(), func() ([]byte, error) {
+		return img.ConfigBlob(ctx)
+	}, fmt.Sprintf("get config blob for %s", manifestRef))
 	if err != nil {
-		log.Fatalf("Failed to get config blob: %v", err)
+		log.Printf("  Warning: failed to get config blob for %s, continuing without image timestamps: %v", manifestRef, err)
+		configBlob = nil
 	}
 
 	log.Printf("Config blob size: %d bytes", len(configBlob))
 
-	// Get layer information
+	// Get layer information (served from the already-fetched manifest, no network I/O)
 	log.Printf("Getting layer infos...")
 	layers := img.LayerInfos()
 	log.Printf("Number of layers: %d", len(layers))
 
 	// Get layer digests from layer infos
-	log.Printf("Layer digests:")
 	for i, layer := range layers {
-		log.Printf("  Layer %d: %s", i+1, layer.Digest)
+		slog.Debug("Layer digest", "ref", manifestRef, "layer", i+1, "digest", layer.Digest)
 	}
-	return src, layers, configBlob
+	return src, layers, configBlob, manifestDigest.String(), nil
 }
 
-// OCI Image Config structure for timestamp extraction
+// OCI Image Config structure for timestamp and platform extraction
 type OCIImageConfig struct {
-	Created string `json:"created"`
-	History []struct {
+	Created      string `json:"created"`
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	History      []struct {
 		Created string `json:"created"`
 	} `json:"history"`
 }
@@ -886,6 +2411,20 @@ func extractTimestampsFromConfig(configBlob []byte) (*int64, *int64) {
 	return createTime, updateTime
 }
 
+// extractPlatformFromConfig returns the os and architecture recorded in an OCI config blob;
+// either is empty when absent or when the blob cannot be parsed
+func extractPlatformFromConfig(configBlob []byte) (string, string) {
+	if len(configBlob) == 0 {
+		return "", ""
+	}
+	var config OCIImageConfig
+	if err := json.Unmarshal(configBlob, &config); err != nil {
+		// extractTimestampsFromConfig already warned about the unparseable blob
+		return "", ""
+	}
+	return config.OS, config.Architecture
+}
+
 // formatTimestamp formats a timestamp pointer for logging
 func formatTimestamp(ts *int64) string {
 	if ts == nil {
@@ -895,17 +2434,31 @@ func formatTimestamp(ts *int64) string {
 }
 
 // generateManifestsYAML creates a manifests.yaml file tracking all processed models
-func generateManifestsYAML(modelResults []ModelResult, outputDir string) error {
+func generateManifestsYAML(modelResults []ModelResult, retainedRefs []string, outputDir string) error {
 	var manifests types.ManifestsData
+	failedCount := 0
+	manifestsPath := filepath.Join(outputDir, "manifests.yaml")
 
 	for _, result := range modelResults {
 		manifest := types.ModelManifest{
 			Ref: result.Ref,
 			ModelCard: types.ModelCard{
-				Present:  result.ModelCardFound,
-				Metadata: result.Metadata,
+				Present:    result.ModelCardFound,
+				Empty:      result.ModelCardEmpty,
+				LayerEmpty: result.LayerEmpty,
+				Metadata:   result.Metadata,
 			},
 		}
+		if result.Err != nil {
+			manifest.Error = result.Err.Error()
+			failedCount++
+		}
+		manifests.Models = append(manifests.Models, manifest)
+	}
+	for _, manifest := range previousManifests(manifestsPath, retainedRefs) {
+		if manifest.Error != "" {
+			failedCount++
+		}
 		manifests.Models = append(manifests.Models, manifest)
 	}
 
@@ -922,12 +2475,88 @@ func generateManifestsYAML(modelResults []ModelResult, outputDir string) error {
 	}
 
 	// Write to file in output directory
-	manifestsPath := filepath.Join(outputDir, "manifests.yaml")
 	err = os.WriteFile(manifestsPath, yamlData, 0644)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Generated manifests.yaml with %d models", len(manifests.Models))
+	log.Printf("Generated manifests.yaml with %d models (%d failed)", len(manifests.Models), failedCount)
+
+	return writeExtractionSummary(modelResults, outputDir)
+}
+
+// previousManifests returns the entries of an earlier manifests.yaml for refs, the models not
+// processed in a --models run. A missing or unreadable file yields none.
+func previousManifests(manifestsPath string, refs []string) []types.ModelManifest {
+	if len(refs) == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(manifestsPath)
+	if err != nil {
+		return nil
+	}
+	var previous types.ManifestsData
+	if err := yaml.Unmarshal(data, &previous); err != nil {
+		log.Printf("  Warning: Could not read previous manifests.yaml, unselected models are left out: %v", err)
+		return nil
+	}
+	retained := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		retained[ref] = true
+	}
+	var manifests []types.ModelManifest
+	for _, manifest := range previous.Models {
+		if retained[manifest.Ref] {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests
+}
+
+// writeExtractionSummary writes extraction-summary.yaml listing, per model, whether a modelcard
+// was found and metadata.yaml written, with aggregate counts so operators can check a run at a glance
+func writeExtractionSummary(modelResults []ModelResult, outputDir string) error {
+	summary := types.ExtractionSummary{
+		Total:  len(modelResults),
+		Models: []types.ExtractionSummaryEntry{},
+	}
+
+	for _, result := range modelResults {
+		entry := types.ExtractionSummaryEntry{
+			Ref:                 result.Ref,
+			ModelCardFound:      result.ModelCardFound,
+			ModelCardEmpty:      result.ModelCardEmpty,
+			ModelCardLayerEmpty: result.LayerEmpty,
+			MetadataWritten:     result.MetadataWritten,
+		}
+		switch {
+		case result.Err != nil:
+			entry.Error = result.Err.Error()
+			summary.Errored++
+		case result.ModelCardFound:
+			summary.Succeeded++
+		default:
+			summary.NoModelCard++
+		}
+		summary.Models = append(summary.Models, entry)
+	}
+
+	// Results arrive in completion order; sort so summaries from different runs can be diffed
+	sort.Slice(summary.Models, func(i, j int) bool {
+		return summary.Models[i].Ref < summary.Models[j].Ref
+	})
+
+	yamlData, err := yaml.Marshal(&summary)
+	if err != nil {
+		return err
+	}
+
+	summaryPath := filepath.Join(outputDir, "extraction-summary.yaml")
+	if err := os.WriteFile(summaryPath, yamlData, 0644); err != nil {
+		return err
+	}
+
+	log.Printf("Generated extraction-summary.yaml: %d total, %d succeeded, %d without modelcard, %d errored",
+		summary.Total, summary.Succeeded, summary.NoModelCard, summary.Errored)
 	return nil
 }
@@ -1,9 +1,37 @@
 package main
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/internal/config"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestLoadDotEnv(t *testing.T) {
@@ -157,3 +185,1530 @@ func TestLoadDotEnv_MissingFile(t *testing.T) {
 	// Should not panic or error on missing file
 	loadDotEnv("/nonexistent/path/.env")
 }
+
+func TestModelSelection_KeepsUnselectedModels(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+	entries := []types.ModelEntry{
+		{Type: "oci", URI: "registry.example.com/org/granite-8b:1.0"},
+		{Type: "oci", URI: "registry.example.com/org/llama-8b:1.0"},
+	}
+	// A previous full run left metadata and manifests for both models
+	for _, entry := range entries {
+		name := path.Base(entry.URI)
+		if !writeModelResult(ModelResult{Ref: entry.URI, ModelCardFound: true, ModelCard: []byte("# " + name), ModelCardPath: "models/README.md", Extracted: types.ExtractedMetadata{Name: &name}}) {
+			t.Fatalf("writeModelResult failed for %s", entry.URI)
+		}
+	}
+	if err := generateManifestsYAML([]ModelResult{{Ref: entries[0].URI}, {Ref: entries[1].URI, Err: errors.New("timeout")}}, nil, tmpDir); err != nil {
+		t.Fatalf("generateManifestsYAML failed: %v", err)
+	}
+
+	var patterns modelPatterns
+	if err := patterns.Set("granite-*"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	selected, retained := splitModelSelection(entries, patterns)
+	if !reflect.DeepEqual(selected, entries[:1]) || !reflect.DeepEqual(retained, []string{entries[1].URI}) {
+		t.Fatalf("splitModelSelection() = %v, %v", selected, retained)
+	}
+
+	if err := generateManifestsYAML([]ModelResult{{Ref: entries[0].URI, ModelCardFound: true}}, retained, tmpDir); err != nil {
+		t.Fatalf("generateManifestsYAML failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "manifests.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read manifests.yaml: %v", err)
+	}
+	var manifests types.ManifestsData
+	if err := yaml.Unmarshal(data, &manifests); err != nil {
+		t.Fatalf("Failed to parse manifests.yaml: %v", err)
+	}
+	if len(manifests.Models) != 2 || manifests.Models[1].Ref != entries[1].URI || manifests.Models[1].Error != "timeout" {
+		t.Errorf("Expected the unselected model's previous manifest to be kept, got %+v", manifests.Models)
+	}
+
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	if err := catalog.CreateModelsCatalogWithStaticFromResults(tmpDir, catalogPath, catalogModelRefs(entries), nil, catalog.LabelFilter{}); err != nil {
+		t.Fatalf("CreateModelsCatalogWithStaticFromResults failed: %v", err)
+	}
+	models, err := catalog.ReadModelsCatalog(catalogPath)
+	if err != nil {
+		t.Fatalf("ReadModelsCatalog failed: %v", err)
+	}
+	var names []string
+	for _, model := range models.Models {
+		names = append(names, *model.Name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"granite-8b:1.0", "llama-8b:1.0"}) {
+		t.Errorf("Catalog models = %v, want both the selected and unselected model", names)
+	}
+}
+
+func TestGenerateManifestsYAML_RecordsFailures(t *testing.T) {
+	tmpDir := t.TempDir()
+	results := []ModelResult{
+		{Ref: "registry.example.com/org/ok:1.0", ModelCardFound: true},
+		{Ref: "registry.example.com/org/hung:1.0", Err: context.DeadlineExceeded},
+		{Ref: "registry.example.com/org/blank:1.0", ModelCardEmpty: true},
+		{Ref: "registry.example.com/org/hollow:1.0", LayerEmpty: true, MetadataWritten: true},
+	}
+
+	if err := generateManifestsYAML(results, nil, tmpDir); err != nil {
+		t.Fatalf("generateManifestsYAML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "manifests.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read manifests.yaml: %v", err)
+	}
+
+	var manifests types.ManifestsData
+	if err := yaml.Unmarshal(data, &manifests); err != nil {
+		t.Fatalf("Failed to parse manifests.yaml: %v", err)
+	}
+
+	if len(manifests.Models) != 4 {
+		t.Fatalf("Expected 4 models, got %d", len(manifests.Models))
+	}
+	if manifests.Models[0].Error != "" {
+		t.Errorf("Expected no error for successful model, got %q", manifests.Models[0].Error)
+	}
+	if manifests.Models[1].Error != context.DeadlineExceeded.Error() {
+		t.Errorf("Expected error %q for failed model, got %q", context.DeadlineExceeded.Error(), manifests.Models[1].Error)
+	}
+	if blank := manifests.Models[2]; !blank.ModelCard.Empty || blank.ModelCard.Present || blank.Error != "" {
+		t.Errorf("Expected empty modelcard to be reported as empty and not present, got %+v", blank.ModelCard)
+	}
+	if hollow := manifests.Models[3]; !hollow.ModelCard.LayerEmpty || hollow.ModelCard.Empty || hollow.ModelCard.Present {
+		t.Errorf("Expected empty modelcard layer to be reported as layerEmpty only, got %+v", hollow.ModelCard)
+	}
+	if manifests.Models[0].ModelCard.LayerEmpty || manifests.Models[1].ModelCard.LayerEmpty {
+		t.Error("Expected layerEmpty only on the model whose modelcard layer held no files")
+	}
+}
+
+func TestGenerateManifestsYAML_WritesExtractionSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	results := []ModelResult{
+		{Ref: "registry.example.com/org/zeta:1.0", ModelCardFound: true, MetadataWritten: true},
+		{Ref: "registry.example.com/org/alpha:1.0", MetadataWritten: true},
+		{Ref: "registry.example.com/org/hung:1.0", Err: context.DeadlineExceeded},
+	}
+
+	if err := generateManifestsYAML(results, nil, tmpDir); err != nil {
+		t.Fatalf("generateManifestsYAML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "extraction-summary.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read extraction-summary.yaml: %v", err)
+	}
+
+	var summary types.ExtractionSummary
+	if err := yaml.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Failed to parse extraction-summary.yaml: %v", err)
+	}
+
+	if summary.Total != 3 || summary.Succeeded != 1 || summary.NoModelCard != 1 || summary.Errored != 1 {
+		t.Errorf("Unexpected counts: total=%d succeeded=%d noModelCard=%d errored=%d",
+			summary.Total, summary.Succeeded, summary.NoModelCard, summary.Errored)
+	}
+
+	expected := []types.ExtractionSummaryEntry{
+		{Ref: "registry.example.com/org/alpha:1.0", MetadataWritten: true},
+		{Ref: "registry.example.com/org/hung:1.0", Error: context.DeadlineExceeded.Error()},
+		{Ref: "registry.example.com/org/zeta:1.0", ModelCardFound: true, MetadataWritten: true},
+	}
+	if !reflect.DeepEqual(summary.Models, expected) {
+		t.Errorf("Models = %+v, want %+v", summary.Models, expected)
+	}
+}
+
+func TestWriteModelResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	name := "Granite Test"
+	result := ModelResult{
+		Ref:            "registry.example.com/org/granite:1.0",
+		ModelCardFound: true,
+		ModelCardPath:  "models/modelcard.md",
+		ModelCard:      []byte("# Granite Test\n"),
+		Extracted:      types.ExtractedMetadata{Name: &name},
+	}
+	if !writeModelResult(result) {
+		t.Error("Expected writeModelResult to report metadata.yaml as written")
+	}
+
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(result.Ref), "models")
+	card, err := os.ReadFile(filepath.Join(modelDir, "modelcard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read modelcard.md: %v", err)
+	}
+	if string(card) != "# Granite Test\n" {
+		t.Errorf("modelcard.md = %q, want %q", card, "# Granite Test\n")
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read metadata.yaml: %v", err)
+	}
+	var extracted types.ExtractedMetadata
+	if err := yaml.Unmarshal(data, &extracted); err != nil {
+		t.Fatalf("Failed to parse metadata.yaml: %v", err)
+	}
+	if extracted.Name == nil || *extracted.Name != name {
+		t.Errorf("Name = %v, want %q", extracted.Name, name)
+	}
+}
+
+func TestWriteModelResult_UnwritableDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	result := ModelResult{
+		Ref:            "registry.example.com/org/granite:1.0",
+		ModelCardFound: true,
+		ModelCardPath:  "models/modelcard.md",
+		ModelCard:      []byte("# Granite Test\n"),
+	}
+	// A file where the models directory belongs makes the directory impossible to create
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(result.Ref))
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "models"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The failure is reported for this model only instead of exiting the whole run
+	if writeModelResult(result) {
+		t.Error("Expected writeModelResult to report metadata.yaml as not written")
+	}
+}
+
+func TestWriteModelResult_EmptyModelCard(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	result := ModelResult{Ref: "registry.example.com/org/blank:1.0", ModelCardEmpty: true}
+	// Left by an earlier run, when the modelcard still had content
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(result.Ref))
+	metadataPath := filepath.Join(modelDir, "models", "metadata.yaml")
+	if err := os.MkdirAll(filepath.Dir(metadataPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(metadataPath, []byte("name: Stale Model\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if writeModelResult(result) {
+		t.Error("Expected writeModelResult to report metadata.yaml as not written")
+	}
+	if _, err := os.Stat(metadataPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no metadata.yaml for an empty modelcard, stat error = %v", err)
+	}
+	if ref, err := utils.ReadManifestRef(modelDir); err != nil || ref != result.Ref {
+		t.Errorf("ReadManifestRef() = %q, %v, want %q", ref, err, result.Ref)
+	}
+}
+
+func TestAddModelLabelTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		labels   []string
+		expected []string
+	}{
+		{
+			name:     "labels seed nil tags",
+			labels:   []string{"validated", "featured"},
+			expected: []string{"validated", "featured"},
+		},
+		{
+			name:     "existing tags are kept and not duplicated",
+			tags:     []string{"granite", "validated"},
+			labels:   []string{"validated", "featured"},
+			expected: []string{"granite", "validated", "featured"},
+		},
+		{
+			name:     "empty labels are skipped",
+			labels:   []string{"", "featured"},
+			expected: []string{"featured"},
+		},
+		{
+			name:     "no labels leaves empty tags",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extracted := types.ExtractedMetadata{Tags: tt.tags}
+			entry := types.ModelEntry{URI: "registry.example.com/org/model:1.0", Labels: tt.labels}
+			addModelLabelTags(&extracted, entry.URI, entry)
+			if !reflect.DeepEqual(extracted.Tags, tt.expected) {
+				t.Errorf("Tags = %v, want %v", extracted.Tags, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyRunConfig_CommandLineTakesPrecedence(t *testing.T) {
+	fs := flag.NewFlagSet("model-extractor", flag.ContinueOnError)
+	input := fs.String("input", "data/models-index.yaml", "")
+	output := fs.String("output-dir", "output", "")
+	concurrency := fs.Int("max-concurrent", 5, "")
+	skipEnrich := fs.Bool("skip-enrichment", false, "")
+	timeout := fs.Duration("fetch-timeout", 120*time.Second, "")
+	if err := fs.Parse([]string{"--output-dir=/cli/output", "--max-concurrent=2"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	fileInput, fileOutput, fileConcurrency, fileSkip, fileTimeout := "file-index.yaml", "/file/output", 10, true, time.Minute
+	runConfig := &types.Config{
+		ModelsIndexPath: &fileInput,
+		OutputDir:       &fileOutput,
+		MaxConcurrent:   &fileConcurrency,
+		SkipEnrichment:  &fileSkip,
+		FetchTimeout:    &fileTimeout,
+	}
+	if err := applyRunConfig(fs, runConfig); err != nil {
+		t.Fatalf("applyRunConfig failed: %v", err)
+	}
+
+	// Values from the file fill in flags that were not given
+	if *input != fileInput {
+		t.Errorf("input = %q, want %q from config file", *input, fileInput)
+	}
+	if !*skipEnrich {
+		t.Error("skip-enrichment = false, want true from config file")
+	}
+	if *timeout != fileTimeout {
+		t.Errorf("fetch-timeout = %v, want %v from config file", *timeout, fileTimeout)
+	}
+	// Flags given on the command line win
+	if *output != "/cli/output" {
+		t.Errorf("output-dir = %q, want command-line value /cli/output", *output)
+	}
+	if *concurrency != 2 {
+		t.Errorf("max-concurrent = %d, want command-line value 2", *concurrency)
+	}
+}
+
+func TestRunConfigKeysMatchFlags(t *testing.T) {
+	// Populate every field so each config key is checked against the real flag set
+	runConfig := &types.Config{}
+	v := reflect.ValueOf(runConfig).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).Set(reflect.New(v.Field(i).Type().Elem()))
+	}
+
+	keys := config.RunConfigFlagValues(runConfig)
+	for name := range keys {
+		if flag.Lookup(name) == nil {
+			t.Errorf("Config key %q has no matching command-line flag", name)
+		}
+	}
+
+	// Flags deliberately left out of the run config file
+	notInConfig := map[string]bool{
+		"config": true, // The config file cannot name another config file
+		"help":   true,
+	}
+	flag.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, "test.") {
+			return // Registered by the go test binary
+		}
+		if notInConfig[f.Name] {
+			if _, ok := keys[f.Name]; ok {
+				t.Errorf("Flag --%s is listed as not in the config but has a config key", f.Name)
+			}
+			return
+		}
+		if _, ok := keys[f.Name]; !ok {
+			t.Errorf("Flag --%s has no run config key", f.Name)
+		}
+	})
+}
+
+func TestModelPatterns(t *testing.T) {
+	var patterns modelPatterns
+	for _, value := range []string{"modelcar-granite-*", "re:rhelai1/.*-2b", "registry.example.com/org/exact:1.0"} {
+		if err := patterns.Set(value); err != nil {
+			t.Fatalf("Set(%q) failed: %v", value, err)
+		}
+	}
+	for _, value := range []string{"", "re:(", "modelcar-[granite"} {
+		if err := (&modelPatterns{}).Set(value); err == nil {
+			t.Errorf("Set(%q) expected an error", value)
+		}
+	}
+
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{ref: "registry.redhat.io/rhai/modelcar-granite-3-1-8b:1.5", want: true},
+		{ref: "registry.redhat.io/rhelai1/modelcar-llama-2b:1.5", want: true},
+		{ref: "registry.example.com/org/exact:1.0", want: true},
+		{ref: "registry.example.com/org/exact:2.0"},
+		{ref: "registry.redhat.io/rhai/modelcar-llama-3-1-8b:1.5"},
+	}
+	entries := make([]types.ModelEntry, len(tests))
+	var want []types.ModelEntry
+	for i, tt := range tests {
+		if got := patterns.matches(tt.ref); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+		entries[i] = types.ModelEntry{Type: "oci", URI: tt.ref}
+		if tt.want {
+			want = append(want, entries[i])
+		}
+	}
+	if got := selectModelEntries(entries, patterns); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectModelEntries() = %+v, want %+v", got, want)
+	}
+	if got := patterns.String(); got != "modelcar-granite-*, re:rhelai1/.*-2b, registry.example.com/org/exact:1.0" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestValidateModelEntries(t *testing.T) {
+	entries := []types.ModelEntry{
+		{Type: types.ModelEntryTypeOCI, URI: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"},
+		{Type: types.ModelEntryTypeOCI, URI: "  quay.io/org/model:1.0  ", Labels: []string{"validated"}},
+		{Type: types.ModelEntryTypeOCI, URI: "registry.redhat.io/Org/Model:1.0"},
+		{Type: types.ModelEntryTypeOCI, URI: "registry.redhat.io/org/model:bad tag"},
+		{Type: types.ModelEntryTypeOCI, URI: ""},
+		{Type: types.ModelEntryTypeHF, URI: "https://huggingface.co/ibm-granite/granite-3.1-8b-instruct"},
+		{Type: types.ModelEntryTypeHF, URI: "https://huggingface.co/"},
+	}
+
+	valid, invalid := validateModelEntries(entries)
+
+	var validURIs []string
+	for _, entry := range valid {
+		validURIs = append(validURIs, entry.URI)
+	}
+	expectedValid := []string{
+		"registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+		"quay.io/org/model:1.0",
+		"https://huggingface.co/ibm-granite/granite-3.1-8b-instruct",
+	}
+	if !reflect.DeepEqual(validURIs, expectedValid) {
+		t.Errorf("Valid URIs = %v, want %v", validURIs, expectedValid)
+	}
+	if !reflect.DeepEqual(valid[1].Labels, []string{"validated"}) {
+		t.Errorf("Expected labels to be kept on normalized entry, got %v", valid[1].Labels)
+	}
+
+	if len(invalid) != 4 {
+		t.Fatalf("Expected 4 invalid results, got %d", len(invalid))
+	}
+	for _, result := range invalid {
+		if result.Err == nil {
+			t.Errorf("Expected an error for invalid model %q", result.Ref)
+		}
+	}
+}
+
+func TestImageOlderThan(t *testing.T) {
+	cutoff := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := cutoff.Add(-24 * time.Hour).UnixMilli()
+	after := cutoff.Add(24 * time.Hour).UnixMilli()
+
+	tests := []struct {
+		name       string
+		createTime *int64
+		updateTime *int64
+		expected   bool
+	}{
+		{name: "updated before cutoff", createTime: &before, updateTime: &before, expected: true},
+		{name: "created before but updated after cutoff", createTime: &before, updateTime: &after, expected: false},
+		{name: "only create time before cutoff", createTime: &before, expected: true},
+		{name: "created after cutoff", createTime: &after, expected: false},
+		{name: "no timestamps", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageOlderThan(tt.createTime, tt.updateTime, cutoff); got != tt.expected {
+				t.Errorf("imageOlderThan() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadExistingModelResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	ref := "registry.example.com/org/granite:1.0"
+	if _, ok := loadExistingModelResult(ref); ok {
+		t.Fatal("Expected no result without existing metadata")
+	}
+
+	name := "Granite Test"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(ref), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	data, err := yaml.Marshal(types.ExtractedMetadata{Name: &name, Tags: []string{"granite"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, extractionCacheFile), data, 0644); err != nil {
+		t.Fatalf("Failed to write cached extraction: %v", err)
+	}
+	// Enrichment rewrote metadata.yaml; none of its values may be reused as extracted ones
+	enrichedName := "Enriched Name"
+	license := "apache-2.0"
+	enriched, err := yaml.Marshal(types.ExtractedMetadata{Name: &enrichedName, License: &license, Tags: []string{"granite", "hf-tag"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), enriched, 0644); err != nil {
+		t.Fatalf("Failed to write metadata.yaml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte("# Granite Test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write modelcard.md: %v", err)
+	}
+
+	result, ok := loadExistingModelResult(ref)
+	if !ok {
+		t.Fatal("Expected existing metadata to be reused")
+	}
+	if !result.Reused || !result.ModelCardFound {
+		t.Errorf("Reused = %v, ModelCardFound = %v, want both true", result.Reused, result.ModelCardFound)
+	}
+	if result.Extracted.Name == nil || *result.Extracted.Name != name {
+		t.Errorf("Name = %v, want %q", result.Extracted.Name, name)
+	}
+	if result.Extracted.License != nil || !reflect.DeepEqual(result.Extracted.Tags, []string{"granite"}) {
+		t.Errorf("License = %v, Tags = %v, want the cached extraction without enriched values", result.Extracted.License, result.Extracted.Tags)
+	}
+
+	// Labels seeded on a reused result are written back without touching modelcard.md, and are
+	// kept out of the cached extraction so removing a label from the index removes the tag
+	extraction := result.Extracted
+	result.Extraction = &extraction
+	addModelLabelTags(&result.Extracted, ref, types.ModelEntry{URI: ref, Labels: []string{"validated"}})
+	if !writeModelResult(result) {
+		t.Fatal("Expected writeModelResult to rewrite metadata.yaml for a reused model")
+	}
+	updated, err := metadata.LoadExistingMetadata(ref, tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to reload metadata: %v", err)
+	}
+	if !reflect.DeepEqual(updated.Tags, []string{"granite", "validated"}) {
+		t.Errorf("Tags = %v, want [granite validated]", updated.Tags)
+	}
+	reused, ok := loadExistingModelResult(ref)
+	if !ok || !reflect.DeepEqual(reused.Extracted.Tags, []string{"granite"}) {
+		t.Errorf("Cached Tags = %v (ok %v), want [granite]", reused.Extracted.Tags, ok)
+	}
+}
+
+func TestMatchesExtractFiles(t *testing.T) {
+	origPatterns := extractFilePatterns
+	t.Cleanup(func() { extractFilePatterns = origPatterns })
+
+	extractFilePatterns = nil
+	if matchesExtractFiles("models/config.json") {
+		t.Error("Expected no files to match without --extract-files")
+	}
+
+	extractFilePatterns = []string{"config.json", "LICENSE*", "models/*_config.json"}
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"models/config.json", true},
+		{"config.json", true},
+		{"models/LICENSE", true},
+		{"models/LICENSE.txt", true},
+		{"models/generation_config.json", true},
+		{"models/tokenizer.json", false},
+		{"models/nested/generation_config.json", false},
+	}
+	for _, tt := range tests {
+		if got := matchesExtractFiles(tt.name); got != tt.expected {
+			t.Errorf("matchesExtractFiles(%q) = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestWriteModelResult_ExtraFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	ref := "registry.example.com/org/granite:1.0"
+	extraFiles := map[string][]byte{
+		"models/config.json": []byte(`{"architectures": ["GraniteForCausalLM"], "model_type": "granite"}`),
+		"models/LICENSE":     []byte("Apache License 2.0\n"),
+		"../escape.txt":      []byte("should not be written"),
+	}
+	result := ModelResult{
+		Ref:            ref,
+		ModelCardFound: true,
+		ModelCardPath:  "models/modelcard.md",
+		ModelCard:      []byte("# Granite\n"),
+		ExtraFiles:     extraFiles,
+	}
+	applyExtraFiles(&result.Extracted, extraFiles, ref)
+
+	if !reflect.DeepEqual(result.Extracted.Architectures, []string{"GraniteForCausalLM"}) {
+		t.Errorf("Architectures = %v, want [GraniteForCausalLM]", result.Extracted.Architectures)
+	}
+	if result.Extracted.ArchitectureType == nil || *result.Extracted.ArchitectureType != "granite" {
+		t.Errorf("ArchitectureType = %v, want granite", result.Extracted.ArchitectureType)
+	}
+
+	if !writeModelResult(result) {
+		t.Fatal("Expected metadata.yaml to be written")
+	}
+
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(ref))
+	for _, name := range []string{"models/config.json", "models/LICENSE"} {
+		content, err := os.ReadFile(filepath.Join(modelDir, name))
+		if err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+			continue
+		}
+		if string(content) != string(extraFiles[name]) {
+			t.Errorf("%s = %q, want %q", name, content, extraFiles[name])
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "escape.txt")); !os.IsNotExist(err) {
+		t.Error("Expected file with an escaping path not to be written")
+	}
+}
+
+func TestNewPlatformSystemContext(t *testing.T) {
+	tests := []struct {
+		name            string
+		platform        string
+		expectedOS      string
+		expectedArch    string
+		expectedVariant string
+		expectError     bool
+	}{
+		{name: "default platform", platform: "linux/amd64", expectedOS: "linux", expectedArch: "amd64"},
+		{name: "platform with variant", platform: "linux/arm64/v8", expectedOS: "linux", expectedArch: "arm64", expectedVariant: "v8"},
+		{name: "missing architecture", platform: "linux", expectError: true},
+		{name: "empty architecture", platform: "linux/", expectError: true},
+		{name: "too many segments", platform: "linux/arm/v7/extra", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys, err := newPlatformSystemContext(tt.platform)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for platform %q, got none", tt.platform)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if sys.OSChoice != tt.expectedOS || sys.ArchitectureChoice != tt.expectedArch || sys.VariantChoice != tt.expectedVariant {
+				t.Errorf("Got %s/%s/%s, want %s/%s/%s", sys.OSChoice, sys.ArchitectureChoice, sys.VariantChoice,
+					tt.expectedOS, tt.expectedArch, tt.expectedVariant)
+			}
+		})
+	}
+}
+
+func TestReadModelCard(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		declaredSize int64
+		limit        int64
+		wantErr      bool
+	}{
+		{name: "within limit", content: "# Model", declaredSize: 7, limit: 10},
+		{name: "exactly at limit", content: "0123456789", declaredSize: 10, limit: 10},
+		{name: "declared size over limit", content: "# Model", declaredSize: 11, limit: 10, wantErr: true},
+		{name: "content over limit despite declared size", content: "01234567890123", declaredSize: 5, limit: 10, wantErr: true},
+		{name: "limit disabled", content: "01234567890123", declaredSize: 14, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readModelCard(strings.NewReader(tt.content), tt.declaredSize, tt.limit)
+			if tt.wantErr {
+				if !errors.Is(err, errModelCardTooLarge) {
+					t.Errorf("Expected errModelCardTooLarge, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(got) != tt.content {
+				t.Errorf("Expected %q, got %q", tt.content, string(got))
+			}
+		})
+	}
+}
+
+func TestResolveHuggingFaceIndexFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if _, err := resolveHuggingFaceIndexFile(); err == nil {
+		t.Error("Expected error when no index files exist")
+	}
+
+	if err := os.MkdirAll(huggingface.CollectionsDir, 0755); err != nil {
+		t.Fatalf("Failed to create collections dir: %v", err)
+	}
+	for _, suffix := range []string{"v1-0", "v2-1", "v2-0"} {
+		if err := os.WriteFile(huggingface.CollectionFilePath(suffix), []byte("models: []\n"), 0644); err != nil {
+			t.Fatalf("Failed to write index file: %v", err)
+		}
+	}
+
+	got, err := resolveHuggingFaceIndexFile()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := huggingface.CollectionFilePath("v2-1"); got != want {
+		t.Errorf("Expected latest version index %s, got %s", want, got)
+	}
+
+	if err := os.WriteFile(huggingface.MergedFilePath(), []byte("models: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write merged index: %v", err)
+	}
+	got, err = resolveHuggingFaceIndexFile()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != huggingface.MergedFilePath() {
+		t.Errorf("Expected merged index %s, got %s", huggingface.MergedFilePath(), got)
+	}
+}
+
+func TestLoadUnchangedModelResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	ref := "registry.example.com/org/granite:1.0"
+	modelCard := []byte("---\nname: Granite Test\n---\n# Granite Test\n")
+	result := ModelResult{
+		Ref:            ref,
+		ModelCardFound: true,
+		ModelCardPath:  filepath.Join("models", "modelcard.md"),
+		ModelCard:      modelCard,
+		Extracted:      types.ExtractedMetadata{Tags: []string{"granite"}},
+	}
+	if _, ok := loadUnchangedModelResult(ref, modelCard); ok {
+		t.Fatal("Expected no reuse before the modelcard was written")
+	}
+	if !writeModelResult(result) {
+		t.Fatal("Expected writeModelResult to write metadata.yaml")
+	}
+
+	checksum, err := os.ReadFile(filepath.Join(tmpDir, utils.SanitizeManifestRef(ref), "models", modelCardChecksumFile))
+	if err != nil {
+		t.Fatalf("Expected modelcard checksum to be written: %v", err)
+	}
+	if strings.TrimSpace(string(checksum)) != modelCardChecksum(modelCard) {
+		t.Errorf("Checksum = %q, want %q", checksum, modelCardChecksum(modelCard))
+	}
+
+	// Enrichment rewrites metadata.yaml after extraction; the reused result must not pick that up
+	enrichedName := "Enriched Name"
+	enriched, err := yaml.Marshal(types.ExtractedMetadata{Name: &enrichedName, Tags: []string{"granite", "hf-tag"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, utils.SanitizeManifestRef(ref), "models", "metadata.yaml"), enriched, 0644); err != nil {
+		t.Fatalf("Failed to write metadata.yaml: %v", err)
+	}
+
+	reused, ok := loadUnchangedModelResult(ref, modelCard)
+	if !ok {
+		t.Fatal("Expected an unchanged modelcard to reuse existing metadata")
+	}
+	if !reused.Reused || !reflect.DeepEqual(reused.Extracted.Tags, []string{"granite"}) || reused.Extracted.Name != nil {
+		t.Errorf("Reused = %v, Name = %v, Tags = %v, want reused pre-enrichment extraction", reused.Reused, reused.Extracted.Name, reused.Extracted.Tags)
+	}
+
+	if _, ok := loadUnchangedModelResult(ref, append(modelCard, []byte("Updated.\n")...)); ok {
+		t.Error("Expected a changed modelcard not to be reused")
+	}
+}
+
+func TestProgressReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	reporter, err := newProgressReporter(path)
+	if err != nil {
+		t.Fatalf("newProgressReporter() error: %v", err)
+	}
+
+	name := "Granite Test"
+	reporter.Report(ModelResult{
+		Ref:             "registry.example.com/org/granite:1.0",
+		ModelCardFound:  true,
+		MetadataWritten: true,
+		Extracted:       types.ExtractedMetadata{Name: &name, Tags: []string{"granite"}, Language: []string{}},
+	}, 1500*time.Millisecond)
+	reporter.Report(ModelResult{Ref: "registry.example.com/org/broken:1.0", Err: fmt.Errorf("manifest unknown")}, 0)
+	if err := reporter.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read progress file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 progress lines, got %d: %q", len(lines), data)
+	}
+
+	var first, second types.ModelProgressEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Failed to parse progress line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("Failed to parse progress line: %v", err)
+	}
+	if !first.Success || !first.ModelCardFound || first.DurationMs != 1500 || !reflect.DeepEqual(first.FieldsExtracted, []string{"name", "tags"}) {
+		t.Errorf("Unexpected first event: %+v", first)
+	}
+	if second.Success || second.Error != "manifest unknown" || len(second.FieldsExtracted) != 0 {
+		t.Errorf("Unexpected second event: %+v", second)
+	}
+
+	// A nil reporter (--progress-json unset) ignores results
+	var disabled *progressReporter
+	disabled.Report(ModelResult{Ref: "ignored"}, 0)
+	if err := disabled.Close(); err != nil {
+		t.Errorf("Close() on nil reporter error: %v", err)
+	}
+}
+
+func TestRunModelWorkers(t *testing.T) {
+	tests := []struct {
+		name          string
+		refs          int
+		maxConcurrent int
+		wantMaxActive int
+	}{
+		{name: "more refs than workers", refs: 20, maxConcurrent: 3, wantMaxActive: 3},
+		{name: "fewer refs than workers", refs: 2, maxConcurrent: 5, wantMaxActive: 2},
+		{name: "single worker", refs: 5, maxConcurrent: 1, wantMaxActive: 1},
+		{name: "no refs", refs: 0, maxConcurrent: 5, wantMaxActive: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var refs []string
+			for i := 0; i < tt.refs; i++ {
+				refs = append(refs, fmt.Sprintf("registry.example.com/org/model-%d:1.0", i))
+			}
+
+			var active, maxActive atomic.Int32
+			process := func(ref string) ModelResult {
+				n := active.Add(1)
+				for {
+					seen := maxActive.Load()
+					if n <= seen || maxActive.CompareAndSwap(seen, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				active.Add(-1)
+				if strings.HasSuffix(ref, "-1:1.0") {
+					return ModelResult{Ref: ref, Err: errors.New("extraction failed")}
+				}
+				return ModelResult{Ref: ref}
+			}
+			var reported int
+			results := runModelWorkers(refs, tt.maxConcurrent, process, func(ModelResult) { reported++ })
+
+			if len(results) != tt.refs || reported != tt.refs {
+				t.Fatalf("got %d results and %d reports, want %d", len(results), reported, tt.refs)
+			}
+			var gotRefs []string
+			for _, result := range results {
+				gotRefs = append(gotRefs, result.Ref)
+			}
+			sort.Strings(gotRefs)
+			sort.Strings(refs)
+			if !reflect.DeepEqual(gotRefs, refs) {
+				t.Errorf("results refs = %v, want %v", gotRefs, refs)
+			}
+			if got := int(maxActive.Load()); got > tt.wantMaxActive || (tt.refs > 0 && got < 1) {
+				t.Errorf("max concurrent workers = %d, want at most %d", got, tt.wantMaxActive)
+			}
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	bar := &progressBar{out: &out, total: 4, start: start}
+
+	if got := bar.line(start); got != "Processed 0/4 models (0%)" {
+		t.Errorf("line() before any model = %q", got)
+	}
+	bar.done = 1
+	if got := bar.line(start.Add(30 * time.Second)); got != "Processed 1/4 models (25%), ETA 1m30s" {
+		t.Errorf("line() after one model = %q", got)
+	}
+	bar.done = 4
+	if got := bar.line(start.Add(2 * time.Minute)); got != "Processed 4/4 models (100%)" {
+		t.Errorf("line() when complete = %q", got)
+	}
+
+	// Log output clears the bar's line and the bar is redrawn after it
+	bar.done = 1
+	out.Reset()
+	if _, err := bar.Write([]byte("model processed\n")); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "\r\033[Kmodel processed\n\r\033[KProcessed 1/4 models (25%)") {
+		t.Errorf("Write() output = %q", got)
+	}
+	out.Reset()
+	bar.Increment()
+	bar.Finish()
+	if got := out.String(); !strings.Contains(got, "Processed 2/4 models (50%)") || !strings.HasSuffix(got, "\n") {
+		t.Errorf("Increment() and Finish() output = %q", got)
+	}
+
+	// A nil bar (--progress unset, JSON logs or no terminal) ignores updates
+	var disabled *progressBar
+	disabled.Increment()
+	disabled.Finish()
+	disabled.captureLogs()()
+}
+
+func TestNewProgressBar_Disabled(t *testing.T) {
+	oldShow, oldFormat := *showProgress, *logFormat
+	t.Cleanup(func() { *showProgress, *logFormat = oldShow, oldFormat })
+
+	*showProgress = false
+	if newProgressBar(10) != nil {
+		t.Error("Expected no progress bar without --progress")
+	}
+	*showProgress = true
+	*logFormat = "json"
+	if newProgressBar(10) != nil {
+		t.Error("Expected no progress bar with JSON logs")
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if isTerminal(f) {
+		t.Error("Expected a regular file not to be a terminal")
+	}
+}
+
+func TestSafeLayerPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"models/modelcard.md", "models/modelcard.md", true},
+		{"./docs/README.md", "docs/README.md", true},
+		{"models/./nested//README.md", "models/nested/README.md", true},
+		{"models/../README.md", "README.md", true},
+		{"../escape.md", "", false},
+		{"models/../../escape.md", "", false},
+		{"/etc/passwd", "", false},
+		{"..\\..\\escape.md", "", false},
+		{"./", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := safeLayerPath(tt.name)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("safeLayerPath(%q) = (%q, %v), want (%q, %v)", tt.name, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestWriteModelResult_NestedModelCardPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	origOutputDir := *outputDir
+	*outputDir = tmpDir
+	t.Cleanup(func() { *outputDir = origOutputDir })
+
+	result := ModelResult{
+		Ref:            "registry.example.com/org/granite:1.0",
+		ModelCardFound: true,
+		ModelCardPath:  "./docs/README.md",
+		ModelCard:      []byte("# Granite\n"),
+	}
+	if !writeModelResult(result) {
+		t.Fatal("Expected metadata.yaml to be written")
+	}
+
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(result.Ref))
+	for _, name := range []string{"docs/README.md", "models/metadata.yaml", "models/" + modelCardChecksumFile} {
+		if _, err := os.Stat(filepath.Join(modelDir, name)); err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+		}
+	}
+
+	result.ModelCardPath = "../../escape.md"
+	if writeModelResult(result) {
+		t.Error("Expected writeModelResult to reject a modelcard path outside the model directory")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "..", "escape.md")); !os.IsNotExist(err) {
+		t.Error("Expected no file to be written outside the output directory")
+	}
+}
+
+func TestExtractPlatformFromConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		configBlob   string
+		expectedOS   string
+		expectedArch string
+	}{
+		{
+			name:         "os and architecture",
+			configBlob:   `{"created":"2025-01-08T10:00:00Z","os":"linux","architecture":"amd64"}`,
+			expectedOS:   "linux",
+			expectedArch: "amd64",
+		},
+		{
+			name:       "missing architecture",
+			configBlob: `{"os":"linux"}`,
+			expectedOS: "linux",
+		},
+		{
+			name:       "no platform fields",
+			configBlob: `{"created":"2025-01-08T10:00:00Z"}`,
+		},
+		{
+			name:       "invalid json",
+			configBlob: `{not json`,
+		},
+		{
+			name: "empty blob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osName, arch := extractPlatformFromConfig([]byte(tt.configBlob))
+			if osName != tt.expectedOS || arch != tt.expectedArch {
+				t.Errorf("extractPlatformFromConfig() = %q, %q, want %q, %q", osName, arch, tt.expectedOS, tt.expectedArch)
+			}
+		})
+	}
+}
+
+func TestModelCardReferrerBlob(t *testing.T) {
+	const title = "org.opencontainers.image.title"
+	tests := []struct {
+		name           string
+		blobs          []referrerBlob
+		expectedDigest string
+		expectedFound  bool
+	}{
+		{
+			name: "markdown title preferred",
+			blobs: []referrerBlob{
+				{Digest: "sha256:json", Annotations: map[string]string{title: "card.json"}},
+				{Digest: "sha256:md", Annotations: map[string]string{title: "README.md"}},
+			},
+			expectedDigest: "sha256:md",
+			expectedFound:  true,
+		},
+		{
+			name:           "untitled blob used",
+			blobs:          []referrerBlob{{Digest: "sha256:first"}, {Digest: "sha256:second"}},
+			expectedDigest: "sha256:first",
+			expectedFound:  true,
+		},
+		{
+			name: "no blobs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob, found := modelCardReferrerBlob(tt.blobs)
+			if found != tt.expectedFound || blob.Digest != tt.expectedDigest {
+				t.Errorf("modelCardReferrerBlob() = %q, %v, want %q, %v", blob.Digest, found, tt.expectedDigest, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestRequireDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "metadata.yaml")
+	if err := os.WriteFile(file, []byte("name: x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := requireDir(tmpDir); err != nil {
+		t.Errorf("requireDir(existing dir) error = %v", err)
+	}
+	if err := requireDir(filepath.Join(tmpDir, "missing")); err == nil {
+		t.Error("requireDir() expected error for a missing directory")
+	}
+	if err := requireDir(file); err == nil {
+		t.Error("requireDir() expected error for a file")
+	}
+}
+
+func TestEnsureWritableDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	t.Run("creates missing directory", func(t *testing.T) {
+		dir := filepath.Join(tmpDir, "output", "nested")
+		if err := ensureWritableDir(dir); err != nil {
+			t.Fatalf("ensureWritableDir() error = %v", err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("Expected directory to exist: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("Expected probe file to be removed, found %v", entries)
+		}
+	})
+
+	t.Run("path under a file", func(t *testing.T) {
+		file := filepath.Join(tmpDir, "not-a-dir")
+		if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ensureWritableDir(filepath.Join(file, "output")); err == nil {
+			t.Error("ensureWritableDir() expected error when a parent is a file")
+		}
+	})
+
+	t.Run("read-only directory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("permission checks do not apply to root")
+		}
+		dir := filepath.Join(tmpDir, "readonly")
+		if err := os.MkdirAll(dir, 0555); err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = os.Chmod(dir, 0755) }()
+		if err := ensureWritableDir(dir); err == nil || !strings.Contains(err.Error(), "not writable") {
+			t.Errorf("ensureWritableDir() error = %v, want not writable", err)
+		}
+	})
+}
+
+// fakeLayerSource serves blobs and manifests by digest from memory; other ImageSource methods
+// are not used
+type fakeLayerSource struct {
+	containertypes.ImageSource
+	blobs     map[digest.Digest][]byte
+	manifests map[digest.Digest][]byte
+}
+
+func (f *fakeLayerSource) GetManifest(_ context.Context, instanceDigest *digest.Digest) ([]byte, string, error) {
+	if instanceDigest == nil {
+		return nil, "", errors.New("fake source has no top-level manifest")
+	}
+	data, ok := f.manifests[*instanceDigest]
+	if !ok {
+		return nil, "", fmt.Errorf("manifest %s not found", *instanceDigest)
+	}
+	return data, "application/vnd.oci.image.manifest.v1+json", nil
+}
+
+func (f *fakeLayerSource) GetBlob(_ context.Context, info containertypes.BlobInfo, _ containertypes.BlobInfoCache) (io.ReadCloser, int64, error) {
+	data, ok := f.blobs[info.Digest]
+	if !ok {
+		return nil, 0, fmt.Errorf("blob %s not found", info.Digest)
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+// modelCardLayer builds an uncompressed modelcard layer tar holding files
+func modelCardLayer(t *testing.T, files map[string]string) (containertypes.BlobInfo, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write tar content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar: %v", err)
+	}
+	data := buf.Bytes()
+	return containertypes.BlobInfo{
+		Digest:      digest.FromBytes(data),
+		MediaType:   "application/vnd.oci.image.layer.v1.tar",
+		Annotations: map[string]string{"io.opendatahub.modelcar.layer.type": "modelcard"},
+	}, data
+}
+
+func TestScanLayersForModelCard_Extensions(t *testing.T) {
+	t.Cleanup(func() { _ = setModelCardExtensions(nil) })
+
+	card := "---\nlicense: apache-2.0\n---\n# Granite\n"
+	tests := []struct {
+		name         string
+		extensions   []string
+		files        map[string]string
+		expectedPath string
+		expectFound  bool
+	}{
+		{
+			name:         "markdown extension found",
+			files:        map[string]string{"models/README.markdown": card, "models/config.json": "{}"},
+			expectedPath: "models/README.markdown",
+			expectFound:  true,
+		},
+		{
+			name:         "mdx extension found",
+			files:        map[string]string{"models/README.mdx": card},
+			expectedPath: "models/README.mdx",
+			expectFound:  true,
+		},
+		{
+			name:         "md preferred over other extensions",
+			files:        map[string]string{"models/README.md": card, "models/README.markdown": "# Other\n"},
+			expectedPath: "models/README.md",
+			expectFound:  true,
+		},
+		{
+			name:         "README.md found next to CHANGELOG.mdx",
+			files:        map[string]string{"models/CHANGELOG.mdx": "# Changelog\n", "models/README.md": card},
+			expectedPath: "models/README.md",
+			expectFound:  true,
+		},
+		{
+			name:         "flag order decides the preferred extension",
+			extensions:   []string{".mdx", ".md"},
+			files:        map[string]string{"models/README.md": "# Other\n", "models/README.mdx": card},
+			expectedPath: "models/README.mdx",
+			expectFound:  true,
+		},
+		{
+			name:  "several files with the preferred extension are ambiguous",
+			files: map[string]string{"models/README.md": card, "models/USAGE.md": card, "models/CHANGELOG.mdx": card},
+		},
+		{
+			name:       "extension not configured",
+			extensions: []string{".md"},
+			files:      map[string]string{"models/README.markdown": card},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setModelCardExtensions(tt.extensions); err != nil {
+				t.Fatalf("setModelCardExtensions failed: %v", err)
+			}
+			layer, data := modelCardLayer(t, tt.files)
+			src := &fakeLayerSource{blobs: map[digest.Digest][]byte{layer.Digest: data}}
+
+			path, content, _, found, err := scanLayersForModelCard(context.Background(), []containertypes.BlobInfo{layer}, src, "registry.example.com/test/model:1.0")
+			if err != nil {
+				t.Fatalf("scanLayersForModelCard failed: %v", err)
+			}
+			if found != tt.expectFound || path != tt.expectedPath {
+				t.Fatalf("scanLayersForModelCard() = %q, %v, want %q, %v", path, found, tt.expectedPath, tt.expectFound)
+			}
+			if !found {
+				return
+			}
+			extracted := metadata.ExtractMetadataValues(content)
+			if extracted.License == nil || *extracted.License != "apache-2.0" {
+				t.Errorf("Expected parsed license %q, got %v", "apache-2.0", extracted.License)
+			}
+		})
+	}
+}
+
+// writeOCILayout writes a single-image OCI layout to dir, tagged tag, whose only layer is the
+// given modelcard layer
+func writeOCILayout(t *testing.T, dir, tag string, layer containertypes.BlobInfo, layerData []byte) {
+	t.Helper()
+	writeBlob := func(data []byte) digest.Digest {
+		d := digest.FromBytes(data)
+		blobDir := filepath.Join(dir, "blobs", d.Algorithm().String())
+		if err := os.MkdirAll(blobDir, 0755); err != nil {
+			t.Fatalf("Failed to create blob directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(blobDir, d.Encoded()), data, 0644); err != nil {
+			t.Fatalf("Failed to write blob: %v", err)
+		}
+		return d
+	}
+	marshal := func(v interface{}) []byte {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Failed to marshal layout JSON: %v", err)
+		}
+		return data
+	}
+
+	layerDigest := writeBlob(layerData)
+	configData := marshal(map[string]interface{}{
+		"created":      "2025-01-15T10:30:00Z",
+		"architecture": "amd64",
+		"os":           "linux",
+		"rootfs":       map[string]interface{}{"type": "layers", "diff_ids": []string{layerDigest.String()}},
+	})
+	configDigest := writeBlob(configData)
+	manifestData := marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"config":        map[string]interface{}{"mediaType": "application/vnd.oci.image.config.v1+json", "digest": configDigest, "size": len(configData)},
+		"layers": []map[string]interface{}{
+			{"mediaType": layer.MediaType, "digest": layerDigest, "size": len(layerData), "annotations": layer.Annotations},
+		},
+	})
+	manifestDigest := writeBlob(manifestData)
+
+	index := marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"manifests": []map[string]interface{}{{
+			"mediaType":   "application/vnd.oci.image.manifest.v1+json",
+			"digest":      manifestDigest,
+			"size":        len(manifestData),
+			"annotations": map[string]string{"org.opencontainers.image.ref.name": tag},
+		}},
+	})
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), index, 0644); err != nil {
+		t.Fatalf("Failed to write index.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644); err != nil {
+		t.Fatalf("Failed to write oci-layout: %v", err)
+	}
+}
+
+func TestExtractModel_OCILayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "granite-layout")
+	layer, data := modelCardLayer(t, map[string]string{
+		"models/README.md":   "---\nlicense: apache-2.0\n---\n# Granite Layout\n",
+		"models/config.json": "{}",
+	})
+	writeOCILayout(t, dir, "1.0", layer, data)
+
+	sys, err := newPlatformSystemContext("linux/amd64")
+	if err != nil {
+		t.Fatalf("newPlatformSystemContext failed: %v", err)
+	}
+	ref := "oci:" + dir + ":1.0"
+	if err := modelEntryError(types.ModelEntry{Type: "oci", URI: ref}); err != nil {
+		t.Fatalf("Expected OCI layout reference to be valid, got %v", err)
+	}
+
+	// --pin-digests has no valid digest form for a layout, so its URI is kept as given
+	origPin := *pinDigests
+	*pinDigests = true
+	t.Cleanup(func() { *pinDigests = origPin })
+
+	result, err := ExtractModel(ref, sys)
+	if err != nil {
+		t.Fatalf("ExtractModel failed: %v", err)
+	}
+	if !result.ModelCardFound || result.ModelCardPath != "models/README.md" {
+		t.Fatalf("Expected modelcard models/README.md to be found, got found=%v path=%q", result.ModelCardFound, result.ModelCardPath)
+	}
+	if result.Extracted.License == nil || *result.Extracted.License != "apache-2.0" {
+		t.Errorf("Expected license apache-2.0, got %v", result.Extracted.License)
+	}
+	if len(result.Extracted.Artifacts) != 1 || result.Extracted.Artifacts[0].URI != ref {
+		t.Fatalf("Expected one artifact with URI %q, got %+v", ref, result.Extracted.Artifacts)
+	}
+	artifact := result.Extracted.Artifacts[0]
+	if _, ok := artifact.CustomProperties["digest"]; !ok {
+		t.Error("Expected the layout manifest digest to be recorded as a custom property")
+	}
+	wantCreate := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC).UnixMilli()
+	if artifact.CreateTimeSinceEpoch == nil || *artifact.CreateTimeSinceEpoch != wantCreate {
+		t.Errorf("Expected create time %d from the image config, got %v", wantCreate, artifact.CreateTimeSinceEpoch)
+	}
+
+	if _, err := ExtractModel("oci:"+dir+":missing", sys); err == nil {
+		t.Error("Expected an error for a tag missing from the layout")
+	}
+}
+
+func TestScanReferrersForModelCard_OCILayout(t *testing.T) {
+	var logs bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(original) })
+
+	ref := "oci:" + filepath.Join(t.TempDir(), "granite-layout") + ":1.0"
+	src := &fakeLayerSource{}
+	if _, _, found := scanReferrersForModelCard(context.Background(), src, ref, "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", &containertypes.SystemContext{}); found {
+		t.Error("Expected no modelcard referrer for an OCI layout")
+	}
+	if !strings.Contains(logs.String(), "Skipping referrers lookup for OCI layout") || strings.Contains(logs.String(), "Referrers lookup failed") {
+		t.Errorf("Expected the referrers lookup to be skipped for an OCI layout, got logs:\n%s", logs.String())
+	}
+}
+
+func TestScanReferrersForModelCard(t *testing.T) {
+	const manifestDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	card := []byte("---\nlicense: apache-2.0\n---\n# Granite\n")
+	cardDigest := digest.FromBytes(card)
+	artifact, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"artifactType":  "application/vnd.redhat.modelcard.v1+markdown",
+		"layers": []referrerBlob{
+			{MediaType: "text/plain", Digest: digest.FromString("notes").String(), Size: 5, Annotations: map[string]string{"org.opencontainers.image.title": "NOTES.txt"}},
+			{MediaType: "text/markdown", Digest: cardDigest.String(), Size: int64(len(card)), Annotations: map[string]string{"org.opencontainers.image.title": "README.md"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifactDigest := digest.FromBytes(artifact)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/org/model/referrers/"+manifestDigest {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"schemaVersion": 2,
+			"manifests": []map[string]interface{}{
+				{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": digest.FromString("sbom").String(), "artifactType": "application/spdx+json"},
+				{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": artifactDigest.String(), "artifactType": "application/vnd.redhat.modelcard.v1+markdown"},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	ref := strings.TrimPrefix(server.URL, "https://") + "/org/model:1.0"
+
+	src := &fakeLayerSource{
+		blobs:     map[digest.Digest][]byte{cardDigest: card},
+		manifests: map[digest.Digest][]byte{artifactDigest: artifact},
+	}
+	// The test server's certificate is self-signed, so the lookup only succeeds when the
+	// SystemContext's TLS setting is honored
+	sys := &containertypes.SystemContext{DockerInsecureSkipTLSVerify: containertypes.OptionalBoolTrue}
+
+	path, content, found := scanReferrersForModelCard(context.Background(), src, ref, manifestDigest, sys)
+	if !found {
+		t.Fatal("Expected the modelcard referrer to be found")
+	}
+	if path != "README.md" || !bytes.Equal(content, card) {
+		t.Errorf("scanReferrersForModelCard() = %q, %q, want README.md, %q", path, content, card)
+	}
+
+	if _, _, found := scanReferrersForModelCard(context.Background(), src, ref, manifestDigest, &containertypes.SystemContext{}); found {
+		t.Error("Expected no modelcard referrer when the registry certificate is not trusted")
+	}
+}
+
+func TestScanLayersForModelCard_EmptyLayer(t *testing.T) {
+	var dirsOnly bytes.Buffer
+	tw := tar.NewWriter(&dirsOnly)
+	if err := tw.WriteHeader(&tar.Header{Name: "models/", Mode: 0755, Typeflag: tar.TypeDir}); err != nil {
+		t.Fatalf("Failed to write tar header: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar: %v", err)
+	}
+
+	emptyLayer, emptyData := modelCardLayer(t, nil)
+	configLayer, configData := modelCardLayer(t, map[string]string{"models/config.json": "{}"})
+	tests := []struct {
+		name      string
+		layer     containertypes.BlobInfo
+		data      []byte
+		wantEmpty bool
+	}{
+		{name: "empty tar", layer: emptyLayer, data: emptyData, wantEmpty: true},
+		{
+			name: "only directories",
+			layer: containertypes.BlobInfo{
+				Digest:      digest.FromBytes(dirsOnly.Bytes()),
+				MediaType:   "application/vnd.oci.image.layer.v1.tar",
+				Annotations: map[string]string{"io.opendatahub.modelcar.layer.type": "modelcard"},
+			},
+			data:      dirsOnly.Bytes(),
+			wantEmpty: true,
+		},
+		{name: "files but no modelcard", layer: configLayer, data: configData},
+		{name: "no modelcard layer", layer: containertypes.BlobInfo{Digest: emptyLayer.Digest}, data: emptyData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &fakeLayerSource{blobs: map[digest.Digest][]byte{tt.layer.Digest: tt.data}}
+			_, _, _, found, err := scanLayersForModelCard(context.Background(), []containertypes.BlobInfo{tt.layer}, src, "registry.example.com/test/model:1.0")
+			if found {
+				t.Fatal("Expected no modelcard to be found")
+			}
+			if errors.Is(err, errModelCardLayerEmpty) != tt.wantEmpty {
+				t.Errorf("scanLayersForModelCard() error = %v, want empty layer %v", err, tt.wantEmpty)
+			}
+			if err != nil && !errors.Is(err, errModelCardLayerEmpty) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSetModelCardExtensions(t *testing.T) {
+	t.Cleanup(func() { _ = setModelCardExtensions(nil) })
+
+	if err := setModelCardExtensions([]string{"MD", ".txt"}); err != nil {
+		t.Fatalf("setModelCardExtensions failed: %v", err)
+	}
+	if !reflect.DeepEqual(modelCardExtensions, []string{".md", ".txt"}) {
+		t.Errorf("Expected normalized extensions, got %v", modelCardExtensions)
+	}
+	if !isModelCardFile("models/README.TXT") || isModelCardFile("models/README.markdown") {
+		t.Error("Expected only configured extensions to match")
+	}
+	for _, invalid := range []string{".", "*.md", ".tar.gz"} {
+		if err := setModelCardExtensions([]string{invalid}); err == nil {
+			t.Errorf("Expected an error for extension %q", invalid)
+		}
+	}
+}
@@ -1,12 +1,12 @@
 package catalog
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -57,6 +57,9 @@ func LoadStaticCatalogs(filePaths []string) ([]types.CatalogMetadata, error) {
 			applyDefaultModelType(&staticCatalog.Models[i])
 		}
 
+		// Static catalogs may use bare image references
+		normalizeArtifactURIs(staticCatalog.Models)
+
 		// Enrich artifacts with architecture information
 		for i := range staticCatalog.Models {
 			enrichStaticArtifactsWithArchitecture(&staticCatalog.Models[i])
@@ -97,19 +100,62 @@ func validateStaticCatalog(catalog *types.ModelsCatalog) error {
 	return nil
 }
 
-// CreateModelsCatalogWithStaticFromResults creates a models catalog from specific model results and static models
-func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, modelRefs []string, staticModels []types.CatalogMetadata) error {
-	var allModels []types.ExtractedMetadata
+// LabelFilter selects catalog models by their labels (tags). An empty Include list
+// selects all models; any label in Exclude drops the model.
+type LabelFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// excludes reports whether the labels contain any excluded label
+func (f LabelFilter) excludes(labels []string) bool {
+	for _, label := range labels {
+		if slices.Contains(f.Exclude, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether a model with these labels passes the filter
+func (f LabelFilter) Matches(labels []string) bool {
+	if f.excludes(labels) {
+		return false
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, label := range labels {
+		if slices.Contains(f.Include, label) {
+			return true
+		}
+	}
+	return false
+}
 
+// CreateModelsCatalogWithStaticFromResults creates a models catalog from specific model results and static models.
+// Extracted models are kept when their tags pass filter; static models are always kept unless they carry an excluded label.
+func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, modelRefs []string, staticModels []types.CatalogMetadata, filter LabelFilter) error {
 	// Process only metadata files for models that were processed in the current run
+	modelDirs := make([]string, 0, len(modelRefs))
 	for _, ref := range modelRefs {
 		// Create sanitized directory name for the model (using same logic as main.go)
-		sanitizedName := utils.SanitizeManifestRef(ref)
-		metadataPath := filepath.Join(outputDir, sanitizedName, "models", "metadata.yaml")
+		modelDirs = append(modelDirs, utils.SanitizeManifestRef(ref))
+	}
+	return createModelsCatalogFromDirs(outputDir, catalogPath, modelDirs, staticModels, filter)
+}
+
+// createModelsCatalogFromDirs creates a models catalog from the metadata.yaml files of the given
+// model directories under outputDir and static models
+func createModelsCatalogFromDirs(outputDir, catalogPath string, modelDirs []string, staticModels []types.CatalogMetadata, filter LabelFilter) error {
+	var allModels []types.ExtractedMetadata
+
+	for _, modelDir := range modelDirs {
+		metadataPath := filepath.Join(outputDir, modelDir, "models", "metadata.yaml")
 
 		// Check if the metadata file exists
 		if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
-			log.Printf("  Warning: metadata file not found for %s: %s", ref, metadataPath)
+			log.Printf("  Warning: metadata file not found for %s: %s", modelDir, metadataPath)
 			continue
 		}
 
@@ -130,6 +176,11 @@ func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, mod
 			continue
 		}
 
+		if !filter.Matches(metadata.Tags) {
+			log.Printf("  Skipping %s: labels %v do not match the label filter", modelDir, metadata.Tags)
+			continue
+		}
+
 		// Add to collection
 		allModels = append(allModels, metadata)
 	}
@@ -154,16 +205,53 @@ func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, mod
 		catalogModels = append(catalogModels, catalogModel)
 	}
 
+	// Normalize artifact URIs so deduplication compares like with like
+	normalizeArtifactURIs(catalogModels)
+
 	// Deduplicate models by consolidating artifacts and merging metadata
 	catalogModels = deduplicateAndMergeModels(catalogModels)
 
 	// Merge static models with dynamic models (static models are appended at the end)
-	catalogModels = append(catalogModels, staticModels...)
+	var includedStatic []types.CatalogMetadata
+	for _, model := range staticModels {
+		if filter.excludes(customPropertyLabels(model.CustomProperties)) {
+			log.Printf("  Skipping static model %s: carries an excluded label", getModelName(&model))
+			continue
+		}
+		includedStatic = append(includedStatic, model)
+	}
+	normalizeArtifactURIs(includedStatic)
+	catalogModels = append(catalogModels, includedStatic...)
 
+	// Any non-default ordering applies to dynamic and static models alike
+	if catalogSort != SortByName {
+		sortCatalogModels(catalogModels, catalogSort)
+	}
+
+	if err := writeModelsCatalog(catalogPath, catalogModels); err != nil {
+		return err
+	}
+	log.Printf("Successfully created %s with %d dynamic models and %d static models", catalogPath, len(allModels), len(includedStatic))
+
+	// The combined catalog is always written; split catalogs are extra views of the same models
+	if len(splitLabels) > 0 {
+		for _, partition := range partitionModelsByLabel(catalogModels, splitLabels) {
+			path := splitCatalogPath(catalogPath, partition.name)
+			if err := writeModelsCatalog(path, partition.models); err != nil {
+				return err
+			}
+			log.Printf("Successfully created %s with %d models", path, len(partition.models))
+		}
+	}
+	return nil
+}
+
+// writeModelsCatalog marshals, validates and writes a models catalog
+func writeModelsCatalog(catalogPath string, models []types.CatalogMetadata) error {
 	// Create the catalog structure
 	catalog := types.ModelsCatalog{
 		Source: "Red Hat",
-		Models: catalogModels,
+		Models: models,
 	}
 
 	// Marshal to YAML
@@ -172,48 +260,134 @@ func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, mod
 		return fmt.Errorf("error marshaling catalog: %v", err)
 	}
 
+	// Validate what will actually be written, so marshaling quirks are caught too
+	if err := validateCatalogOutput(output); err != nil {
+		return err
+	}
+
 	// Write to the specified catalog path
 	err = os.WriteFile(catalogPath, output, 0644)
 	if err != nil {
 		return fmt.Errorf("error writing catalog file: %v", err)
 	}
+	return nil
+}
+
+// splitOtherPartition names the split catalog of models carrying none of the split labels
+const splitOtherPartition = "other"
 
-	log.Printf("Successfully created %s with %d dynamic models and %d static models", catalogPath, len(allModels), len(staticModels))
+// splitLabels are the labels catalogs are additionally split by; empty writes only the combined catalog
+var splitLabels []string
+
+// SetSplitLabels makes catalog generation also write one catalog per label, holding the models
+// carrying it, plus an "other" catalog for the rest. A model with several of the labels goes to
+// the first one listed. An empty list disables splitting.
+func SetSplitLabels(labels []string) error {
+	for _, label := range labels {
+		if label == splitOtherPartition {
+			return fmt.Errorf("invalid split label %q: reserved for models without any split label", label)
+		}
+		if strings.ContainsAny(label, `/\`) {
+			return fmt.Errorf("invalid split label %q: must not contain path separators", label)
+		}
+	}
+	splitLabels = labels
 	return nil
 }
 
-// CreateModelsCatalogWithStatic collects all metadata.yaml files, merges with static models, and creates a models-catalog.yaml (backward compatibility)
-func CreateModelsCatalogWithStatic(outputDir, catalogPath string, staticModels []types.CatalogMetadata) error {
-	var modelRefs []string
+// catalogPartition is the named subset of catalog models written to one split catalog
+type catalogPartition struct {
+	name   string
+	models []types.CatalogMetadata
+}
 
-	// Find all metadata.yaml files in the specified output directory to maintain backward compatibility
-	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if info.Name() == "metadata.yaml" {
-			// Extract model reference from path for backward compatibility
-			// Path format: outputDir/sanitized-ref/models/metadata.yaml
-			relPath, _ := filepath.Rel(outputDir, path)
-			pathParts := strings.Split(relPath, string(filepath.Separator))
-			if len(pathParts) >= 2 {
-				sanitizedRef := pathParts[0]
-				// Convert back to original reference format (this is a best effort)
-				// For exact matching, use CreateModelsCatalogWithStaticFromResults
-				modelRefs = append(modelRefs, sanitizedRef)
+// partitionModelsByLabel assigns each model to the partition of the first label it carries, or to
+// "other". Every label gets a partition, possibly empty, so each split catalog is always written.
+func partitionModelsByLabel(models []types.CatalogMetadata, labels []string) []catalogPartition {
+	partitions := make([]catalogPartition, 0, len(labels)+1)
+	for _, label := range labels {
+		partitions = append(partitions, catalogPartition{name: label, models: []types.CatalogMetadata{}})
+	}
+	partitions = append(partitions, catalogPartition{name: splitOtherPartition, models: []types.CatalogMetadata{}})
+
+	for _, model := range models {
+		modelLabels := customPropertyLabels(model.CustomProperties)
+		target := len(labels)
+		for i, label := range labels {
+			if slices.Contains(modelLabels, label) {
+				target = i
+				break
 			}
 		}
+		partitions[target].models = append(partitions[target].models, model)
+	}
+	return partitions
+}
 
-		return nil
-	})
+// splitCatalogPath returns the path of a split catalog next to the combined one,
+// e.g. "data/models-catalog.yaml" and "validated" -> "data/models-catalog-validated.yaml"
+func splitCatalogPath(catalogPath, partition string) string {
+	ext := filepath.Ext(catalogPath)
+	return strings.TrimSuffix(catalogPath, ext) + "-" + partition + ext
+}
+
+// customPropertyLabels returns the sorted label keys of a catalog model; labels are stored as
+// customProperties with an empty string value
+func customPropertyLabels(props map[string]types.MetadataValue) []string {
+	var labels []string
+	for key, value := range props {
+		if value.StringValue == "" {
+			labels = append(labels, key)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// sortedKeys returns the keys of m in sorted order, for deterministic iteration
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
 
+// CreateModelsCatalogWithStatic collects all metadata.yaml files, merges with static models, and creates a models-catalog.yaml (backward compatibility)
+func CreateModelsCatalogWithStatic(outputDir, catalogPath string, staticModels []types.CatalogMetadata) error {
+	return CreateModelsCatalogFromOutput(outputDir, catalogPath, staticModels, LabelFilter{})
+}
+
+// CreateModelsCatalogFiltered collects all metadata.yaml files and creates a models catalog containing
+// only models whose labels include any of includeLabels (all models when empty) and none of excludeLabels
+func CreateModelsCatalogFiltered(outputDir, catalogPath string, includeLabels, excludeLabels []string) error {
+	filter := LabelFilter{Include: includeLabels, Exclude: excludeLabels}
+	return CreateModelsCatalogFromOutput(outputDir, catalogPath, []types.CatalogMetadata{}, filter)
+}
+
+// CreateModelsCatalogFromOutput creates a models catalog from every metadata.yaml under outputDir
+// and static models, without needing the refs of a current run (e.g. to rebuild the catalog from
+// a previous run's output)
+func CreateModelsCatalogFromOutput(outputDir, catalogPath string, staticModels []types.CatalogMetadata, filter LabelFilter) error {
+	modelDirs, err := findModelDirs(outputDir)
 	if err != nil {
-		return fmt.Errorf("error walking directory: %v", err)
+		return err
 	}
 
-	// Use the new function with the found model references
-	return CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath, modelRefs, staticModels)
+	return createModelsCatalogFromDirs(outputDir, catalogPath, modelDirs, staticModels, filter)
+}
+
+// findModelDirs returns the sanitized model directory names that contain a metadata.yaml under
+// outputDir. Directory names cannot be turned back into refs, so the catalog is built from the
+// directories themselves; directories without a manifest-ref.txt predate the current layout and
+// are ignored.
+func findModelDirs(outputDir string) ([]string, error) {
+	modelDirs, err := utils.ModelOutputDirs(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading output directory: %v", err)
+	}
+	return modelDirs, nil
 }
 
 // CreateModelsCatalog collects all metadata.yaml files and creates a models-catalog.yaml (backward compatibility)
@@ -269,6 +443,54 @@ func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.Cata
 		customProps["hardware_tag"] = createMetadataValue(strings.Join(model.HardwareTag, ","))
 	}
 
+	// Add base_model as comma-separated customProperty if present
+	if len(model.BaseModel) > 0 {
+		customProps["base_model"] = createMetadataValue(strings.Join(model.BaseModel, ","))
+	}
+
+	// Add maturity as customProperty if present
+	if model.Maturity != nil && *model.Maturity != "" {
+		customProps["maturity"] = createMetadataValue(*model.Maturity)
+	}
+
+	// Add deprecation as customProperties so the UI can show a deprecation banner
+	if model.Deprecated {
+		customProps["deprecated"] = createMetadataValue("true")
+	}
+	if model.ReplacedBy != "" {
+		customProps["replaced_by"] = createMetadataValue(model.ReplacedBy)
+	}
+
+	// Add model_size as customProperty if present, for filtering models by size
+	if model.ModelSize != nil && *model.ModelSize != "" {
+		customProps["model_size"] = createMetadataValue(*model.ModelSize)
+	}
+
+	// Add quantization as customProperty for filtering models by precision; names changed by
+	// enrichment (or metadata from older runs) are parsed here
+	quantization := model.Quantization
+	if quantization == nil && model.Name != nil {
+		if scheme, ok := utils.ParseQuantization(*model.Name); ok {
+			quantization = &scheme
+		}
+	}
+	if quantization != nil && *quantization != "" {
+		customProps["quantization"] = createMetadataValue(*quantization)
+	}
+
+	// Add modelcard benchmark scores as eval_<metric> customProperties for the UI
+	for metric, score := range model.Evaluations {
+		customProps["eval_"+metric] = createMetadataValue(score)
+	}
+
+	// Add HuggingFace popularity counts as customProperties for ranking models
+	if model.Downloads != nil {
+		customProps["downloads"] = createMetadataValue(strconv.Itoa(*model.Downloads))
+	}
+	if model.Likes != nil {
+		customProps["likes"] = createMetadataValue(strconv.Itoa(*model.Likes))
+	}
+
 	// Add model_type as customProperty (defaults to "generative")
 	// Note: In future, this could be extracted from modelcard metadata
 	customProps["model_type"] = createMetadataValue(types.GetDefaultModelType())
@@ -330,7 +552,9 @@ func convertTimestampToString(timestamp *int64) *string {
 	return &str
 }
 
-// convertTagsToCustomProperties converts all tags to customProperties format
+// convertTagsToCustomProperties converts all tags to customProperties format. The result is a
+// map; yaml.v3 marshals map keys in sorted order, so the emitted YAML does not depend on
+// tag order or map iteration.
 func convertTagsToCustomProperties(tags []string) map[string]types.MetadataValue {
 	customProps := make(map[string]types.MetadataValue)
 
@@ -357,7 +581,8 @@ func convertCustomPropertiesToMetadataValue(customProps map[string]interface{})
 		return nil
 	}
 
-	result := make(map[string]interface{})
+	// Map order is irrelevant here: yaml.v3 sorts map keys when the catalog is marshaled
+	result := make(map[string]interface{}, len(customProps))
 	for key, value := range customProps {
 		result[key] = ensureMetadataValueFormat(value)
 	}
@@ -374,69 +599,118 @@ func ensureMetadataValueFormat(value interface{}) map[string]interface{} {
 			return valueMap
 		} else {
 			// Convert to proper MetadataValue format
-			stringValue := ""
-			if strVal, hasStringValue := valueMap["string_value"]; hasStringValue {
-				if str, ok := strVal.(string); ok {
-					stringValue = str
-				}
-			}
 			return map[string]interface{}{
 				"metadataType": "MetadataStringValue",
-				"string_value": stringValue,
+				"string_value": scalarToString(valueMap["string_value"]),
 			}
 		}
 	} else {
 		// Convert simple values to MetadataValue format
-		stringValue := ""
-		if str, ok := value.(string); ok {
-			stringValue = str
-		}
 		return map[string]interface{}{
 			"metadataType": "MetadataStringValue",
-			"string_value": stringValue,
+			"string_value": scalarToString(value),
 		}
 	}
 }
 
-// determineLogo determines which logo to use based on model tags and returns base64-encoded data URI
-func determineLogo(tags []string) *string {
-	var svgPath string
-
-	// Check if the model has the "validated" label
-	for _, tag := range tags {
-		if tag == "validated" {
-			svgPath = "assets/catalog-validated_model.svg"
-			break
-		}
+// scalarToString renders a string or number custom property value as a string, so
+// numeric values such as sizes and counts are not lost; other types become ""
+func scalarToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int, int64, uint64, float64:
+		return fmt.Sprint(v)
+	default:
+		return ""
 	}
+}
 
-	// Default logo for non-validated models
-	if svgPath == "" {
-		svgPath = "assets/catalog-model.svg"
-	}
+// Supported deduplication strategies for catalog models
+const (
+	DedupByName     = "name"
+	DedupByArtifact = "artifact"
+)
 
-	// Read and encode the SVG file
-	dataUri := encodeSVGToDataURI(svgPath)
-	return dataUri
+// dedupStrategy controls how deduplicateAndMergeModels groups duplicate models
+var dedupStrategy = DedupByName
+
+// SetDedupStrategy configures how duplicate models are detected ("name" or "artifact").
+// "name" groups models by case-insensitive display name; "artifact" groups models whose
+// artifacts point at the same set of image repositories, ignoring tags and digests, so
+// different quantizations sharing a display name stay separate while the same model
+// published under several tags is consolidated.
+func SetDedupStrategy(strategy string) error {
+	switch strategy {
+	case DedupByName, DedupByArtifact:
+		dedupStrategy = strategy
+		return nil
+	default:
+		return fmt.Errorf("invalid dedup strategy: %q (allowed values: %q, %q)", strategy, DedupByName, DedupByArtifact)
+	}
 }
 
-// encodeSVGToDataURI reads an SVG file and returns a base64-encoded data URI
-func encodeSVGToDataURI(svgPath string) *string {
-	// Read the SVG file
-	svgContent, err := os.ReadFile(svgPath)
-	if err != nil {
-		log.Printf("Warning: Failed to read SVG file %s: %v", svgPath, err)
-		// Return the file path as fallback
-		fallback := svgPath
-		return &fallback
+// Supported catalog orderings
+const (
+	SortByName      = "name"
+	SortByCreated   = "created"
+	SortByUpdated   = "updated"
+	SortByDownloads = "downloads"
+)
+
+// catalogSort is the catalog model ordering; SortByName keeps dynamic models sorted by name
+// followed by static models in file order
+var catalogSort = SortByName
+
+// SetCatalogSort configures how catalog models are ordered: "name" (ascending, the default),
+// "created" or "updated" (newest first) or "downloads" (most downloaded first). Models
+// without the chosen value are placed last, and ties are ordered by name.
+func SetCatalogSort(key string) error {
+	switch key {
+	case SortByName, SortByCreated, SortByUpdated, SortByDownloads:
+		catalogSort = key
+		return nil
+	default:
+		return fmt.Errorf("invalid catalog sort: %q (allowed values: %q, %q, %q, %q)", key, SortByName, SortByCreated, SortByUpdated, SortByDownloads)
 	}
+}
 
-	// Encode to base64
-	base64Content := base64.StdEncoding.EncodeToString(svgContent)
+// sortCatalogModels orders models in place by the given sort key
+func sortCatalogModels(models []types.CatalogMetadata, key string) {
+	sort.SliceStable(models, func(i, j int) bool {
+		a, b := &models[i], &models[j]
+		if key != SortByName {
+			valueA, okA := catalogSortValue(a, key)
+			valueB, okB := catalogSortValue(b, key)
+			if okA != okB {
+				return okA
+			}
+			if okA && valueA != valueB {
+				return valueA > valueB
+			}
+		}
+		return getModelName(a) < getModelName(b)
+	})
+}
 
-	// Create data URI
-	dataUri := "data:image/svg+xml;base64," + base64Content
-	return &dataUri
+// catalogSortValue returns the numeric value a model is ordered by for a descending sort key
+func catalogSortValue(model *types.CatalogMetadata, key string) (int64, bool) {
+	var raw *string
+	switch key {
+	case SortByCreated:
+		raw = model.CreateTimeSinceEpoch
+	case SortByUpdated:
+		raw = model.LastUpdateTimeSinceEpoch
+	case SortByDownloads:
+		if prop, ok := model.CustomProperties["downloads"]; ok {
+			raw = &prop.StringValue
+		}
+	}
+	if raw == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(*raw, 10, 64)
+	return value, err == nil
 }
 
 // deduplicateAndMergeModels consolidates duplicate models by merging their artifacts and metadata
@@ -447,15 +721,15 @@ func deduplicateAndMergeModels(models []types.CatalogMetadata) []types.CatalogMe
 
 	var unnamed []types.CatalogMetadata
 
-	// Group models by name (case-insensitive)
+	// Group models by the configured dedup key
 	modelGroups := make(map[string][]types.CatalogMetadata)
 	for _, model := range models {
 		if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
 			unnamed = append(unnamed, model)
 			continue
 		}
-		normalizedName := strings.ToLower(strings.TrimSpace(*model.Name))
-		modelGroups[normalizedName] = append(modelGroups[normalizedName], model)
+		key := dedupKey(model)
+		modelGroups[key] = append(modelGroups[key], model)
 	}
 
 	var result []types.CatalogMetadata
@@ -495,6 +769,73 @@ func deduplicateAndMergeModels(models []types.CatalogMetadata) []types.CatalogMe
 	return append(result, unnamed...)
 }
 
+// dedupKey returns the grouping key for a model under the configured dedup strategy
+func dedupKey(model types.CatalogMetadata) string {
+	nameKey := strings.ToLower(strings.TrimSpace(*model.Name))
+	if dedupStrategy != DedupByArtifact {
+		return nameKey
+	}
+
+	repositories := make(map[string]bool)
+	for _, artifact := range model.Artifacts {
+		if repo := artifactRepository(artifact.URI); repo != "" {
+			repositories[repo] = true
+		}
+	}
+	// Models without artifacts have no identity beyond their name
+	if len(repositories) == 0 {
+		return "name:" + nameKey
+	}
+
+	keys := make([]string, 0, len(repositories))
+	for repo := range repositories {
+		keys = append(keys, repo)
+	}
+	sort.Strings(keys)
+	return "artifact:" + strings.Join(keys, ",")
+}
+
+// ociScheme is the URI scheme every catalog artifact reference carries
+const ociScheme = "oci://"
+
+// normalizeArtifactURI ensures an artifact reference carries the oci:// scheme,
+// rewriting bare ("registry.redhat.io/...") and docker-style ("docker://...") references.
+// OCI layout references ("oci:<dir>[:<tag>]") are kept as they are.
+func normalizeArtifactURI(uri string) string {
+	uri = strings.TrimSpace(uri)
+	if uri == "" || strings.HasPrefix(uri, ociScheme) || registry.IsOCILayoutRef(uri) {
+		return uri
+	}
+	return ociScheme + strings.TrimPrefix(uri, "docker://")
+}
+
+// normalizeArtifactURIs rewrites the artifact URIs of every model to the oci:// form
+func normalizeArtifactURIs(models []types.CatalogMetadata) {
+	for i := range models {
+		for j := range models[i].Artifacts {
+			artifact := &models[i].Artifacts[j]
+			if normalized := normalizeArtifactURI(artifact.URI); normalized != artifact.URI {
+				log.Printf("  Normalized artifact URI %q to %q", artifact.URI, normalized)
+				artifact.URI = normalized
+			}
+		}
+	}
+}
+
+// artifactRepository strips the oci:// scheme, digest and tag from an artifact URI,
+// e.g. "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5" -> "registry.redhat.io/rhelai1/modelcar-granite"
+func artifactRepository(uri string) string {
+	repo := strings.TrimPrefix(normalizeArtifactURI(uri), ociScheme)
+	if at := strings.Index(repo, "@"); at >= 0 {
+		repo = repo[:at]
+	}
+	// A colon after the last slash separates the tag; earlier colons belong to a registry port
+	if colon := strings.LastIndex(repo, ":"); colon > strings.LastIndex(repo, "/") {
+		repo = repo[:colon]
+	}
+	return repo
+}
+
 // mergeModelGroup merges a group of duplicate models into a single consolidated model
 func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	if len(group) == 0 {
@@ -554,6 +895,9 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	merged.CreateTimeSinceEpoch = earliestCreate
 	merged.LastUpdateTimeSinceEpoch = latestUpdate
 
+	// A real description from any duplicate beats a fallback generated from the name
+	merged.Description = richestDescription(group)
+
 	// Merge metadata fields - prefer non-empty values, with priority to first model
 	for i := 1; i < len(group); i++ {
 		model := group[i]
@@ -562,9 +906,6 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 		if merged.Provider == nil && model.Provider != nil {
 			merged.Provider = model.Provider
 		}
-		if merged.Description == nil && model.Description != nil {
-			merged.Description = model.Description
-		}
 		if merged.Readme == nil && model.Readme != nil {
 			merged.Readme = model.Readme
 		}
@@ -611,6 +952,46 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	return merged
 }
 
+// richestDescription picks the description to keep for a merged model: descriptions
+// written by a human win over ones generated from the model name, then the longest wins,
+// with ties going to the earlier model
+func richestDescription(group []types.CatalogMetadata) *string {
+	var best *string
+	bestGenerated := false
+
+	for _, model := range group {
+		if model.Description == nil || strings.TrimSpace(*model.Description) == "" {
+			continue
+		}
+		generated := isGeneratedDescription(model)
+		better := best == nil ||
+			(bestGenerated && !generated) ||
+			(bestGenerated == generated && len(*model.Description) > len(*best))
+		if better {
+			best = model.Description
+			bestGenerated = generated
+		}
+	}
+
+	if best == nil {
+		// Keep an empty description rather than dropping the field
+		return group[0].Description
+	}
+	return best
+}
+
+// isGeneratedDescription reports whether a model's description is one of the fallbacks
+// produced from its name during extraction or enrichment
+func isGeneratedDescription(model types.CatalogMetadata) bool {
+	if model.Description == nil || model.Name == nil {
+		return false
+	}
+	description := *model.Description
+	return description == utils.GenerateDescriptionFromModelName(*model.Name) ||
+		description == utils.GenerateReadableDescription(*model.Name) ||
+		description == utils.GenerateReadableDescriptionForTasks(*model.Name, model.Tasks)
+}
+
 // compareTimestamps compares two timestamp strings, returns -1 if a < b, 1 if a > b, 0 if equal
 func compareTimestamps(a, b string) int {
 	timestampA, errA := strconv.ParseInt(a, 10, 64)
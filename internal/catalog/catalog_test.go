@@ -4,12 +4,15 @@ import (
 	"encoding/base64"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestCreateModelsCatalog(t *testing.T) {
@@ -78,6 +81,9 @@ func TestCreateModelsCatalog(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create test directory %s: %v", dir, err)
 		}
+		if err := utils.WriteManifestRef(filepath.Dir(dir), "registry.example.com/"+filepath.Base(filepath.Dir(dir))+":1.0"); err != nil {
+			t.Fatal(err)
+		}
 
 		data, err := yaml.Marshal(model.metadata)
 		if err != nil {
@@ -481,6 +487,9 @@ func TestLogoAssignment(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create test directory %s: %v", dir, err)
 		}
+		if err := utils.WriteManifestRef(filepath.Dir(dir), "registry.example.com/"+filepath.Base(filepath.Dir(dir))+":1.0"); err != nil {
+			t.Fatal(err)
+		}
 
 		data, err := yaml.Marshal(model.metadata)
 		if err != nil {
@@ -1053,6 +1062,25 @@ func TestConvertCustomPropertiesToMetadataValue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "numeric values",
+			input: map[string]interface{}{
+				"layer_count": 3,
+				"total_size_bytes": map[string]interface{}{
+					"string_value": int64(17179874304),
+				},
+			},
+			expected: map[string]interface{}{
+				"layer_count": map[string]interface{}{
+					"metadataType": "MetadataStringValue",
+					"string_value": "3",
+				},
+				"total_size_bytes": map[string]interface{}{
+					"metadataType": "MetadataStringValue",
+					"string_value": "17179874304",
+				},
+			},
+		},
 		{
 			name: "mixed format properties",
 			input: map[string]interface{}{
@@ -1180,6 +1208,9 @@ func TestCreateModelsCatalogWithStatic(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create test directory %s: %v", dir, err)
 		}
+		if err := utils.WriteManifestRef(filepath.Dir(dir), "registry.example.com/"+filepath.Base(filepath.Dir(dir))+":1.0"); err != nil {
+			t.Fatal(err)
+		}
 
 		data, err := yaml.Marshal(model.metadata)
 		if err != nil {
@@ -1447,6 +1478,36 @@ func TestConvertExtractedToCatalogMetadata_HardwareTag(t *testing.T) {
 	}
 }
 
+func TestConvertExtractedToCatalogMetadata_BaseModel(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:      stringPtr("Test Model"),
+		BaseModel: []string{"meta-llama/Llama-3.1-8B", "meta-llama/Llama-3.1-8B-Instruct"},
+		Artifacts: []types.OCIArtifact{},
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+
+	expected := types.MetadataValue{
+		MetadataType: "MetadataStringValue",
+		StringValue:  "meta-llama/Llama-3.1-8B,meta-llama/Llama-3.1-8B-Instruct",
+	}
+	if got := result.CustomProperties["base_model"]; got != expected {
+		t.Errorf("Expected base_model customProperty to be %+v, got %+v", expected, got)
+	}
+
+	metadata.BaseModel = []string{"ibm-granite/granite-3.1-8b-instruct"}
+	result = convertExtractedToCatalogMetadata(metadata)
+	if got := result.CustomProperties["base_model"].StringValue; got != "ibm-granite/granite-3.1-8b-instruct" {
+		t.Errorf("Expected single base_model customProperty, got %q", got)
+	}
+
+	metadata.BaseModel = nil
+	result = convertExtractedToCatalogMetadata(metadata)
+	if _, exists := result.CustomProperties["base_model"]; exists {
+		t.Error("Expected base_model to NOT be in CustomProperties when BaseModel is empty")
+	}
+}
+
 func TestConvertExtractedToCatalogMetadata_NoValidatedOn(t *testing.T) {
 	// Test that models without ValidatedOn don't have the customProperty
 	metadata := types.ExtractedMetadata{
@@ -1618,3 +1679,817 @@ func TestConvertExtractedToCatalogMetadata_ToolCallingInjectsTask(t *testing.T)
 		t.Errorf("Expected 'tool-calling' to be injected into tasks, got %v", result.Tasks)
 	}
 }
+
+func TestDeduplicateAndMergeModels_Strategies(t *testing.T) {
+	models := []types.CatalogMetadata{
+		{
+			Name:                     stringPtr("Llama 3.1 8B Instruct"),
+			CreateTimeSinceEpoch:     stringPtr("2000"),
+			LastUpdateTimeSinceEpoch: stringPtr("2000"),
+			Artifacts:                []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct-quantized-w4a16:1.5"}},
+		},
+		{
+			Name:      stringPtr("Llama 3.1 8B Instruct"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct-quantized-w8a8:1.5"}},
+		},
+		{
+			Name:                     stringPtr("granite-3.1-8b-instruct"),
+			CreateTimeSinceEpoch:     stringPtr("3000"),
+			LastUpdateTimeSinceEpoch: stringPtr("3000"),
+			Artifacts:                []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"}},
+		},
+		{
+			Name:                     stringPtr("Granite 3.1 8B Instruct"),
+			CreateTimeSinceEpoch:     stringPtr("1000"),
+			LastUpdateTimeSinceEpoch: stringPtr("4000"),
+			Artifacts:                []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct@sha256:abc123"}},
+		},
+	}
+
+	tests := []struct {
+		strategy      string
+		expectedCount int
+	}{
+		// Quantizations sharing a name are merged, the retagged granite stays split
+		{strategy: DedupByName, expectedCount: 3},
+		// Quantizations stay separate, granite tag and digest artifacts are merged
+		{strategy: DedupByArtifact, expectedCount: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			if err := SetDedupStrategy(tt.strategy); err != nil {
+				t.Fatalf("SetDedupStrategy failed: %v", err)
+			}
+			defer func() { _ = SetDedupStrategy(DedupByName) }()
+
+			result := deduplicateAndMergeModels(models)
+			if len(result) != tt.expectedCount {
+				t.Fatalf("Expected %d models, got %d", tt.expectedCount, len(result))
+			}
+
+			artifactCounts := make(map[string]int)
+			for _, model := range result {
+				artifactCounts[*model.Name] += len(model.Artifacts)
+			}
+
+			switch tt.strategy {
+			case DedupByName:
+				if artifactCounts["Llama 3.1 8B Instruct"] != 2 {
+					t.Errorf("Expected llama quantizations to be merged by name, got %v", artifactCounts)
+				}
+			case DedupByArtifact:
+				llamaEntries := 0
+				for _, model := range result {
+					if *model.Name == "Llama 3.1 8B Instruct" {
+						llamaEntries++
+					}
+				}
+				if llamaEntries != 2 {
+					t.Errorf("Expected w4a16 and w8a8 to stay separate, got %d llama entries", llamaEntries)
+				}
+
+				// The first granite entry wins the name; timestamps span both entries
+				var granite *types.CatalogMetadata
+				for i := range result {
+					if *result[i].Name == "granite-3.1-8b-instruct" {
+						granite = &result[i]
+					}
+				}
+				if granite == nil {
+					t.Fatalf("Expected merged granite entry, got %v", artifactCounts)
+				}
+				if len(granite.Artifacts) != 2 {
+					t.Errorf("Expected granite artifacts to be consolidated, got %d", len(granite.Artifacts))
+				}
+				if *granite.CreateTimeSinceEpoch != "1000" || *granite.LastUpdateTimeSinceEpoch != "4000" {
+					t.Errorf("Expected earliest create 1000 and latest update 4000, got %s/%s",
+						*granite.CreateTimeSinceEpoch, *granite.LastUpdateTimeSinceEpoch)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDedupStrategy_Invalid(t *testing.T) {
+	if err := SetDedupStrategy("uri"); err == nil {
+		t.Error("Expected error for unknown dedup strategy")
+	}
+	if dedupStrategy != DedupByName {
+		t.Errorf("Expected strategy to remain %q, got %q", DedupByName, dedupStrategy)
+	}
+}
+
+func TestArtifactRepository(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+	}{
+		{"oci://registry.redhat.io/rhelai1/modelcar-granite:1.5", "registry.redhat.io/rhelai1/modelcar-granite"},
+		{"oci://registry.redhat.io/rhelai1/modelcar-granite@sha256:abc123", "registry.redhat.io/rhelai1/modelcar-granite"},
+		{"oci://localhost:5000/models/granite:latest", "localhost:5000/models/granite"},
+		{"localhost:5000/models/granite", "localhost:5000/models/granite"},
+		{"quay.io/RedHatAI/Granite", "quay.io/RedHatAI/Granite"},
+	}
+
+	for _, tt := range tests {
+		if got := artifactRepository(tt.uri); got != tt.expected {
+			t.Errorf("artifactRepository(%q) = %q, want %q", tt.uri, got, tt.expected)
+		}
+	}
+}
+
+func TestMergeModelGroup_PrefersRichestDescription(t *testing.T) {
+	name := "RedHatAI/Llama-3.1-8B-Instruct"
+	generated := utils.GenerateDescriptionFromModelName(name)
+	overview := "Llama 3.1 8B Instruct is a multilingual instruction-tuned model optimized for dialogue, outperforming many open chat models on common industry benchmarks."
+
+	tests := []struct {
+		name     string
+		group    []types.CatalogMetadata
+		expected string
+	}{
+		{
+			name: "real overview replaces generated fallback on first model",
+			group: []types.CatalogMetadata{
+				{Name: stringPtr(name), Description: stringPtr(generated)},
+				{Name: stringPtr(name), Description: stringPtr(overview)},
+			},
+			expected: overview,
+		},
+		{
+			name: "short real description beats longer generated one",
+			group: []types.CatalogMetadata{
+				{Name: stringPtr(name), Description: stringPtr(generated)},
+				{Name: stringPtr(name), Description: stringPtr("Chat model.")},
+			},
+			expected: "Chat model.",
+		},
+		{
+			name: "longest real description wins",
+			group: []types.CatalogMetadata{
+				{Name: stringPtr(name), Description: stringPtr("Chat model.")},
+				{Name: stringPtr(name), Description: stringPtr(overview)},
+			},
+			expected: overview,
+		},
+		{
+			name: "missing description is filled from duplicate",
+			group: []types.CatalogMetadata{
+				{Name: stringPtr(name)},
+				{Name: stringPtr(name), Description: stringPtr(generated)},
+			},
+			expected: generated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeModelGroup(tt.group)
+			if merged.Description == nil {
+				t.Fatalf("Expected description %q, got nil", tt.expected)
+			}
+			if *merged.Description != tt.expected {
+				t.Errorf("Expected description %q, got %q", tt.expected, *merged.Description)
+			}
+		})
+	}
+}
+
+func TestCreateModelsCatalogFiltered(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "output")
+
+	models := map[string][]string{
+		"validated-model": {"validated", "featured"},
+		"featured-model":  {"featured"},
+		"teacher-model":   {"validated", "lab-teacher"},
+		"plain-model":     nil,
+	}
+	for dir, tags := range models {
+		metadataPath := filepath.Join(outputDir, dir, "models", "metadata.yaml")
+		if err := os.MkdirAll(filepath.Dir(metadataPath), 0755); err != nil {
+			t.Fatalf("Failed to create model directory: %v", err)
+		}
+		if err := utils.WriteManifestRef(filepath.Join(outputDir, dir), "registry.example.com/org/"+dir+":1.0"); err != nil {
+			t.Fatal(err)
+		}
+		data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr(dir), Tags: tags})
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(metadataPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		includeLabels []string
+		excludeLabels []string
+		expected      []string
+	}{
+		{
+			name:     "no filter keeps all models",
+			expected: []string{"featured-model", "plain-model", "teacher-model", "validated-model"},
+		},
+		{
+			name:          "include validated",
+			includeLabels: []string{"validated"},
+			expected:      []string{"teacher-model", "validated-model"},
+		},
+		{
+			name:          "include validated excluding lab-teacher",
+			includeLabels: []string{"validated"},
+			excludeLabels: []string{"lab-teacher"},
+			expected:      []string{"validated-model"},
+		},
+		{
+			name:          "exclude only",
+			excludeLabels: []string{"featured"},
+			expected:      []string{"plain-model", "teacher-model"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+			if err := CreateModelsCatalogFiltered(outputDir, catalogPath, tt.includeLabels, tt.excludeLabels); err != nil {
+				t.Fatalf("CreateModelsCatalogFiltered failed: %v", err)
+			}
+
+			catalog := readTestCatalog(t, catalogPath)
+			var names []string
+			for _, model := range catalog.Models {
+				names = append(names, *model.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected models %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestCreateModelsCatalogFromOutput(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "output")
+	for dir, tags := range map[string][]string{"validated-model": {"validated"}, "plain-model": nil} {
+		metadataPath := filepath.Join(outputDir, dir, "models", "metadata.yaml")
+		if err := os.MkdirAll(filepath.Dir(metadataPath), 0755); err != nil {
+			t.Fatalf("Failed to create model directory: %v", err)
+		}
+		if err := utils.WriteManifestRef(filepath.Join(outputDir, dir), "registry.example.com/org/"+dir+":1.0"); err != nil {
+			t.Fatal(err)
+		}
+		data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr(dir), Tags: tags})
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(metadataPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+	}
+	// A directory from before manifest-ref.txt was written duplicates a current one and is ignored
+	legacyPath := filepath.Join(outputDir, "legacy-model", "models", "metadata.yaml")
+	if err := os.MkdirAll(filepath.Dir(legacyPath), 0755); err != nil {
+		t.Fatalf("Failed to create model directory: %v", err)
+	}
+	data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr("legacy-model"), Tags: []string{"validated"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(legacyPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	staticModels := []types.CatalogMetadata{{
+		Name:      stringPtr("static-model"),
+		Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.example.com/org/static-model:1.0"}},
+	}}
+
+	// Every current model directory is used, without the refs of a current run
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	filter := LabelFilter{Include: []string{"validated"}}
+	if err := CreateModelsCatalogFromOutput(outputDir, catalogPath, staticModels, filter); err != nil {
+		t.Fatalf("CreateModelsCatalogFromOutput failed: %v", err)
+	}
+
+	var names []string
+	for _, model := range readTestCatalog(t, catalogPath).Models {
+		names = append(names, *model.Name)
+	}
+	sort.Strings(names)
+	expected := []string{"static-model", "validated-model"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected models %v, got %v", expected, names)
+	}
+}
+
+func TestCreateModelsCatalogWithStaticFromResults_LabelFilterOnStatic(t *testing.T) {
+	outputDir := t.TempDir()
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+
+	staticModels := []types.CatalogMetadata{
+		{
+			Name:             stringPtr("Static Featured"),
+			CustomProperties: map[string]types.MetadataValue{"featured": {MetadataType: "MetadataStringValue"}},
+			Artifacts:        []types.CatalogOCIArtifact{{URI: "oci://example.com/static-featured:1.0"}},
+		},
+		{
+			Name: stringPtr("Static Deprecated"),
+			CustomProperties: map[string]types.MetadataValue{
+				"deprecated": {MetadataType: "MetadataStringValue"},
+				"model_type": {MetadataType: "MetadataStringValue", StringValue: "generative"},
+			},
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/static-deprecated:1.0"}},
+		},
+		{
+			Name: stringPtr("Static Generative"),
+			CustomProperties: map[string]types.MetadataValue{
+				"model_type": {MetadataType: "MetadataStringValue", StringValue: "generative"},
+			},
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/static-generative:1.0"}},
+		},
+	}
+
+	// The include list only applies to extracted models; properties with values are not labels
+	filter := LabelFilter{Include: []string{"validated"}, Exclude: []string{"deprecated", "generative"}}
+	if err := CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath, nil, staticModels, filter); err != nil {
+		t.Fatalf("CreateModelsCatalogWithStaticFromResults failed: %v", err)
+	}
+
+	catalog := readTestCatalog(t, catalogPath)
+	var names []string
+	for _, model := range catalog.Models {
+		names = append(names, *model.Name)
+	}
+	expected := []string{"Static Featured", "Static Generative"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected static models %v, got %v", expected, names)
+	}
+}
+
+// readTestCatalog parses a generated catalog file
+func readTestCatalog(t *testing.T, path string) types.ModelsCatalog {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+	return catalog
+}
+
+func TestNormalizeArtifactURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		uri      string
+		expected string
+	}{
+		{"already prefixed", "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5", "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"},
+		{"bare reference", "registry.redhat.io/rhelai1/modelcar-granite:1.5", "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"},
+		{"bare digest reference", "quay.io/redhat-ai/granite@sha256:abc123", "oci://quay.io/redhat-ai/granite@sha256:abc123"},
+		{"docker-style reference", "docker://registry.redhat.io/rhelai1/modelcar-granite:1.5", "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"},
+		{"surrounding whitespace", "  registry.redhat.io/rhelai1/modelcar-granite:1.5 ", "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"},
+		{"oci layout reference", "oci:/srv/layouts/granite:1.0", "oci:/srv/layouts/granite:1.0"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeArtifactURI(tt.uri); got != tt.expected {
+				t.Errorf("normalizeArtifactURI(%q) = %q, want %q", tt.uri, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeArtifactURIs(t *testing.T) {
+	models := []types.CatalogMetadata{
+		{
+			Name: stringPtr("Mixed Model"),
+			Artifacts: []types.CatalogOCIArtifact{
+				{URI: "registry.redhat.io/rhelai1/modelcar-mixed:1.0"},
+				{URI: "docker://registry.redhat.io/rhelai1/modelcar-mixed:1.1"},
+				{URI: "oci://registry.redhat.io/rhelai1/modelcar-mixed:1.2"},
+			},
+		},
+		{Name: stringPtr("No Artifacts")},
+	}
+
+	normalizeArtifactURIs(models)
+
+	for _, artifact := range models[0].Artifacts {
+		if !strings.HasPrefix(artifact.URI, "oci://registry.redhat.io/") {
+			t.Errorf("Expected normalized oci:// URI, got %q", artifact.URI)
+		}
+	}
+
+	// Bare and prefixed references to the same image now share a dedup key
+	if err := SetDedupStrategy(DedupByArtifact); err != nil {
+		t.Fatalf("SetDedupStrategy failed: %v", err)
+	}
+	defer func() { _ = SetDedupStrategy(DedupByName) }()
+	merged := deduplicateAndMergeModels([]types.CatalogMetadata{
+		{Name: stringPtr("Static"), Artifacts: []types.CatalogOCIArtifact{{URI: "registry.redhat.io/rhelai1/modelcar-mixed:1.0"}}},
+		{Name: stringPtr("Dynamic"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-mixed:1.0"}}},
+	})
+	if len(merged) != 1 {
+		t.Errorf("Expected bare and oci:// references to be merged, got %d models", len(merged))
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Maturity(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:     stringPtr("Test Model"),
+		Maturity: stringPtr("production"),
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+	if got := result.CustomProperties["maturity"].StringValue; got != "production" {
+		t.Errorf("Expected maturity customProperty %q, got %q", "production", got)
+	}
+
+	metadata.Maturity = nil
+	result = convertExtractedToCatalogMetadata(metadata)
+	if _, exists := result.CustomProperties["maturity"]; exists {
+		t.Error("Expected maturity to NOT be in CustomProperties when Maturity is nil")
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Deprecation(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:       stringPtr("Granite 3.0 8B Instruct"),
+		Deprecated: true,
+		ReplacedBy: "RedHatAI/granite-3.1-8b-instruct",
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+	if got := result.CustomProperties["deprecated"].StringValue; got != "true" {
+		t.Errorf("Expected deprecated customProperty %q, got %q", "true", got)
+	}
+	if got := result.CustomProperties["replaced_by"].StringValue; got != "RedHatAI/granite-3.1-8b-instruct" {
+		t.Errorf("Expected replaced_by customProperty, got %q", got)
+	}
+
+	result = convertExtractedToCatalogMetadata(types.ExtractedMetadata{Name: stringPtr("Granite 3.1 8B Instruct")})
+	for _, key := range []string{"deprecated", "replaced_by"} {
+		if _, exists := result.CustomProperties[key]; exists {
+			t.Errorf("Expected %s to NOT be in CustomProperties for a current model", key)
+		}
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Quantization(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata types.ExtractedMetadata
+		expected string
+	}{
+		{
+			name:     "extracted scheme",
+			metadata: types.ExtractedMetadata{Name: stringPtr("Granite 3.1 8B Instruct"), Quantization: stringPtr("w8a8")},
+			expected: "w8a8",
+		},
+		{
+			name:     "parsed from enriched name",
+			metadata: types.ExtractedMetadata{Name: stringPtr("RedHatAI/Llama-3.1-8B-Instruct-FP8-dynamic")},
+			expected: "fp8-dynamic",
+		},
+		{
+			name:     "non-quantized model",
+			metadata: types.ExtractedMetadata{Name: stringPtr("ibm-granite/granite-3.1-8b-instruct")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := convertExtractedToCatalogMetadata(tt.metadata)
+			value, exists := result.CustomProperties["quantization"]
+			if tt.expected == "" {
+				if exists {
+					t.Errorf("Expected no quantization customProperty, got %q", value.StringValue)
+				}
+				return
+			}
+			if value.StringValue != tt.expected {
+				t.Errorf("Expected quantization customProperty %q, got %q", tt.expected, value.StringValue)
+			}
+		})
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_ModelSize(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:      stringPtr("Test Model"),
+		ModelSize: stringPtr("8B"),
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+	if got := result.CustomProperties["model_size"].StringValue; got != "8B" {
+		t.Errorf("Expected model_size customProperty %q, got %q", "8B", got)
+	}
+
+	metadata.ModelSize = nil
+	result = convertExtractedToCatalogMetadata(metadata)
+	if _, exists := result.CustomProperties["model_size"]; exists {
+		t.Error("Expected model_size to NOT be in CustomProperties when ModelSize is nil")
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Evaluations(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:        stringPtr("Test Model"),
+		Evaluations: map[string]string{"mmlu_5_shot": "65.82", "gsm8k": "70.3%"},
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+	for key, want := range map[string]string{"eval_mmlu_5_shot": "65.82", "eval_gsm8k": "70.3%"} {
+		if got := result.CustomProperties[key]; got.StringValue != want || got.MetadataType != "MetadataStringValue" {
+			t.Errorf("Expected %s customProperty %q, got %+v", key, want, got)
+		}
+	}
+
+	metadata.Evaluations = nil
+	result = convertExtractedToCatalogMetadata(metadata)
+	for key := range result.CustomProperties {
+		if strings.HasPrefix(key, "eval_") {
+			t.Errorf("Expected no eval_ customProperties without evaluations, got %s", key)
+		}
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Popularity(t *testing.T) {
+	downloads, likes := 125000, 0
+	metadata := types.ExtractedMetadata{
+		Name:      stringPtr("Test Model"),
+		Downloads: &downloads,
+		Likes:     &likes,
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata)
+	expected := map[string]string{"downloads": "125000", "likes": "0"}
+	for key, value := range expected {
+		prop, exists := result.CustomProperties[key]
+		if !exists || prop.MetadataType != "MetadataStringValue" || prop.StringValue != value {
+			t.Errorf("Expected %s customProperty %q, got %+v (present: %v)", key, value, prop, exists)
+		}
+	}
+
+	metadata.Downloads, metadata.Likes = nil, nil
+	result = convertExtractedToCatalogMetadata(metadata)
+	for key := range expected {
+		if _, exists := result.CustomProperties[key]; exists {
+			t.Errorf("Expected %s to NOT be in CustomProperties when unset", key)
+		}
+	}
+}
+
+func TestSetCatalogSort(t *testing.T) {
+	defer func() { _ = SetCatalogSort(SortByName) }()
+
+	for _, key := range []string{SortByName, SortByCreated, SortByUpdated, SortByDownloads} {
+		if err := SetCatalogSort(key); err != nil {
+			t.Errorf("SetCatalogSort(%q) unexpected error: %v", key, err)
+		}
+	}
+	if err := SetCatalogSort("popularity"); err == nil {
+		t.Error("Expected error for unsupported sort key")
+	}
+}
+
+func TestSortCatalogModels(t *testing.T) {
+	downloads := func(n string) map[string]types.MetadataValue {
+		return map[string]types.MetadataValue{"downloads": {MetadataType: "MetadataStringValue", StringValue: n}}
+	}
+	models := []types.CatalogMetadata{
+		{Name: stringPtr("Bravo"), CreateTimeSinceEpoch: stringPtr("2000"), CustomProperties: downloads("50")},
+		{Name: stringPtr("Alpha"), CreateTimeSinceEpoch: stringPtr("1000"), LastUpdateTimeSinceEpoch: stringPtr("9000")},
+		{Name: stringPtr("Delta"), CustomProperties: downloads("500")},
+		{Name: stringPtr("Charlie"), CreateTimeSinceEpoch: stringPtr("2000"), LastUpdateTimeSinceEpoch: stringPtr("3000"), CustomProperties: downloads("50")},
+	}
+
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{SortByName, []string{"Alpha", "Bravo", "Charlie", "Delta"}},
+		{SortByCreated, []string{"Bravo", "Charlie", "Alpha", "Delta"}},
+		{SortByUpdated, []string{"Alpha", "Charlie", "Bravo", "Delta"}},
+		{SortByDownloads, []string{"Delta", "Bravo", "Charlie", "Alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			sorted := append([]types.CatalogMetadata(nil), models...)
+			sortCatalogModels(sorted, tt.key)
+			var names []string
+			for i := range sorted {
+				names = append(names, getModelName(&sorted[i]))
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("sortCatalogModels(%q) = %v, want %v", tt.key, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateModelsCatalogWithStaticFromResults_CatalogSort(t *testing.T) {
+	outputDir := t.TempDir()
+	staticModels := []types.CatalogMetadata{
+		{
+			Name:                 stringPtr("Static Newest"),
+			CreateTimeSinceEpoch: stringPtr("3000"),
+			Artifacts:            []types.CatalogOCIArtifact{{URI: "oci://example.com/static-newest:1.0"}},
+		},
+	}
+	modelRefs := []string{"example.com/org/older:1.0", "example.com/org/newer:1.0"}
+	for i, ref := range modelRefs {
+		created := int64(1000 * (i + 1))
+		name := []string{"Older Model", "Newer Model"}[i]
+		modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models")
+		if err := os.MkdirAll(modelDir, 0755); err != nil {
+			t.Fatalf("Failed to create model dir: %v", err)
+		}
+		data, err := yaml.Marshal(types.ExtractedMetadata{
+			Name:                 &name,
+			CreateTimeSinceEpoch: &created,
+			Artifacts:            []types.OCIArtifact{{URI: "oci://" + ref}},
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+	}
+
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{SortByName, []string{"Newer Model", "Older Model", "Static Newest"}},
+		{SortByCreated, []string{"Static Newest", "Newer Model", "Older Model"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := SetCatalogSort(tt.key); err != nil {
+				t.Fatalf("SetCatalogSort() error: %v", err)
+			}
+			defer func() { _ = SetCatalogSort(SortByName) }()
+
+			catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+			if err := CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath, modelRefs, staticModels, LabelFilter{}); err != nil {
+				t.Fatalf("CreateModelsCatalogWithStaticFromResults failed: %v", err)
+			}
+			var names []string
+			for _, model := range readTestCatalog(t, catalogPath).Models {
+				names = append(names, *model.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Catalog order = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetSplitLabels(t *testing.T) {
+	defer func() { _ = SetSplitLabels(nil) }()
+
+	for _, labels := range [][]string{nil, {"validated"}, {"validated", "featured"}} {
+		if err := SetSplitLabels(labels); err != nil {
+			t.Errorf("SetSplitLabels(%v) error: %v", labels, err)
+		}
+	}
+	for _, labels := range [][]string{{"other"}, {"validated", "../escape"}} {
+		if err := SetSplitLabels(labels); err == nil {
+			t.Errorf("SetSplitLabels(%v) expected error", labels)
+		}
+	}
+}
+
+func TestSplitCatalogPath(t *testing.T) {
+	tests := []struct {
+		catalogPath string
+		partition   string
+		expected    string
+	}{
+		{"data/models-catalog.yaml", "validated", "data/models-catalog-validated.yaml"},
+		{"data/models-catalog.yaml", "other", "data/models-catalog-other.yaml"},
+		{"catalog", "featured", "catalog-featured"},
+	}
+	for _, tt := range tests {
+		if got := splitCatalogPath(tt.catalogPath, tt.partition); got != tt.expected {
+			t.Errorf("splitCatalogPath(%q, %q) = %q, want %q", tt.catalogPath, tt.partition, got, tt.expected)
+		}
+	}
+}
+
+func TestCreateModelsCatalogWithStaticFromResults_SplitByLabel(t *testing.T) {
+	outputDir := t.TempDir()
+	models := map[string]types.ExtractedMetadata{
+		"example.com/org/validated:1.0": {Name: stringPtr("Validated Model"), Tags: []string{"validated", "featured"}},
+		"example.com/org/featured:1.0":  {Name: stringPtr("Featured Model"), Tags: []string{"featured"}},
+		"example.com/org/plain:1.0":     {Name: stringPtr("Plain Model")},
+	}
+	var modelRefs []string
+	for ref, md := range models {
+		modelRefs = append(modelRefs, ref)
+		md.Artifacts = []types.OCIArtifact{{URI: "oci://" + ref}}
+		modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models")
+		if err := os.MkdirAll(modelDir, 0755); err != nil {
+			t.Fatalf("Failed to create model dir: %v", err)
+		}
+		data, err := yaml.Marshal(md)
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+	}
+	staticModels := []types.CatalogMetadata{
+		{
+			Name:             stringPtr("Static Validated"),
+			CustomProperties: map[string]types.MetadataValue{"validated": {MetadataType: "MetadataStringValue"}},
+			Artifacts:        []types.CatalogOCIArtifact{{URI: "oci://example.com/static:1.0"}},
+		},
+	}
+
+	if err := SetSplitLabels([]string{"validated"}); err != nil {
+		t.Fatalf("SetSplitLabels() error: %v", err)
+	}
+	defer func() { _ = SetSplitLabels(nil) }()
+
+	catalogPath := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	if err := CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath, modelRefs, staticModels, LabelFilter{}); err != nil {
+		t.Fatalf("CreateModelsCatalogWithStaticFromResults failed: %v", err)
+	}
+
+	expected := map[string][]string{
+		catalogPath: {"Featured Model", "Plain Model", "Validated Model", "Static Validated"},
+		splitCatalogPath(catalogPath, "validated"): {"Validated Model", "Static Validated"},
+		splitCatalogPath(catalogPath, "other"):     {"Featured Model", "Plain Model"},
+	}
+	for path, want := range expected {
+		catalog := readTestCatalog(t, path)
+		if catalog.Source == "" {
+			t.Errorf("%s has no source", path)
+		}
+		var names []string
+		for _, model := range catalog.Models {
+			names = append(names, *model.Name)
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("%s models = %v, want %v", filepath.Base(path), names, want)
+		}
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_DeterministicYAML(t *testing.T) {
+	var tags []string
+	artifactProps := make(map[string]interface{})
+	for i := 0; i < 50; i++ {
+		tags = append(tags, "tag-"+strings.Repeat("x", i%7)+string(rune('a'+i%26)))
+		artifactProps["prop-"+string(rune('a'+i%26))+strings.Repeat("y", i%5)] = i
+	}
+	metadata := types.ExtractedMetadata{
+		Name:     stringPtr("Deterministic Model"),
+		Maturity: stringPtr("production"),
+		Tags:     tags,
+		Artifacts: []types.OCIArtifact{
+			{URI: "oci://registry.example.com/model:1.0", CustomProperties: artifactProps},
+		},
+	}
+
+	render := func() string {
+		catalog := types.ModelsCatalog{
+			Source: "Red Hat",
+			Models: []types.CatalogMetadata{convertExtractedToCatalogMetadata(metadata)},
+		}
+		data, err := yaml.Marshal(catalog)
+		if err != nil {
+			t.Fatalf("Failed to marshal catalog: %v", err)
+		}
+		return string(data)
+	}
+
+	first := render()
+	for i := 0; i < 20; i++ {
+		if got := render(); got != first {
+			t.Fatalf("Run %d produced different YAML:\n%s\nwant:\n%s", i, got, first)
+		}
+	}
+}
+
+func TestCustomPropertyLabels_Sorted(t *testing.T) {
+	props := convertTagsToCustomProperties([]string{"zeta", "alpha", "mid", "beta"})
+	props["maturity"] = createMetadataValue("production")
+
+	want := []string{"alpha", "beta", "mid", "zeta"}
+	for i := 0; i < 20; i++ {
+		if got := customPropertyLabels(props); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Expected labels %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Facet is one distinct value of a catalog field and the number of models carrying it
+type Facet struct {
+	Name  string `yaml:"name"`
+	Count int    `yaml:"count"`
+}
+
+// CatalogFacets are the precomputed filter values offered by the catalog UI, each list sorted by
+// count descending, then name
+type CatalogFacets struct {
+	Providers []Facet `yaml:"providers"`
+	Tasks     []Facet `yaml:"tasks"`
+	Languages []Facet `yaml:"languages"`
+	Licenses  []Facet `yaml:"licenses"`
+}
+
+// ReadModelsCatalog reads and parses a models catalog file
+func ReadModelsCatalog(catalogPath string) (*types.ModelsCatalog, error) {
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog %s: %v", catalogPath, err)
+	}
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("catalog %s is not valid YAML: %v", catalogPath, err)
+	}
+	return &catalog, nil
+}
+
+// BuildFacets counts the distinct providers, tasks, languages and licenses across the catalog
+// models. A model counts once per distinct value; empty values are skipped.
+func BuildFacets(catalog *types.ModelsCatalog) CatalogFacets {
+	providers := make(map[string]int)
+	tasks := make(map[string]int)
+	languages := make(map[string]int)
+	licenses := make(map[string]int)
+
+	for _, model := range catalog.Models {
+		if model.Provider != nil {
+			countFacetValues(providers, []string{*model.Provider})
+		}
+		countFacetValues(tasks, model.Tasks)
+		countFacetValues(languages, model.Language)
+		if model.License != nil {
+			countFacetValues(licenses, []string{*model.License})
+		}
+	}
+
+	return CatalogFacets{
+		Providers: sortedFacets(providers),
+		Tasks:     sortedFacets(tasks),
+		Languages: sortedFacets(languages),
+		Licenses:  sortedFacets(licenses),
+	}
+}
+
+// countFacetValues increments the count of each distinct non-empty value of one model
+func countFacetValues(counts map[string]int, values []string) {
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		counts[value]++
+	}
+}
+
+// sortedFacets turns value counts into facets sorted by count descending, then name
+func sortedFacets(counts map[string]int) []Facet {
+	facets := make([]Facet, 0, len(counts))
+	for name, count := range counts {
+		facets = append(facets, Facet{Name: name, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Name < facets[j].Name
+	})
+	return facets
+}
+
+// WriteFacets writes the facets of catalog (see BuildFacets) to path as YAML
+func WriteFacets(catalog *types.ModelsCatalog, path string) error {
+	output, err := yaml.Marshal(BuildFacets(catalog))
+	if err != nil {
+		return fmt.Errorf("error marshaling catalog facets: %v", err)
+	}
+	if err := os.WriteFile(path, output, 0644); err != nil {
+		return fmt.Errorf("error writing catalog facets file: %v", err)
+	}
+	return nil
+}
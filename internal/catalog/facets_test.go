@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestBuildFacets(t *testing.T) {
+	redHat, ibm, meta := "Red Hat", "IBM", "Meta"
+	apache, llama := "apache-2.0", "llama3.1"
+	empty := " "
+	catalog := &types.ModelsCatalog{
+		Models: []types.CatalogMetadata{
+			{Provider: &redHat, License: &apache, Tasks: []string{"text-generation"}, Language: []string{"en", "fr"}},
+			{Provider: &ibm, License: &apache, Tasks: []string{"text-generation", "text-generation"}, Language: []string{"en"}},
+			{Provider: &meta, License: &llama, Tasks: []string{"image-text-to-text", "text-generation"}},
+			{Provider: &empty},
+		},
+	}
+
+	want := CatalogFacets{
+		Providers: []Facet{{Name: "IBM", Count: 1}, {Name: "Meta", Count: 1}, {Name: "Red Hat", Count: 1}},
+		Tasks:     []Facet{{Name: "text-generation", Count: 3}, {Name: "image-text-to-text", Count: 1}},
+		Languages: []Facet{{Name: "en", Count: 2}, {Name: "fr", Count: 1}},
+		Licenses:  []Facet{{Name: "apache-2.0", Count: 2}, {Name: "llama3.1", Count: 1}},
+	}
+	if got := BuildFacets(catalog); !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildFacets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteFacets(t *testing.T) {
+	provider := "Red Hat"
+	catalog := &types.ModelsCatalog{
+		Models: []types.CatalogMetadata{{Provider: &provider, Tasks: []string{"text-generation"}}},
+	}
+	path := filepath.Join(t.TempDir(), "catalog-facets.yaml")
+	if err := WriteFacets(catalog, path); err != nil {
+		t.Fatalf("WriteFacets failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read facets file: %v", err)
+	}
+	var facets CatalogFacets
+	if err := yaml.Unmarshal(data, &facets); err != nil {
+		t.Fatalf("Failed to parse facets file: %v", err)
+	}
+	if len(facets.Providers) != 1 || facets.Providers[0] != (Facet{Name: "Red Hat", Count: 1}) {
+		t.Errorf("Expected one Red Hat provider facet, got %+v", facets.Providers)
+	}
+	if len(facets.Licenses) != 0 {
+		t.Errorf("Expected no license facets, got %+v", facets.Licenses)
+	}
+
+	if err := WriteFacets(catalog, filepath.Join(t.TempDir(), "missing", "facets.yaml")); err == nil {
+		t.Error("Expected an error writing to a missing directory")
+	}
+}
@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/assets"
+)
+
+// LogoRule assigns a logo to models carrying a tag
+type LogoRule struct {
+	Tag  string `yaml:"tag"`
+	Path string `yaml:"path"`
+}
+
+// LogoMap maps model tags to SVG logos. Rules are evaluated in order and the first
+// matching tag whose SVG can be read wins; models matching no rule get Default.
+type LogoMap struct {
+	Rules   []LogoRule `yaml:"logos"`
+	Default string     `yaml:"default"`
+}
+
+// DefaultLogoMap returns the built-in logos: validated models get the validated logo,
+// everything else the generic model logo
+func DefaultLogoMap() LogoMap {
+	return LogoMap{
+		Rules: []LogoRule{
+			{Tag: "validated", Path: "assets/catalog-validated_model.svg"},
+		},
+		Default: "assets/catalog-model.svg",
+	}
+}
+
+// logoMap is the mapping used by determineLogo
+var logoMap = DefaultLogoMap()
+
+// LoadLogoMap reads a logo map YAML file, e.g.
+//
+//	logos:
+//	  - tag: validated
+//	    path: assets/catalog-validated_model.svg
+//	  - tag: featured
+//	    path: assets/catalog-featured_model.svg
+//	default: assets/catalog-model.svg
+//
+// The default logo falls back to the built-in one when omitted.
+func LoadLogoMap(path string) (LogoMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return LogoMap{}, fmt.Errorf("failed to read logo map %s: %v", path, err)
+	}
+
+	var m LogoMap
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return LogoMap{}, fmt.Errorf("failed to parse logo map %s: %v", path, err)
+	}
+
+	for i, rule := range m.Rules {
+		if rule.Tag == "" {
+			return LogoMap{}, fmt.Errorf("logo map %s: rule at index %d missing required 'tag' field", path, i)
+		}
+		if rule.Path == "" {
+			return LogoMap{}, fmt.Errorf("logo map %s: rule for tag %q missing required 'path' field", path, rule.Tag)
+		}
+	}
+	if m.Default == "" {
+		m.Default = DefaultLogoMap().Default
+	}
+
+	return m, nil
+}
+
+// SetLogoMap replaces the logo mapping used when building catalog entries
+func SetLogoMap(m LogoMap) {
+	logoMap = m
+}
+
+// determineLogo determines which logo to use based on model tags and returns base64-encoded data URI
+func determineLogo(tags []string) *string {
+	tagSet := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = true
+	}
+
+	// Rules are in priority order; a missing SVG falls through to the next match
+	for _, rule := range logoMap.Rules {
+		if !tagSet[rule.Tag] {
+			continue
+		}
+		dataURI, err := readSVGDataURI(rule.Path)
+		if err != nil {
+			log.Printf("Warning: Failed to read SVG file %s for tag %q: %v", rule.Path, rule.Tag, err)
+			continue
+		}
+		return &dataURI
+	}
+
+	// Default logo for models matching no rule
+	return encodeSVGToDataURI(logoMap.Default)
+}
+
+// encodeSVGToDataURI reads an SVG file and returns a base64-encoded data URI
+func encodeSVGToDataURI(svgPath string) *string {
+	dataURI, err := readSVGDataURI(svgPath)
+	if err != nil {
+		log.Printf("Warning: Failed to read SVG file %s: %v", svgPath, err)
+		// Return the file path as fallback
+		fallback := svgPath
+		return &fallback
+	}
+	return &dataURI
+}
+
+// readSVGDataURI reads an SVG file and encodes it as a base64 data URI. A file on disk always
+// wins, so the default logos can be overridden; when an "assets/..." path is missing, the copy
+// embedded in the binary is used so logos work from any working directory.
+func readSVGDataURI(svgPath string) (string, error) {
+	svgContent, err := os.ReadFile(svgPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if embedded, embedErr := readEmbeddedSVG(svgPath); embedErr == nil {
+			svgContent, err = embedded, nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(svgContent), nil
+}
+
+// readEmbeddedSVG reads a relative "assets/<name>.svg" path from the embedded default logos
+func readEmbeddedSVG(svgPath string) ([]byte, error) {
+	name, ok := strings.CutPrefix(path.Clean(filepath.ToSlash(svgPath)), "assets/")
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return fs.ReadFile(assets.FS, name)
+}
@@ -0,0 +1,185 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetermineLogo_LogoMap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeSVG := func(name, fill string) string {
+		content := `<svg xmlns="http://www.w3.org/2000/svg"><circle r="40" fill="` + fill + `"/></svg>`
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write SVG file: %v", err)
+		}
+		return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(content))
+	}
+
+	validatedURI := writeSVG("validated.svg", "green")
+	featuredURI := writeSVG("featured.svg", "gold")
+	defaultURI := writeSVG("model.svg", "blue")
+
+	SetLogoMap(LogoMap{
+		Rules: []LogoRule{
+			{Tag: "validated", Path: filepath.Join(tmpDir, "validated.svg")},
+			{Tag: "lab-teacher", Path: filepath.Join(tmpDir, "missing.svg")},
+			{Tag: "featured", Path: filepath.Join(tmpDir, "featured.svg")},
+		},
+		Default: filepath.Join(tmpDir, "model.svg"),
+	})
+	defer SetLogoMap(DefaultLogoMap())
+
+	testCases := []struct {
+		name     string
+		tags     []string
+		expected string
+	}{
+		{name: "validated wins over featured", tags: []string{"featured", "validated"}, expected: validatedURI},
+		{name: "unvalidated featured", tags: []string{"featured"}, expected: featuredURI},
+		{name: "missing SVG falls through to next rule", tags: []string{"lab-teacher", "featured"}, expected: featuredURI},
+		{name: "missing SVG falls back to default", tags: []string{"lab-teacher"}, expected: defaultURI},
+		{name: "no matching tag", tags: []string{"lab-base"}, expected: defaultURI},
+		{name: "nil tags", tags: nil, expected: defaultURI},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logo := determineLogo(tc.tags)
+			if logo == nil {
+				t.Fatal("determineLogo returned nil")
+			}
+			if *logo != tc.expected {
+				t.Errorf("Expected logo %s, got %s", tc.expected, *logo)
+			}
+		})
+	}
+}
+
+func TestLoadLogoMap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testCases := []struct {
+		name            string
+		content         string
+		expectError     bool
+		expectedRules   int
+		expectedDefault string
+	}{
+		{
+			name: "rules with default",
+			content: `logos:
+  - tag: validated
+    path: assets/catalog-validated_model.svg
+  - tag: featured
+    path: assets/catalog-featured_model.svg
+default: assets/custom-model.svg
+`,
+			expectedRules:   2,
+			expectedDefault: "assets/custom-model.svg",
+		},
+		{
+			name: "default omitted",
+			content: `logos:
+  - tag: featured
+    path: assets/catalog-featured_model.svg
+`,
+			expectedRules:   1,
+			expectedDefault: DefaultLogoMap().Default,
+		},
+		{
+			name: "rule missing path",
+			content: `logos:
+  - tag: featured
+`,
+			expectError: true,
+		},
+		{
+			name: "rule missing tag",
+			content: `logos:
+  - path: assets/catalog-featured_model.svg
+`,
+			expectError: true,
+		},
+		{
+			name:        "invalid YAML",
+			content:     "logos: [",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, "logo-map.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("Failed to write logo map: %v", err)
+			}
+
+			m, err := LoadLogoMap(path)
+			if tc.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(m.Rules) != tc.expectedRules {
+				t.Errorf("Expected %d rules, got %d", tc.expectedRules, len(m.Rules))
+			}
+			if m.Default != tc.expectedDefault {
+				t.Errorf("Expected default %q, got %q", tc.expectedDefault, m.Default)
+			}
+		})
+	}
+
+	if _, err := LoadLogoMap(filepath.Join(tmpDir, "missing.yaml")); err == nil {
+		t.Error("Expected error for missing logo map file")
+	}
+}
+
+func TestDetermineLogo_EmbeddedFallback(t *testing.T) {
+	embeddedURI := func(name string) string {
+		content, err := os.ReadFile(filepath.Join("..", "..", "assets", name))
+		if err != nil {
+			t.Fatalf("Failed to read repo asset %s: %v", name, err)
+		}
+		return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(content)
+	}
+	validatedURI := embeddedURI("catalog-validated_model.svg")
+	modelURI := embeddedURI("catalog-model.svg")
+
+	// No assets directory in the working directory: the embedded defaults are used
+	t.Chdir(t.TempDir())
+	SetLogoMap(DefaultLogoMap())
+	defer SetLogoMap(DefaultLogoMap())
+
+	if logo := determineLogo([]string{"validated"}); logo == nil || *logo != validatedURI {
+		t.Errorf("Expected embedded validated logo, got %v", logo)
+	}
+	if logo := determineLogo(nil); logo == nil || *logo != modelURI {
+		t.Errorf("Expected embedded model logo, got %v", logo)
+	}
+
+	// A file on disk overrides the embedded copy
+	overrideSVG := `<svg xmlns="http://www.w3.org/2000/svg"><rect width="10" height="10"/></svg>`
+	if err := os.MkdirAll("assets", 0755); err != nil {
+		t.Fatalf("Failed to create assets directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join("assets", "catalog-model.svg"), []byte(overrideSVG), 0644); err != nil {
+		t.Fatalf("Failed to write override SVG: %v", err)
+	}
+	overrideURI := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(overrideSVG))
+	if logo := determineLogo(nil); logo == nil || *logo != overrideURI {
+		t.Errorf("Expected on-disk override logo, got %v", logo)
+	}
+
+	// Paths outside assets/ have no embedded fallback
+	if _, err := readSVGDataURI("other/catalog-model.svg"); err == nil {
+		t.Error("Expected an error for a missing file outside assets/")
+	}
+}
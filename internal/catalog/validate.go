@@ -0,0 +1,133 @@
+package catalog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// strictValidation makes catalog validation errors fail catalog generation instead of
+// only being logged
+var strictValidation = false
+
+// SetStrictValidation controls whether catalogs failing ValidateCatalog are rejected
+func SetStrictValidation(strict bool) {
+	strictValidation = strict
+}
+
+// ValidateCatalog checks a models catalog for problems that break the model registry
+// importer: missing source, unnamed models, models without artifact URIs, malformed
+// customProperties and timestamps that are not int64 strings. It returns every problem
+// found rather than stopping at the first one.
+func ValidateCatalog(catalog *types.ModelsCatalog) []error {
+	var errs []error
+
+	if catalog.Source == "" {
+		errs = append(errs, fmt.Errorf("catalog missing required 'source' field"))
+	}
+
+	for i, model := range catalog.Models {
+		label := fmt.Sprintf("model at index %d", i)
+		if model.Name == nil || *model.Name == "" {
+			errs = append(errs, fmt.Errorf("%s missing required 'name' field", label))
+		} else {
+			label = fmt.Sprintf("model '%s'", *model.Name)
+		}
+
+		if len(model.Artifacts) == 0 {
+			errs = append(errs, fmt.Errorf("%s has no artifacts", label))
+		}
+		for j, artifact := range model.Artifacts {
+			artifactLabel := fmt.Sprintf("%s artifact at index %d", label, j)
+			if artifact.URI == "" {
+				errs = append(errs, fmt.Errorf("%s missing required 'uri' field", artifactLabel))
+			}
+			errs = append(errs, validateTimestamp(artifactLabel, "createTimeSinceEpoch", artifact.CreateTimeSinceEpoch)...)
+			errs = append(errs, validateTimestamp(artifactLabel, "lastUpdateTimeSinceEpoch", artifact.LastUpdateTimeSinceEpoch)...)
+			for _, key := range sortedKeys(artifact.CustomProperties) {
+				errs = append(errs, validateRawMetadataValue(artifactLabel, key, artifact.CustomProperties[key])...)
+			}
+		}
+
+		errs = append(errs, validateTimestamp(label, "createTimeSinceEpoch", model.CreateTimeSinceEpoch)...)
+		errs = append(errs, validateTimestamp(label, "lastUpdateTimeSinceEpoch", model.LastUpdateTimeSinceEpoch)...)
+		for _, key := range sortedKeys(model.CustomProperties) {
+			if model.CustomProperties[key].MetadataType == "" {
+				errs = append(errs, fmt.Errorf("%s customProperty %q missing 'metadataType'", label, key))
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateCatalogFile loads a models catalog YAML file and validates it. The returned
+// error is set only when the file cannot be read or parsed; validation problems are
+// returned in the slice.
+func ValidateCatalogFile(path string) ([]error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog %s: %v", path, err)
+	}
+
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("catalog %s is not valid YAML: %v", path, err)
+	}
+	return ValidateCatalog(&catalog), nil
+}
+
+// validateTimestamp checks that a timestamp, when set, is an int64 epoch string
+func validateTimestamp(label, field string, value *string) []error {
+	if value == nil {
+		return nil
+	}
+	if _, err := strconv.ParseInt(*value, 10, 64); err != nil {
+		return []error{fmt.Errorf("%s has invalid %s %q: not an int64 epoch", label, field, *value)}
+	}
+	return nil
+}
+
+// validateRawMetadataValue checks an untyped customProperties entry has the MetadataValue shape
+func validateRawMetadataValue(label, key string, value interface{}) []error {
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return []error{fmt.Errorf("%s customProperty %q is not a MetadataValue (got %T)", label, key, value)}
+	}
+
+	var errs []error
+	if metadataType, ok := valueMap["metadataType"].(string); !ok || metadataType == "" {
+		errs = append(errs, fmt.Errorf("%s customProperty %q missing 'metadataType'", label, key))
+	}
+	if _, ok := valueMap["string_value"]; !ok {
+		errs = append(errs, fmt.Errorf("%s customProperty %q missing 'string_value'", label, key))
+	}
+	return errs
+}
+
+// validateCatalogOutput parses marshaled catalog YAML and validates it. Problems are
+// logged as warnings, or returned as an error when strict validation is enabled.
+func validateCatalogOutput(output []byte) error {
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal(output, &catalog); err != nil {
+		return fmt.Errorf("generated catalog is not valid YAML: %v", err)
+	}
+
+	errs := ValidateCatalog(&catalog)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	for _, err := range errs {
+		log.Printf("  Warning: catalog validation: %v", err)
+	}
+	if strictValidation {
+		return fmt.Errorf("generated catalog failed validation with %d errors (first: %v)", len(errs), errs[0])
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func validCatalogModel() types.CatalogMetadata {
+	return types.CatalogMetadata{
+		Name:                     stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+		CreateTimeSinceEpoch:     stringPtr("1739776988000"),
+		LastUpdateTimeSinceEpoch: stringPtr("1739776988000"),
+		CustomProperties: map[string]types.MetadataValue{
+			"validated": {MetadataType: "MetadataStringValue"},
+		},
+		Artifacts: []types.CatalogOCIArtifact{
+			{
+				URI:                  "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+				CreateTimeSinceEpoch: stringPtr("1739776988000"),
+				CustomProperties: map[string]interface{}{
+					"architecture": map[string]interface{}{"metadataType": "MetadataStringValue", "string_value": "[\"amd64\"]"},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateCatalog(t *testing.T) {
+	tests := []struct {
+		name           string
+		modify         func(c *types.ModelsCatalog)
+		expectedErrors []string
+	}{
+		{
+			name:   "valid catalog",
+			modify: func(c *types.ModelsCatalog) {},
+		},
+		{
+			name:           "missing source",
+			modify:         func(c *types.ModelsCatalog) { c.Source = "" },
+			expectedErrors: []string{"missing required 'source'"},
+		},
+		{
+			name:           "missing name",
+			modify:         func(c *types.ModelsCatalog) { c.Models[0].Name = nil },
+			expectedErrors: []string{"model at index 0 missing required 'name'"},
+		},
+		{
+			name:           "no artifacts",
+			modify:         func(c *types.ModelsCatalog) { c.Models[0].Artifacts = nil },
+			expectedErrors: []string{"has no artifacts"},
+		},
+		{
+			name:           "artifact without URI",
+			modify:         func(c *types.ModelsCatalog) { c.Models[0].Artifacts[0].URI = "" },
+			expectedErrors: []string{"artifact at index 0 missing required 'uri'"},
+		},
+		{
+			name: "malformed model customProperty",
+			modify: func(c *types.ModelsCatalog) {
+				c.Models[0].CustomProperties["featured"] = types.MetadataValue{}
+			},
+			expectedErrors: []string{`customProperty "featured" missing 'metadataType'`},
+		},
+		{
+			name: "malformed artifact customProperties",
+			modify: func(c *types.ModelsCatalog) {
+				c.Models[0].Artifacts[0].CustomProperties["architecture"] = map[string]interface{}{"string_value": "amd64"}
+				c.Models[0].Artifacts[0].CustomProperties["size"] = map[string]interface{}{"metadataType": "MetadataStringValue"}
+				c.Models[0].Artifacts[0].CustomProperties["raw"] = "amd64"
+			},
+			expectedErrors: []string{
+				`customProperty "architecture" missing 'metadataType'`,
+				`customProperty "size" missing 'string_value'`,
+				`customProperty "raw" is not a MetadataValue`,
+			},
+		},
+		{
+			name: "invalid timestamps",
+			modify: func(c *types.ModelsCatalog) {
+				c.Models[0].CreateTimeSinceEpoch = stringPtr("2025-02-17")
+				c.Models[0].Artifacts[0].CreateTimeSinceEpoch = stringPtr("null")
+			},
+			expectedErrors: []string{
+				`invalid createTimeSinceEpoch "2025-02-17"`,
+				`artifact at index 0 has invalid createTimeSinceEpoch "null"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog := &types.ModelsCatalog{Source: "Red Hat", Models: []types.CatalogMetadata{validCatalogModel()}}
+			tt.modify(catalog)
+
+			errs := ValidateCatalog(catalog)
+			if len(errs) != len(tt.expectedErrors) {
+				t.Fatalf("Expected %d errors, got %d: %v", len(tt.expectedErrors), len(errs), errs)
+			}
+			for _, expected := range tt.expectedErrors {
+				found := false
+				for _, err := range errs {
+					if strings.Contains(err.Error(), expected) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("Expected an error containing %q, got %v", expected, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateModelsCatalogWithStaticFromResults_StrictValidation(t *testing.T) {
+	outputDir := t.TempDir()
+	invalid := validCatalogModel()
+	invalid.Artifacts = nil
+
+	// Non-strict validation only warns and still writes the catalog
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath, nil, []types.CatalogMetadata{invalid}, LabelFilter{}); err != nil {
+		t.Fatalf("Expected non-strict validation to succeed, got: %v", err)
+	}
+	if _, err := os.Stat(catalogPath); err != nil {
+		t.Errorf("Expected catalog to be written: %v", err)
+	}
+
+	SetStrictValidation(true)
+	defer SetStrictValidation(false)
+
+	strictPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	err := CreateModelsCatalogWithStaticFromResults(outputDir, strictPath, nil, []types.CatalogMetadata{invalid}, LabelFilter{})
+	if err == nil || !strings.Contains(err.Error(), "failed validation") {
+		t.Fatalf("Expected strict validation error, got: %v", err)
+	}
+	if _, err := os.Stat(strictPath); !os.IsNotExist(err) {
+		t.Error("Expected no catalog to be written when strict validation fails")
+	}
+}
+
+func TestValidateCatalogFile(t *testing.T) {
+	dir := t.TempDir()
+
+	invalid := validCatalogModel()
+	invalid.Artifacts = nil
+	data, err := yaml.Marshal(&types.ModelsCatalog{Models: []types.CatalogMetadata{validCatalogModel(), invalid}})
+	if err != nil {
+		t.Fatalf("Failed to marshal catalog: %v", err)
+	}
+	catalogPath := filepath.Join(dir, "catalog.yaml")
+	if err := os.WriteFile(catalogPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	errs, err := ValidateCatalogFile(catalogPath)
+	if err != nil {
+		t.Fatalf("ValidateCatalogFile() error: %v", err)
+	}
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 validation errors (source, artifacts), got %d: %v", len(errs), errs)
+	}
+
+	if _, err := ValidateCatalogFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing catalog file")
+	}
+
+	malformedPath := filepath.Join(dir, "malformed.yaml")
+	if err := os.WriteFile(malformedPath, []byte("models: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	if _, err := ValidateCatalogFile(malformedPath); err == nil || !strings.Contains(err.Error(), "not valid YAML") {
+		t.Errorf("Expected a YAML parse error, got: %v", err)
+	}
+}
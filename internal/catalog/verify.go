@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// VerifyReport lists the mismatches found by VerifyCatalogOutputs
+type VerifyReport struct {
+	Orphans   []string // Catalog models with no extracted output or static catalog entry behind them
+	Unmatched []string // Extracted metadata.yaml files whose model is missing from the catalog
+}
+
+// HasProblems reports whether any orphans or unmatched outputs were found
+func (r *VerifyReport) HasProblems() bool {
+	return len(r.Orphans) > 0 || len(r.Unmatched) > 0
+}
+
+// VerifyCatalogOutputs cross-references the models in a generated catalog against the
+// metadata.yaml outputs of modelRefs, the models the catalog was built from; refs without an
+// output (failed models) are skipped. A nil modelRefs checks every outputDir/*/models/metadata.yaml,
+// for catalogs rebuilt from the output directory alone. A catalog model and an output match when
+// they share a (case-insensitive) name or an artifact URI, so models consolidated by deduplication
+// still trace back to their sources. Catalog models named in staticModels are not orphans, and
+// outputs excluded by filter are not expected in the catalog.
+func VerifyCatalogOutputs(catalogPath, outputDir string, modelRefs []string, staticModels []types.CatalogMetadata, filter LabelFilter) (*VerifyReport, error) {
+	catalog, err := ReadModelsCatalog(catalogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadataPaths []string
+	if modelRefs == nil {
+		modelDirs, err := utils.ModelOutputDirs(outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list output metadata: %v", err)
+		}
+		for _, dir := range modelDirs {
+			metadataPaths = append(metadataPaths, filepath.Join(outputDir, dir, "models", "metadata.yaml"))
+		}
+	} else {
+		for _, ref := range modelRefs {
+			path := filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models", "metadata.yaml")
+			if _, err := os.Stat(path); err == nil {
+				metadataPaths = append(metadataPaths, path)
+			}
+		}
+	}
+	sort.Strings(metadataPaths)
+
+	type output struct {
+		path string
+		name string
+		uris []string
+	}
+	var outputs []output
+	outputNames := make(map[string]bool)
+	outputURIs := make(map[string]bool)
+	for _, path := range metadataPaths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		var md types.ExtractedMetadata
+		if err := yaml.Unmarshal(content, &md); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		if !filter.Matches(md.Tags) {
+			continue
+		}
+
+		out := output{path: path}
+		if md.Name != nil {
+			out.name = verifyNameKey(*md.Name)
+		}
+		for _, artifact := range md.Artifacts {
+			if uri := normalizeArtifactURI(artifact.URI); uri != "" {
+				out.uris = append(out.uris, uri)
+				outputURIs[uri] = true
+			}
+		}
+		outputNames[out.name] = true
+		outputs = append(outputs, out)
+	}
+
+	staticNames := make(map[string]bool)
+	for i := range staticModels {
+		if staticModels[i].Name != nil {
+			staticNames[verifyNameKey(*staticModels[i].Name)] = true
+		}
+	}
+
+	report := &VerifyReport{}
+	catalogNames := make(map[string]bool)
+	catalogURIs := make(map[string]bool)
+	for i := range catalog.Models {
+		model := &catalog.Models[i]
+		name := ""
+		if model.Name != nil {
+			name = verifyNameKey(*model.Name)
+		}
+		catalogNames[name] = true
+
+		matched := outputNames[name]
+		for _, artifact := range model.Artifacts {
+			uri := normalizeArtifactURI(artifact.URI)
+			catalogURIs[uri] = true
+			matched = matched || outputURIs[uri]
+		}
+		if !matched && !staticNames[name] {
+			report.Orphans = append(report.Orphans, getModelName(model))
+		}
+	}
+
+	for _, out := range outputs {
+		matched := catalogNames[out.name]
+		for _, uri := range out.uris {
+			matched = matched || catalogURIs[uri]
+		}
+		if !matched {
+			report.Unmatched = append(report.Unmatched, out.path)
+		}
+	}
+
+	return report, nil
+}
+
+// verifyNameKey folds a model name the way name deduplication compares it
+func verifyNameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
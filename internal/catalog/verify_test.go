@@ -0,0 +1,183 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func writeVerifyOutput(t *testing.T, outputDir, ref string, md types.ExtractedMetadata) string {
+	t.Helper()
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(ref))
+	if err := utils.WriteManifestRef(modelDir, ref); err != nil {
+		t.Fatal(err)
+	}
+	modelsDir := filepath.Join(modelDir, "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := yaml.Marshal(&md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(modelsDir, "metadata.yaml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func catalogModel(name string, uris ...string) types.CatalogMetadata {
+	model := types.CatalogMetadata{Name: stringPtr(name)}
+	for _, uri := range uris {
+		model.Artifacts = append(model.Artifacts, types.CatalogOCIArtifact{URI: uri})
+	}
+	return model
+}
+
+func TestVerifyCatalogOutputs(t *testing.T) {
+	outputDir := t.TempDir()
+	granitePath := writeVerifyOutput(t, outputDir, "registry.redhat.io/granite:1.5", types.ExtractedMetadata{
+		Name:      stringPtr("Granite 3.1 8B Instruct"),
+		Artifacts: []types.OCIArtifact{{URI: "registry.redhat.io/rhelai1/granite:1.5"}},
+	})
+	renamedPath := writeVerifyOutput(t, outputDir, "registry.redhat.io/granite-fp8:1.5", types.ExtractedMetadata{
+		Name:      stringPtr("Granite FP8"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/granite-fp8:1.5"}},
+	})
+	missingPath := writeVerifyOutput(t, outputDir, "registry.redhat.io/llama:1.0", types.ExtractedMetadata{
+		Name:      stringPtr("Llama 3.1 8B"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/llama:1.0"}},
+	})
+	internalPath := writeVerifyOutput(t, outputDir, "registry.redhat.io/internal:1.0", types.ExtractedMetadata{
+		Name: stringPtr("Internal Model"),
+		Tags: []string{"internal"},
+	})
+
+	models := []types.CatalogMetadata{
+		// Matches by name, case-insensitively
+		catalogModel("granite 3.1 8b instruct", "oci://registry.redhat.io/rhelai1/granite:1.5"),
+		// Matches by artifact URI after deduplication kept another name
+		catalogModel("Granite 3.1 8B Instruct FP8", "oci://registry.redhat.io/rhelai1/granite-fp8:1.5"),
+		catalogModel("Static Model", "oci://quay.io/static/model:1.0"),
+		catalogModel("Phantom Model", "oci://quay.io/phantom/model:1.0"),
+	}
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	data, err := yaml.Marshal(&types.ModelsCatalog{Source: "Red Hat", Models: models})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(catalogPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		staticModels      []types.CatalogMetadata
+		filter            LabelFilter
+		expectedOrphans   []string
+		expectedUnmatched []string
+	}{
+		{
+			name:              "orphans and unmatched outputs",
+			staticModels:      []types.CatalogMetadata{catalogModel("Static Model")},
+			expectedOrphans:   []string{"Phantom Model"},
+			expectedUnmatched: []string{internalPath, missingPath},
+		},
+		{
+			name:              "static models not passed are orphans",
+			filter:            LabelFilter{Exclude: []string{"internal"}},
+			expectedOrphans:   []string{"Static Model", "Phantom Model"},
+			expectedUnmatched: []string{missingPath},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := VerifyCatalogOutputs(catalogPath, outputDir, nil, tt.staticModels, tt.filter)
+			if err != nil {
+				t.Fatalf("VerifyCatalogOutputs() error = %v", err)
+			}
+			if !reflect.DeepEqual(report.Orphans, tt.expectedOrphans) {
+				t.Errorf("Orphans = %v, want %v", report.Orphans, tt.expectedOrphans)
+			}
+			if !reflect.DeepEqual(report.Unmatched, tt.expectedUnmatched) {
+				t.Errorf("Unmatched = %v, want %v", report.Unmatched, tt.expectedUnmatched)
+			}
+			if !report.HasProblems() {
+				t.Error("HasProblems() = false, want true")
+			}
+		})
+	}
+
+	// The matched outputs must not be reported
+	report, _ := VerifyCatalogOutputs(catalogPath, outputDir, nil, nil, LabelFilter{})
+	for _, path := range report.Unmatched {
+		if path == granitePath || path == renamedPath {
+			t.Errorf("matched output %s reported as unmatched", path)
+		}
+	}
+}
+
+func TestVerifyCatalogOutputs_ModelRefs(t *testing.T) {
+	outputDir := t.TempDir()
+	current := "registry.redhat.io/rhelai1/granite:1.5"
+	writeVerifyOutput(t, outputDir, current, types.ExtractedMetadata{
+		Name:      stringPtr("Granite 3.1 8B Instruct"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://" + current}},
+	})
+	// Left behind by an earlier run for a model no longer in the index
+	stalePath := writeVerifyOutput(t, outputDir, "registry.redhat.io/rhelai1/retired:1.0", types.ExtractedMetadata{
+		Name:      stringPtr("Retired Model"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/retired:1.0"}},
+	})
+	// Written before directory names were hashed and manifest-ref.txt recorded; ignored
+	legacyDir := filepath.Join(outputDir, "registry.redhat.io_rhelai1_granite_1.5", "models")
+	if err := os.MkdirAll(legacyDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacyDir, "metadata.yaml"), []byte("name: Legacy Granite\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	data, err := yaml.Marshal(&types.ModelsCatalog{Source: "Red Hat", Models: []types.CatalogMetadata{
+		catalogModel("Granite 3.1 8B Instruct", "oci://"+current),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(catalogPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only this run's models are checked; a ref that failed to produce output is skipped
+	report, err := VerifyCatalogOutputs(catalogPath, outputDir, []string{current, "registry.redhat.io/rhelai1/failed:1.0"}, nil, LabelFilter{})
+	if err != nil {
+		t.Fatalf("VerifyCatalogOutputs() error = %v", err)
+	}
+	if report.HasProblems() {
+		t.Errorf("Expected no problems for this run's models, got orphans %v, unmatched %v", report.Orphans, report.Unmatched)
+	}
+
+	// Without refs (--catalog-only) every current output is checked
+	report, err = VerifyCatalogOutputs(catalogPath, outputDir, nil, nil, LabelFilter{})
+	if err != nil {
+		t.Fatalf("VerifyCatalogOutputs() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Unmatched, []string{stalePath}) {
+		t.Errorf("Unmatched = %v, want [%s]", report.Unmatched, stalePath)
+	}
+}
+
+func TestVerifyCatalogOutputs_MissingCatalog(t *testing.T) {
+	if _, err := VerifyCatalogOutputs(filepath.Join(t.TempDir(), "missing.yaml"), t.TempDir(), nil, nil, LabelFilter{}); err == nil {
+		t.Error("expected error for missing catalog")
+	}
+}
@@ -10,9 +10,10 @@ import (
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
-// LoadModelsFromYAML reads the models list from the YAML configuration file
+// LoadModelsFromYAML reads the models list from the YAML configuration file.
+// filePath may also be an http(s) URL.
 func LoadModelsFromYAML(filePath string) ([]string, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := readIndexFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -32,9 +33,10 @@ func LoadModelsFromYAML(filePath string) ([]string, error) {
 	return modelURIs, nil
 }
 
-// LoadModelsConfigFromYAML reads the full models configuration from the YAML file
+// LoadModelsConfigFromYAML reads the full models configuration from the YAML file.
+// filePath may also be an http(s) URL.
 func LoadModelsConfigFromYAML(filePath string) ([]types.ModelEntry, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := readIndexFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -45,10 +47,25 @@ func LoadModelsConfigFromYAML(filePath string) ([]types.ModelEntry, error) {
 		return nil, err
 	}
 
+	// Entries without a type are registry modelcars
+	for i := range config.Models {
+		entryType := strings.ToLower(strings.TrimSpace(config.Models[i].Type))
+		switch entryType {
+		case "":
+			entryType = types.ModelEntryTypeOCI
+		case types.ModelEntryTypeOCI, types.ModelEntryTypeHF:
+		default:
+			return nil, fmt.Errorf("model %s has unsupported type %q (expected %s or %s)",
+				config.Models[i].URI, config.Models[i].Type, types.ModelEntryTypeOCI, types.ModelEntryTypeHF)
+		}
+		config.Models[i].Type = entryType
+	}
+
 	return config.Models, nil
 }
 
-// LoadModelsFromVersionIndex loads models from a version-specific index file
+// LoadModelsFromVersionIndex loads models from a version-specific index file, mapping each
+// HuggingFace model ID to a registry reference with the registry template (see SetRegistryTemplate)
 func LoadModelsFromVersionIndex(filePath string) ([]string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -64,9 +81,10 @@ func LoadModelsFromVersionIndex(filePath string) ([]string, error) {
 	var modelRefs []string
 	for _, model := range versionIndex.Models {
 		// Convert HuggingFace model to container registry format
-		// This would need to be mapped to actual container registry URLs
-		// For now, we'll use a placeholder format
-		modelRef := fmt.Sprintf("registry.redhat.io/rhelai1/modelcar-%s", strings.ToLower(strings.ReplaceAll(model.Name, "/", "-")))
+		modelRef, err := RegistryRefForModel(model, versionIndex.Version)
+		if err != nil {
+			return nil, err
+		}
 		modelRefs = append(modelRefs, modelRef)
 	}
 
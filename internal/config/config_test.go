@@ -113,6 +113,71 @@ func TestLoadModelsFromYAML_FileNotFound(t *testing.T) {
 	}
 }
 
+func TestLoadModelsConfigFromYAML_EntryTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedTypes []string
+		expectError   bool
+	}{
+		{
+			name: "missing type defaults to oci",
+			content: `models:
+  - type: ""
+    uri: registry.example.com/org/model-a:1.0
+  - uri: registry.example.com/org/model-b:1.0
+`,
+			expectedTypes: []string{types.ModelEntryTypeOCI, types.ModelEntryTypeOCI},
+		},
+		{
+			name: "types are normalised to lowercase",
+			content: `models:
+  - type: OCI
+    uri: registry.example.com/org/model-a:1.0
+  - type: HF
+    uri: https://huggingface.co/org/model-b
+`,
+			expectedTypes: []string{types.ModelEntryTypeOCI, types.ModelEntryTypeHF},
+		},
+		{
+			name: "unknown type is rejected",
+			content: `models:
+  - type: s3
+    uri: s3://bucket/model
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "models-index.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write models index: %v", err)
+			}
+
+			entries, err := LoadModelsConfigFromYAML(path)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error for unsupported entry type")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(entries) != len(tt.expectedTypes) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.expectedTypes), len(entries))
+			}
+			for i, expected := range tt.expectedTypes {
+				if entries[i].Type != expected {
+					t.Errorf("Expected entry[%d].Type = %s, got %s", i, expected, entries[i].Type)
+				}
+			}
+		})
+	}
+}
+
 func TestLoadModelsFromVersionIndex(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadDescriptionOverrides reads a YAML file of "model: description" pairs, keyed by registry
+// reference or model name, e.g. "granite-3.1-8b-instruct: Granite 3.1 8B Instruct is ...".
+// Empty keys or descriptions are rejected.
+func LoadDescriptionOverrides(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read description overrides %s: %w", path, err)
+	}
+
+	var overrides map[string]string
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse description overrides %s: %w", path, err)
+	}
+
+	for model, description := range overrides {
+		if strings.TrimSpace(model) == "" || strings.TrimSpace(description) == "" {
+			return nil, fmt.Errorf("invalid description overrides %s: empty override %q: %q", path, model, description)
+		}
+	}
+
+	return overrides, nil
+}
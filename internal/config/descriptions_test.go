@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadDescriptionOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name: "overrides keyed by registry reference and model name",
+			content: `registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5: Granite base model for fine-tuning.
+granite-3.1-8b-instruct: |
+  Granite instruct model tuned for chat.
+`,
+			expected: map[string]string{
+				"registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5": "Granite base model for fine-tuning.",
+				"granite-3.1-8b-instruct":                                     "Granite instruct model tuned for chat.\n",
+			},
+		},
+		{
+			name:        "empty description is rejected",
+			content:     "granite-3.1-8b-instruct: \" \"\n",
+			expectError: true,
+		},
+		{
+			name:        "non-mapping content is rejected",
+			content:     "- granite-3.1-8b-instruct\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "description-overrides.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write overrides file: %v", err)
+			}
+
+			got, err := LoadDescriptionOverrides(path)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error loading overrides file")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("LoadDescriptionOverrides() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := LoadDescriptionOverrides(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing overrides file")
+	}
+}
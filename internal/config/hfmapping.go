@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadHFMapping reads a YAML file of "registry reference: HuggingFace model" pairs, e.g.
+// "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5: ibm-granite/granite-3.1-8b-base".
+// Values may be model IDs or HuggingFace URLs; empty keys or values are rejected.
+func LoadHFMapping(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HuggingFace mapping %s: %w", path, err)
+	}
+
+	var mapping map[string]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse HuggingFace mapping %s: %w", path, err)
+	}
+
+	for regModel, hfModel := range mapping {
+		if strings.TrimSpace(regModel) == "" || strings.TrimSpace(hfModel) == "" {
+			return nil, fmt.Errorf("invalid HuggingFace mapping %s: empty mapping %q: %q", path, regModel, hfModel)
+		}
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadHFMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name: "registry references mapped to model IDs and URLs",
+			content: `registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5: ibm-granite/granite-3.1-8b-base
+quay.io/example/model:latest: https://huggingface.co/example/model
+`,
+			expected: map[string]string{
+				"registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5": "ibm-granite/granite-3.1-8b-base",
+				"quay.io/example/model:latest":                                "https://huggingface.co/example/model",
+			},
+		},
+		{
+			name:        "empty model is rejected",
+			content:     "quay.io/example/model:latest: \"\"\n",
+			expectError: true,
+		},
+		{
+			name:        "non-mapping content is rejected",
+			content:     "- quay.io/example/model:latest\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hf-mapping.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write mapping file: %v", err)
+			}
+
+			got, err := LoadHFMapping(path)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error loading mapping file")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("LoadHFMapping() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// KnownModelLabels are the models index labels the catalog and its consumers act on
+var KnownModelLabels = []string{"validated", "featured", "lab-teacher", "lab-base"}
+
+// Index lint severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// IndexIssue is a problem found by LintModelsIndex, with the line of the offending entry
+type IndexIssue struct {
+	Line     int
+	Severity string
+	Message  string
+}
+
+// LintModelsIndex checks a models index file for common problems: unsupported or missing
+// types, invalid model types, empty and duplicate URIs and unknown labels. checkRef, when
+// set, is called for each non-empty URI so the caller can verify the reference parses; its
+// errors are reported as issues. The returned error is set only when the file cannot be
+// read or parsed.
+func LintModelsIndex(filePath string, checkRef func(entry types.ModelEntry) error) ([]IndexIssue, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models index: %v", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("models index is not valid YAML: %v", err)
+	}
+	models := mappingValue(&doc, "models")
+	if models == nil || models.Kind != yaml.SequenceNode {
+		return []IndexIssue{{Line: 1, Severity: SeverityError, Message: "missing 'models' list"}}, nil
+	}
+
+	var issues []IndexIssue
+	add := func(line int, severity, format string, args ...any) {
+		issues = append(issues, IndexIssue{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	firstLine := make(map[string]int)
+	for _, node := range models.Content {
+		var entry types.ModelEntry
+		if err := node.Decode(&entry); err != nil {
+			add(node.Line, SeverityError, "invalid model entry: %v", err)
+			continue
+		}
+		uri := strings.TrimSpace(entry.URI)
+
+		entryType := strings.ToLower(strings.TrimSpace(entry.Type))
+		switch entryType {
+		case "":
+			add(node.Line, SeverityWarning, "model %q has no 'type' (defaults to %s)", uri, types.ModelEntryTypeOCI)
+			entryType = types.ModelEntryTypeOCI
+		case types.ModelEntryTypeOCI, types.ModelEntryTypeHF:
+		default:
+			add(lineOf(node, "type"), SeverityError, "model %q has unsupported type %q (expected %s or %s)",
+				uri, entry.Type, types.ModelEntryTypeOCI, types.ModelEntryTypeHF)
+		}
+		entry.Type = entryType
+		entry.URI = uri
+
+		if entry.ModelType != "" {
+			if err := types.ValidateModelType(entry.ModelType); err != nil {
+				add(lineOf(node, "model_type"), SeverityError, "model %q: %v", uri, err)
+			}
+		}
+
+		for _, label := range entry.Labels {
+			if !slices.Contains(KnownModelLabels, label) {
+				add(lineOf(node, "labels"), SeverityWarning, "model %q has unknown label %q (known: %s)",
+					uri, label, strings.Join(KnownModelLabels, ", "))
+			}
+		}
+
+		if uri == "" {
+			add(node.Line, SeverityWarning, "model entry has an empty 'uri'")
+			continue
+		}
+		if line, ok := firstLine[uri]; ok {
+			add(lineOf(node, "uri"), SeverityWarning, "duplicate uri %q (first listed on line %d)", uri, line)
+		} else {
+			firstLine[uri] = lineOf(node, "uri")
+		}
+
+		if checkRef != nil {
+			if err := checkRef(entry); err != nil {
+				add(lineOf(node, "uri"), SeverityError, "model %q: %v", uri, err)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// mappingValue returns the value node for key in a YAML document or mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// lineOf returns the line of key's value in a mapping node, or the node's own line
+func lineOf(node *yaml.Node, key string) int {
+	if value := mappingValue(node, key); value != nil {
+		return value.Line
+	}
+	return node.Line
+}
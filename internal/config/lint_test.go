@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestLintModelsIndex(t *testing.T) {
+	content := `models:
+- type: oci
+  uri: registry.redhat.io/rhai/modelcar-granite:1.0
+  labels: [validated]
+- uri: registry.redhat.io/rhai/modelcar-granite:1.0
+  labels: [validated, shiny]
+- type: docker
+  uri: registry.redhat.io/rhai/modelcar-llama:1.0
+- type: oci
+  uri: ""
+- type: hf
+  uri: RedHatAI/granite-3.1-8b-instruct
+  model_type: weird
+- type: oci
+  uri: bad-ref
+`
+	path := filepath.Join(t.TempDir(), "models-index.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write models index: %v", err)
+	}
+
+	checkRef := func(entry types.ModelEntry) error {
+		if !strings.Contains(entry.URI, "/") {
+			return errors.New("invalid registry reference")
+		}
+		return nil
+	}
+	issues, err := LintModelsIndex(path, checkRef)
+	if err != nil {
+		t.Fatalf("LintModelsIndex() error: %v", err)
+	}
+
+	expected := []IndexIssue{
+		{Line: 5, Severity: SeverityWarning, Message: "no 'type'"},
+		{Line: 6, Severity: SeverityWarning, Message: `unknown label "shiny"`},
+		{Line: 5, Severity: SeverityWarning, Message: "duplicate uri"},
+		{Line: 7, Severity: SeverityError, Message: `unsupported type "docker"`},
+		{Line: 9, Severity: SeverityWarning, Message: "empty 'uri'"},
+		{Line: 13, Severity: SeverityError, Message: "invalid model_type"},
+		{Line: 15, Severity: SeverityError, Message: "invalid registry reference"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(issues), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Line != want.Line || got.Severity != want.Severity || !strings.Contains(got.Message, want.Message) {
+			t.Errorf("Issue %d = %+v, want line %d %s containing %q", i, got, want.Line, want.Severity, want.Message)
+		}
+	}
+}
+
+func TestLintModelsIndex_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LintModelsIndex(filepath.Join(dir, "missing.yaml"), nil); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+
+	malformed := filepath.Join(dir, "malformed.yaml")
+	if err := os.WriteFile(malformed, []byte("models: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := LintModelsIndex(malformed, nil); err == nil {
+		t.Error("Expected an error for malformed YAML")
+	}
+
+	noModels := filepath.Join(dir, "no-models.yaml")
+	if err := os.WriteFile(noModels, []byte("servers: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	issues, err := LintModelsIndex(noModels, nil)
+	if err != nil {
+		t.Fatalf("LintModelsIndex() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Severity != SeverityError {
+		t.Errorf("Expected a single missing-models error, got %+v", issues)
+	}
+}
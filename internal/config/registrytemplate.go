@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// DefaultRegistryTemplate maps a HuggingFace model ID to its modelcar image, e.g.
+// RedHatAI/granite-3.1-8b-base -> registry.redhat.io/rhelai1/modelcar-redhatai-granite-3.1-8b-base
+const DefaultRegistryTemplate = `registry.redhat.io/rhelai1/modelcar-{{.ID | replace "/" "-" | lower}}`
+
+// RegistryTemplateData holds the fields available to a registry template
+type RegistryTemplateData struct {
+	ID      string // Full HuggingFace model ID, e.g. RedHatAI/granite-3.1-8b-base
+	Org     string // Organization part of the ID, empty when the ID has no "/"
+	Name    string // Model part of the ID, after the first "/"
+	Version string // Version of the collection index the model came from
+}
+
+var registryTemplateFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+}
+
+// registryTemplate is set once at startup by SetRegistryTemplate
+var registryTemplate = template.Must(parseRegistryTemplate(DefaultRegistryTemplate))
+
+func parseRegistryTemplate(text string) (*template.Template, error) {
+	return template.New("registry").Funcs(registryTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// SetRegistryTemplate sets the Go template that maps HuggingFace model IDs from version index
+// files to registry references. Fields are those of RegistryTemplateData; the lower, upper and
+// replace (old, new, s) functions are available. An empty text restores DefaultRegistryTemplate.
+func SetRegistryTemplate(text string) error {
+	if text == "" {
+		text = DefaultRegistryTemplate
+	}
+	tmpl, err := parseRegistryTemplate(text)
+	if err != nil {
+		return fmt.Errorf("invalid registry template: %w", err)
+	}
+	// Catch templates that only fail on execution, e.g. unknown fields
+	if _, err := executeRegistryTemplate(tmpl, types.ModelIndex{Name: "org/model"}, "v1.0"); err != nil {
+		return err
+	}
+	registryTemplate = tmpl
+	return nil
+}
+
+// RegistryRefForModel renders the registry reference for a version index model
+func RegistryRefForModel(model types.ModelIndex, version string) (string, error) {
+	return executeRegistryTemplate(registryTemplate, model, version)
+}
+
+func executeRegistryTemplate(tmpl *template.Template, model types.ModelIndex, version string) (string, error) {
+	data := RegistryTemplateData{ID: model.Name, Name: model.Name, Version: version}
+	if org, name, found := strings.Cut(model.Name, "/"); found {
+		data.Org, data.Name = org, name
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render registry template for %s: %w", model.Name, err)
+	}
+	ref := strings.TrimSpace(buf.String())
+	if ref == "" {
+		return "", fmt.Errorf("registry template produced an empty reference for %s", model.Name)
+	}
+	return ref, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestRegistryRefForModel(t *testing.T) {
+	t.Cleanup(func() { _ = SetRegistryTemplate("") })
+
+	tests := []struct {
+		name     string
+		template string
+		model    string
+		version  string
+		expected string
+	}{
+		{
+			name:     "default template",
+			model:    "RedHatAI/granite-3.1-8b-base",
+			version:  "v1.0",
+			expected: "registry.redhat.io/rhelai1/modelcar-redhatai-granite-3.1-8b-base",
+		},
+		{
+			name:     "org and name as separate path components",
+			template: "quay.io/{{.Org | lower}}/{{.Name | lower}}:{{.Version}}",
+			model:    "RedHatAI/Llama-3.1-8B-Instruct",
+			version:  "v2.0",
+			expected: "quay.io/redhatai/llama-3.1-8b-instruct:v2.0",
+		},
+		{
+			name:     "ID without organization",
+			template: "registry.example.com/models/{{.Name}}",
+			model:    "simple-model",
+			expected: "registry.example.com/models/simple-model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetRegistryTemplate(tt.template); err != nil {
+				t.Fatalf("SetRegistryTemplate() error: %v", err)
+			}
+			got, err := RegistryRefForModel(types.ModelIndex{Name: tt.model}, tt.version)
+			if err != nil {
+				t.Fatalf("RegistryRefForModel() error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("RegistryRefForModel() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetRegistryTemplate_Invalid(t *testing.T) {
+	t.Cleanup(func() { _ = SetRegistryTemplate("") })
+
+	for _, text := range []string{"registry.example.com/{{.Name", "registry.example.com/{{.Repository}}"} {
+		if err := SetRegistryTemplate(text); err == nil {
+			t.Errorf("SetRegistryTemplate(%q) expected error", text)
+		}
+	}
+}
+
+func TestLoadModelsFromVersionIndex_CustomRegistryTemplate(t *testing.T) {
+	if err := SetRegistryTemplate("quay.io/{{.Org | lower}}/modelcar-{{.Name | lower}}:{{.Version}}"); err != nil {
+		t.Fatalf("SetRegistryTemplate() error: %v", err)
+	}
+	t.Cleanup(func() { _ = SetRegistryTemplate("") })
+
+	tmpFile := filepath.Join(t.TempDir(), "version-index.yaml")
+	content := `version: v2.0
+models:
+  - name: RedHatAI/granite-3.1-8b-base
+  - name: microsoft/Phi-3.5-mini-instruct
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	refs, err := LoadModelsFromVersionIndex(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadModelsFromVersionIndex() error: %v", err)
+	}
+
+	expected := []string{
+		"quay.io/redhatai/modelcar-granite-3.1-8b-base:v2.0",
+		"quay.io/microsoft/modelcar-phi-3.5-mini-instruct:v2.0",
+	}
+	if !reflect.DeepEqual(refs, expected) {
+		t.Errorf("LoadModelsFromVersionIndex() = %v, want %v", refs, expected)
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxIndexSize = 10 * 1024 * 1024 // 10 MiB safety cap for remote index files
+
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+var (
+	indexToken     string
+	indexTokenOnce sync.Once
+)
+
+// getIndexToken returns the bearer token for remote index URLs from MODELS_INDEX_TOKEN
+func getIndexToken() string {
+	indexTokenOnce.Do(func() {
+		indexToken = os.Getenv("MODELS_INDEX_TOKEN")
+	})
+	return indexToken
+}
+
+// SetHTTPTimeout sets the timeout used when fetching index files from a URL
+func SetHTTPTimeout(timeout time.Duration) {
+	httpClient = &http.Client{Timeout: timeout}
+}
+
+// IsRemotePath reports whether path is an http:// or https:// URL
+func IsRemotePath(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// readIndexFile reads an index file from a local path or, for http(s) URLs, over HTTP
+func readIndexFile(path string) ([]byte, error) {
+	if !IsRemotePath(path) {
+		return os.ReadFile(path)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := getIndexToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", path, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if len(data) > maxIndexSize {
+		return nil, fmt.Errorf("index file %s exceeds %d bytes", path, maxIndexSize)
+	}
+	return data, nil
+}
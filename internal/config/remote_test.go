@@ -0,0 +1,64 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsRemotePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"https://artifacts.example.com/models-index.yaml", true},
+		{"http://localhost:8080/models-index.yaml", true},
+		{"data/models-index.yaml", false},
+		{"/abs/path/models-index.yaml", false},
+		{"httpdata/models-index.yaml", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRemotePath(tt.path); got != tt.expected {
+			t.Errorf("IsRemotePath(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadModelsConfigFromYAML_RemoteURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models-index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`models:
+  - type: oci
+    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5
+    labels:
+      - validated
+`))
+	}))
+	defer server.Close()
+
+	entries, err := LoadModelsConfigFromYAML(server.URL + "/models-index.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].URI != "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	uris, err := LoadModelsFromYAML(server.URL + "/models-index.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(uris) != 1 {
+		t.Errorf("Expected 1 model URI, got %v", uris)
+	}
+
+	_, err = LoadModelsConfigFromYAML(server.URL + "/missing.yaml")
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected status 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// LoadRunConfig reads an extractor run configuration file. Unknown keys are rejected so
+// that a misspelled setting fails the run instead of being silently ignored.
+func LoadRunConfig(path string) (*types.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var cfg types.Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// RunConfigFlagValues returns the settings present in cfg keyed by CLI flag name, with each
+// value formatted so it can be passed to flag.Set
+func RunConfigFlagValues(cfg *types.Config) map[string]string {
+	values := make(map[string]string)
+	if cfg == nil {
+		return values
+	}
+
+	v := reflect.ValueOf(*cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		// time.Duration formats as e.g. "2m0s", which flag.Duration parses back
+		values[name] = fmt.Sprint(field.Elem().Interface())
+	}
+	return values
+}
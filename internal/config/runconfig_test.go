@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadRunConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name: "settings are keyed by flag name",
+			content: `input: custom-index.yaml
+output-dir: /tmp/output
+max-concurrent: 8
+skip-enrichment: true
+match-threshold: 0.85
+fetch-timeout: 2m
+`,
+			expected: map[string]string{
+				"input":           "custom-index.yaml",
+				"output-dir":      "/tmp/output",
+				"max-concurrent":  "8",
+				"skip-enrichment": "true",
+				"match-threshold": "0.85",
+				"fetch-timeout":   "2m0s",
+			},
+		},
+		{
+			name:     "explicit false is kept",
+			content:  "skip-catalog: false\n",
+			expected: map[string]string{"skip-catalog": "false"},
+		},
+		{
+			name:     "empty file sets nothing",
+			content:  "",
+			expected: map[string]string{},
+		},
+		{
+			name:        "unknown key is rejected",
+			content:     "output_dir: /tmp/output\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid duration is rejected",
+			content:     "fetch-timeout: soon\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "run-config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			cfg, err := LoadRunConfig(path)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error loading config file")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := RunConfigFlagValues(cfg); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("RunConfigFlagValues() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadRunConfig_FileNotFound(t *testing.T) {
+	if _, err := LoadRunConfig("nonexistent-config.yaml"); err == nil {
+		t.Error("Expected error for non-existent file")
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadTaskMap reads a task normalization map from a YAML file of
+// "task description: standard task" pairs, e.g. "embedding: feature-extraction".
+// Empty keys or values are rejected.
+func LoadTaskMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task map %s: %w", path, err)
+	}
+
+	var taskMap map[string]string
+	if err := yaml.Unmarshal(data, &taskMap); err != nil {
+		return nil, fmt.Errorf("failed to parse task map %s: %w", path, err)
+	}
+
+	for task, normalized := range taskMap {
+		if strings.TrimSpace(task) == "" || strings.TrimSpace(normalized) == "" {
+			return nil, fmt.Errorf("invalid task map %s: empty mapping %q: %q", path, task, normalized)
+		}
+	}
+
+	return taskMap, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadTaskMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "task mappings",
+			content:  "embedding: feature-extraction\nreranking: text-ranking\n",
+			expected: map[string]string{"embedding": "feature-extraction", "reranking": "text-ranking"},
+		},
+		{
+			name:        "empty target is rejected",
+			content:     "guard: \"\"\n",
+			expectError: true,
+		},
+		{
+			name:        "non-mapping content is rejected",
+			content:     "- embedding\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "task-map.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write task map: %v", err)
+			}
+
+			got, err := LoadTaskMap(path)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error loading task map")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("LoadTaskMap() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package enrichment
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"gopkg.in/yaml.v3"
 
@@ -51,9 +57,219 @@ func extractModelFamily(normalizedName string) string {
 	return ""
 }
 
-// EnrichMetadataFromHuggingFace enriches registry model metadata using HuggingFace data
-func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllmConfigDir string) error {
-	log.Println("Enriching registry model metadata with HuggingFace data...")
+// MatchOptions controls how registry models are matched to HuggingFace models
+type MatchOptions struct {
+	// Threshold is the minimum similarity score required to use a HuggingFace match.
+	// Raising it reduces false-positive matches, which can overwrite good modelcard names.
+	Threshold float64
+	// HighConfidenceThreshold is the score at or above which a match is "high" confidence
+	// (high-confidence matches may override existing model names).
+	HighConfidenceThreshold float64
+	// AmbiguityMargin is the minimum lead the best match must have over the second-best
+	// match. Closer matches are downgraded to "low" confidence; 0 disables the check.
+	AmbiguityMargin float64
+}
+
+// DefaultMatchOptions returns the default HuggingFace match thresholds
+func DefaultMatchOptions() MatchOptions {
+	return MatchOptions{
+		Threshold:               0.5,
+		HighConfidenceThreshold: 0.8,
+		AmbiguityMargin:         0.1,
+	}
+}
+
+// Validate checks that the thresholds are within [0, 1] and consistently ordered
+func (o MatchOptions) Validate() error {
+	if o.Threshold < 0 || o.Threshold > 1 {
+		return fmt.Errorf("match threshold must be between 0 and 1, got %v", o.Threshold)
+	}
+	if o.HighConfidenceThreshold < 0 || o.HighConfidenceThreshold > 1 {
+		return fmt.Errorf("high-confidence threshold must be between 0 and 1, got %v", o.HighConfidenceThreshold)
+	}
+	if o.HighConfidenceThreshold < o.Threshold {
+		return fmt.Errorf("high-confidence threshold (%v) must not be lower than match threshold (%v)", o.HighConfidenceThreshold, o.Threshold)
+	}
+	if o.AmbiguityMargin < 0 || o.AmbiguityMargin > 1 {
+		return fmt.Errorf("ambiguity margin must be between 0 and 1, got %v", o.AmbiguityMargin)
+	}
+	return nil
+}
+
+// findBestHuggingFaceMatch returns the best and second-best scoring HuggingFace models for a
+// registry model. The second-best is always a differently named model, so duplicate index
+// entries for the same model don't make a match look ambiguous.
+func findBestHuggingFaceMatch(regModel string, hfModels []types.ModelIndex) (best, second types.ModelIndex, bestScore, secondScore float64) {
+	for _, hfModel := range hfModels {
+		// Skip cross-family matches to prevent llama containers from matching granite HF entries
+		if !isCompatibleModelFamily(regModel, hfModel.Name) {
+			continue
+		}
+
+		score := utils.CalculateSimilarity(regModel, hfModel.Name)
+		if score > bestScore {
+			if hfModel.Name != best.Name {
+				second, secondScore = best, bestScore
+			}
+			best, bestScore = hfModel, score
+		} else if score > secondScore && hfModel.Name != best.Name {
+			second, secondScore = hfModel, score
+		}
+	}
+	return best, second, bestScore, secondScore
+}
+
+// hfMapping holds explicit registry reference -> HuggingFace model ID pairs (--hf-mapping).
+// It is set once at startup, before enrichment workers start.
+var hfMapping map[string]string
+
+// SetHFMapping sets registry references whose HuggingFace model is known, so they are enriched
+// from that model instead of the best fuzzy match. Values may be model IDs or HuggingFace URLs.
+func SetHFMapping(mapping map[string]string) {
+	hfMapping = make(map[string]string, len(mapping))
+	for regModel, hfModel := range mapping {
+		if repoID := huggingface.RepoIDFromURI(hfModel); repoID != "" {
+			hfMapping[strings.TrimSpace(regModel)] = repoID
+		}
+	}
+}
+
+// selectedModels limits enrichment to these registry references when non-empty (--models). It
+// is set once at startup, before enrichment workers start.
+var selectedModels map[string]bool
+
+// SetModelSelection limits enrichment and OCI artifact updates to the given registry references;
+// an empty list enriches every model in the index
+func SetModelSelection(refs []string) {
+	selectedModels = make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		selectedModels[ref] = true
+	}
+}
+
+// selectModelEntries returns the index entries to enrich, those set with SetModelSelection
+func selectModelEntries(entries []types.ModelEntry) []types.ModelEntry {
+	if len(selectedModels) == 0 {
+		return entries
+	}
+	var selected []types.ModelEntry
+	for _, entry := range entries {
+		if selectedModels[strings.TrimSpace(entry.URI)] {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
+
+// explicitHFMappings returns the HuggingFace model ID of every registry model whose pairing is
+// known: models-index entries of type hf map to their own URI, and --hf-mapping entries take
+// precedence
+func explicitHFMappings(entries []types.ModelEntry) map[string]string {
+	mappings := make(map[string]string, len(entries)+len(hfMapping))
+	for _, entry := range entries {
+		if entry.Type == types.ModelEntryTypeHF {
+			if repoID := huggingface.RepoIDFromURI(entry.URI); repoID != "" {
+				mappings[entry.URI] = repoID
+			}
+		}
+	}
+	for regModel, repoID := range hfMapping {
+		mappings[regModel] = repoID
+	}
+	return mappings
+}
+
+// mappedHuggingFaceModel returns the explicitly mapped HuggingFace model for a registry model,
+// preferring its entry in the HuggingFace index when there is one
+func mappedHuggingFaceModel(regModel string, mappings map[string]string, hfModels []types.ModelIndex) (types.ModelIndex, bool) {
+	repoID, ok := mappings[regModel]
+	if !ok {
+		return types.ModelIndex{}, false
+	}
+	for _, hfModel := range hfModels {
+		if strings.EqualFold(hfModel.Name, repoID) {
+			return hfModel, true
+		}
+	}
+	return types.ModelIndex{
+		Name:       repoID,
+		URL:        "https://huggingface.co/" + repoID,
+		ReadmePath: "/" + repoID + "/README.md",
+	}, true
+}
+
+// hfNameMatchesRegistryModel reports whether a fetched HuggingFace model ID is itself similar
+// enough to the registry model to be used as its name
+func hfNameMatchesRegistryModel(regModel, hfID string, threshold float64) bool {
+	return isCompatibleModelFamily(regModel, hfID) && utils.CalculateSimilarity(regModel, hfID) >= threshold
+}
+
+// DefaultMaxConcurrent is the default number of registry models enriched in parallel
+const DefaultMaxConcurrent = 5
+
+// maxConcurrent bounds the enrichment worker pool
+var maxConcurrent = DefaultMaxConcurrent
+
+// applyCreatedAtFallback sets a missing createTimeSinceEpoch from the HuggingFace API createdAt
+// time, in epoch milliseconds, and reports whether it did
+func applyCreatedAtFallback(enriched *types.EnrichedModelMetadata, details *types.HFModelDetails) bool {
+	if details == nil || details.CreatedAt.IsZero() {
+		return false
+	}
+	if enriched.CreateTimeSinceEpoch.Source != "null" && enriched.CreateTimeSinceEpoch.Value != nil {
+		return false
+	}
+	enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(details.CreatedAt.UnixMilli(), "huggingface.api")
+	return true
+}
+
+// SetMaxConcurrent sets how many registry models are enriched in parallel
+func SetMaxConcurrent(n int) error {
+	if n < 1 {
+		return fmt.Errorf("max concurrent enrichment must be at least 1, got %d", n)
+	}
+	maxConcurrent = n
+	return nil
+}
+
+// AggregateEnrichmentPath is the legacy combined enrichment file consumed by some downstream tooling
+const AggregateEnrichmentPath = "data/enriched-model-metadata.yaml"
+
+// writeAggregate keeps AggregateEnrichmentPath instead of deleting it after enrichment
+var writeAggregate bool
+
+// SetWriteAggregate controls whether enrichment writes the combined AggregateEnrichmentPath
+// file with every model's source-tracked metadata; by default a stale copy is deleted
+func SetWriteAggregate(enabled bool) {
+	writeAggregate = enabled
+}
+
+// minEnrichmentRate is the lowest acceptable percentage of registry models matched to HuggingFace
+var minEnrichmentRate float64
+
+// ErrEnrichmentRateTooLow is returned by EnrichMetadataFromHuggingFace when fewer models were
+// matched than SetMinEnrichmentRate requires
+var ErrEnrichmentRateTooLow = errors.New("enrichment rate below minimum")
+
+// SetMinEnrichmentRate sets the percentage (0-100) of registry models that must be matched to
+// HuggingFace for enrichment to succeed; 0 never fails
+func SetMinEnrichmentRate(percent float64) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("minimum enrichment rate must be between 0 and 100, got %v", percent)
+	}
+	minEnrichmentRate = percent
+	return nil
+}
+
+// EnrichMetadataFromHuggingFace enriches registry model metadata using HuggingFace data.
+// Every model is enriched and written before the rate is checked, so a run that fails with
+// ErrEnrichmentRateTooLow still leaves its output for inspection.
+func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllmConfigDir string, opts MatchOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	slog.Info("Enriching registry model metadata with HuggingFace data")
 
 	// Load HuggingFace models
 	hfFilePath := hfIndexPath
@@ -69,538 +285,624 @@ func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllm
 	}
 
 	// Load registry models
-	regModels, err := config.LoadModelsFromYAML(modelsIndexPath)
+	modelEntries, err := config.LoadModelsConfigFromYAML(modelsIndexPath)
 	if err != nil {
 		return fmt.Errorf("failed to load registry models: %v", err)
 	}
+	modelEntries = selectModelEntries(modelEntries)
+	regModels := make([]string, 0, len(modelEntries))
+	hfHosted := make(map[string]bool)
+	for _, entry := range modelEntries {
+		regModels = append(regModels, entry.URI)
+		if entry.Type == types.ModelEntryTypeHF {
+			hfHosted[entry.URI] = true
+		}
+	}
+
+	// Known registry -> HuggingFace pairs are used directly; other models are fuzzy matched
+	hfMappings := explicitHFMappings(modelEntries)
 
 	// Load vLLM recommended configurations from static files
 	vllmIndex, vllmErr := config.LoadVLLMConfigs(vllmConfigDir)
 	if vllmErr != nil {
-		log.Printf("Warning: Failed to load vLLM configs: %v", vllmErr)
+		slog.Warn("Failed to load vLLM configs", "error", vllmErr)
 	} else {
-		log.Printf("Loaded %d vLLM recommended configurations", vllmIndex.ModelCount())
+		slog.Info("Loaded vLLM recommended configurations", "count", vllmIndex.ModelCount())
 	}
 
-	matchCount := 0
+	// Enrich registry models concurrently; HuggingFace calls share the rate-limited client
+	// and each worker only writes the metadata.yaml and enrichment.yaml of its own model
+	var matchCount atomic.Int64
+	var wg sync.WaitGroup
+	var aggregateMu sync.Mutex
+	aggregate := make(map[string]types.EnrichedModelMetadata)
+	semaphore := make(chan struct{}, maxConcurrent)
 
-	// For each registry model, find the best HuggingFace match and enrich metadata
 	for _, regModel := range regModels {
-		log.Printf("Processing model: %s", regModel)
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+		go func(regModel string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			enriched, matched := enrichRegistryModel(regModel, hfHosted[regModel], hfIndex.Models, hfMappings, vllmIndex, outputDir, opts)
+			if matched {
+				matchCount.Add(1)
+			}
+			if writeAggregate {
+				aggregateMu.Lock()
+				aggregate[regModel] = enriched
+				aggregateMu.Unlock()
+			}
+		}(regModel)
+	}
+	wg.Wait()
 
-		enriched := types.EnrichedModelMetadata{
-			RegistryModel:    regModel,
-			EnrichmentStatus: "no_match",
+	if writeAggregate {
+		if len(selectedModels) > 0 {
+			retainPreviousAggregate(AggregateEnrichmentPath, aggregate)
 		}
+		if err := writeAggregateEnrichment(AggregateEnrichmentPath, aggregate); err != nil {
+			return fmt.Errorf("failed to write aggregate enrichment file: %v", err)
+		}
+		slog.Info("Wrote aggregate enrichment file", "path", AggregateEnrichmentPath, "models", len(aggregate))
+	} else {
+		// Clean up the old enriched metadata file if it exists
+		_ = os.Remove(AggregateEnrichmentPath)
+	}
 
-		// Try to load existing modelcard metadata
-		existingMetadata, err := metadata.LoadExistingMetadata(regModel, outputDir)
-		if err != nil {
-			log.Printf("  No existing metadata found for %s", regModel)
+	enrichmentRate := float64(matchCount.Load()) / float64(len(regModels)) * 100
+
+	slog.Info("Metadata enrichment complete", "models", len(regModels), "enriched", matchCount.Load(),
+		"enrichmentRate", fmt.Sprintf("%.1f%%", enrichmentRate))
+
+	if len(regModels) > 0 && enrichmentRate < minEnrichmentRate {
+		return fmt.Errorf("%w: %.1f%% of %d models enriched, minimum is %.1f%%",
+			ErrEnrichmentRateTooLow, enrichmentRate, len(regModels), minEnrichmentRate)
+	}
+
+	return nil
+}
+
+// retainPreviousAggregate adds the models of an earlier aggregate enrichment file that were not
+// enriched in this run, so a --models run does not drop them
+func retainPreviousAggregate(path string, aggregate map[string]types.EnrichedModelMetadata) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var previous map[string]types.EnrichedModelMetadata
+	if err := yaml.Unmarshal(data, &previous); err != nil {
+		slog.Warn("Could not read previous aggregate enrichment file", "path", path, "error", err)
+		return
+	}
+	for regModel, enriched := range previous {
+		if _, ok := aggregate[regModel]; !ok {
+			aggregate[regModel] = enriched
 		}
+	}
+}
 
-		// Initialize metadata sources with existing data or nulls
-		enriched.Name = metadata.CreateMetadataSource(nil, "null")
-		enriched.Provider = metadata.CreateMetadataSource(nil, "null")
-		enriched.Description = metadata.CreateMetadataSource(nil, "null")
-		enriched.License = metadata.CreateMetadataSource(nil, "null")
-		enriched.LicenseLink = metadata.CreateMetadataSource(nil, "null")
-		enriched.Language = metadata.CreateMetadataSource(nil, "null")
-		enriched.LastModified = metadata.CreateMetadataSource(nil, "null")
-		enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(nil, "null")
-		enriched.Tags = metadata.CreateMetadataSource(nil, "null")
-		enriched.Tasks = metadata.CreateMetadataSource(nil, "null")
-		enriched.Downloads = metadata.CreateMetadataSource(nil, "null")
-		enriched.Likes = metadata.CreateMetadataSource(nil, "null")
-		enriched.ModelSize = metadata.CreateMetadataSource(nil, "null")
-		enriched.ValidatedOn = metadata.CreateMetadataSource(nil, "null")
-		enriched.HardwareTag = metadata.CreateMetadataSource(nil, "null")
-		enriched.ValidatedTasks = metadata.CreateMetadataSource(nil, "null")
-
-		// Populate from existing modelcard metadata if available (only for non-empty values)
-		// We need to determine if the data came from YAML frontmatter or text parsing
-		if existingMetadata != nil {
-			// Try to load the modelcard.md file to analyze the source
-			sanitizedName := utils.SanitizeManifestRef(regModel)
-			modelcardPath := fmt.Sprintf("%s/%s/models/modelcard.md", outputDir, sanitizedName)
-
-			var modelcardContent string
-			var hasYAMLFrontmatter bool
-			if content, err := os.ReadFile(modelcardPath); err == nil {
-				modelcardContent = string(content)
-				// Check if modelcard has YAML frontmatter
-				if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(modelcardContent); err == nil {
-					hasYAMLFrontmatter = true
-					// Determine sources based on YAML frontmatter presence
-					// Note: Only check fields that exist in ModelCardYAMLFrontmatter struct
-
-					// Name can come from YAML frontmatter
-					if existingMetadata.Name != nil && *existingMetadata.Name != "" {
-						source := "modelcard.regex"
-						if frontmatter.Name != "" && frontmatter.Name == *existingMetadata.Name {
-							source = "modelcard.yaml"
-						}
-						enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, source)
+// writeAggregateEnrichment writes every model's enriched metadata, keyed by registry model, to one YAML file
+func writeAggregateEnrichment(path string, aggregate map[string]types.EnrichedModelMetadata) error {
+	data, err := yaml.Marshal(aggregate)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// enrichRegistryModel finds the HuggingFace model for a single registry model - its entry in
+// hfMappings, or else the best fuzzy match - and writes the enriched metadata.yaml and
+// enrichment.yaml. Returns the source-tracked metadata and whether a match above the threshold
+// was found. HuggingFace-hosted models (hfHosted) get no OCI artifacts.
+func enrichRegistryModel(regModel string, hfHosted bool, hfModels []types.ModelIndex, hfMappings map[string]string, vllmIndex *config.VLLMConfigIndex, outputDir string, opts MatchOptions) (types.EnrichedModelMetadata, bool) {
+	slog.Info("Processing model", "model", regModel)
+
+	enriched := types.EnrichedModelMetadata{
+		RegistryModel:    regModel,
+		EnrichmentStatus: "no_match",
+	}
+
+	// Try to load existing modelcard metadata
+	existingMetadata, err := metadata.LoadExistingMetadata(regModel, outputDir)
+	if err != nil {
+		slog.Debug("No existing metadata found", "model", regModel)
+	}
+
+	// Initialize metadata sources with existing data or nulls
+	enriched.Name = metadata.CreateMetadataSource(nil, "null")
+	enriched.Provider = metadata.CreateMetadataSource(nil, "null")
+	enriched.Description = metadata.CreateMetadataSource(nil, "null")
+	enriched.License = metadata.CreateMetadataSource(nil, "null")
+	enriched.LicenseLink = metadata.CreateMetadataSource(nil, "null")
+	enriched.Language = metadata.CreateMetadataSource(nil, "null")
+	enriched.LastModified = metadata.CreateMetadataSource(nil, "null")
+	enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(nil, "null")
+	enriched.Tags = metadata.CreateMetadataSource(nil, "null")
+	enriched.Tasks = metadata.CreateMetadataSource(nil, "null")
+	enriched.Downloads = metadata.CreateMetadataSource(nil, "null")
+	enriched.Likes = metadata.CreateMetadataSource(nil, "null")
+	enriched.ModelSize = metadata.CreateMetadataSource(nil, "null")
+	enriched.ValidatedOn = metadata.CreateMetadataSource(nil, "null")
+	enriched.HardwareTag = metadata.CreateMetadataSource(nil, "null")
+	enriched.ValidatedTasks = metadata.CreateMetadataSource(nil, "null")
+	enriched.BaseModel = metadata.CreateMetadataSource(nil, "null")
+
+	// Populate from existing modelcard metadata if available (only for non-empty values)
+	// We need to determine if the data came from YAML frontmatter or text parsing
+	if existingMetadata != nil {
+		// Try to load the modelcard.md file to analyze the source
+		sanitizedName := utils.SanitizeManifestRef(regModel)
+		modelcardPath := fmt.Sprintf("%s/%s/models/modelcard.md", outputDir, sanitizedName)
+
+		var modelcardContent string
+		var hasYAMLFrontmatter bool
+		if content, err := os.ReadFile(modelcardPath); err == nil {
+			modelcardContent = string(content)
+			// Check if modelcard has YAML frontmatter
+			if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(modelcardContent); err == nil {
+				hasYAMLFrontmatter = true
+				// Determine sources based on YAML frontmatter presence
+				// Note: Only check fields that exist in ModelCardYAMLFrontmatter struct
+
+				// Name can come from YAML frontmatter
+				if existingMetadata.Name != nil && *existingMetadata.Name != "" {
+					source := "modelcard.regex"
+					if frontmatter.Name != "" && frontmatter.Name == *existingMetadata.Name {
+						source = "modelcard.yaml"
 					}
+					enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, source)
+				}
 
-					// Provider can come from YAML frontmatter
-					if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
-						source := "modelcard.regex"
-						if frontmatter.Provider != "" && frontmatter.Provider == *existingMetadata.Provider {
-							source = "modelcard.yaml"
-						}
-						enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, source)
+				// Provider can come from YAML frontmatter
+				if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
+					source := "modelcard.regex"
+					if frontmatter.Provider != "" && frontmatter.Provider == *existingMetadata.Provider {
+						source = "modelcard.yaml"
 					}
+					enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, source)
+				}
 
-					// Description can come from YAML frontmatter
-					if existingMetadata.Description != nil && *existingMetadata.Description != "" {
-						source := "modelcard.regex"
-						if frontmatter.Description != "" && frontmatter.Description == *existingMetadata.Description {
-							source = "modelcard.yaml"
-						}
-						enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, source)
+				// Description can come from YAML frontmatter
+				if existingMetadata.Description != nil && *existingMetadata.Description != "" {
+					source := "modelcard.regex"
+					if frontmatter.Description != "" && frontmatter.Description == *existingMetadata.Description {
+						source = "modelcard.yaml"
 					}
+					enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, source)
+				}
 
-					// License can come from YAML frontmatter
-					if existingMetadata.License != nil && *existingMetadata.License != "" {
-						source := "modelcard.regex"
-						if frontmatter.License != "" && frontmatter.License == *existingMetadata.License {
-							source = "modelcard.yaml"
-						} else if frontmatter.LicenseName != "" && frontmatter.LicenseName == *existingMetadata.License {
-							source = "modelcard.yaml"
-						}
-						enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, source)
+				// License can come from YAML frontmatter
+				if existingMetadata.License != nil && *existingMetadata.License != "" {
+					source := "modelcard.regex"
+					if frontmatter.License != "" && frontmatter.License == *existingMetadata.License {
+						source = "modelcard.yaml"
+					} else if frontmatter.LicenseName != "" && frontmatter.LicenseName == *existingMetadata.License {
+						source = "modelcard.yaml"
 					}
+					enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, source)
+				}
 
-					// LicenseLink can come from YAML frontmatter
-					if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
-						source := "modelcard.regex"
-						if frontmatter.LicenseLink != "" && frontmatter.LicenseLink == *existingMetadata.LicenseLink {
-							source = "modelcard.yaml"
-						}
-						enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, source)
+				// LicenseLink can come from YAML frontmatter
+				if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
+					source := "modelcard.regex"
+					if frontmatter.LicenseLink != "" && frontmatter.LicenseLink == *existingMetadata.LicenseLink {
+						source = "modelcard.yaml"
 					}
+					enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, source)
+				}
 
-					// Tasks can come from YAML frontmatter (tasks field or pipeline_tag)
-					if len(existingMetadata.Tasks) > 0 {
-						source := "modelcard.regex"
-						// Check if tasks match the tasks field or pipeline_tag
-						if len(frontmatter.Tasks) > 0 && len(existingMetadata.Tasks) == len(frontmatter.Tasks) {
-							allMatch := true
-							for i, task := range existingMetadata.Tasks {
-								if i >= len(frontmatter.Tasks) || task != frontmatter.Tasks[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
+				// Tasks can come from YAML frontmatter (tasks field or pipeline_tag)
+				if len(existingMetadata.Tasks) > 0 {
+					source := "modelcard.regex"
+					// Check if tasks match the tasks field or pipeline_tag
+					if len(frontmatter.Tasks) > 0 && len(existingMetadata.Tasks) == len(frontmatter.Tasks) {
+						allMatch := true
+						for i, task := range existingMetadata.Tasks {
+							if i >= len(frontmatter.Tasks) || task != frontmatter.Tasks[i] {
+								allMatch = false
+								break
 							}
-						} else if frontmatter.PipelineTag != "" && len(existingMetadata.Tasks) == 1 && existingMetadata.Tasks[0] == frontmatter.PipelineTag {
+						}
+						if allMatch {
 							source = "modelcard.yaml"
 						}
-						enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, source)
+					} else if frontmatter.PipelineTag != "" && len(existingMetadata.Tasks) == 1 && existingMetadata.Tasks[0] == frontmatter.PipelineTag {
+						source = "modelcard.yaml"
 					}
+					enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, source)
+				}
 
-					// Language can come from YAML frontmatter
-					if len(existingMetadata.Language) > 0 {
-						source := "modelcard.regex"
-						if len(frontmatter.Language) > 0 && len(existingMetadata.Language) == len(frontmatter.Language) {
-							allMatch := true
-							for i, lang := range existingMetadata.Language {
-								if i >= len(frontmatter.Language) || lang != frontmatter.Language[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
+				// Language can come from YAML frontmatter
+				if len(existingMetadata.Language) > 0 {
+					source := "modelcard.regex"
+					if len(frontmatter.Language) > 0 && len(existingMetadata.Language) == len(frontmatter.Language) {
+						allMatch := true
+						for i, lang := range existingMetadata.Language {
+							if i >= len(frontmatter.Language) || lang != frontmatter.Language[i] {
+								allMatch = false
+								break
 							}
 						}
-						enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, source)
+						if allMatch {
+							source = "modelcard.yaml"
+						}
 					}
+					enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, source)
+				}
 
-					// Tags can come from YAML frontmatter
-					if len(existingMetadata.Tags) > 0 {
-						source := "modelcard.regex"
-						if len(frontmatter.Tags) > 0 && len(existingMetadata.Tags) == len(frontmatter.Tags) {
-							allMatch := true
-							for i, tag := range existingMetadata.Tags {
-								if i >= len(frontmatter.Tags) || tag != frontmatter.Tags[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
-							}
+				// Tags only come from YAML frontmatter; other tags are labels from the models index,
+				// appended after the frontmatter tags during extraction, and are left unsourced
+				if len(frontmatter.Tags) > 0 && len(existingMetadata.Tags) >= len(frontmatter.Tags) {
+					allMatch := true
+					for i, tag := range frontmatter.Tags {
+						if tag != existingMetadata.Tags[i] {
+							allMatch = false
+							break
 						}
-						enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, source)
+					}
+					if allMatch {
+						enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, "modelcard.yaml")
 					}
 				}
 			}
+		}
 
-			// If no YAML frontmatter analysis was possible, assume all modelcard data comes from regex/text parsing
-			if !hasYAMLFrontmatter {
-				if existingMetadata.Name != nil && *existingMetadata.Name != "" {
-					enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, "modelcard.regex")
-				}
-				if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
-					enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, "modelcard.regex")
-				}
-				if existingMetadata.Description != nil && *existingMetadata.Description != "" {
-					enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, "modelcard.regex")
-				}
-				if existingMetadata.License != nil && *existingMetadata.License != "" {
-					enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, "modelcard.regex")
-				}
-				if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
-					enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, "modelcard.regex")
-				}
-				if len(existingMetadata.Language) > 0 {
-					enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, "modelcard.regex")
-				}
-				if len(existingMetadata.Tags) > 0 {
-					enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, "modelcard.regex")
-				}
-				if len(existingMetadata.Tasks) > 0 {
-					enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, "modelcard.regex")
-				}
+		// If no YAML frontmatter analysis was possible, assume all modelcard data comes from regex/text parsing
+		if !hasYAMLFrontmatter {
+			if existingMetadata.Name != nil && *existingMetadata.Name != "" {
+				enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, "modelcard.regex")
 			}
-
-			// Handle timestamps (these are typically from text parsing, not YAML)
-			if existingMetadata.LastUpdateTimeSinceEpoch != nil {
-				enriched.LastModified = metadata.CreateMetadataSource(*existingMetadata.LastUpdateTimeSinceEpoch, "modelcard.regex")
+			if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
+				enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, "modelcard.regex")
+			}
+			if existingMetadata.Description != nil && *existingMetadata.Description != "" {
+				enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, "modelcard.regex")
+			}
+			if existingMetadata.License != nil && *existingMetadata.License != "" {
+				enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, "modelcard.regex")
 			}
-			if existingMetadata.CreateTimeSinceEpoch != nil {
-				enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*existingMetadata.CreateTimeSinceEpoch, "modelcard.regex")
+			if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
+				enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, "modelcard.regex")
+			}
+			if len(existingMetadata.Language) > 0 {
+				enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, "modelcard.regex")
+			}
+			if len(existingMetadata.Tasks) > 0 {
+				enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, "modelcard.regex")
 			}
 		}
 
-		// Find best matching HuggingFace model
-		bestMatch := types.ModelIndex{}
-		bestScore := 0.0
+		// Tags without frontmatter (models index labels) and timestamps (modelcard text or copied
+		// between each other) have no single known origin, so they stay "null" here and
+		// enrichment.yaml records where the kept value came from when metadata.yaml is updated
+	}
 
-		for _, hfModel := range hfIndex.Models {
-			// Skip cross-family matches to prevent llama containers from matching granite HF entries
-			if !isCompatibleModelFamily(regModel, hfModel.Name) {
-				continue
-			}
+	// Use the mapped HuggingFace model as an exact, high-confidence match, otherwise find the best
+	// fuzzy match
+	bestMatch, mapped := mappedHuggingFaceModel(regModel, hfMappings, hfModels)
+	var secondMatch types.ModelIndex
+	var bestScore, secondScore float64
+	if mapped {
+		bestScore = 1
+		slog.Debug("Using mapped HuggingFace model", "model", regModel, "huggingface", bestMatch.Name)
+	} else {
+		bestMatch, secondMatch, bestScore, secondScore = findBestHuggingFaceMatch(regModel, hfModels)
+	}
 
-			score := utils.CalculateSimilarity(regModel, hfModel.Name)
-			if score > bestScore {
-				bestScore = score
-				bestMatch = hfModel
-			}
+	// Enrich with HuggingFace data if we found a good match
+	if bestScore >= opts.Threshold {
+		enriched.HuggingFaceModel = bestMatch.Name
+		enriched.HuggingFaceURL = bestMatch.URL
+		enriched.ReadmePath = bestMatch.ReadmePath
+		enriched.EnrichmentStatus = "enriched"
+
+		// Set confidence level; an ambiguous match (second-best nearly as good) is
+		// downgraded to "low" so it never overrides existing modelcard values
+		if secondScore > 0 && bestScore-secondScore < opts.AmbiguityMargin {
+			enriched.MatchConfidence = "low"
+			slog.Warn("Ambiguous HuggingFace match, using low confidence", "model", regModel,
+				"best", bestMatch.Name, "bestScore", bestScore, "second", secondMatch.Name, "secondScore", secondScore)
+		} else if bestScore >= opts.HighConfidenceThreshold {
+			enriched.MatchConfidence = "high"
+		} else {
+			enriched.MatchConfidence = "medium"
 		}
 
-		// Enrich with HuggingFace data if we found a good match
-		threshold := 0.5
-		if bestScore >= threshold {
-			enriched.HuggingFaceModel = bestMatch.Name
-			enriched.HuggingFaceURL = bestMatch.URL
-			enriched.ReadmePath = bestMatch.ReadmePath
-			enriched.EnrichmentStatus = "enriched"
-
-			// Set confidence level
-			if bestScore >= 0.8 {
-				enriched.MatchConfidence = "high"
-			} else {
-				enriched.MatchConfidence = "medium"
+		// Try to fetch detailed HuggingFace metadata
+		slog.Debug("Fetching HuggingFace details", "model", regModel, "huggingface", bestMatch.Name)
+		hfDetails, err := huggingface.FetchModelDetails(bestMatch.Name)
+		if errors.Is(err, huggingface.ErrGatedModel) {
+			slog.Info("Gated model skipped for HuggingFace details", "model", regModel, "error", err)
+		} else if err != nil {
+			slog.Warn("Failed to fetch HuggingFace details", "model", regModel, "error", err)
+		} else {
+			// The canonical model ID is a name candidate ranked like any other huggingface.api value.
+			// The name is skipped when the fetched model ID does not itself match the registry model
+			// (e.g. the repository was renamed or redirected to a sibling model).
+			if hfDetails.ID != "" && !mapped && !hfNameMatchesRegistryModel(regModel, hfDetails.ID, opts.Threshold) {
+				slog.Warn("HuggingFace model ID does not match registry model, not using it as the model name",
+					"model", regModel, "huggingface", bestMatch.Name, "fetchedID", hfDetails.ID)
+			} else if hfDetails.ID != "" && prefersSource(enriched.Name.Source, "huggingface.api") {
+				enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
+			}
+			if hfDetails.License != "" && prefersLicense(enriched.License, hfDetails.License, "huggingface.api") {
+				enriched.License = metadata.CreateMetadataSource(hfDetails.License, "huggingface.api")
+			}
+			if hfDetails.LastModified != "" && prefersSource(enriched.LastModified.Source, "huggingface.api") {
+				enriched.LastModified = metadata.CreateMetadataSource(hfDetails.LastModified, "huggingface.api")
 			}
+			if len(hfDetails.Tags) > 0 {
+				// Parse tags for structured data and potentially extract license
+				languages, tagLicense, tasks := huggingface.ParseTagsForStructuredData(hfDetails.Tags)
+				slog.Debug("Parsed HuggingFace tags", "model", regModel, "languages", languages, "license", tagLicense, "tasks", tasks)
 
-			// Try to fetch detailed HuggingFace metadata
-			log.Printf("  Fetching HuggingFace details for: %s", bestMatch.Name)
-			hfDetails, err := huggingface.FetchModelDetails(bestMatch.Name)
-			if err != nil {
-				log.Printf("  Warning: Failed to fetch HF details: %v", err)
-			} else {
-				// Always store HuggingFace name when available - the confidence-based override logic will decide whether to use it
-				if hfDetails.ID != "" {
-					// For high-confidence matches, always set the HuggingFace name so it can be used by confidence-based override logic
-					if enriched.MatchConfidence == "high" {
-						enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
-					} else if enriched.Name.Source == "null" {
-						// For medium/low confidence, only set if no existing name
-						enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
-					}
-				}
-				if enriched.License.Source == "null" && hfDetails.License != "" {
-					enriched.License = metadata.CreateMetadataSource(hfDetails.License, "huggingface.api")
-				}
-				if enriched.LastModified.Source == "null" && hfDetails.LastModified != "" {
-					enriched.LastModified = metadata.CreateMetadataSource(hfDetails.LastModified, "huggingface.api")
+				// NOTE: Do NOT store raw repository tags here - they will be used as fallback later
+				// Raw repository tags contain language codes, arxiv refs, and other metadata that should be filtered
+
+				// Store parsed languages (if no YAML frontmatter languages available)
+				if len(languages) > 0 && prefersSource(enriched.Language.Source, "huggingface.tags") {
+					enriched.Language = metadata.CreateMetadataSource(languages, "huggingface.tags")
 				}
-				if len(hfDetails.Tags) > 0 {
-					// Parse tags for structured data and potentially extract license
-					languages, tagLicense, tasks := huggingface.ParseTagsForStructuredData(hfDetails.Tags)
-					log.Printf("  Parsed from tags - Languages: %v, License: %s, Tasks: %v", languages, tagLicense, tasks)
 
-					// NOTE: Do NOT store raw repository tags here - they will be used as fallback later
-					// Raw repository tags contain language codes, arxiv refs, and other metadata that should be filtered
+				// Use license from tags if not already set, or if only an "other" placeholder is held
+				if tagLicense != "" && prefersLicense(enriched.License, tagLicense, "huggingface.tags") {
+					enriched.License = metadata.CreateMetadataSource(tagLicense, "huggingface.tags")
+				}
 
-					// Store parsed languages (if no YAML frontmatter languages available)
-					if enriched.Language.Source == "null" && len(languages) > 0 {
-						enriched.Language = metadata.CreateMetadataSource(languages, "huggingface.tags")
-					}
+				// Store tasks if found
+				if len(tasks) > 0 && prefersSource(enriched.Tasks.Source, "huggingface.tags") {
+					enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.tags")
+				}
+			}
+			if enriched.Downloads.Source == "null" && hfDetails.Downloads > 0 {
+				enriched.Downloads = metadata.CreateMetadataSource(hfDetails.Downloads, "huggingface.api")
+			}
+			if enriched.Likes.Source == "null" && hfDetails.Likes > 0 {
+				enriched.Likes = metadata.CreateMetadataSource(hfDetails.Likes, "huggingface.api")
+			}
+		}
 
-					// Use license from tags if not already set
-					if enriched.License.Source == "null" && tagLicense != "" {
-						enriched.License = metadata.CreateMetadataSource(tagLicense, "huggingface.tags")
-					}
+		// Always fetch HuggingFace README to check for YAML frontmatter (the highest-priority source by default)
+		// Also extract release date and other metadata information as needed
+		needsProvider := enriched.Provider.Source == "null"
+		// The README release date only replaces a last-modified time from a lower-priority source
+		needsReleaseDate := prefersSource(enriched.LastModified.Source, "huggingface.regex")
+
+		slog.Debug("Checking release date", "model", regModel, "lastModifiedSource", enriched.LastModified.Source,
+			"lastModified", enriched.LastModified.Value, "needsReleaseDate", needsReleaseDate)
+		slog.Debug("Fetching HuggingFace README", "model", regModel, "huggingface", bestMatch.Name)
+		hfReadme, err := huggingface.FetchReadme(bestMatch.Name)
+		if errors.Is(err, huggingface.ErrGatedModel) {
+			slog.Info("Gated model skipped for HuggingFace README", "model", regModel, "error", err)
+		} else if err != nil {
+			slog.Warn("Failed to fetch HuggingFace README", "model", regModel, "error", err)
+		} else {
+			// Try to extract YAML frontmatter first
+			frontmatter, err := huggingface.ExtractYAMLFrontmatter(hfReadme)
+			if err == nil {
+				slog.Debug("Extracted YAML frontmatter from HuggingFace README", "model", regModel)
+
+				// The README's display name competes with the canonical API model path (e.g.
+				// "RedHatAI/Qwen3.5-122B-A10B-FP8-dynamic") by source priority; list huggingface.api
+				// above huggingface.yaml to keep canonical names
+				if frontmatter.Name != "" && prefersSource(enriched.Name.Source, "huggingface.yaml") {
+					enriched.Name = metadata.CreateMetadataSource(frontmatter.Name, "huggingface.yaml")
+					slog.Debug("Found name in YAML frontmatter", "model", regModel, "name", frontmatter.Name)
+				}
 
-					// Store tasks if found
-					if enriched.Tasks.Source == "null" && len(tasks) > 0 {
-						enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.tags")
-					}
+				// Use provider from HuggingFace YAML unless a higher-priority source supplied it
+				if frontmatter.Provider != "" && prefersSource(enriched.Provider.Source, "huggingface.yaml") {
+					enriched.Provider = metadata.CreateMetadataSource(frontmatter.Provider, "huggingface.yaml")
+					slog.Debug("Found provider in YAML frontmatter", "model", regModel, "provider", frontmatter.Provider)
 				}
-				if enriched.Downloads.Source == "null" && hfDetails.Downloads > 0 {
-					enriched.Downloads = metadata.CreateMetadataSource(hfDetails.Downloads, "huggingface.api")
+
+				// Use description from HuggingFace YAML unless a higher-priority source supplied it
+				if frontmatter.Description != "" && prefersSource(enriched.Description.Source, "huggingface.yaml") {
+					enriched.Description = metadata.CreateMetadataSource(frontmatter.Description, "huggingface.yaml")
+					slog.Debug("Found description in YAML frontmatter", "model", regModel, "description", frontmatter.Description)
 				}
-				if enriched.Likes.Source == "null" && hfDetails.Likes > 0 {
-					enriched.Likes = metadata.CreateMetadataSource(hfDetails.Likes, "huggingface.api")
+
+				// Use language from HuggingFace YAML frontmatter unless a higher-priority source supplied it
+				if len(frontmatter.Language) > 0 && prefersSource(enriched.Language.Source, "huggingface.yaml") {
+					// Convert to []string to ensure type compatibility
+					enriched.Language = metadata.CreateMetadataSource([]string(frontmatter.Language), "huggingface.yaml")
+					slog.Debug("Found languages in YAML frontmatter", "model", regModel, "languages", frontmatter.Language)
 				}
-			}
 
-			// Always fetch HuggingFace README to check for YAML frontmatter (highest priority)
-			// Also extract release date and other metadata information as needed
-			needsProvider := enriched.Provider.Source == "null"
-			// Extract release date if we don't have a valid date yet (even from modelcard.regex with null value)
-			needsReleaseDate := enriched.LastModified.Source == "null" ||
-				(enriched.LastModified.Source == "modelcard.regex" && enriched.LastModified.Value == nil)
-
-			log.Printf("  DEBUG: LastModified source='%s', value=%v, needsReleaseDate=%v",
-				enriched.LastModified.Source, enriched.LastModified.Value, needsReleaseDate)
-			log.Printf("  Fetching HuggingFace README for additional metadata: %s", bestMatch.Name)
-			hfReadme, err := huggingface.FetchReadme(bestMatch.Name)
-			if err != nil {
-				log.Printf("  Warning: Failed to fetch HF README: %v", err)
-			} else {
-				// Try to extract YAML frontmatter first
-				frontmatter, err := huggingface.ExtractYAMLFrontmatter(hfReadme)
-				if err == nil {
-					log.Printf("  Successfully extracted YAML frontmatter from HF README")
-
-					// Use name from HuggingFace YAML only when no canonical API name is available.
-					// The huggingface.api source provides the canonical model path (e.g. "RedHatAI/Qwen3.5-122B-A10B-FP8-dynamic"),
-					// which must not be overridden by the README's human-readable display name.
-					if frontmatter.Name != "" && enriched.Name.Source != "huggingface.api" {
-						enriched.Name = metadata.CreateMetadataSource(frontmatter.Name, "huggingface.yaml")
-						log.Printf("  Found name in YAML frontmatter: %s", frontmatter.Name)
-					}
+				// Use tags from HuggingFace YAML frontmatter unless a higher-priority source supplied it
+				if frontmatterTags := huggingface.RemoveDeniedTags(frontmatter.Tags); len(frontmatterTags) > 0 && prefersSource(enriched.Tags.Source, "huggingface.yaml") {
+					enriched.Tags = metadata.CreateMetadataSource(frontmatterTags, "huggingface.yaml")
+					slog.Debug("Found tags in YAML frontmatter", "model", regModel, "tags", frontmatter.Tags)
+				}
 
-					// Always use provider from HuggingFace YAML (highest priority)
-					if frontmatter.Provider != "" {
-						enriched.Provider = metadata.CreateMetadataSource(frontmatter.Provider, "huggingface.yaml")
-						log.Printf("  Found provider in YAML frontmatter: %s", frontmatter.Provider)
-					}
+				// Use license from HuggingFace YAML frontmatter unless a higher-priority source supplied it
+				if frontmatter.License != "" && prefersLicense(enriched.License, frontmatter.License, "huggingface.yaml") {
+					enriched.License = metadata.CreateMetadataSource(frontmatter.License, "huggingface.yaml")
+					slog.Debug("Found license in YAML frontmatter", "model", regModel, "license", frontmatter.License)
+				}
 
-					// Always use description from HuggingFace YAML (highest priority)
-					if frontmatter.Description != "" {
-						enriched.Description = metadata.CreateMetadataSource(frontmatter.Description, "huggingface.yaml")
-						log.Printf("  Found description in YAML frontmatter: %s", frontmatter.Description)
-					}
+				// Use license_name if available and more specific
+				if frontmatter.LicenseName != "" && prefersLicense(enriched.License, frontmatter.LicenseName, "huggingface.yaml") {
+					enriched.License = metadata.CreateMetadataSource(frontmatter.LicenseName, "huggingface.yaml")
+					slog.Debug("Found license_name in YAML frontmatter", "model", regModel, "license_name", frontmatter.LicenseName)
+				}
 
-					// Always use language from HuggingFace YAML frontmatter (highest priority)
-					if len(frontmatter.Language) > 0 {
-						// Convert to []string to ensure type compatibility
-						enriched.Language = metadata.CreateMetadataSource([]string(frontmatter.Language), "huggingface.yaml")
-						log.Printf("  Found languages in YAML frontmatter: %v", frontmatter.Language)
-					}
+				// Use license_link from HuggingFace YAML frontmatter unless a higher-priority source supplied it
+				if frontmatter.LicenseLink != "" && prefersSource(enriched.LicenseLink.Source, "huggingface.yaml") {
+					enriched.LicenseLink = metadata.CreateMetadataSource(frontmatter.LicenseLink, "huggingface.yaml")
+					slog.Debug("Found license_link in YAML frontmatter", "model", regModel, "license_link", frontmatter.LicenseLink)
+				}
 
-					// Always use tags from HuggingFace YAML frontmatter (highest priority)
-					if len(frontmatter.Tags) > 0 {
-						enriched.Tags = metadata.CreateMetadataSource(frontmatter.Tags, "huggingface.yaml")
-						log.Printf("  Found tags in YAML frontmatter: %v", frontmatter.Tags)
-					}
+				// Use tasks from HuggingFace YAML unless a higher-priority source supplied it
+				if !prefersSource(enriched.Tasks.Source, "huggingface.yaml") {
+					slog.Debug("Keeping higher-priority tasks over YAML frontmatter", "model", regModel, "source", enriched.Tasks.Source)
+				} else if len(frontmatter.Tasks) > 0 {
+					enriched.Tasks = metadata.CreateMetadataSource(utils.ApplyTaskOverrides(frontmatter.Tasks), "huggingface.yaml")
+					slog.Debug("Found tasks in YAML frontmatter", "model", regModel, "tasks", frontmatter.Tasks)
+				} else if frontmatter.PipelineTag != "" {
+					// Fallback to pipeline_tag for tasks if tasks field is not available
+					tasks := utils.ApplyTaskOverrides([]string{frontmatter.PipelineTag})
+					enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.yaml")
+					slog.Debug("Found pipeline_tag in YAML frontmatter", "model", regModel, "pipeline_tag", frontmatter.PipelineTag)
+				}
+				// Use validated_on from HuggingFace YAML unless a higher-priority source supplied it
+				if len(frontmatter.ValidatedOn) > 0 && prefersSource(enriched.ValidatedOn.Source, "huggingface.yaml") {
+					enriched.ValidatedOn = metadata.CreateMetadataSource([]string(frontmatter.ValidatedOn), "huggingface.yaml")
+					slog.Debug("Found validated_on in YAML frontmatter", "model", regModel, "validated_on", frontmatter.ValidatedOn)
+				}
+				// Use hardware_tag from HuggingFace YAML unless a higher-priority source supplied it
+				if len(frontmatter.HardwareTag) > 0 && prefersSource(enriched.HardwareTag.Source, "huggingface.yaml") {
+					enriched.HardwareTag = metadata.CreateMetadataSource([]string(frontmatter.HardwareTag), "huggingface.yaml")
+					slog.Debug("Found hardware_tag in YAML frontmatter", "model", regModel, "hardware_tag", frontmatter.HardwareTag)
+				}
 
-					// Always use license from HuggingFace YAML frontmatter (highest priority)
-					if frontmatter.License != "" {
-						enriched.License = metadata.CreateMetadataSource(frontmatter.License, "huggingface.yaml")
-						log.Printf("  Extracted license from YAML frontmatter: %s", frontmatter.License)
-					}
+				// Extract validated_tasks from HuggingFace YAML unless a higher-priority source supplied it
+				if len(frontmatter.ValidatedTasks) > 0 && prefersSource(enriched.ValidatedTasks.Source, "huggingface.yaml") {
+					enriched.ValidatedTasks = metadata.CreateMetadataSource([]string(frontmatter.ValidatedTasks), "huggingface.yaml")
+					slog.Debug("Found validated_tasks in YAML frontmatter", "model", regModel, "validated_tasks", frontmatter.ValidatedTasks)
+				}
 
-					// Always use license_name if available and more specific (highest priority)
-					if frontmatter.LicenseName != "" {
-						enriched.License = metadata.CreateMetadataSource(frontmatter.LicenseName, "huggingface.yaml")
-						log.Printf("  Extracted license_name from YAML frontmatter: %s", frontmatter.LicenseName)
-					}
+				// Record the base model(s) this model was fine-tuned or quantized from
+				if len(frontmatter.BaseModel) > 0 && prefersSource(enriched.BaseModel.Source, "huggingface.yaml") {
+					enriched.BaseModel = metadata.CreateMetadataSource([]string(frontmatter.BaseModel), "huggingface.yaml")
+					slog.Debug("Found base_model in YAML frontmatter", "model", regModel, "base_model", frontmatter.BaseModel)
 
-					// Always use license_link from HuggingFace YAML frontmatter (highest priority)
-					if frontmatter.LicenseLink != "" {
-						enriched.LicenseLink = metadata.CreateMetadataSource(frontmatter.LicenseLink, "huggingface.yaml")
-						log.Printf("  Extracted license_link from YAML frontmatter: %s", frontmatter.LicenseLink)
+					// Infer provider from the base model organization when nothing better was found
+					if enriched.Provider.Source == "null" {
+						if provider := huggingface.ExtractProviderFromBaseModel(frontmatter.BaseModel); provider != "" {
+							enriched.Provider = metadata.CreateMetadataSource(provider, "huggingface.base_model")
+							slog.Debug("Inferred provider from base_model", "model", regModel, "provider", provider)
+						}
 					}
+				}
 
-					// Always use tasks from HuggingFace YAML (highest priority)
-					if len(frontmatter.Tasks) > 0 {
-						enriched.Tasks = metadata.CreateMetadataSource(frontmatter.Tasks, "huggingface.yaml")
-						log.Printf("  Extracted tasks from YAML frontmatter: %v", frontmatter.Tasks)
-					} else if frontmatter.PipelineTag != "" {
-						// Fallback to pipeline_tag for tasks if tasks field is not available
-						tasks := []string{frontmatter.PipelineTag}
-						enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.yaml")
-						log.Printf("  Extracted pipeline_tag from YAML frontmatter: %s", frontmatter.PipelineTag)
-					}
-					// Always use validated_on from HuggingFace YAML (highest priority)
-					if len(frontmatter.ValidatedOn) > 0 {
-						enriched.ValidatedOn = metadata.CreateMetadataSource([]string(frontmatter.ValidatedOn), "huggingface.yaml")
-						log.Printf("  Extracted validated_on from YAML frontmatter: %v", frontmatter.ValidatedOn)
-					}
-					// Always use hardware_tag from HuggingFace YAML (highest priority)
-					if len(frontmatter.HardwareTag) > 0 {
-						enriched.HardwareTag = metadata.CreateMetadataSource([]string(frontmatter.HardwareTag), "huggingface.yaml")
-						log.Printf("  Extracted hardware_tag from YAML frontmatter: %v", frontmatter.HardwareTag)
+				// Extract tool-calling configuration from HuggingFace YAML frontmatter ONLY
+				// NOTE: We do NOT extract this from container modelcard YAML - only from HuggingFace
+				var toolCallingConfig *types.ToolCallingConfig
+				if frontmatter.ToolCallingSupported || len(frontmatter.RequiredCLIArgs) > 0 || frontmatter.ToolCallParser != "" {
+					toolCallingConfig = &types.ToolCallingConfig{
+						Supported:        frontmatter.ToolCallingSupported,
+						RequiredCLIArgs:  []string(frontmatter.RequiredCLIArgs),
+						ChatTemplateFile: frontmatter.ChatTemplateFileName,
+						ChatTemplatePath: frontmatter.ChatTemplatePath,
+						ToolCallParser:   frontmatter.ToolCallParser,
 					}
+					slog.Debug("Found tool-calling config in YAML frontmatter", "model", regModel, "config", toolCallingConfig)
 
-					// Extract validated_tasks from HuggingFace YAML (highest priority)
-					if len(frontmatter.ValidatedTasks) > 0 {
-						enriched.ValidatedTasks = metadata.CreateMetadataSource([]string(frontmatter.ValidatedTasks), "huggingface.yaml")
-						log.Printf("  Extracted validated_tasks from YAML frontmatter: %v", frontmatter.ValidatedTasks)
+					// Validate the tool-calling configuration
+					if err := toolCallingConfig.Validate(); err != nil {
+						slog.Warn("Invalid tool-calling config", "model", regModel, "error", err)
+						toolCallingConfig = nil // Discard invalid config
 					}
+				}
 
-					// Extract tool-calling configuration from HuggingFace YAML frontmatter ONLY
-					// NOTE: We do NOT extract this from container modelcard YAML - only from HuggingFace
-					var toolCallingConfig *types.ToolCallingConfig
-					if frontmatter.ToolCallingSupported || len(frontmatter.RequiredCLIArgs) > 0 || frontmatter.ToolCallParser != "" {
-						toolCallingConfig = &types.ToolCallingConfig{
-							Supported:        frontmatter.ToolCallingSupported,
-							RequiredCLIArgs:  []string(frontmatter.RequiredCLIArgs),
-							ChatTemplateFile: frontmatter.ChatTemplateFileName,
-							ChatTemplatePath: frontmatter.ChatTemplatePath,
-							ToolCallParser:   frontmatter.ToolCallParser,
-						}
-						log.Printf("  Extracted tool-calling config from HuggingFace: %+v", toolCallingConfig)
-
-						// Validate the tool-calling configuration
-						if err := toolCallingConfig.Validate(); err != nil {
-							log.Printf("  Warning: Invalid tool-calling config for %s: %v", regModel, err)
-							toolCallingConfig = nil // Discard invalid config
-						}
-					}
+				// Store for use during metadata update (will be nil if no tool-calling metadata)
+				enriched.ToolCallingConfig = toolCallingConfig
+			} else {
+				slog.Debug("No valid YAML frontmatter found in HuggingFace README", "model", regModel, "error", err)
+			}
 
-					// Store for use during metadata update (will be nil if no tool-calling metadata)
-					enriched.ToolCallingConfig = toolCallingConfig
-				} else {
-					log.Printf("  No valid YAML frontmatter found in HF README: %v", err)
-				}
+			// Store the README content (strip YAML frontmatter first) for use during metadata update
+			readmeContent := utils.StripYAMLFrontmatter(hfReadme)
+			if readmeContent != "" {
+				enriched.ReadmeContent = readmeContent
+				slog.Debug("Stored HuggingFace README content", "model", regModel, "chars", len(readmeContent))
+			}
 
-				// Store the README content (strip YAML frontmatter first) for use during metadata update
-				readmeContent := utils.StripYAMLFrontmatter(hfReadme)
-				if readmeContent != "" {
-					enriched.ReadmeContent = readmeContent
-					log.Printf("  Stored HuggingFace README content (%d chars)", len(readmeContent))
+			// Fallback to text parsing for provider if needed
+			if needsProvider && enriched.Provider.Source == "null" {
+				provider := huggingface.ExtractProviderFromReadme(hfReadme)
+				if provider != "" {
+					enriched.Provider = metadata.CreateMetadataSource(provider, "huggingface.regex")
+					slog.Debug("Found provider in HuggingFace README text", "model", regModel, "provider", provider)
 				}
+			}
 
-				// Fallback to text parsing for provider if needed
-				if needsProvider && enriched.Provider.Source == "null" {
-					provider := huggingface.ExtractProviderFromReadme(hfReadme)
-					if provider != "" {
-						enriched.Provider = metadata.CreateMetadataSource(provider, "huggingface.regex")
-						log.Printf("  Extracted provider from HF README text: %s", provider)
+			// Try to extract explicit release date from README (high priority)
+			releaseDate := huggingface.ExtractReleaseDateFromReadme(hfReadme)
+			if releaseDate != "" {
+				if epoch := utils.ParseDateToEpoch(releaseDate); epoch != nil {
+					// Use this for createTimeSinceEpoch if we don't have it from modelcard
+					if prefersSource(enriched.CreateTimeSinceEpoch.Source, "huggingface.regex") {
+						enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
+						slog.Debug("Found createTimeSinceEpoch in HuggingFace README release date", "model", regModel, "releaseDate", releaseDate, "epoch", *epoch)
 					}
-				}
-
-				// Try to extract explicit release date from README (high priority)
-				releaseDate := huggingface.ExtractReleaseDateFromReadme(hfReadme)
-				if releaseDate != "" {
-					if epoch := utils.ParseDateToEpoch(releaseDate); epoch != nil {
-						// Use this for createTimeSinceEpoch if we don't have it from modelcard
-						if enriched.CreateTimeSinceEpoch.Source == "null" {
-							enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
-							log.Printf("  Extracted createTimeSinceEpoch from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
-						}
-						// Also update lastModified if we don't have a more recent one
-						if needsReleaseDate {
-							enriched.LastModified = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
-							log.Printf("  Extracted lastModified from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
-						}
+					// Also update lastModified if we don't have a more recent one
+					if needsReleaseDate {
+						enriched.LastModified = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
+						slog.Debug("Found lastModified in HuggingFace README release date", "model", regModel, "releaseDate", releaseDate, "epoch", *epoch)
 					}
 				}
 			}
+		}
 
-			// Use repository tags as additional enrichment: Apply if no YAML frontmatter tags were found
-			// This will merge with existing modelcard tags (like "validated"/"featured") during update phase
-			if enriched.Tags.Source == "null" && len(hfDetails.Tags) > 0 {
-				log.Printf("  No YAML frontmatter tags found, using filtered repository tags")
-				// Filter out language codes, arxiv references, and other non-tag metadata
-				filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
-				if len(filteredTags) > 0 {
-					enriched.Tags = metadata.CreateMetadataSource(filteredTags, "huggingface.tags")
-					log.Printf("  Using filtered repository tags: %v", filteredTags)
-				}
-			} else if enriched.Tags.Source == "modelcard.regex" && len(hfDetails.Tags) > 0 {
-				log.Printf("  Found modelcard tags, merging with filtered repository tags")
-				// Filter out language codes, arxiv references, and other non-tag metadata
-				filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
-				if len(filteredTags) > 0 {
-					// Merge existing modelcard tags with HuggingFace tags
-					existingTags := enriched.Tags.Value.([]string)
-					allTags := make([]string, 0)
-
-					// First add existing tags
-					allTags = append(allTags, existingTags...)
-
-					// Then add new tags, avoiding duplicates
-					for _, newTag := range filteredTags {
-						found := false
-						for _, existingTag := range allTags {
-							if existingTag == newTag {
-								found = true
-								break
-							}
-						}
-						if !found {
-							allTags = append(allTags, newTag)
-						}
-					}
+		// Fall back to the HuggingFace repository creation time when neither card had a release date
+		if applyCreatedAtFallback(&enriched, hfDetails) {
+			slog.Debug("Using HuggingFace createdAt for createTimeSinceEpoch", "model", regModel, "createdAt", hfDetails.CreatedAt)
+		}
 
-					enriched.Tags = metadata.CreateMetadataSource(allTags, "huggingface.tags")
-					log.Printf("  Merged modelcard + repository tags: %v", allTags)
+		// Use repository tags as additional enrichment: Apply if no YAML frontmatter tags were found
+		// This will merge with existing modelcard tags (like "validated"/"featured") during update phase
+		// hfDetails is nil when the details fetch failed (e.g. gated model without a token)
+		if hfDetails == nil {
+			slog.Debug("No HuggingFace repository tags available", "model", regModel, "huggingface", bestMatch.Name)
+		} else if len(hfDetails.Tags) > 0 && prefersSource(enriched.Tags.Source, "huggingface.tags") {
+			// Filter out language codes, arxiv references, and other non-tag metadata
+			filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
+			if len(filteredTags) > 0 {
+				// Repository tags are added to any lower-priority tags already held
+				var allTags []string
+				if heldTags, ok := enriched.Tags.Value.([]string); ok {
+					allTags = append(allTags, heldTags...)
+				}
+				for _, tag := range filteredTags {
+					if !slices.Contains(allTags, tag) {
+						allTags = append(allTags, tag)
+					}
 				}
+				enriched.Tags = metadata.CreateMetadataSource(allTags, "huggingface.tags")
+				slog.Debug("Using filtered repository tags", "model", regModel, "tags", allTags)
 			}
+		}
 
-			// Look up vLLM recommended configuration by exact model name match
-			if vllmIndex != nil && enriched.HuggingFaceModel != "" {
-				if vllmCfg := vllmIndex.GetConfig(enriched.HuggingFaceModel); vllmCfg != nil {
-					enriched.VLLMConfig = vllmCfg
-					log.Printf("  Found vLLM recommended config for: %s", enriched.HuggingFaceModel)
-				}
+		// Look up vLLM recommended configuration by exact model name match
+		if vllmIndex != nil && enriched.HuggingFaceModel != "" {
+			if vllmCfg := vllmIndex.GetConfig(enriched.HuggingFaceModel); vllmCfg != nil {
+				enriched.VLLMConfig = vllmCfg
+				slog.Debug("Found vLLM recommended config", "model", regModel, "huggingface", enriched.HuggingFaceModel)
 			}
+		}
 
-			// Update the model's metadata.yaml file with enriched data
-			err = UpdateModelMetadataFile(regModel, &enriched, outputDir)
-			if err != nil {
-				log.Printf("  Warning: Failed to update metadata file for %s: %v", regModel, err)
-			} else {
-				log.Printf("  Successfully updated metadata file for: %s", regModel)
+		// Update the model's metadata.yaml file with enriched data
+		err = UpdateModelMetadataFile(regModel, &enriched, outputDir)
+		if err != nil {
+			slog.Warn("Failed to update metadata file", "model", regModel, "error", err)
+		} else {
+			slog.Info("Updated metadata file", "model", regModel)
 
-				// Also update artifacts with OCI metadata
-				log.Printf("  Updating OCI artifacts for: %s", regModel)
+			// Also update artifacts with OCI metadata; like UpdateAllModelsWithOCIArtifacts,
+			// HuggingFace-hosted models are skipped as they have no OCI image
+			if !hfHosted {
+				slog.Debug("Updating OCI artifacts", "model", regModel)
 				err = UpdateOCIArtifacts(regModel, outputDir)
 				if err != nil {
-					log.Printf("  Warning: Failed to update OCI artifacts for %s: %v", regModel, err)
+					slog.Warn("Failed to update OCI artifacts", "model", regModel, "error", err)
 				} else {
-					log.Printf("  Successfully updated OCI artifacts for: %s", regModel)
+					slog.Debug("Updated OCI artifacts", "model", regModel)
 				}
 			}
-
-			matchCount++
 		}
 
+		return enriched, true
 	}
 
-	// Clean up the old enriched metadata file if it exists
-	_ = os.Remove("data/enriched-model-metadata.yaml")
-
-	enrichmentRate := float64(matchCount) / float64(len(regModels)) * 100
-
-	log.Printf("Metadata enrichment complete:")
-	log.Printf("- Total registry models: %d", len(regModels))
-	log.Printf("- Successfully enriched: %d (%.1f%%)", matchCount, enrichmentRate)
-	log.Printf("- Individual metadata.yaml files have been updated with enriched data")
-
-	return nil
+	return enriched, false
 }
 
 // UpdateAllModelsWithOCIArtifacts updates all existing models with OCI artifact metadata
@@ -608,11 +910,20 @@ func UpdateAllModelsWithOCIArtifacts(modelsIndexPath, outputDir string) error {
 	log.Println("Updating all existing models with OCI artifact metadata...")
 
 	// Load all models from the index
-	regModels, err := config.LoadModelsFromYAML(modelsIndexPath)
+	modelEntries, err := config.LoadModelsConfigFromYAML(modelsIndexPath)
 	if err != nil {
 		return fmt.Errorf("failed to load registry models: %v", err)
 	}
 
+	// HuggingFace-hosted models have no OCI image to take artifacts from
+	var regModels []string
+	for _, entry := range selectModelEntries(modelEntries) {
+		if entry.Type == types.ModelEntryTypeHF {
+			continue
+		}
+		regModels = append(regModels, entry.URI)
+	}
+
 	updateCount := 0
 
 	// Update each model that has existing metadata
@@ -650,12 +961,21 @@ func UpdateOCIArtifacts(registryModel, outputDir string) error {
 		return fmt.Errorf("failed to load existing metadata: %v", err)
 	}
 
-	// Generate OCI artifacts from the registry model reference
-	ociArtifacts := registry.ExtractOCIArtifactsFromRegistry(registryModel)
+	// Generate OCI artifacts from the registry model reference; existing metadata is left
+	// untouched when the reference cannot be parsed
+	ociArtifacts, err := registry.ExtractOCIArtifactsFromRegistryE(registryModel)
+	if err != nil {
+		return fmt.Errorf("failed to extract OCI artifacts: %w", err)
+	}
 
 	// Preserve existing data when updating artifacts
 	for i := range ociArtifacts {
 		if i < len(existingMetadata.Artifacts) {
+			// Keep a digest-pinned URI written during extraction (--pin-digests)
+			if registry.IsDigestPinned(existingMetadata.Artifacts[i].URI) {
+				ociArtifacts[i].URI = existingMetadata.Artifacts[i].URI
+			}
+
 			// Preserve timestamps from existing artifacts if they exist
 			if existingMetadata.Artifacts[i].CreateTimeSinceEpoch != nil {
 				ociArtifacts[i].CreateTimeSinceEpoch = existingMetadata.Artifacts[i].CreateTimeSinceEpoch
@@ -689,13 +1009,7 @@ func UpdateOCIArtifacts(registryModel, outputDir string) error {
 	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", outputDir, sanitizedName)
 
-	updatedData, err := yaml.Marshal(existingMetadata)
-	if err != nil {
-		return fmt.Errorf("failed to marshal updated metadata: %v", err)
-	}
-
-	err = os.WriteFile(metadataPath, updatedData, 0644)
-	if err != nil {
+	if err := metadata.WriteMetadataFile(metadataPath, existingMetadata); err != nil {
 		return fmt.Errorf("failed to write updated metadata: %v", err)
 	}
 
@@ -1,14 +1,25 @@
 package enrichment
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestEnrichMetadataFromHuggingFace_FilesNotExist(t *testing.T) {
@@ -31,7 +42,7 @@ func TestEnrichMetadataFromHuggingFace_FilesNotExist(t *testing.T) {
 	}
 
 	// Test with missing HuggingFace index file
-	err = EnrichMetadataFromHuggingFace("nonexistent-hf.yaml", "nonexistent-models.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace("nonexistent-hf.yaml", "nonexistent-models.yaml", "output", "", DefaultMatchOptions())
 	if err == nil {
 		t.Error("Expected error when HuggingFace index file doesn't exist")
 	}
@@ -71,7 +82,7 @@ func TestEnrichMetadataFromHuggingFace_InvalidHFFile(t *testing.T) {
 
 	// Test with invalid HuggingFace file — must pass the prepared file so we
 	// actually exercise the YAML parse path, not a file-not-found error.
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "", DefaultMatchOptions())
 	if err == nil {
 		t.Error("Expected error when HuggingFace index file is invalid")
 	}
@@ -129,7 +140,7 @@ func TestEnrichMetadataFromHuggingFace_MissingModelsIndex(t *testing.T) {
 
 	// Test with missing models-index.yaml — must pass the prepared valid HF file
 	// so we exercise the models index load path, not a file-not-found on the HF file.
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "", DefaultMatchOptions())
 	if err == nil {
 		t.Error("Expected error when models-index.yaml doesn't exist")
 	}
@@ -199,7 +210,7 @@ func TestEnrichMetadataFromHuggingFace_EmptyFiles(t *testing.T) {
 	}
 
 	// Test with empty files - should succeed
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "data/models-index.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "data/models-index.yaml", "output", "", DefaultMatchOptions())
 	if err != nil {
 		t.Errorf("Unexpected error with empty files: %v", err)
 	}
@@ -236,7 +247,7 @@ func TestUpdateModelMetadataFile_NoExistingFile(t *testing.T) {
 	}
 
 	// Create output directory structure
-	outputDir := "output/registry.example.com_test_model_latest/models"
+	outputDir := filepath.Join("output", utils.SanitizeManifestRef(registryModel), "models")
 	err = os.MkdirAll(outputDir, 0755)
 	if err != nil {
 		t.Fatalf("Failed to create output directory: %v", err)
@@ -249,7 +260,7 @@ func TestUpdateModelMetadataFile_NoExistingFile(t *testing.T) {
 	}
 
 	// Verify enrichment.yaml was created
-	enrichmentPath := "output/registry.example.com_test_model_latest/models/enrichment.yaml"
+	enrichmentPath := filepath.Join("output", utils.SanitizeManifestRef(registryModel), "models", "enrichment.yaml")
 	if _, err := os.Stat(enrichmentPath); os.IsNotExist(err) {
 		t.Errorf("Enrichment file was not created at %s", enrichmentPath)
 	}
@@ -276,7 +287,7 @@ func TestUpdateModelMetadataFile_WithExistingFile(t *testing.T) {
 
 	// Create output directory structure
 	registryModel := "registry.example.com/test/model:latest"
-	outputDir := "output/registry.example.com_test_model_latest/models"
+	outputDir := filepath.Join("output", utils.SanitizeManifestRef(registryModel), "models")
 	err = os.MkdirAll(outputDir, 0755)
 	if err != nil {
 		t.Fatalf("Failed to create output directory: %v", err)
@@ -388,12 +399,63 @@ func TestUpdateAllModelsWithOCIArtifacts(t *testing.T) {
 
 func TestUpdateOCIArtifacts_InvalidModel(t *testing.T) {
 	// Test UpdateOCIArtifacts with invalid model reference
-	err := UpdateOCIArtifacts("invalid-model-reference", "output")
+	err := UpdateOCIArtifacts("invalid model reference", "output")
 	if err == nil {
 		t.Error("Expected error for invalid model reference")
 	}
 }
 
+func TestUpdateOCIArtifacts_KeepsPinnedURI(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.invalid/test/model:1.0"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	pinnedURI := "oci://registry.example.invalid/test/model@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	existing := types.ExtractedMetadata{
+		Artifacts: []types.OCIArtifact{{
+			URI: pinnedURI,
+			CustomProperties: map[string]interface{}{
+				"digest": map[string]interface{}{
+					"metadataType": "MetadataStringValue",
+					"string_value": "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				},
+			},
+		}},
+	}
+	data, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatalf("Failed to marshal existing metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	if err := UpdateOCIArtifacts(registryModel, tmpDir); err != nil {
+		t.Fatalf("UpdateOCIArtifacts failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read updated metadata: %v", err)
+	}
+	var result types.ExtractedMetadata
+	if err := yaml.Unmarshal(updated, &result); err != nil {
+		t.Fatalf("Failed to parse updated metadata: %v", err)
+	}
+	if len(result.Artifacts) != 1 {
+		t.Fatalf("Expected 1 artifact, got %d", len(result.Artifacts))
+	}
+	if result.Artifacts[0].URI != pinnedURI {
+		t.Errorf("Expected pinned URI %s to be kept, got %s", pinnedURI, result.Artifacts[0].URI)
+	}
+	if _, ok := result.Artifacts[0].CustomProperties["digest"]; !ok {
+		t.Error("Expected digest custom property to be kept")
+	}
+}
+
 func TestIsLowQualityModelName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -509,3 +571,1330 @@ func TestIsLowQualityModelName(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    MatchOptions
+		wantErr bool
+	}{
+		{name: "defaults", opts: DefaultMatchOptions(), wantErr: false},
+		{name: "equal thresholds", opts: MatchOptions{Threshold: 0.7, HighConfidenceThreshold: 0.7}, wantErr: false},
+		{name: "threshold above 1", opts: MatchOptions{Threshold: 1.5, HighConfidenceThreshold: 0.8}, wantErr: true},
+		{name: "negative threshold", opts: MatchOptions{Threshold: -0.1, HighConfidenceThreshold: 0.8}, wantErr: true},
+		{name: "high confidence above 1", opts: MatchOptions{Threshold: 0.5, HighConfidenceThreshold: 1.2}, wantErr: true},
+		{name: "high confidence below threshold", opts: MatchOptions{Threshold: 0.8, HighConfidenceThreshold: 0.6}, wantErr: true},
+		{name: "negative ambiguity margin", opts: MatchOptions{Threshold: 0.5, HighConfidenceThreshold: 0.8, AmbiguityMargin: -0.1}, wantErr: true},
+		{name: "zero ambiguity margin disables check", opts: MatchOptions{Threshold: 0.5, HighConfidenceThreshold: 0.8}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindBestHuggingFaceMatch(t *testing.T) {
+	hfModels := []types.ModelIndex{
+		{Name: "RedHatAI/granite-3.1-8b-instruct"},
+		{Name: "RedHatAI/granite-3.1-8b-base"},
+		{Name: "RedHatAI/granite-3.1-8b-instruct"}, // duplicate entry from another collection
+		{Name: "RedHatAI/Llama-3.1-8B-Instruct"},
+	}
+
+	best, second, bestScore, secondScore := findBestHuggingFaceMatch("registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5", hfModels)
+	if best.Name != "RedHatAI/granite-3.1-8b-instruct" {
+		t.Errorf("Expected best match granite-3.1-8b-instruct, got %q", best.Name)
+	}
+	if second.Name != "RedHatAI/granite-3.1-8b-base" {
+		t.Errorf("Expected second-best match granite-3.1-8b-base (duplicates and other families excluded), got %q", second.Name)
+	}
+	if bestScore != 1.0 || secondScore >= bestScore {
+		t.Errorf("Unexpected scores: best=%f, second=%f", bestScore, secondScore)
+	}
+
+	_, second, _, secondScore = findBestHuggingFaceMatch("registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5", hfModels[:1])
+	if second.Name != "" || secondScore != 0 {
+		t.Errorf("Expected no second-best match for a single candidate, got %q (%f)", second.Name, secondScore)
+	}
+}
+
+func TestHFNameMatchesRegistryModel(t *testing.T) {
+	regModel := "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"
+	threshold := DefaultMatchOptions().Threshold
+
+	tests := []struct {
+		name     string
+		hfID     string
+		expected bool
+	}{
+		{name: "fetched ID is the matched model", hfID: "RedHatAI/granite-3.1-8b-instruct", expected: true},
+		{name: "fetched ID is an unrelated sibling", hfID: "ibm-granite/granite-guardian-hap-38m", expected: false},
+		{name: "fetched ID is another model family", hfID: "meta-llama/Llama-3.1-8B-Instruct", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hfNameMatchesRegistryModel(regModel, tt.hfID, threshold); got != tt.expected {
+				t.Errorf("hfNameMatchesRegistryModel(%q) = %v, want %v (similarity %.2f)",
+					tt.hfID, got, tt.expected, utils.CalculateSimilarity(regModel, tt.hfID))
+			}
+		})
+	}
+}
+
+func TestMappedHuggingFaceModel(t *testing.T) {
+	SetHFMapping(map[string]string{
+		"registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5": "https://huggingface.co/RedHatAI/granite-3.1-8b-base",
+		"quay.io/example/custom:1.0":                                      "example/custom-model",
+		"https://huggingface.co/RedHatAI/Llama-3.1-8B-Instruct":           "RedHatAI/Llama-3.1-8B-Instruct-FP8",
+	})
+	t.Cleanup(func() { SetHFMapping(nil) })
+
+	entries := []types.ModelEntry{
+		{Type: types.ModelEntryTypeOCI, URI: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"},
+		{Type: types.ModelEntryTypeHF, URI: "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct"},
+		{Type: types.ModelEntryTypeHF, URI: "https://huggingface.co/RedHatAI/Llama-3.1-8B-Instruct"},
+		{Type: types.ModelEntryTypeOCI, URI: "registry.redhat.io/rhelai1/modelcar-unmapped:1.5"},
+	}
+	hfModels := []types.ModelIndex{
+		{Name: "RedHatAI/granite-3.1-8b-instruct", URL: "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct", ReadmePath: "/RedHatAI/granite-3.1-8b-instruct/README.md"},
+		{Name: "RedHatAI/granite-3.1-8b-base", URL: "https://huggingface.co/RedHatAI/granite-3.1-8b-base", ReadmePath: "/RedHatAI/granite-3.1-8b-base/README.md"},
+	}
+	mappings := explicitHFMappings(entries)
+
+	tests := []struct {
+		name       string
+		regModel   string
+		wantMapped bool
+		wantName   string
+		wantURL    string
+	}{
+		{
+			name:       "mapping wins over a closer fuzzy match",
+			regModel:   "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+			wantMapped: true,
+			wantName:   "RedHatAI/granite-3.1-8b-base",
+			wantURL:    "https://huggingface.co/RedHatAI/granite-3.1-8b-base",
+		},
+		{
+			name:       "mapped model missing from the HuggingFace index",
+			regModel:   "quay.io/example/custom:1.0",
+			wantMapped: true,
+			wantName:   "example/custom-model",
+			wantURL:    "https://huggingface.co/example/custom-model",
+		},
+		{
+			name:       "hf index entry pairs with its own URI",
+			regModel:   "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct",
+			wantMapped: true,
+			wantName:   "RedHatAI/granite-3.1-8b-instruct",
+			wantURL:    "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:       "--hf-mapping takes precedence over an hf entry URI",
+			regModel:   "https://huggingface.co/RedHatAI/Llama-3.1-8B-Instruct",
+			wantMapped: true,
+			wantName:   "RedHatAI/Llama-3.1-8B-Instruct-FP8",
+			wantURL:    "https://huggingface.co/RedHatAI/Llama-3.1-8B-Instruct-FP8",
+		},
+		{
+			name:       "unmapped model falls back to fuzzy matching",
+			regModel:   "registry.redhat.io/rhelai1/modelcar-unmapped:1.5",
+			wantMapped: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mapped := mappedHuggingFaceModel(tt.regModel, mappings, hfModels)
+			if mapped != tt.wantMapped {
+				t.Fatalf("mappedHuggingFaceModel() mapped = %v, want %v", mapped, tt.wantMapped)
+			}
+			if got.Name != tt.wantName || got.URL != tt.wantURL {
+				t.Errorf("mappedHuggingFaceModel() = %+v, want name %q url %q", got, tt.wantName, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestUpdateModelMetadataFile_AmbiguousMatchKeepsExistingValues(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	existingName := "Granite 3.1 8B Base"
+	existingLicense := "apache-2.0"
+	existing := types.ExtractedMetadata{
+		Name:    &existingName,
+		License: &existingLicense,
+		Tasks:   []string{"text-generation"},
+	}
+	data, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatalf("Failed to marshal existing metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:    registryModel,
+		EnrichmentStatus: "enriched",
+		MatchConfidence:  "low",
+		Name:             types.MetadataSource{Value: "Granite 3.1 8B Instruct", Source: "huggingface.yaml"},
+		Provider:         types.MetadataSource{Value: "IBM", Source: "huggingface.yaml"},
+		License:          types.MetadataSource{Value: "mit", Source: "huggingface.yaml"},
+		Description:      types.MetadataSource{Source: "null"},
+		LicenseLink:      types.MetadataSource{Source: "null"},
+		Tasks:            types.MetadataSource{Value: []string{"text-generation", "chat"}, Source: "huggingface.yaml"},
+	}
+
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read updated metadata: %v", err)
+	}
+	var result types.ExtractedMetadata
+	if err := yaml.Unmarshal(updated, &result); err != nil {
+		t.Fatalf("Failed to parse updated metadata: %v", err)
+	}
+
+	if result.Name == nil || *result.Name != existingName {
+		t.Errorf("Expected name to stay %q, got %v", existingName, result.Name)
+	}
+	if result.License == nil || *result.License != existingLicense {
+		t.Errorf("Expected license to stay %q, got %v", existingLicense, result.License)
+	}
+	if len(result.Tasks) != 1 || result.Tasks[0] != "text-generation" {
+		t.Errorf("Expected tasks to stay [text-generation], got %v", result.Tasks)
+	}
+	// Missing values can still be filled in
+	if result.Provider == nil || *result.Provider != "IBM" {
+		t.Errorf("Expected missing provider to be filled with IBM, got %v", result.Provider)
+	}
+}
+
+func TestUpdateModelMetadataFile_PreservesIndexLabelTags(t *testing.T) {
+	tests := []struct {
+		name            string
+		matchConfidence string
+		expected        []string
+	}{
+		{
+			name:            "confident match merges HuggingFace tags after labels",
+			matchConfidence: "high",
+			expected:        []string{"validated", "featured", "granite", "text-generation"},
+		},
+		{
+			name:            "ambiguous match keeps labels untouched",
+			matchConfidence: "low",
+			expected:        []string{"validated", "featured"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+
+			// Tags seeded from models-index labels during extraction
+			data, err := yaml.Marshal(types.ExtractedMetadata{Tags: []string{"validated", "featured"}})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  tt.matchConfidence,
+				Name:             types.MetadataSource{Source: "null"},
+				Provider:         types.MetadataSource{Source: "null"},
+				License:          types.MetadataSource{Source: "null"},
+				Description:      types.MetadataSource{Source: "null"},
+				LicenseLink:      types.MetadataSource{Source: "null"},
+				Tags:             types.MetadataSource{Value: []string{"granite", "validated", "text-generation"}, Source: "huggingface.yaml"},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if !reflect.DeepEqual(result.Tags, tt.expected) {
+				t.Errorf("Tags = %v, want %v", result.Tags, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateModelMetadataFile_DeniedTagsAreDropped(t *testing.T) {
+	huggingface.SetTagFilter(nil, []string{"autotrain", "endpoints_compatible"})
+	t.Cleanup(func() { huggingface.SetTagFilter(nil, nil) })
+
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	// A tag written by an earlier run is dropped as well
+	data, err := yaml.Marshal(types.ExtractedMetadata{Tags: []string{"validated", "endpoints_compatible"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal existing metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:    registryModel,
+		EnrichmentStatus: "enriched",
+		MatchConfidence:  "high",
+		Name:             types.MetadataSource{Source: "null"},
+		Provider:         types.MetadataSource{Source: "null"},
+		License:          types.MetadataSource{Source: "null"},
+		Description:      types.MetadataSource{Source: "null"},
+		LicenseLink:      types.MetadataSource{Source: "null"},
+		Tags:             types.MetadataSource{Value: []string{"granite", "autotrain"}, Source: "huggingface.yaml"},
+	}
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	result, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to load updated metadata: %v", err)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"validated", "granite"}) {
+		t.Errorf("Tags = %v, want [validated granite]", result.Tags)
+	}
+}
+
+func TestUpdateModelMetadataFile_InfersTasksFromArchitecture(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	// Architectures come from an extracted config.json; the modelcard gave no tasks
+	data, err := yaml.Marshal(types.ExtractedMetadata{Architectures: []string{"LlamaForCausalLM"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal existing metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:    registryModel,
+		EnrichmentStatus: "not_found",
+		Name:             types.MetadataSource{Source: "null"},
+		Provider:         types.MetadataSource{Source: "null"},
+		License:          types.MetadataSource{Source: "null"},
+		Description:      types.MetadataSource{Source: "null"},
+		LicenseLink:      types.MetadataSource{Source: "null"},
+		Tags:             types.MetadataSource{Source: "null"},
+		Tasks:            types.MetadataSource{Source: "null"},
+	}
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read updated metadata: %v", err)
+	}
+	var result types.ExtractedMetadata
+	if err := yaml.Unmarshal(updated, &result); err != nil {
+		t.Fatalf("Failed to parse updated metadata: %v", err)
+	}
+	if !reflect.DeepEqual(result.Tasks, []string{"text-generation"}) {
+		t.Errorf("Tasks = %v, want [text-generation]", result.Tasks)
+	}
+
+	enrichment, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read enrichment.yaml: %v", err)
+	}
+	if !strings.Contains(string(enrichment), "tasks: config.inferred") {
+		t.Errorf("Expected tasks source config.inferred in enrichment.yaml, got:\n%s", enrichment)
+	}
+}
+
+func TestApplyDescriptionOverrides(t *testing.T) {
+	t.Cleanup(func() { SetDescriptionOverrides(nil) })
+
+	tests := []struct {
+		name            string
+		overrides       map[string]string
+		enriched        bool
+		wantDescription string
+		wantSource      string
+	}{
+		{
+			name:            "override by registry reference beats huggingface yaml",
+			overrides:       map[string]string{"registry.example.com/test/model:latest": "Curated by reference."},
+			enriched:        true,
+			wantDescription: "Curated by reference.",
+			wantSource:      "description: override",
+		},
+		{
+			name:            "override by model name is case-insensitive",
+			overrides:       map[string]string{" granite test model ": "Curated by name."},
+			enriched:        true,
+			wantDescription: "Curated by name.",
+			wantSource:      "description: override",
+		},
+		{
+			name:            "override by huggingface model ID",
+			overrides:       map[string]string{"ibm-granite/granite-test": "Curated by HuggingFace ID."},
+			enriched:        true,
+			wantDescription: "Curated by HuggingFace ID.",
+			wantSource:      "description: override",
+		},
+		{
+			name:            "unmatched overrides keep the enriched description",
+			overrides:       map[string]string{"other-model": "Not this one."},
+			enriched:        true,
+			wantDescription: "From HuggingFace.",
+			wantSource:      "description: huggingface.yaml",
+		},
+		{
+			name:            "model without a huggingface match",
+			overrides:       map[string]string{"registry.example.com/test/model:latest": "Curated by reference."},
+			wantDescription: "Curated by reference.",
+			wantSource:      "description: override",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDescriptionOverrides(tt.overrides)
+
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			existingName := "Granite Test Model"
+			existingDescription := "From the modelcard."
+			data, err := yaml.Marshal(types.ExtractedMetadata{Name: &existingName, Description: &existingDescription})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+			if err := utils.WriteManifestRef(filepath.Dir(modelDir), registryModel); err != nil {
+				t.Fatalf("Failed to record manifest ref: %v", err)
+			}
+
+			if tt.enriched {
+				enrichedData := &types.EnrichedModelMetadata{
+					RegistryModel:    registryModel,
+					HuggingFaceModel: "ibm-granite/granite-test",
+					EnrichmentStatus: "enriched",
+					MatchConfidence:  "high",
+					Name:             types.MetadataSource{Source: "null"},
+					Provider:         types.MetadataSource{Source: "null"},
+					License:          types.MetadataSource{Source: "null"},
+					Description:      types.MetadataSource{Value: "From HuggingFace.", Source: "huggingface.yaml"},
+					LicenseLink:      types.MetadataSource{Source: "null"},
+					Tags:             types.MetadataSource{Source: "null"},
+				}
+				if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+					t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+				}
+			}
+
+			dirs := []string{utils.SanitizeManifestRef(registryModel), "missing-model"}
+			if err := ApplyDescriptionOverrides(tmpDir, dirs); err != nil {
+				t.Fatalf("ApplyDescriptionOverrides failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if result.Description == nil || *result.Description != tt.wantDescription {
+				t.Errorf("Description = %v, want %q", result.Description, tt.wantDescription)
+			}
+			if result.Name == nil || *result.Name != existingName {
+				t.Errorf("Name = %v, want %q", result.Name, existingName)
+			}
+
+			enrichment, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read enrichment.yaml: %v", err)
+			}
+			if !strings.Contains(string(enrichment), tt.wantSource) {
+				t.Errorf("Expected %q in enrichment.yaml, got:\n%s", tt.wantSource, enrichment)
+			}
+			if tt.enriched && !strings.Contains(string(enrichment), "huggingface_model: ibm-granite/granite-test") {
+				t.Errorf("Expected the rest of enrichment.yaml to be kept, got:\n%s", enrichment)
+			}
+		})
+	}
+}
+
+func TestSetModelSelection(t *testing.T) {
+	t.Cleanup(func() { SetModelSelection(nil) })
+	entries := []types.ModelEntry{
+		{Type: "oci", URI: "registry.example.com/org/granite-8b:1.0"},
+		{Type: "hf", URI: "https://huggingface.co/org/llama-8b"},
+	}
+
+	if got := selectModelEntries(entries); !reflect.DeepEqual(got, entries) {
+		t.Errorf("selectModelEntries() without a selection = %v, want every entry", got)
+	}
+	SetModelSelection([]string{"registry.example.com/org/granite-8b:1.0"})
+	if got := selectModelEntries(entries); !reflect.DeepEqual(got, entries[:1]) {
+		t.Errorf("selectModelEntries() = %v, want only the selected model", got)
+	}
+}
+
+// roundTripFunc serves HuggingFace API requests from a test function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestEnrichMetadataFromHuggingFace_HFEntryHasNoArtifacts(t *testing.T) {
+	t.Chdir(t.TempDir())
+	huggingface.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"id": "example-org/example-model", "author": "example-org", "tags": ["license:apache-2.0"]}`
+		if strings.HasSuffix(req.URL.Path, "/README.md") {
+			body = "---\nlicense: apache-2.0\n---\n# Example Model\n"
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})})
+	t.Cleanup(func() { huggingface.SetHTTPClient(&http.Client{Timeout: 30 * time.Second}) })
+
+	hfRef := "https://huggingface.co/example-org/example-model"
+	hfData, err := yaml.Marshal(types.VersionIndex{Version: "v1.0", Models: []types.ModelIndex{}})
+	if err != nil {
+		t.Fatalf("Failed to marshal HF index: %v", err)
+	}
+	if err := os.WriteFile("hf-index.yaml", hfData, 0644); err != nil {
+		t.Fatalf("Failed to write HF index: %v", err)
+	}
+	modelsData, err := yaml.Marshal(types.ModelsConfig{Models: []types.ModelEntry{{Type: types.ModelEntryTypeHF, URI: hfRef}}})
+	if err != nil {
+		t.Fatalf("Failed to marshal models config: %v", err)
+	}
+	if err := os.WriteFile("models-index.yaml", modelsData, 0644); err != nil {
+		t.Fatalf("Failed to write models index: %v", err)
+	}
+	modelDir := filepath.Join("output", utils.SanitizeManifestRef(hfRef), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	name := "example-model"
+	if err := metadata.WriteMetadataFile(filepath.Join(modelDir, "metadata.yaml"), &types.ExtractedMetadata{Name: &name}); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	if err := EnrichMetadataFromHuggingFace("hf-index.yaml", "models-index.yaml", "output", "", DefaultMatchOptions()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(logs.String(), "OCI artifacts") {
+		t.Errorf("Expected no OCI artifact update for a HuggingFace-hosted model, got logs:\n%s", logs.String())
+	}
+
+	enriched, err := metadata.LoadExistingMetadata(hfRef, "output")
+	if err != nil {
+		t.Fatalf("Failed to load enriched metadata: %v", err)
+	}
+	if enriched.License == nil || *enriched.License != "apache-2.0" {
+		t.Errorf("Expected the hf entry to be enriched with its license, got %v", enriched.License)
+	}
+	if len(enriched.Artifacts) != 0 {
+		t.Errorf("Expected no OCI artifacts for a HuggingFace-hosted model, got %+v", enriched.Artifacts)
+	}
+}
+
+func TestSetMaxConcurrent(t *testing.T) {
+	t.Cleanup(func() { maxConcurrent = DefaultMaxConcurrent })
+
+	if err := SetMaxConcurrent(0); err == nil {
+		t.Error("Expected error for zero concurrency")
+	}
+	if err := SetMaxConcurrent(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maxConcurrent != 3 {
+		t.Errorf("maxConcurrent = %d, want 3", maxConcurrent)
+	}
+}
+
+func TestEnrichMetadataFromHuggingFace_MoreModelsThanWorkers(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { maxConcurrent = DefaultMaxConcurrent })
+	if err := SetMaxConcurrent(2); err != nil {
+		t.Fatalf("SetMaxConcurrent failed: %v", err)
+	}
+
+	hfData, err := yaml.Marshal(types.VersionIndex{Version: "v1.0", Models: []types.ModelIndex{}})
+	if err != nil {
+		t.Fatalf("Failed to marshal HF index: %v", err)
+	}
+	if err := os.WriteFile("hf-index.yaml", hfData, 0644); err != nil {
+		t.Fatalf("Failed to write HF index: %v", err)
+	}
+
+	var entries []types.ModelEntry
+	for i := 0; i < 7; i++ {
+		entries = append(entries, types.ModelEntry{Type: "oci", URI: fmt.Sprintf("registry.example.com/org/model-%d:1.0", i)})
+	}
+	modelsData, err := yaml.Marshal(types.ModelsConfig{Models: entries})
+	if err != nil {
+		t.Fatalf("Failed to marshal models config: %v", err)
+	}
+	if err := os.WriteFile("models-index.yaml", modelsData, 0644); err != nil {
+		t.Fatalf("Failed to write models index: %v", err)
+	}
+
+	if err := EnrichMetadataFromHuggingFace("hf-index.yaml", "models-index.yaml", "output", "", DefaultMatchOptions()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestUpdateModelMetadataFile_RecordsSourcesForKeptFields(t *testing.T) {
+	outputDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	modelcard := "---\nmaturity: Generally Available\nbase_model: ibm-granite/granite-3.1-8b-base\n---\n# Model\n"
+	if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte(modelcard), 0644); err != nil {
+		t.Fatalf("Failed to write modelcard: %v", err)
+	}
+
+	name := "Existing Model"
+	license := "apache-2.0"
+	licenseLink := "https://www.apache.org/licenses/LICENSE-2.0"
+	readme := "# Model"
+	maturity := "Generally Available"
+	modelSize := "8B"
+	existing := types.ExtractedMetadata{
+		Name:        &name,
+		License:     &license,
+		LicenseLink: &licenseLink,
+		Readme:      &readme,
+		Maturity:    &maturity,
+		ModelSize:   &modelSize,
+		// Tags from the models config labels; validated tasks kept from an earlier enrichment run
+		Tags:           []string{"featured"},
+		ValidatedTasks: []string{"text-generation"},
+		BaseModel:      []string{"ibm-granite/granite-3.1-8b-base"},
+		Artifacts:      []types.OCIArtifact{{URI: "oci://registry.example.com/test/model:latest"}},
+	}
+	data, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	null := types.MetadataSource{Source: "null"}
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:        registryModel,
+		EnrichmentStatus:     "no_match",
+		Name:                 types.MetadataSource{Value: name, Source: "modelcard.yaml"},
+		Provider:             null,
+		Description:          null,
+		License:              types.MetadataSource{Value: license, Source: "modelcard.regex"},
+		LicenseLink:          null,
+		Language:             null,
+		Tags:                 null,
+		Tasks:                null,
+		LastModified:         null,
+		CreateTimeSinceEpoch: null,
+		ModelSize:            null,
+		ValidatedOn:          null,
+		HardwareTag:          null,
+		ValidatedTasks:       null,
+		BaseModel:            null,
+	}
+
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, outputDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	enrichmentData, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read enrichment.yaml: %v", err)
+	}
+	var enrichment struct {
+		DataSources map[string]string `yaml:"data_sources"`
+	}
+	if err := yaml.Unmarshal(enrichmentData, &enrichment); err != nil {
+		t.Fatalf("Failed to parse enrichment.yaml: %v", err)
+	}
+
+	expected := map[string]string{
+		"name":         "modelcard.yaml",
+		"license":      "modelcard.regex",
+		"license_link": "modelcard.regex",
+		"readme":       "modelcard.md",
+		"maturity":     "modelcard.yaml",
+		"model_size":   "modelcard.regex",
+		"artifacts":    "registry",
+		// Generated from the model name, as the modelcard has no description
+		"description":     "generated",
+		"tags":            "config",
+		"validated_tasks": "unknown",
+		"base_model":      "modelcard.yaml",
+	}
+	for field, want := range expected {
+		if got := enrichment.DataSources[field]; got != want {
+			t.Errorf("data_sources.%s = %q, want %q", field, got, want)
+		}
+	}
+	if _, ok := enrichment.DataSources["provider"]; ok {
+		t.Errorf("Expected no source for unpopulated provider, got %q", enrichment.DataSources["provider"])
+	}
+}
+
+func TestUpdateModelMetadataFile_RecordsSourceOfWinningValue(t *testing.T) {
+	outputDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	modelcard := "---\nname: Granite 3.1 8B Instruct\nprovider: IBM\n---\n# Model\nLicense: Apache 2.0\n"
+	if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte(modelcard), 0644); err != nil {
+		t.Fatalf("Failed to write modelcard: %v", err)
+	}
+	name := "Granite 3.1 8B Instruct"
+	provider := "IBM"
+	license := "apache-2.0"
+	existing := types.ExtractedMetadata{
+		Name:     &name,
+		Provider: &provider,
+		License:  &license,
+		Tags:     []string{"featured"},
+	}
+	data, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	// Every enriched value has a real source, but only the description and tasks outrank or fill
+	// the modelcard values
+	null := types.MetadataSource{Source: "null"}
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:        registryModel,
+		EnrichmentStatus:     "enriched",
+		MatchConfidence:      "medium",
+		HuggingFaceModel:     "ibm-granite/granite-3.1-8b-instruct",
+		Name:                 types.MetadataSource{Value: "ibm-granite/granite-3.1-8b-instruct", Source: "huggingface.api"},
+		Provider:             types.MetadataSource{Value: "ibm-granite", Source: "huggingface.regex"},
+		Description:          types.MetadataSource{Value: "An instruct model", Source: "huggingface.yaml"},
+		License:              types.MetadataSource{Value: "mit", Source: "huggingface.api"},
+		LicenseLink:          null,
+		Language:             null,
+		Tags:                 null,
+		Tasks:                types.MetadataSource{Value: []string{"text-generation"}, Source: "huggingface.tags"},
+		LastModified:         null,
+		CreateTimeSinceEpoch: null,
+		ModelSize:            null,
+		ValidatedOn:          null,
+		HardwareTag:          null,
+		ValidatedTasks:       null,
+		BaseModel:            null,
+	}
+
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, outputDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	updated, err := metadata.LoadExistingMetadata(registryModel, outputDir)
+	if err != nil {
+		t.Fatalf("Failed to load updated metadata: %v", err)
+	}
+	if *updated.Name != name || *updated.Provider != provider || *updated.License != license {
+		t.Errorf("Expected modelcard values to be kept, got name %q provider %q license %q", *updated.Name, *updated.Provider, *updated.License)
+	}
+
+	enrichmentData, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read enrichment.yaml: %v", err)
+	}
+	var enrichment struct {
+		DataSources map[string]string `yaml:"data_sources"`
+	}
+	if err := yaml.Unmarshal(enrichmentData, &enrichment); err != nil {
+		t.Fatalf("Failed to parse enrichment.yaml: %v", err)
+	}
+	expected := map[string]string{
+		"name":        "modelcard.yaml",
+		"provider":    "modelcard.yaml",
+		"license":     "modelcard.regex",
+		"description": "huggingface.yaml",
+		"tasks":       "huggingface.tags",
+		"tags":        "config",
+	}
+	for field, want := range expected {
+		if got := enrichment.DataSources[field]; got != want {
+			t.Errorf("data_sources.%s = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestEnrichRegistryModel_UnknownOriginsStayNull(t *testing.T) {
+	outputDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte("---\nname: Test Model\n---\n# Model\n"), 0644); err != nil {
+		t.Fatalf("Failed to write modelcard: %v", err)
+	}
+	name := "Test Model"
+	created := int64(1736937000000)
+	existing := types.ExtractedMetadata{
+		Name:                     &name,
+		Tags:                     []string{"validated"},
+		CreateTimeSinceEpoch:     &created,
+		LastUpdateTimeSinceEpoch: &created,
+	}
+	data, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	enriched, _ := enrichRegistryModel(registryModel, false, nil, nil, nil, outputDir, DefaultMatchOptions())
+	if enriched.Name.Source != "modelcard.yaml" {
+		t.Errorf("Name source = %q, want modelcard.yaml", enriched.Name.Source)
+	}
+	for field, source := range map[string]string{
+		"tags":                 enriched.Tags.Source,
+		"lastModified":         enriched.LastModified.Source,
+		"createTimeSinceEpoch": enriched.CreateTimeSinceEpoch.Source,
+	} {
+		if source != "null" {
+			t.Errorf("%s source = %q, want null for a value of unknown origin", field, source)
+		}
+	}
+}
+
+func TestEnrichMetadataFromHuggingFace_AggregateFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	hfData, err := yaml.Marshal(types.VersionIndex{Version: "v1.0", Models: []types.ModelIndex{}})
+	if err != nil {
+		t.Fatalf("Failed to marshal HF index: %v", err)
+	}
+	if err := os.WriteFile("hf-index.yaml", hfData, 0644); err != nil {
+		t.Fatalf("Failed to write HF index: %v", err)
+	}
+	modelsData, err := yaml.Marshal(types.ModelsConfig{Models: []types.ModelEntry{
+		{Type: "oci", URI: "registry.example.com/org/model-a:1.0"},
+		{Type: "oci", URI: "registry.example.com/org/model-b:1.0"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to marshal models config: %v", err)
+	}
+	if err := os.WriteFile("models-index.yaml", modelsData, 0644); err != nil {
+		t.Fatalf("Failed to write models index: %v", err)
+	}
+
+	// By default a stale aggregate file is removed
+	if err := os.MkdirAll(filepath.Dir(AggregateEnrichmentPath), 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+	if err := os.WriteFile(AggregateEnrichmentPath, []byte("stale: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write stale aggregate file: %v", err)
+	}
+	if err := EnrichMetadataFromHuggingFace("hf-index.yaml", "models-index.yaml", "output", "", DefaultMatchOptions()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(AggregateEnrichmentPath); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted by default", AggregateEnrichmentPath)
+	}
+
+	SetWriteAggregate(true)
+	t.Cleanup(func() { SetWriteAggregate(false) })
+	if err := EnrichMetadataFromHuggingFace("hf-index.yaml", "models-index.yaml", "output", "", DefaultMatchOptions()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(AggregateEnrichmentPath)
+	if err != nil {
+		t.Fatalf("Failed to read aggregate file: %v", err)
+	}
+	var aggregate map[string]types.EnrichedModelMetadata
+	if err := yaml.Unmarshal(data, &aggregate); err != nil {
+		t.Fatalf("Failed to parse aggregate file: %v", err)
+	}
+	if len(aggregate) != 2 {
+		t.Fatalf("Expected 2 models in aggregate file, got %d", len(aggregate))
+	}
+	entry := aggregate["registry.example.com/org/model-a:1.0"]
+	if entry.EnrichmentStatus != "no_match" || entry.Name.Source != "null" {
+		t.Errorf("Unexpected aggregate entry: %+v", entry)
+	}
+}
+
+func TestUpdateOCIArtifacts_UnparseableReferenceKeepsMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "invalid/Model"
+	modelDir := filepath.Join(tmpDir, "invalid_Model", "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	metadataPath := filepath.Join(modelDir, "metadata.yaml")
+	original := []byte("artifacts:\n  - uri: oci://registry.example.invalid/test/model:1.0\n")
+	if err := os.WriteFile(metadataPath, original, 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	if err := UpdateOCIArtifacts(registryModel, tmpDir); err == nil {
+		t.Fatal("Expected error for unparseable registry reference")
+	}
+
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		t.Fatalf("Failed to read metadata: %v", err)
+	}
+	if string(data) != string(original) {
+		t.Errorf("Metadata was rewritten:\n%s", data)
+	}
+}
+
+func TestUpdateModelMetadataFile_PreferModelcardName(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefer       bool
+		priority     []string
+		existingName string
+		nameSource   string
+		expected     string
+	}{
+		{
+			name:         "default keeps a modelcard name over a lower-priority source",
+			existingName: "Granite 3.1 8B Instruct (Red Hat)",
+			nameSource:   "huggingface.api",
+			expected:     "Granite 3.1 8B Instruct (Red Hat)",
+		},
+		{
+			name:         "default takes a higher-priority HuggingFace YAML name",
+			existingName: "Granite 3.1 8B Instruct (Red Hat)",
+			nameSource:   "huggingface.yaml",
+			expected:     "RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:         "priority listing the API above the modelcard overrides",
+			priority:     []string{"huggingface.api", "modelcard.regex"},
+			existingName: "Granite 3.1 8B Instruct (Red Hat)",
+			nameSource:   "huggingface.api",
+			expected:     "RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:         "default replaces a document title",
+			existingName: "Granite Model Card",
+			nameSource:   "huggingface.api",
+			expected:     "RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:         "prefer keeps a good modelcard name",
+			prefer:       true,
+			existingName: "Granite 3.1 8B Instruct (Red Hat)",
+			nameSource:   "huggingface.api",
+			expected:     "Granite 3.1 8B Instruct (Red Hat)",
+		},
+		{
+			name:         "prefer keeps a good modelcard name over HuggingFace YAML",
+			prefer:       true,
+			existingName: "Granite 3.1 8B Instruct (Red Hat)",
+			nameSource:   "huggingface.yaml",
+			expected:     "Granite 3.1 8B Instruct (Red Hat)",
+		},
+		{
+			name:         "prefer replaces a document title",
+			prefer:       true,
+			existingName: "Granite Model Card",
+			nameSource:   "huggingface.api",
+			expected:     "RedHatAI/granite-3.1-8b-instruct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPreferModelcardName(tt.prefer)
+			t.Cleanup(func() { SetPreferModelcardName(false) })
+			if err := SetSourcePriority(tt.priority); err != nil {
+				t.Fatalf("SetSourcePriority failed: %v", err)
+			}
+			t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			data, err := yaml.Marshal(types.ExtractedMetadata{Name: &tt.existingName})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  "high",
+				Name:             types.MetadataSource{Value: "RedHatAI/granite-3.1-8b-instruct", Source: tt.nameSource},
+				Provider:         types.MetadataSource{Source: "null"},
+				License:          types.MetadataSource{Source: "null"},
+				Description:      types.MetadataSource{Source: "null"},
+				LicenseLink:      types.MetadataSource{Source: "null"},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if result.Name == nil || *result.Name != tt.expected {
+				t.Errorf("Expected name %q, got %v", tt.expected, result.Name)
+			}
+		})
+	}
+}
+
+func TestUpdateModelMetadataFile_PreservesManualFieldsAndComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	existing := `# Reviewed by the model team
+name: Granite 3.1 8B Instruct (Red Hat)
+provider: null
+reviewNotes: keep pinned to 1.5 # manual annotation
+license: null
+artifacts: []
+`
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write existing metadata: %v", err)
+	}
+
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:    registryModel,
+		EnrichmentStatus: "enriched",
+		MatchConfidence:  "high",
+		Name:             types.MetadataSource{Source: "null"},
+		Provider:         types.MetadataSource{Value: "IBM", Source: "huggingface.yaml"},
+		License:          types.MetadataSource{Source: "null"},
+		Description:      types.MetadataSource{Source: "null"},
+		LicenseLink:      types.MetadataSource{Source: "null"},
+	}
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read updated metadata: %v", err)
+	}
+	content := string(updated)
+	for _, expected := range []string{"# Reviewed by the model team", "reviewNotes: keep pinned to 1.5 # manual annotation", "provider: IBM"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected updated metadata to contain %q, got:\n%s", expected, content)
+		}
+	}
+	if strings.Index(content, "name:") > strings.Index(content, "reviewNotes:") {
+		t.Errorf("Expected existing field order to be kept, got:\n%s", content)
+	}
+
+	var result types.ExtractedMetadata
+	if err := yaml.Unmarshal(updated, &result); err != nil {
+		t.Fatalf("Failed to parse updated metadata: %v", err)
+	}
+	if result.Name == nil || *result.Name != "Granite 3.1 8B Instruct (Red Hat)" {
+		t.Errorf("Expected name to be kept, got %v", result.Name)
+	}
+}
+
+func TestUpdateModelMetadataFile_Popularity(t *testing.T) {
+	tmpDir := t.TempDir()
+	registryModel := "registry.example.com/test/model:latest"
+	if err := os.MkdirAll(filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models"), 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:    registryModel,
+		EnrichmentStatus: "enriched",
+		MatchConfidence:  "high",
+		Name:             types.MetadataSource{Source: "null"},
+		Provider:         types.MetadataSource{Source: "null"},
+		License:          types.MetadataSource{Source: "null"},
+		Description:      types.MetadataSource{Source: "null"},
+		LicenseLink:      types.MetadataSource{Source: "null"},
+		Downloads:        types.MetadataSource{Value: 125000, Source: "huggingface.api"},
+		Likes:            types.MetadataSource{Value: float64(42), Source: "huggingface.api"},
+	}
+	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	updated, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to load updated metadata: %v", err)
+	}
+	if updated.Downloads == nil || *updated.Downloads != 125000 {
+		t.Errorf("Expected downloads 125000, got %v", updated.Downloads)
+	}
+	if updated.Likes == nil || *updated.Likes != 42 {
+		t.Errorf("Expected likes 42, got %v", updated.Likes)
+	}
+}
+
+func TestApplyCreatedAtFallback(t *testing.T) {
+	createdAt := time.Date(2024, 5, 14, 12, 30, 0, 0, time.UTC)
+	readmeEpoch := int64(1700000000000)
+
+	tests := []struct {
+		name       string
+		current    types.MetadataSource
+		details    *types.HFModelDetails
+		wantApply  bool
+		wantValue  int64
+		wantSource string
+	}{
+		{
+			name:       "missing create time uses createdAt",
+			current:    types.MetadataSource{Source: "null"},
+			details:    &types.HFModelDetails{CreatedAt: createdAt},
+			wantApply:  true,
+			wantValue:  createdAt.UnixMilli(),
+			wantSource: "huggingface.api",
+		},
+		{
+			name:       "existing release date is kept",
+			current:    types.MetadataSource{Value: readmeEpoch, Source: "huggingface.regex"},
+			details:    &types.HFModelDetails{CreatedAt: createdAt},
+			wantValue:  readmeEpoch,
+			wantSource: "huggingface.regex",
+		},
+		{
+			name:       "zero createdAt is ignored",
+			current:    types.MetadataSource{Source: "null"},
+			details:    &types.HFModelDetails{},
+			wantSource: "null",
+		},
+		{
+			name:       "no details",
+			current:    types.MetadataSource{Source: "null"},
+			wantSource: "null",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enriched := types.EnrichedModelMetadata{CreateTimeSinceEpoch: tt.current}
+			if got := applyCreatedAtFallback(&enriched, tt.details); got != tt.wantApply {
+				t.Errorf("applyCreatedAtFallback() = %v, want %v", got, tt.wantApply)
+			}
+			if enriched.CreateTimeSinceEpoch.Source != tt.wantSource {
+				t.Errorf("Expected source %q, got %q", tt.wantSource, enriched.CreateTimeSinceEpoch.Source)
+			}
+			if tt.wantValue != 0 {
+				if value, ok := enriched.CreateTimeSinceEpoch.Value.(int64); !ok || value != tt.wantValue {
+					t.Errorf("Expected createTimeSinceEpoch %d, got %v", tt.wantValue, enriched.CreateTimeSinceEpoch.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateModelMetadataFile_LicenseConflict(t *testing.T) {
+	tests := []struct {
+		name           string
+		hfLicense      string
+		hfSource       string
+		expectConflict bool
+	}{
+		{name: "different licenses conflict", hfLicense: "llama3.1", hfSource: "huggingface.yaml", expectConflict: true},
+		{name: "tag license conflicts too", hfLicense: "mit", hfSource: "huggingface.tags", expectConflict: true},
+		{name: "same license spelled differently", hfLicense: "Apache 2.0", hfSource: "huggingface.yaml"},
+		{name: "modelcard source is not a conflict", hfLicense: "mit", hfSource: "modelcard.regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			existingName := "Test Model"
+			existingLicense := "Apache-2.0"
+			data, err := yaml.Marshal(types.ExtractedMetadata{Name: &existingName, License: &existingLicense})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  "high",
+				Name:             types.MetadataSource{Source: "null"},
+				Provider:         types.MetadataSource{Source: "null"},
+				Description:      types.MetadataSource{Source: "null"},
+				License:          types.MetadataSource{Value: tt.hfLicense, Source: tt.hfSource},
+				LicenseLink:      types.MetadataSource{Source: "null"},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read enrichment.yaml: %v", err)
+			}
+			var enrichment struct {
+				LicenseConflict    bool   `yaml:"license_conflict"`
+				LicenseModelcard   string `yaml:"license_modelcard"`
+				LicenseHuggingFace string `yaml:"license_huggingface"`
+			}
+			if err := yaml.Unmarshal(content, &enrichment); err != nil {
+				t.Fatalf("Failed to parse enrichment.yaml: %v", err)
+			}
+			if enrichment.LicenseConflict != tt.expectConflict {
+				t.Fatalf("Expected license_conflict %v, got %v", tt.expectConflict, enrichment.LicenseConflict)
+			}
+			if tt.expectConflict && (enrichment.LicenseModelcard != existingLicense || enrichment.LicenseHuggingFace != tt.hfLicense) {
+				t.Errorf("Expected licenses %q and %q recorded, got %q and %q",
+					existingLicense, tt.hfLicense, enrichment.LicenseModelcard, enrichment.LicenseHuggingFace)
+			}
+		})
+	}
+}
+
+func TestSetMinEnrichmentRate(t *testing.T) {
+	t.Cleanup(func() { minEnrichmentRate = 0 })
+
+	for _, invalid := range []float64{-1, 100.5} {
+		if err := SetMinEnrichmentRate(invalid); err == nil {
+			t.Errorf("Expected error for minimum enrichment rate %v", invalid)
+		}
+	}
+	if err := SetMinEnrichmentRate(90); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if minEnrichmentRate != 90 {
+		t.Errorf("minEnrichmentRate = %v, want 90", minEnrichmentRate)
+	}
+}
+
+func TestEnrichMetadataFromHuggingFace_MinEnrichmentRate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { minEnrichmentRate = 0 })
+
+	hfData, err := yaml.Marshal(types.VersionIndex{Version: "v1.0", Models: []types.ModelIndex{}})
+	if err != nil {
+		t.Fatalf("Failed to marshal HF index: %v", err)
+	}
+	if err := os.WriteFile("hf-index.yaml", hfData, 0644); err != nil {
+		t.Fatalf("Failed to write HF index: %v", err)
+	}
+	modelsData, err := yaml.Marshal(types.ModelsConfig{Models: []types.ModelEntry{
+		{Type: "oci", URI: "registry.example.com/org/model-a:1.0"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to marshal models config: %v", err)
+	}
+	if err := os.WriteFile("models-index.yaml", modelsData, 0644); err != nil {
+		t.Fatalf("Failed to write models index: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		minRate float64
+		wantErr bool
+	}{
+		{name: "default never fails", minRate: 0},
+		{name: "unmatched models below minimum fail", minRate: 50, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetMinEnrichmentRate(tt.minRate); err != nil {
+				t.Fatalf("SetMinEnrichmentRate failed: %v", err)
+			}
+			err := EnrichMetadataFromHuggingFace("hf-index.yaml", "models-index.yaml", "output", "", DefaultMatchOptions())
+			if tt.wantErr {
+				if !errors.Is(err, ErrEnrichmentRateTooLow) {
+					t.Errorf("Expected ErrEnrichmentRateTooLow, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
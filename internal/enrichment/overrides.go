@@ -0,0 +1,157 @@
+package enrichment
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// descriptionOverrides holds curated descriptions keyed by lowercase registry reference or model
+// name (--description-overrides). It is set once at startup, before enrichment workers start.
+var descriptionOverrides map[string]string
+
+// SetDescriptionOverrides sets curated model descriptions, keyed by registry reference or model
+// name, that replace every extracted, enriched or generated description
+func SetDescriptionOverrides(overrides map[string]string) {
+	descriptionOverrides = make(map[string]string, len(overrides))
+	for model, description := range overrides {
+		model = strings.ToLower(strings.TrimSpace(model))
+		description = strings.TrimSpace(description)
+		if model == "" || description == "" {
+			continue
+		}
+		descriptionOverrides[model] = description
+	}
+}
+
+// descriptionOverride returns the curated description for a model, looked up by its registry
+// reference, then its metadata name, then its HuggingFace model ID
+func descriptionOverride(registryModel string, name *string, huggingFaceModel string) (string, bool) {
+	keys := []string{registryModel, huggingFaceModel}
+	if name != nil {
+		keys = []string{registryModel, *name, huggingFaceModel}
+	}
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if description, ok := descriptionOverrides[key]; ok {
+			return description, true
+		}
+	}
+	return "", false
+}
+
+// ApplyDescriptionOverrides writes the curated description (--description-overrides) of each model
+// output directory under outputDir into its metadata.yaml and records "override" as the
+// description source in its enrichment.yaml. It runs as its own step after enrichment, so
+// overrides apply whatever the enrichment outcome: without a HuggingFace match, with
+// --skip-enrichment and with --catalog-only. Models without metadata.yaml are skipped.
+func ApplyDescriptionOverrides(outputDir string, modelDirs []string) error {
+	if len(descriptionOverrides) == 0 {
+		return nil
+	}
+
+	for _, modelDir := range modelDirs {
+		modelsDir := filepath.Join(outputDir, modelDir, "models")
+		metadataPath := filepath.Join(modelsDir, "metadata.yaml")
+		if _, err := os.Stat(metadataPath); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(metadataPath)
+		if err != nil {
+			log.Printf("  Warning: Could not read metadata for description override of %s: %v", modelDir, err)
+			continue
+		}
+		var existing types.ExtractedMetadata
+		if err := yaml.Unmarshal(data, &existing); err != nil {
+			log.Printf("  Warning: Could not parse metadata for description override of %s: %v", modelDir, err)
+			continue
+		}
+		// Directory names cannot be turned back into refs; the ref is recorded next to the models
+		registryModel, _ := utils.ReadManifestRef(filepath.Join(outputDir, modelDir))
+
+		enrichmentPath := filepath.Join(modelsDir, "enrichment.yaml")
+		var enriched struct {
+			HuggingFaceModel string `yaml:"huggingface_model"`
+		}
+		if data, err := os.ReadFile(enrichmentPath); err == nil {
+			_ = yaml.Unmarshal(data, &enriched)
+		}
+
+		description, ok := descriptionOverride(registryModel, existing.Name, enriched.HuggingFaceModel)
+		if !ok {
+			continue
+		}
+		if existing.Description == nil || *existing.Description != description {
+			existing.Description = &description
+			if err := metadata.UpdateMetadataFile(metadataPath, &existing); err != nil {
+				return fmt.Errorf("failed to write description override for %s: %v", modelDir, err)
+			}
+		}
+		if err := recordOverrideSource(enrichmentPath); err != nil {
+			return fmt.Errorf("failed to record description override for %s: %v", modelDir, err)
+		}
+		log.Printf("  Applied description override for: %s", modelDir)
+	}
+	return nil
+}
+
+// recordOverrideSource sets data_sources.description to "override" in an enrichment.yaml,
+// keeping the rest of the file and creating it for models that were not enriched
+func recordOverrideSource(enrichmentPath string) error {
+	var doc yaml.Node
+	if data, err := os.ReadFile(enrichmentPath); err == nil {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse %s: %v", enrichmentPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	sources := mappingValue(doc.Content[0], "data_sources")
+	if sources == nil || sources.Kind != yaml.MappingNode {
+		sources = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(doc.Content[0], "data_sources", sources)
+	}
+	setMappingValue(sources, "description", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "override"})
+
+	data, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal enrichment data: %v", err)
+	}
+	return os.WriteFile(enrichmentPath, data, 0644)
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil when it is absent
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a YAML mapping node, appending the key when absent
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
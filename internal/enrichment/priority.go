@@ -0,0 +1,112 @@
+package enrichment
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// DefaultSourcePriority is the built-in enrichment source order, highest priority first:
+// HuggingFace README frontmatter overrides modelcard data, which in turn wins over values
+// from the HuggingFace API, repository tags and README text
+var DefaultSourcePriority = []string{
+	"huggingface.yaml",
+	"modelcard.yaml",
+	"modelcard.regex",
+	"huggingface.api",
+	"huggingface.tags",
+	"huggingface.regex",
+	"generated",
+}
+
+// knownSources are the data sources a priority list may name
+var knownSources = []string{
+	"huggingface.yaml",
+	"huggingface.api",
+	"huggingface.tags",
+	"huggingface.regex",
+	"huggingface.base_model",
+	"modelcard.yaml",
+	"modelcard.regex",
+	"generated",
+}
+
+// sourcePriority is the active source order (--source-priority); it never aliases
+// DefaultSourcePriority or a caller's slice, so neither can change the policy afterwards
+var sourcePriority = slices.Clone(DefaultSourcePriority)
+
+// SetSourcePriority sets the order in which enrichment sources win, highest priority first.
+// Sources left out rank below every listed one; an empty list restores DefaultSourcePriority.
+func SetSourcePriority(sources []string) error {
+	if len(sources) == 0 {
+		sourcePriority = slices.Clone(DefaultSourcePriority)
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		if !slices.Contains(knownSources, source) {
+			return fmt.Errorf("unknown source %q (valid: %s)", source, strings.Join(knownSources, ", "))
+		}
+		if seen[source] {
+			return fmt.Errorf("source %q listed more than once", source)
+		}
+		seen[source] = true
+	}
+	sourcePriority = slices.Clone(sources)
+	return nil
+}
+
+// sourceRank returns the position of source in the active priority list; lower ranks win and
+// unlisted sources share the lowest rank
+func sourceRank(source string) int {
+	if i := slices.Index(sourcePriority, source); i >= 0 {
+		return i
+	}
+	return len(sourcePriority)
+}
+
+// prefersSource reports whether a candidate value from candidateSource should replace the
+// enriched value currently held, which it does when none is held or the candidate's source
+// ranks at least as high
+func prefersSource(currentSource, candidateSource string) bool {
+	return currentSource == "null" || sourceRank(candidateSource) <= sourceRank(currentSource)
+}
+
+// overridesExisting reports whether an enriched value from source replaces a value already in
+// metadata.yaml whose source is existingSource. Missing values are always filled in; existing
+// ones only give way to a strictly higher-priority source, and never to an ambiguous match.
+func overridesExisting(hasExisting bool, existingSource, source string, ambiguousMatch bool) bool {
+	if !hasExisting {
+		return true
+	}
+	return !ambiguousMatch && sourceRank(source) < sourceRank(existingSource)
+}
+
+// isPlaceholderLicense reports whether license is the HuggingFace "other" value, which only says
+// the license is not a standard one and so carries no license of its own
+func isPlaceholderLicense(license string) bool {
+	return strings.EqualFold(strings.TrimSpace(license), "other")
+}
+
+// prefersLicense is prefersSource for license candidates: whatever the source order, a concrete
+// license replaces an "other" placeholder and a placeholder never replaces a concrete license
+func prefersLicense(current types.MetadataSource, candidate, candidateSource string) bool {
+	if current.Source != "null" {
+		currentLicense, _ := current.Value.(string)
+		if isPlaceholderLicense(currentLicense) != isPlaceholderLicense(candidate) {
+			return isPlaceholderLicense(currentLicense)
+		}
+	}
+	return prefersSource(current.Source, candidateSource)
+}
+
+// overridesExistingLicense is overridesExisting for licenses, applying the placeholder rules of
+// prefersLicense to the license already in metadata.yaml. An ambiguous match still never overrides.
+func overridesExistingLicense(existing *string, existingSource, license, source string, ambiguousMatch bool) bool {
+	if existing != nil && !ambiguousMatch && isPlaceholderLicense(*existing) != isPlaceholderLicense(license) {
+		return isPlaceholderLicense(*existing)
+	}
+	return overridesExisting(existing != nil, existingSource, source, ambiguousMatch)
+}
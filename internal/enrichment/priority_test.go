@@ -0,0 +1,425 @@
+package enrichment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestSetSourcePriority(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	tests := []struct {
+		name    string
+		sources []string
+		wantErr bool
+	}{
+		{name: "empty restores default", sources: nil},
+		{name: "reordered", sources: []string{"modelcard.yaml", "huggingface.yaml", "modelcard.regex"}},
+		{name: "unknown source", sources: []string{"huggingface.yaml", "wikipedia"}, wantErr: true},
+		{name: "duplicate source", sources: []string{"huggingface.yaml", "huggingface.yaml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetSourcePriority(tt.sources)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetSourcePriority(%v) error = %v, wantErr %v", tt.sources, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSourcePriorityRanking(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	tests := []struct {
+		name           string
+		priority       []string
+		existingSource string
+		source         string
+		hasExisting    bool
+		ambiguous      bool
+		wantOverride   bool
+	}{
+		{name: "missing value is always filled", source: "huggingface.tags", existingSource: "modelcard.yaml", wantOverride: true},
+		{name: "default: huggingface yaml beats modelcard", source: "huggingface.yaml", existingSource: "modelcard.yaml", hasExisting: true, wantOverride: true},
+		{name: "default: modelcard beats huggingface api", source: "huggingface.api", existingSource: "modelcard.regex", hasExisting: true},
+		{name: "ambiguous match never overrides", source: "huggingface.yaml", existingSource: "modelcard.regex", hasExisting: true, ambiguous: true},
+		{name: "same source does not override", source: "modelcard.yaml", existingSource: "modelcard.yaml", hasExisting: true},
+		{name: "unknown source ranks last", source: "huggingface.readme", existingSource: "generated", hasExisting: true},
+		{
+			name:           "custom: modelcard yaml first",
+			priority:       []string{"modelcard.yaml", "huggingface.yaml"},
+			source:         "huggingface.yaml",
+			existingSource: "modelcard.yaml",
+			hasExisting:    true,
+		},
+		{
+			name:           "custom: api above modelcard text",
+			priority:       []string{"huggingface.api", "modelcard.regex"},
+			source:         "huggingface.api",
+			existingSource: "modelcard.regex",
+			hasExisting:    true,
+			wantOverride:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetSourcePriority(tt.priority); err != nil {
+				t.Fatalf("SetSourcePriority failed: %v", err)
+			}
+			if got := overridesExisting(tt.hasExisting, tt.existingSource, tt.source, tt.ambiguous); got != tt.wantOverride {
+				t.Errorf("overridesExisting(%v, %q, %q, %v) = %v, want %v",
+					tt.hasExisting, tt.existingSource, tt.source, tt.ambiguous, got, tt.wantOverride)
+			}
+		})
+	}
+}
+
+func TestPrefersSource(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	if !prefersSource("null", "huggingface.tags") {
+		t.Error("Expected any source to replace a missing value")
+	}
+	if !prefersSource("huggingface.yaml", "huggingface.yaml") {
+		t.Error("Expected a later value from the same source to replace an earlier one")
+	}
+	if prefersSource("modelcard.regex", "huggingface.api") {
+		t.Error("Expected modelcard data to win over the HuggingFace API by default")
+	}
+
+	if err := SetSourcePriority([]string{"modelcard.yaml", "huggingface.yaml"}); err != nil {
+		t.Fatalf("SetSourcePriority failed: %v", err)
+	}
+	if prefersSource("modelcard.yaml", "huggingface.yaml") {
+		t.Error("Expected modelcard YAML to win when listed first")
+	}
+}
+
+func TestSetSourcePriority_CopiesSlices(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	// Writing into DefaultSourcePriority or a slice passed in must not change the active order
+	if err := SetSourcePriority(nil); err != nil {
+		t.Fatalf("SetSourcePriority failed: %v", err)
+	}
+	original := DefaultSourcePriority[0]
+	DefaultSourcePriority[0] = "generated"
+	t.Cleanup(func() { DefaultSourcePriority[0] = original })
+	if sourceRank("huggingface.yaml") != 0 {
+		t.Errorf("Editing DefaultSourcePriority changed the active priority: rank %d", sourceRank("huggingface.yaml"))
+	}
+
+	sources := []string{"modelcard.yaml", "huggingface.yaml"}
+	if err := SetSourcePriority(sources); err != nil {
+		t.Fatalf("SetSourcePriority failed: %v", err)
+	}
+	sources[0] = "generated"
+	if sourceRank("modelcard.yaml") != 0 {
+		t.Errorf("Editing the slice passed to SetSourcePriority changed the active priority: rank %d", sourceRank("modelcard.yaml"))
+	}
+}
+
+func TestUpdateModelMetadataFile_SourcePriority(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	tests := []struct {
+		name        string
+		priority    []string
+		modelcard   string
+		source      string
+		wantLicense string
+	}{
+		{name: "default priority lets huggingface yaml win", modelcard: "---\nlicense: apache-2.0\n---\n# Model\n", source: "huggingface.yaml", wantLicense: "mit"},
+		{name: "modelcard yaml listed first wins", priority: []string{"modelcard.yaml", "huggingface.yaml"}, modelcard: "---\nlicense: apache-2.0\n---\n# Model\n", source: "huggingface.yaml", wantLicense: "apache-2.0"},
+		{name: "default priority keeps modelcard over api", modelcard: "# Model\n", source: "huggingface.api", wantLicense: "apache-2.0"},
+		{name: "api listed above modelcard text wins", priority: []string{"huggingface.api", "modelcard.regex"}, modelcard: "# Model\n", source: "huggingface.api", wantLicense: "mit"},
+		{name: "frontmatter without a license ranks the license as modelcard text", priority: []string{"modelcard.yaml", "huggingface.yaml", "modelcard.regex"}, modelcard: "---\nname: Test Model\n---\n# Model\n", source: "huggingface.yaml", wantLicense: "mit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetSourcePriority(tt.priority); err != nil {
+				t.Fatalf("SetSourcePriority failed: %v", err)
+			}
+
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte(tt.modelcard), 0644); err != nil {
+				t.Fatalf("Failed to write modelcard: %v", err)
+			}
+
+			existingName := "Test Model"
+			existingLicense := "apache-2.0"
+			data, err := yaml.Marshal(types.ExtractedMetadata{Name: &existingName, License: &existingLicense})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  "medium",
+				Name:             types.MetadataSource{Source: "null"},
+				Provider:         types.MetadataSource{Source: "null"},
+				Description:      types.MetadataSource{Source: "null"},
+				License:          types.MetadataSource{Value: "mit", Source: tt.source},
+				LicenseLink:      types.MetadataSource{Source: "null"},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if result.License == nil || *result.License != tt.wantLicense {
+				t.Errorf("Expected license %q, got %v", tt.wantLicense, result.License)
+			}
+		})
+	}
+}
+
+func TestUpdateModelMetadataFile_SourcePriorityTagsAndBaseModel(t *testing.T) {
+	t.Cleanup(func() { _ = SetSourcePriority(nil) })
+
+	const frontmatterCard = "---\ntags: [granite]\nbase_model: ibm-granite/granite-3.1-8b-base\n---\n# Model\n"
+	tests := []struct {
+		name          string
+		priority      []string
+		confidence    string
+		modelcard     string
+		existingTags  []string
+		existingBase  []string
+		enrichedTags  string // Source of the enriched tags ["chat"]
+		enrichedBase  string // Source of the enriched base model
+		wantTags      []string
+		wantBaseModel []string
+	}{
+		{
+			name:       "label tags take any source; a higher-priority base model replaces the modelcard's",
+			confidence: "medium", modelcard: "---\nbase_model: ibm-granite/granite-3.1-8b-base\n---\n# Model\n",
+			existingTags: []string{"featured"}, existingBase: []string{"ibm-granite/granite-3.1-8b-base"},
+			enrichedTags: "huggingface.tags", enrichedBase: "huggingface.yaml",
+			wantTags: []string{"featured", "chat"}, wantBaseModel: []string{"other/base"},
+		},
+		{
+			name:     "frontmatter tags and base model outrank lower-priority sources",
+			priority: []string{"modelcard.yaml", "huggingface.yaml"}, confidence: "medium", modelcard: frontmatterCard,
+			existingTags: []string{"granite"}, existingBase: []string{"ibm-granite/granite-3.1-8b-base"},
+			enrichedTags: "huggingface.tags", enrichedBase: "huggingface.yaml",
+			wantTags: []string{"granite"}, wantBaseModel: []string{"ibm-granite/granite-3.1-8b-base"},
+		},
+		{
+			name:     "repository tags listed above the modelcard are merged",
+			priority: []string{"huggingface.tags", "modelcard.yaml"}, confidence: "medium", modelcard: frontmatterCard,
+			existingTags: []string{"granite"}, enrichedTags: "huggingface.tags", enrichedBase: "huggingface.yaml",
+			wantTags: []string{"granite", "chat"}, wantBaseModel: []string{"other/base"},
+		},
+		{
+			name:       "ambiguous match never overrides the base model",
+			confidence: "low", modelcard: frontmatterCard,
+			existingTags: []string{"granite"}, existingBase: []string{"ibm-granite/granite-3.1-8b-base"},
+			enrichedTags: "huggingface.yaml", enrichedBase: "huggingface.yaml",
+			wantTags: []string{"granite"}, wantBaseModel: []string{"ibm-granite/granite-3.1-8b-base"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetSourcePriority(tt.priority); err != nil {
+				t.Fatalf("SetSourcePriority failed: %v", err)
+			}
+
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte(tt.modelcard), 0644); err != nil {
+				t.Fatalf("Failed to write modelcard: %v", err)
+			}
+			existingName := "Test Model"
+			data, err := yaml.Marshal(types.ExtractedMetadata{Name: &existingName, Tags: tt.existingTags, BaseModel: tt.existingBase})
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			null := types.MetadataSource{Source: "null"}
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  tt.confidence,
+				Name:             null,
+				Provider:         null,
+				Description:      null,
+				License:          null,
+				LicenseLink:      null,
+				Tags:             types.MetadataSource{Value: []string{"chat"}, Source: tt.enrichedTags},
+				BaseModel:        types.MetadataSource{Value: []string{"other/base"}, Source: tt.enrichedBase},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if !reflect.DeepEqual(result.Tags, tt.wantTags) {
+				t.Errorf("Tags = %v, want %v", result.Tags, tt.wantTags)
+			}
+			if !reflect.DeepEqual(result.BaseModel, tt.wantBaseModel) {
+				t.Errorf("BaseModel = %v, want %v", result.BaseModel, tt.wantBaseModel)
+			}
+		})
+	}
+}
+
+func TestModelcardSource(t *testing.T) {
+	frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard("---\nname: Test Model\npipeline_tag: text-generation\nmaturity: someday\n---\n# Model\n")
+	if err != nil {
+		t.Fatalf("Failed to parse frontmatter: %v", err)
+	}
+	tests := []struct {
+		name        string
+		frontmatter *metadata.ModelCardYAMLFrontmatter
+		field       frontmatterField
+		want        string
+	}{
+		{name: "field set in frontmatter", frontmatter: frontmatter, field: frontmatterHasName, want: "modelcard.yaml"},
+		{name: "tasks from pipeline_tag", frontmatter: frontmatter, field: frontmatterHasTasks, want: "modelcard.yaml"},
+		{name: "field missing from frontmatter", frontmatter: frontmatter, field: frontmatterHasLicense, want: "modelcard.regex"},
+		{name: "unrecognized maturity", frontmatter: frontmatter, field: frontmatterHasMaturity, want: "modelcard.regex"},
+		{name: "field never in frontmatter", frontmatter: frontmatter, field: nil, want: "modelcard.regex"},
+		{name: "no frontmatter", field: frontmatterHasName, want: "modelcard.regex"},
+	}
+	for _, tt := range tests {
+		if got := modelcardSource(tt.frontmatter, tt.field); got != tt.want {
+			t.Errorf("%s: modelcardSource() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrefersLicense(t *testing.T) {
+	// Candidates in the order enrichment offers them: API, repository tags, then README frontmatter
+	license := types.MetadataSource{Source: "null"}
+	for _, candidate := range []types.MetadataSource{
+		{Value: "other", Source: "huggingface.api"},
+		{Value: "llama3.1", Source: "huggingface.tags"},
+		{Value: "other", Source: "huggingface.yaml"},
+	} {
+		if prefersLicense(license, candidate.Value.(string), candidate.Source) {
+			license = candidate
+		}
+	}
+	if license.Value != "llama3.1" || license.Source != "huggingface.tags" {
+		t.Errorf("Expected llama3.1 from huggingface.tags, got %v from %s", license.Value, license.Source)
+	}
+
+	if !prefersLicense(types.MetadataSource{Value: "mit", Source: "huggingface.tags"}, "apache-2.0", "huggingface.yaml") {
+		t.Error("Expected concrete licenses to keep following the source priority")
+	}
+}
+
+func TestUpdateModelMetadataFile_OtherLicense(t *testing.T) {
+	tests := []struct {
+		name            string
+		existingLicense string
+		enrichedLicense string
+		wantLicense     string
+	}{
+		{name: "yaml other falls back to tag license", enrichedLicense: "other", wantLicense: "llama3.1"},
+		{name: "modelcard other is replaced by tag license", existingLicense: "other", enrichedLicense: "other", wantLicense: "llama3.1"},
+		{name: "modelcard other is replaced by api license", existingLicense: "other", enrichedLicense: "mit", wantLicense: "mit"},
+		{name: "yaml other keeps concrete modelcard license", existingLicense: "apache-2.0", enrichedLicense: "other", wantLicense: "apache-2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			registryModel := "registry.example.com/test/model:latest"
+			modelDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(registryModel), "models")
+			if err := os.MkdirAll(modelDir, 0755); err != nil {
+				t.Fatalf("Failed to create output directory: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "modelcard.md"), []byte("# Model\n"), 0644); err != nil {
+				t.Fatalf("Failed to write modelcard: %v", err)
+			}
+
+			existingName := "Test Model"
+			existing := types.ExtractedMetadata{Name: &existingName}
+			if tt.existingLicense != "" {
+				existing.License = &tt.existingLicense
+			}
+			data, err := yaml.Marshal(existing)
+			if err != nil {
+				t.Fatalf("Failed to marshal existing metadata: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+				t.Fatalf("Failed to write existing metadata: %v", err)
+			}
+
+			source := "huggingface.yaml"
+			if tt.enrichedLicense != "other" {
+				source = "huggingface.api"
+			}
+			enrichedData := &types.EnrichedModelMetadata{
+				RegistryModel:    registryModel,
+				EnrichmentStatus: "enriched",
+				MatchConfidence:  "high",
+				Name:             types.MetadataSource{Source: "null"},
+				Provider:         types.MetadataSource{Source: "null"},
+				Description:      types.MetadataSource{Source: "null"},
+				License:          types.MetadataSource{Value: tt.enrichedLicense, Source: source},
+				LicenseLink:      types.MetadataSource{Source: "null"},
+				Tags:             types.MetadataSource{Value: []string{"license:llama3.1"}, Source: "huggingface.tags"},
+			}
+			if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+				t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+			}
+
+			updated, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+			if err != nil {
+				t.Fatalf("Failed to read updated metadata: %v", err)
+			}
+			var result types.ExtractedMetadata
+			if err := yaml.Unmarshal(updated, &result); err != nil {
+				t.Fatalf("Failed to parse updated metadata: %v", err)
+			}
+			if result.License == nil || *result.License != tt.wantLicense {
+				t.Errorf("Expected license %q, got %v", tt.wantLicense, result.License)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestToolCallingIntegration_WithToolCalling(t *testing.T) {
@@ -48,7 +49,7 @@ func TestToolCallingIntegration_WithToolCalling(t *testing.T) {
 	}
 
 	// Create modelcard.md in expected location
-	sanitizedName := "registry.redhat.io_rhai_modelcar-mistral-7b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -144,7 +145,7 @@ func TestToolCallingIntegration_WithoutToolCalling(t *testing.T) {
 	}
 
 	// Create modelcard.md in expected location
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-3b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -229,7 +230,7 @@ func TestToolCallingIntegration_EmptyConfig(t *testing.T) {
 	}
 
 	// Create modelcard.md
-	sanitizedName := "registry.redhat.io_rhai_modelcar-test_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -310,7 +311,7 @@ func TestToolCallingIntegration_WithoutValidatedOn(t *testing.T) {
 	}
 
 	// Create modelcard.md
-	sanitizedName := "registry.redhat.io_rhai_modelcar-qwen-7b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -405,7 +406,7 @@ func TestToolCallingIntegration_WithValidatedTasks(t *testing.T) {
 		ValidatedTasks:       metadata.CreateMetadataSource([]string{"tool-calling"}, "huggingface.yaml"),
 	}
 
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-4-0_3.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -3,7 +3,9 @@ package enrichment
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -32,6 +34,16 @@ func normalizeAndDedup(raw []string) []string {
 	return result
 }
 
+// preferModelcardName keeps modelcard names unless they are empty or low quality (--prefer-modelcard-name)
+var preferModelcardName bool
+
+// SetPreferModelcardName controls whether a modelcard name is treated as authoritative: when
+// enabled, HuggingFace data only replaces names that are empty or look like a document title,
+// whatever the name source ranks in the source priority
+func SetPreferModelcardName(enabled bool) {
+	preferModelcardName = enabled
+}
+
 // isLowQualityModelName checks if a name appears to be a document title,
 // code comment, or other non-model name that should be overridden.
 // Returns true if the name is low quality and should be replaced.
@@ -92,7 +104,11 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		HuggingFaceModel string `yaml:"huggingface_model,omitempty"`
 		HuggingFaceURL   string `yaml:"huggingface_url,omitempty"`
 		MatchConfidence  string `yaml:"match_confidence,omitempty"`
-		DataSources      struct {
+		// Set when the modelcard and HuggingFace licenses disagree, for a human to resolve
+		LicenseConflict    bool   `yaml:"license_conflict,omitempty"`
+		LicenseModelcard   string `yaml:"license_modelcard,omitempty"`
+		LicenseHuggingFace string `yaml:"license_huggingface,omitempty"`
+		DataSources        struct {
 			Name                 string `yaml:"name,omitempty"`
 			Provider             string `yaml:"provider,omitempty"`
 			Description          string `yaml:"description,omitempty"`
@@ -106,7 +122,13 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 			ValidatedOn          string `yaml:"validated_on,omitempty"`
 			HardwareTag          string `yaml:"hardware_tag,omitempty"`
 			ValidatedTasks       string `yaml:"validated_tasks,omitempty"`
+			BaseModel            string `yaml:"base_model,omitempty"`
 			Readme               string `yaml:"readme,omitempty"`
+			Maturity             string `yaml:"maturity,omitempty"`
+			ModelSize            string `yaml:"model_size,omitempty"`
+			Downloads            string `yaml:"downloads,omitempty"`
+			Likes                string `yaml:"likes,omitempty"`
+			Artifacts            string `yaml:"artifacts,omitempty"`
 		} `yaml:"data_sources"`
 	}{}
 
@@ -115,25 +137,35 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	enrichmentInfo.HuggingFaceURL = enrichedData.HuggingFaceURL
 	enrichmentInfo.MatchConfidence = enrichedData.MatchConfidence
 
+	// An ambiguous ("low" confidence) match may only fill in missing values; it never
+	// overrides existing modelcard data, even when the value came from HuggingFace YAML
+	ambiguousMatch := enrichedData.MatchConfidence == "low"
+	if ambiguousMatch {
+		log.Printf("  Ambiguous HuggingFace match for %s, keeping existing modelcard values", registryModel)
+	}
+
+	// Existing values are modelcard data; enriched values replace them only when their source
+	// ranks higher in the source priority (--source-priority). The modelcard is parsed once and
+	// each field is ranked by whether its own value came from frontmatter or text.
+	frontmatter := modelcardFrontmatter(outputDir, sanitizedName)
+
 	// Update metadata with enriched values and track sources in enrichment file
 	if enrichedData.Name.Source != "null" {
-		// Always override with HuggingFace YAML data (highest priority)
-		// For other sources, use confidence-based logic
-		shouldOverrideName := existingMetadata.Name == nil || enrichedData.Name.Source == "huggingface.yaml"
-
-		if !shouldOverrideName && existingMetadata.Name != nil {
-			// Override based on HuggingFace match confidence for non-YAML sources
-			switch enrichedData.MatchConfidence {
-			case "high":
-				shouldOverrideName = true
-				log.Printf("  Overriding model name '%s' with high-confidence HuggingFace data", *existingMetadata.Name)
-			case "medium":
-				// For medium confidence, override if the existing name looks like a document title or code comment
-				if isLowQualityModelName(*existingMetadata.Name) {
-					shouldOverrideName = true
-					log.Printf("  Overriding poor quality model name '%s' with medium-confidence HuggingFace data", *existingMetadata.Name)
-				}
+		// Higher-priority sources override the modelcard name; for other sources, use confidence-based logic
+		shouldOverrideName := overridesExisting(existingMetadata.Name != nil, modelcardSource(frontmatter, frontmatterHasName), enrichedData.Name.Source, ambiguousMatch)
+
+		if preferModelcardName && existingMetadata.Name != nil {
+			// The modelcard name is authoritative; only replace it when it is empty or looks like a
+			// document title. Ambiguous matches still never override it.
+			shouldOverrideName = !ambiguousMatch && isLowQualityModelName(*existingMetadata.Name)
+			if shouldOverrideName {
+				log.Printf("  Overriding poor quality model name '%s' with HuggingFace data", *existingMetadata.Name)
 			}
+		} else if !shouldOverrideName && existingMetadata.Name != nil && !ambiguousMatch && isLowQualityModelName(*existingMetadata.Name) {
+			// A name that looks like a document title or code comment is no name at all, so any
+			// unambiguous match replaces it whatever the source priority
+			shouldOverrideName = true
+			log.Printf("  Overriding poor quality model name '%s' with HuggingFace data", *existingMetadata.Name)
 		}
 
 		if shouldOverrideName {
@@ -144,54 +176,59 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 					log.Printf("  Updated model name to: %s (source: %s)", nameStr, enrichedData.Name.Source)
 				}
 			}
-		} else {
-			enrichmentInfo.DataSources.Name = enrichedData.Name.Source
 		}
 	}
 
 	if enrichedData.Provider.Source != "null" {
-		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.Provider == nil || enrichedData.Provider.Source == "huggingface.yaml"
+		shouldOverride := overridesExisting(existingMetadata.Provider != nil, modelcardSource(frontmatter, frontmatterHasProvider), enrichedData.Provider.Source, ambiguousMatch)
 		if shouldOverride {
-			providerStr := enrichedData.Provider.Value.(string)
+			providerStr := utils.NormalizeProvider(enrichedData.Provider.Value.(string))
 			existingMetadata.Provider = &providerStr
+			enrichmentInfo.DataSources.Provider = enrichedData.Provider.Source
 		}
-		enrichmentInfo.DataSources.Provider = enrichedData.Provider.Source
 	}
 
 	if enrichedData.Description.Source != "null" {
-		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.Description == nil || enrichedData.Description.Source == "huggingface.yaml"
+		shouldOverride := overridesExisting(existingMetadata.Description != nil, modelcardSource(frontmatter, frontmatterHasDescription), enrichedData.Description.Source, ambiguousMatch)
 		if shouldOverride {
 			descStr := enrichedData.Description.Value.(string)
 			existingMetadata.Description = &descStr
+			enrichmentInfo.DataSources.Description = enrichedData.Description.Source
+		}
+	}
+
+	// Record a modelcard license that disagrees with HuggingFace before either one is picked
+	if existingMetadata.License != nil && strings.HasPrefix(enrichedData.License.Source, "huggingface") {
+		if hfLicense, ok := enrichedData.License.Value.(string); ok && licensesConflict(*existingMetadata.License, hfLicense) {
+			enrichmentInfo.LicenseConflict = true
+			enrichmentInfo.LicenseModelcard = *existingMetadata.License
+			enrichmentInfo.LicenseHuggingFace = hfLicense
+			log.Printf("  Warning: License conflict for %s: modelcard says %q, HuggingFace (%s) says %q",
+				registryModel, *existingMetadata.License, enrichedData.License.Source, hfLicense)
 		}
-		enrichmentInfo.DataSources.Description = enrichedData.Description.Source
 	}
 
 	if enrichedData.License.Source != "null" {
-		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.License == nil || enrichedData.License.Source == "huggingface.yaml"
+		licenseStr, _ := enrichedData.License.Value.(string)
+		shouldOverride := overridesExistingLicense(existingMetadata.License, modelcardSource(frontmatter, frontmatterHasLicense), licenseStr, enrichedData.License.Source, ambiguousMatch)
 		if shouldOverride {
-			licenseStr := enrichedData.License.Value.(string)
 			existingMetadata.License = &licenseStr
+			enrichmentInfo.DataSources.License = enrichedData.License.Source
 			// Automatically set license link if we have a well-known license
 			if licenseURL := utils.GetLicenseURL(licenseStr); licenseURL != "" {
 				existingMetadata.LicenseLink = &licenseURL
 				enrichmentInfo.DataSources.LicenseLink = "generated"
 			}
 		}
-		enrichmentInfo.DataSources.License = enrichedData.License.Source
 	}
 
 	if enrichedData.LicenseLink.Source != "null" {
-		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.LicenseLink == nil || enrichedData.LicenseLink.Source == "huggingface.yaml"
+		shouldOverride := overridesExisting(existingMetadata.LicenseLink != nil, modelcardSource(frontmatter, frontmatterHasLicenseLink), enrichedData.LicenseLink.Source, ambiguousMatch)
 		if shouldOverride {
 			licenseLinkStr := enrichedData.LicenseLink.Value.(string)
 			existingMetadata.LicenseLink = &licenseLinkStr
+			enrichmentInfo.DataSources.LicenseLink = enrichedData.LicenseLink.Source
 		}
-		enrichmentInfo.DataSources.LicenseLink = enrichedData.LicenseLink.Source
 	}
 
 	// Handle license from tags
@@ -199,7 +236,8 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		tags, ok := enrichedData.Tags.Value.([]string)
 		if ok {
 			_, tagLicense, _ := huggingface.ParseTagsForStructuredData(tags)
-			if tagLicense != "" && existingMetadata.License == nil {
+			if tagLicense != "" && !isPlaceholderLicense(tagLicense) &&
+				(existingMetadata.License == nil || isPlaceholderLicense(*existingMetadata.License)) {
 				existingMetadata.License = &tagLicense
 				enrichmentInfo.DataSources.License = "huggingface.tags"
 				// Automatically set license link if we have a well-known license
@@ -211,23 +249,33 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		}
 	}
 
+	// HuggingFace popularity counts change between runs, so they are refreshed unless the match is ambiguous
+	if count, ok := intValue(enrichedData.Downloads); ok && (existingMetadata.Downloads == nil || !ambiguousMatch) {
+		existingMetadata.Downloads = &count
+		enrichmentInfo.DataSources.Downloads = enrichedData.Downloads.Source
+	}
+	if count, ok := intValue(enrichedData.Likes); ok && (existingMetadata.Likes == nil || !ambiguousMatch) {
+		existingMetadata.Likes = &count
+		enrichmentInfo.DataSources.Likes = enrichedData.Likes.Source
+	}
+
 	// Handle languages from enriched Language field
 	if enrichedData.Language.Source != "null" && enrichedData.Language.Value != nil {
 		if languages, ok := enrichedData.Language.Value.([]string); ok && len(languages) > 0 {
-			// Always override with enriched language data (highest priority sources)
-			shouldOverride := len(existingMetadata.Language) == 0 || enrichedData.Language.Source == "huggingface.yaml"
+			shouldOverride := overridesExisting(len(existingMetadata.Language) > 0, modelcardSource(frontmatter, frontmatterHasLanguage), enrichedData.Language.Source, ambiguousMatch)
 			if shouldOverride {
 				existingMetadata.Language = languages
+				enrichmentInfo.DataSources.Language = enrichedData.Language.Source
 			}
-			enrichmentInfo.DataSources.Language = enrichedData.Language.Source
 		}
 	}
 
 	// Handle tags from enriched Tags field
 	if enrichedData.Tags.Source != "null" && enrichedData.Tags.Value != nil {
 		if newTags, ok := enrichedData.Tags.Value.([]string); ok && len(newTags) > 0 {
-			// Always merge with existing tags to preserve "validated" and "featured" tags
-			shouldMerge := len(existingMetadata.Tags) == 0 || enrichedData.Tags.Source == "huggingface.yaml" || enrichedData.Tags.Source == "huggingface.tags"
+			// Tags are merged rather than replaced, preserving labels like "validated" and "featured",
+			// when the enriched source ranks at least as high as that of the existing tags
+			shouldMerge := len(existingMetadata.Tags) == 0 || (!ambiguousMatch && prefersSource(tagsSource(frontmatter), enrichedData.Tags.Source))
 			if shouldMerge {
 				// Preserve existing tags (like "validated", "featured") and merge with new ones
 				mergedTags := make([]string, 0)
@@ -252,10 +300,11 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 				originalTags := make([]string, len(existingMetadata.Tags))
 				copy(originalTags, existingMetadata.Tags)
 
-				existingMetadata.Tags = mergedTags
-				log.Printf("  Merged tags: existing %v + new %v = %v", originalTags, newTags, mergedTags)
+				// --deny-tags applies to the merged list, including tags from earlier runs
+				existingMetadata.Tags = huggingface.RemoveDeniedTags(mergedTags)
+				log.Printf("  Merged tags: existing %v + new %v = %v", originalTags, newTags, existingMetadata.Tags)
+				enrichmentInfo.DataSources.Tags = enrichedData.Tags.Source
 			}
-			enrichmentInfo.DataSources.Tags = enrichedData.Tags.Source
 		}
 	}
 
@@ -263,20 +312,19 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if enrichedData.Tasks.Source != "null" && enrichedData.Tasks.Value != nil {
 		tasks, ok := enrichedData.Tasks.Value.([]string)
 		if ok && len(tasks) > 0 {
-			// Always override with HuggingFace YAML tasks (highest priority)
-			shouldOverride := len(existingMetadata.Tasks) == 0 || enrichedData.Tasks.Source == "huggingface.yaml"
+			shouldOverride := overridesExisting(len(existingMetadata.Tasks) > 0, modelcardSource(frontmatter, frontmatterHasTasks), enrichedData.Tasks.Source, ambiguousMatch)
 			if shouldOverride {
-				log.Printf("  Debug: Using tasks from enrichedData.Tasks: %v", tasks)
+				slog.Debug("Using tasks from enriched data", "ref", registryModel, "source", enrichedData.Tasks.Source, "tasks", tasks)
 				existingMetadata.Tasks = tasks
+				enrichmentInfo.DataSources.Tasks = enrichedData.Tasks.Source
 			}
-			enrichmentInfo.DataSources.Tasks = enrichedData.Tasks.Source
 		}
 	} else if enrichedData.Tags.Source == "huggingface.tags" && enrichedData.Tags.Value != nil {
 		// Fallback: parse tasks from tags if tasks field is not available
 		tags, ok := enrichedData.Tags.Value.([]string)
 		if ok {
 			_, _, tasks := huggingface.ParseTagsForStructuredData(tags)
-			log.Printf("  Debug: Parsed tasks from tags: %v", tasks)
+			slog.Debug("Parsed tasks from HuggingFace tags", "ref", registryModel, "tasks", tasks)
 			if len(tasks) > 0 && len(existingMetadata.Tasks) == 0 {
 				existingMetadata.Tasks = tasks
 				enrichmentInfo.DataSources.Tasks = "huggingface.tags"
@@ -293,15 +341,32 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		}
 	}
 
+	// Last resort: the config.json architecture class implies the task (e.g. LlamaForCausalLM)
+	if len(existingMetadata.Tasks) == 0 && len(existingMetadata.Architectures) > 0 {
+		var inferredTasks []string
+		for _, arch := range existingMetadata.Architectures {
+			for _, task := range huggingface.InferTasksFromArchitecture(arch) {
+				if !slices.Contains(inferredTasks, task) {
+					inferredTasks = append(inferredTasks, task)
+				}
+			}
+		}
+		if len(inferredTasks) > 0 {
+			existingMetadata.Tasks = inferredTasks
+			enrichmentInfo.DataSources.Tasks = "config.inferred"
+			log.Printf("  Inferred tasks %v from architectures %v for: %s", inferredTasks, existingMetadata.Architectures, registryModel)
+		}
+	}
+
 	// Handle enriched ValidatedOn data from HuggingFace YAML
 	if enrichedData.ValidatedOn.Source != "null" && enrichedData.ValidatedOn.Value != nil {
 		if raw, ok := enrichedData.ValidatedOn.Value.([]string); ok && len(raw) > 0 {
 			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
-				if len(existingMetadata.ValidatedOn) == 0 || enrichedData.ValidatedOn.Source == "huggingface.yaml" {
+				if overridesExisting(len(existingMetadata.ValidatedOn) > 0, modelcardSource(frontmatter, frontmatterHasValidatedOn), enrichedData.ValidatedOn.Source, ambiguousMatch) {
 					log.Printf("  Using validated_on from enrichedData: %v", normalized)
 					existingMetadata.ValidatedOn = normalized
+					enrichmentInfo.DataSources.ValidatedOn = enrichedData.ValidatedOn.Source
 				}
-				enrichmentInfo.DataSources.ValidatedOn = enrichedData.ValidatedOn.Source
 			}
 		}
 	}
@@ -310,11 +375,11 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if enrichedData.HardwareTag.Source != "null" && enrichedData.HardwareTag.Value != nil {
 		if raw, ok := enrichedData.HardwareTag.Value.([]string); ok && len(raw) > 0 {
 			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
-				if len(existingMetadata.HardwareTag) == 0 || enrichedData.HardwareTag.Source == "huggingface.yaml" {
+				if overridesExisting(len(existingMetadata.HardwareTag) > 0, modelcardSource(frontmatter, frontmatterHasHardwareTag), enrichedData.HardwareTag.Source, ambiguousMatch) {
 					log.Printf("  Using hardware_tag from enrichedData: %v", normalized)
 					existingMetadata.HardwareTag = normalized
+					enrichmentInfo.DataSources.HardwareTag = enrichedData.HardwareTag.Source
 				}
-				enrichmentInfo.DataSources.HardwareTag = enrichedData.HardwareTag.Source
 			}
 		}
 	}
@@ -323,11 +388,23 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if enrichedData.ValidatedTasks.Source != "null" && enrichedData.ValidatedTasks.Value != nil {
 		if raw, ok := enrichedData.ValidatedTasks.Value.([]string); ok && len(raw) > 0 {
 			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
-				if len(existingMetadata.ValidatedTasks) == 0 || enrichedData.ValidatedTasks.Source == "huggingface.yaml" {
+				if overridesExisting(len(existingMetadata.ValidatedTasks) > 0, modelcardSource(frontmatter, nil), enrichedData.ValidatedTasks.Source, ambiguousMatch) {
 					log.Printf("  Using validated_tasks from enrichedData: %v", normalized)
 					existingMetadata.ValidatedTasks = normalized
+					enrichmentInfo.DataSources.ValidatedTasks = enrichedData.ValidatedTasks.Source
+				}
+			}
+		}
+	}
+
+	// Handle enriched BaseModel data from HuggingFace YAML
+	if enrichedData.BaseModel.Source != "null" && enrichedData.BaseModel.Value != nil {
+		if raw, ok := enrichedData.BaseModel.Value.([]string); ok && len(raw) > 0 {
+			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
+				if overridesExisting(len(existingMetadata.BaseModel) > 0, modelcardSource(frontmatter, frontmatterHasBaseModel), enrichedData.BaseModel.Source, ambiguousMatch) {
+					existingMetadata.BaseModel = normalized
+					enrichmentInfo.DataSources.BaseModel = enrichedData.BaseModel.Source
 				}
-				enrichmentInfo.DataSources.ValidatedTasks = enrichedData.ValidatedTasks.Source
 			}
 		}
 	}
@@ -369,6 +446,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if existingMetadata.License != nil && existingMetadata.LicenseLink == nil {
 		if licenseURL := utils.GetLicenseURL(*existingMetadata.License); licenseURL != "" {
 			existingMetadata.LicenseLink = &licenseURL
+			enrichmentInfo.DataSources.LicenseLink = "generated"
 		}
 	}
 
@@ -430,18 +508,38 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 		if description != "" {
 			existingMetadata.Description = &description
+			enrichmentInfo.DataSources.Description = "generated"
 			log.Printf("  Generated description from model name for: %s", registryModel)
 		}
 	}
 
-	// Write clean metadata to metadata.yaml (without enrichment section)
-	updatedData, err := yaml.Marshal(existingMetadata)
-	if err != nil {
-		return fmt.Errorf("failed to marshal updated metadata: %v", err)
-	}
-
-	err = os.WriteFile(metadataPath, updatedData, 0644)
-	if err != nil {
+	// Record provenance for populated fields that kept their modelcard or registry value, so
+	// enrichment.yaml has a source for every field in metadata.yaml
+	sources := &enrichmentInfo.DataSources
+	recordSource(&sources.Name, existingMetadata.Name != nil, modelcardSource(frontmatter, frontmatterHasName))
+	recordSource(&sources.Provider, existingMetadata.Provider != nil, modelcardSource(frontmatter, frontmatterHasProvider))
+	recordSource(&sources.Description, existingMetadata.Description != nil, modelcardSource(frontmatter, frontmatterHasDescription))
+	recordSource(&sources.License, existingMetadata.License != nil, modelcardSource(frontmatter, frontmatterHasLicense))
+	recordSource(&sources.LicenseLink, existingMetadata.LicenseLink != nil, modelcardSource(frontmatter, frontmatterHasLicenseLink))
+	recordSource(&sources.Language, len(existingMetadata.Language) > 0, modelcardSource(frontmatter, frontmatterHasLanguage))
+	recordSource(&sources.Tags, len(existingMetadata.Tags) > 0, tagsSource(frontmatter))
+	recordSource(&sources.Tasks, len(existingMetadata.Tasks) > 0, modelcardSource(frontmatter, frontmatterHasTasks))
+	recordSource(&sources.LastModified, existingMetadata.LastUpdateTimeSinceEpoch != nil, modelcardSource(frontmatter, nil))
+	recordSource(&sources.CreateTimeSinceEpoch, existingMetadata.CreateTimeSinceEpoch != nil, modelcardSource(frontmatter, nil))
+	recordSource(&sources.ValidatedOn, len(existingMetadata.ValidatedOn) > 0, modelcardSource(frontmatter, frontmatterHasValidatedOn))
+	recordSource(&sources.HardwareTag, len(existingMetadata.HardwareTag) > 0, modelcardSource(frontmatter, frontmatterHasHardwareTag))
+	recordSource(&sources.ValidatedTasks, len(existingMetadata.ValidatedTasks) > 0, "unknown")
+	recordSource(&sources.BaseModel, len(existingMetadata.BaseModel) > 0, modelcardSource(frontmatter, frontmatterHasBaseModel))
+	recordSource(&sources.Readme, existingMetadata.Readme != nil, "modelcard.md")
+	recordSource(&sources.Maturity, existingMetadata.Maturity != nil, modelcardSource(frontmatter, frontmatterHasMaturity))
+	recordSource(&sources.ModelSize, existingMetadata.ModelSize != nil, modelcardSource(frontmatter, nil))
+	recordSource(&sources.Artifacts, len(existingMetadata.Artifacts) > 0, "registry")
+	recordSource(&sources.Downloads, existingMetadata.Downloads != nil, "huggingface.api")
+	recordSource(&sources.Likes, existingMetadata.Likes != nil, "huggingface.api")
+
+	// Write clean metadata to metadata.yaml (without enrichment section), keeping manual
+	// comments, annotations and field order
+	if err := metadata.UpdateMetadataFile(metadataPath, &existingMetadata); err != nil {
 		return fmt.Errorf("failed to write updated metadata: %v", err)
 	}
 
@@ -458,3 +556,97 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 	return nil
 }
+
+// licensesConflict reports whether two non-empty licenses differ once normalized to SPDX form;
+// an "other" placeholder never conflicts
+func licensesConflict(a, b string) bool {
+	a, b = utils.NormalizeLicenseSPDX(a), utils.NormalizeLicenseSPDX(b)
+	if isPlaceholderLicense(a) || isPlaceholderLicense(b) {
+		return false
+	}
+	return a != "" && b != "" && !strings.EqualFold(a, b)
+}
+
+// intValue returns the integer value of an enriched count, which is an int when fetched and may
+// decode as another numeric type when read back from a file
+func intValue(source types.MetadataSource) (int, bool) {
+	if source.Source == "null" {
+		return 0, false
+	}
+	switch v := source.Value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// recordSource sets the source of a populated field that has none recorded yet
+func recordSource(dst *string, populated bool, source string) {
+	if !populated || *dst != "" {
+		return
+	}
+	*dst = source
+}
+
+// tagsSource returns the source of the tags extraction wrote to metadata.yaml: modelcard
+// frontmatter, or otherwise the labels of the models config entry ("config"), which as an unlisted
+// source ranks below every enrichment source
+func tagsSource(frontmatter *metadata.ModelCardYAMLFrontmatter) string {
+	if frontmatter != nil && frontmatterHasTags(frontmatter) {
+		return "modelcard.yaml"
+	}
+	return "config"
+}
+
+// modelcardFrontmatter reads and parses the frontmatter of a model's modelcard.md; nil when the
+// modelcard is missing or has no frontmatter
+func modelcardFrontmatter(outputDir, sanitizedName string) *metadata.ModelCardYAMLFrontmatter {
+	modelcardPath := fmt.Sprintf("%s/%s/models/modelcard.md", outputDir, sanitizedName)
+	content, err := os.ReadFile(modelcardPath)
+	if err != nil {
+		return nil
+	}
+	frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(string(content))
+	if err != nil {
+		return nil
+	}
+	return frontmatter
+}
+
+// frontmatterField reports whether modelcard frontmatter sets the value of one metadata field
+type frontmatterField func(*metadata.ModelCardYAMLFrontmatter) bool
+
+// Frontmatter fields each metadata field is read from by metadata.ExtractMetadataValues
+var (
+	frontmatterHasName        frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return f.Name != "" }
+	frontmatterHasProvider    frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return f.Provider != "" }
+	frontmatterHasDescription frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return f.Description != "" }
+	frontmatterHasLicense     frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return f.LicenseName != "" || f.License != "" }
+	// A license link is also generated from a frontmatter license
+	frontmatterHasLicenseLink frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool {
+		return f.LicenseLink != "" || frontmatterHasLicense(f)
+	}
+	frontmatterHasLanguage    frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.Language) > 0 }
+	frontmatterHasTags        frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.Tags) > 0 }
+	frontmatterHasBaseModel   frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.BaseModel) > 0 }
+	frontmatterHasTasks       frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.Tasks) > 0 || f.PipelineTag != "" }
+	frontmatterHasValidatedOn frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.ValidatedOn) > 0 }
+	frontmatterHasHardwareTag frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool { return len(f.HardwareTag) > 0 }
+	frontmatterHasMaturity    frontmatterField = func(f *metadata.ModelCardYAMLFrontmatter) bool {
+		return metadata.NormalizeMaturity(f.Maturity) != ""
+	}
+)
+
+// modelcardSource reports whether a model's modelcard value of one field came from frontmatter
+// ("modelcard.yaml") or text parsing ("modelcard.regex"), for ranking it against enriched sources
+// and recording its provenance. A nil field is never read from frontmatter.
+func modelcardSource(frontmatter *metadata.ModelCardYAMLFrontmatter, has frontmatterField) string {
+	if frontmatter != nil && has != nil && has(frontmatter) {
+		return "modelcard.yaml"
+	}
+	return "modelcard.regex"
+}
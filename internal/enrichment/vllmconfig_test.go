@@ -10,6 +10,7 @@ import (
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // newNullEnriched creates an EnrichedModelMetadata with all metadata sources set to "null"
@@ -63,7 +64,7 @@ func setupTestDir(t *testing.T, sanitizedName, modelcardContent string) string {
 
 func TestVLLMConfigIntegration_WithConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-llama-3-3-70b-fp8:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-llama-3-3-70b-fp8_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Llama 3.3 70B\n\nThis is a test model.")
@@ -155,7 +156,7 @@ func TestVLLMConfigIntegration_WithConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_WithoutConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-granite-3b:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-3b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Granite 3B\n\nThis model has no vLLM config.")
@@ -185,7 +186,7 @@ func TestVLLMConfigIntegration_WithoutConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_WithConstraintsAndEnvVars(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-test-constrained:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-test-constrained_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Test Model\n\nBase content.")
@@ -247,7 +248,7 @@ func TestVLLMConfigIntegration_WithConstraintsAndEnvVars(t *testing.T) {
 
 func TestVLLMConfigIntegration_BothToolCallingAndVLLMConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-dual-config:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-dual-config_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Dual Config Model\n\nBase content.")
@@ -310,7 +311,7 @@ func TestVLLMConfigIntegration_BothToolCallingAndVLLMConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_IdempotentReEnrichment(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-idempotent-test:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-idempotent-test_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Idempotent Test Model\n\nBase content.")
@@ -0,0 +1,171 @@
+package huggingface
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache endpoints, used as subdirectories of the cache directory
+const (
+	cacheEndpointReadme       = "readme"
+	cacheEndpointModelDetails = "model-details"
+)
+
+// responseCache stores successful HuggingFace API responses on disk,
+// keyed by endpoint and model name, so repeated runs don't re-fetch them.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// cache is the active response cache; nil disables caching
+var (
+	cache   *responseCache
+	cacheMu sync.RWMutex
+)
+
+// EnableCache turns on the on-disk response cache for FetchReadme and FetchModelDetails.
+// Entries older than ttl are ignored and refreshed on the next fetch.
+func EnableCache(dir string, ttl time.Duration) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = &responseCache{dir: dir, ttl: ttl}
+}
+
+// DisableCache turns off the on-disk response cache
+func DisableCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = nil
+}
+
+func getCache() *responseCache {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	return cache
+}
+
+// path returns the cache file for a model, e.g. <dir>/readme/RedHatAI--granite-3.1-8b-instruct
+func (c *responseCache) path(endpoint, modelName string) string {
+	return filepath.Join(c.dir, endpoint, strings.ReplaceAll(modelName, "/", "--"))
+}
+
+// get returns the cached response body if present and not older than the TTL
+func (c *responseCache) get(endpoint, modelName string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	path := c.path(endpoint, modelName)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	body, _ := decodeCacheEntry(data)
+	return body, true
+}
+
+// etagHeader starts the first line of a cache entry, which holds the response's ETag (possibly
+// empty) ahead of the body. Keeping both in one atomically written file means a body is never
+// paired with another response's ETag.
+const etagHeader = "hf-cache-etag: "
+
+// encodeCacheEntry returns the cache file content for a response body and its ETag
+func encodeCacheEntry(data []byte, etag string) []byte {
+	entry := make([]byte, 0, len(etagHeader)+len(etag)+1+len(data))
+	entry = append(entry, etagHeader...)
+	entry = append(entry, strings.TrimSpace(etag)...)
+	entry = append(entry, '\n')
+	return append(entry, data...)
+}
+
+// decodeCacheEntry splits cache file content into the response body and its ETag. Entries
+// written before ETags were stored inline are all body.
+func decodeCacheEntry(entry []byte) (data []byte, etag string) {
+	if !bytes.HasPrefix(entry, []byte(etagHeader)) {
+		return entry, ""
+	}
+	header, body, _ := bytes.Cut(entry, []byte{'\n'})
+	return body, strings.TrimSpace(string(header[len(etagHeader):]))
+}
+
+// getStale returns a cached response body whatever its age, together with the ETag stored with
+// it, so an expired entry can be revalidated with a conditional request
+func (c *responseCache) getStale(endpoint, modelName string) (data []byte, etag string, ok bool) {
+	if c == nil {
+		return nil, "", false
+	}
+	entry, err := os.ReadFile(c.path(endpoint, modelName))
+	if err != nil {
+		return nil, "", false
+	}
+	data, etag = decodeCacheEntry(entry)
+	return data, etag, true
+}
+
+// putWithETag stores a response body and its ETag together in one cache file; an empty ETag
+// stores the body without one
+func (c *responseCache) putWithETag(endpoint, modelName string, data []byte, etag string) {
+	if c == nil {
+		return
+	}
+	path := c.path(endpoint, modelName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("  Warning: Failed to create HuggingFace cache directory: %v", err)
+		return
+	}
+	if err := writeFileAtomic(path, encodeCacheEntry(data, etag)); err != nil {
+		log.Printf("  Warning: Failed to write HuggingFace cache entry %s: %v", path, err)
+	}
+}
+
+// touch marks a revalidated entry as fresh, restarting its TTL
+func (c *responseCache) touch(endpoint, modelName string) {
+	if c == nil {
+		return
+	}
+	now := time.Now()
+	path := c.path(endpoint, modelName)
+	if err := os.Chtimes(path, now, now); err != nil {
+		log.Printf("  Warning: Failed to refresh HuggingFace cache entry %s: %v", path, err)
+	}
+}
+
+// put stores a successful response body without an ETag; failures are logged but never fatal
+func (c *responseCache) put(endpoint, modelName string, data []byte) {
+	c.putWithETag(endpoint, modelName, data, "")
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so
+// concurrent enrichment workers reading the entry see the old or the new body, never part of one
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,232 @@
+package huggingface
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache_RoundTrip(t *testing.T) {
+	c := &responseCache{dir: t.TempDir(), ttl: time.Hour}
+
+	if _, ok := c.get(cacheEndpointReadme, "org/model"); ok {
+		t.Fatal("expected cache miss for empty cache")
+	}
+
+	c.put(cacheEndpointReadme, "org/model", []byte("# Model"))
+
+	data, ok := c.get(cacheEndpointReadme, "org/model")
+	if !ok {
+		t.Fatal("expected cache hit after put")
+	}
+	if string(data) != "# Model" {
+		t.Errorf("expected cached body %q, got %q", "# Model", string(data))
+	}
+
+	// Entries are keyed per endpoint
+	if _, ok := c.get(cacheEndpointModelDetails, "org/model"); ok {
+		t.Error("expected cache miss for a different endpoint")
+	}
+}
+
+func TestResponseCache_Expired(t *testing.T) {
+	c := &responseCache{dir: t.TempDir(), ttl: time.Minute}
+	c.put(cacheEndpointModelDetails, "org/model", []byte("{}"))
+
+	old := time.Now().Add(-2 * time.Minute)
+	path := c.path(cacheEndpointModelDetails, "org/model")
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+
+	if _, ok := c.get(cacheEndpointModelDetails, "org/model"); ok {
+		t.Error("expected expired entry to be a cache miss")
+	}
+}
+
+func TestResponseCache_ConcurrentReadersSeeWholeBodies(t *testing.T) {
+	c := &responseCache{dir: t.TempDir(), ttl: time.Hour}
+	bodies := [][]byte{
+		bytes.Repeat([]byte("a"), 1<<20),
+		bytes.Repeat([]byte("b"), 1<<19),
+	}
+	c.put(cacheEndpointReadme, "org/model", bodies[0])
+
+	var wg sync.WaitGroup
+	var torn atomic.Int32
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				c.put(cacheEndpointReadme, "org/model", bodies[(w+i)%2])
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				data, ok := c.get(cacheEndpointReadme, "org/model")
+				if ok && !bytes.Equal(data, bodies[0]) && !bytes.Equal(data, bodies[1]) {
+					torn.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := torn.Load(); n > 0 {
+		t.Errorf("readers saw %d partly written cache bodies", n)
+	}
+	entries, err := os.ReadDir(filepath.Dir(c.path(cacheEndpointReadme, "org/model")))
+	if err != nil {
+		t.Fatalf("failed to read cache directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the cache entry to remain, got %d files", len(entries))
+	}
+}
+
+func TestResponseCache_ETagStoredWithBody(t *testing.T) {
+	c := &responseCache{dir: t.TempDir(), ttl: time.Hour}
+	path := c.path(cacheEndpointReadme, "org/model")
+
+	// Entries written before ETags were stored inline are read as a body without an ETag
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create cache directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("# Legacy"), 0644); err != nil {
+		t.Fatalf("failed to write legacy entry: %v", err)
+	}
+	if data, etag, ok := c.getStale(cacheEndpointReadme, "org/model"); !ok || string(data) != "# Legacy" || etag != "" {
+		t.Errorf("legacy entry: got body %q, ETag %q, ok %v", data, etag, ok)
+	}
+
+	c.putWithETag(cacheEndpointReadme, "org/model", []byte("# Model\nline two\n"), `"v1"`)
+	if data, etag, ok := c.getStale(cacheEndpointReadme, "org/model"); !ok || string(data) != "# Model\nline two\n" || etag != `"v1"` {
+		t.Errorf("getStale() = %q, %q, %v", data, etag, ok)
+	}
+	if data, ok := c.get(cacheEndpointReadme, "org/model"); !ok || string(data) != "# Model\nline two\n" {
+		t.Errorf("get() = %q, %v", data, ok)
+	}
+
+	// A response without an ETag replaces the stored one
+	c.put(cacheEndpointReadme, "org/model", []byte("# Model v2"))
+	if data, etag, ok := c.getStale(cacheEndpointReadme, "org/model"); !ok || string(data) != "# Model v2" || etag != "" {
+		t.Errorf("after put: got body %q, ETag %q, ok %v", data, etag, ok)
+	}
+}
+
+func TestResponseCache_Path(t *testing.T) {
+	c := &responseCache{dir: "cache"}
+	got := c.path(cacheEndpointReadme, "RedHatAI/granite-3.1-8b-instruct")
+	want := filepath.Join("cache", "readme", "RedHatAI--granite-3.1-8b-instruct")
+	if got != want {
+		t.Errorf("expected path %q, got %q", want, got)
+	}
+}
+
+func TestResponseCache_Disabled(t *testing.T) {
+	var c *responseCache
+	c.put(cacheEndpointReadme, "org/model", []byte("ignored"))
+	if _, ok := c.get(cacheEndpointReadme, "org/model"); ok {
+		t.Error("expected nil cache to always miss")
+	}
+}
+
+func TestFetchReadme_UsesCache(t *testing.T) {
+	dir := t.TempDir()
+	EnableCache(dir, time.Hour)
+	defer DisableCache()
+
+	getCache().put(cacheEndpointReadme, "org/cached-model", []byte("cached readme"))
+
+	readme, err := FetchReadme("org/cached-model")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if readme != "cached readme" {
+		t.Errorf("expected cached readme, got %q", readme)
+	}
+}
+
+func TestFetchReadme_RevalidatesWithETag(t *testing.T) {
+	SetRateLimit(0)
+	dir := t.TempDir()
+	EnableCache(dir, time.Minute)
+
+	etag := `"v1"`
+	body := "# Model v1"
+	var fullResponses, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body))
+	}))
+	origBaseURL := apiBaseURL
+	apiBaseURL = srv.URL
+	t.Cleanup(func() {
+		apiBaseURL = origBaseURL
+		srv.Close()
+		DisableCache()
+		SetRateLimit(DefaultRequestsPerSecond)
+	})
+
+	expire := func() {
+		t.Helper()
+		old := time.Now().Add(-2 * time.Minute)
+		if err := os.Chtimes(getCache().path(cacheEndpointReadme, "org/model"), old, old); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+	}
+	fetch := func(want string) {
+		t.Helper()
+		readme, err := FetchReadme("org/model")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if readme != want {
+			t.Errorf("expected readme %q, got %q", want, readme)
+		}
+	}
+
+	fetch("# Model v1")
+	if _, stored, ok := getCache().getStale(cacheEndpointReadme, "org/model"); !ok || stored != etag {
+		t.Fatalf("expected ETag %q stored with the cached README, got %q", etag, stored)
+	}
+
+	// An expired entry is revalidated; 304 reuses the cached copy and refreshes its TTL
+	expire()
+	fetch("# Model v1")
+	if fullResponses != 1 || notModified != 1 {
+		t.Errorf("expected 1 full response and 1 not-modified, got %d and %d", fullResponses, notModified)
+	}
+	fetch("# Model v1")
+	if notModified != 1 {
+		t.Errorf("expected the revalidated entry to be fresh, got %d not-modified responses", notModified)
+	}
+
+	// A changed README is fetched in full and replaces the cached copy and ETag
+	expire()
+	etag, body = `"v2"`, "# Model v2"
+	fetch("# Model v2")
+	if fullResponses != 2 {
+		t.Errorf("expected a second full response, got %d", fullResponses)
+	}
+	if _, stored, _ := getCache().getStale(cacheEndpointReadme, "org/model"); stored != `"v2"` {
+		t.Errorf("expected updated ETag, got %q", stored)
+	}
+}
@@ -1,29 +1,170 @@
 package huggingface
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/config"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
+// apiBaseURL is the HuggingFace endpoint all API and README requests are made against
+var apiBaseURL = "https://huggingface.co"
+
 // httpClient is a shared HTTP client with timeout for all HuggingFace API calls
 var httpClient = &http.Client{
 	Timeout: 30 * time.Second,
 }
 
+// DefaultRequestTimeout bounds each HuggingFace request, including reading its body
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultMaxResponseBytes caps how much of a HuggingFace response body is read
+const DefaultMaxResponseBytes = 5 << 20
+
+// requestTimeout and maxResponseBytes apply to every request whatever HTTP client is in use;
+// zero disables them
+var (
+	requestTimeout         = DefaultRequestTimeout
+	maxResponseBytes int64 = DefaultMaxResponseBytes
+)
+
+// ErrResponseTooLarge is returned when a HuggingFace response body exceeds SetMaxResponseBytes
+var ErrResponseTooLarge = errors.New("HuggingFace response exceeds size limit")
+
+// SetRequestTimeout sets the per-request timeout for HuggingFace calls; 0 disables it,
+// leaving only the HTTP client's own timeout
+func SetRequestTimeout(timeout time.Duration) {
+	requestTimeout = max(timeout, 0)
+}
+
+// SetMaxResponseBytes sets the largest HuggingFace response body that is read; 0 disables the limit
+func SetMaxResponseBytes(n int64) {
+	maxResponseBytes = max(n, 0)
+}
+
+// readBody reads a response body, failing with ErrResponseTooLarge instead of buffering
+// more than maxResponseBytes
+func readBody(r io.Reader) ([]byte, error) {
+	limit := maxResponseBytes
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, nil
+}
+
+// cancelOnClose releases a request's timeout context once its body has been read and closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// DefaultRequestsPerSecond is the HuggingFace API rate limit used when
+// neither SetRateLimit nor HF_REQUESTS_PER_SECOND is set.
+const DefaultRequestsPerSecond = 5.0
+
+// Rate limiting settings for 429 responses
+const (
+	maxRateLimitRetries = 3
+	maxRetryAfter       = 60 * time.Second
+)
+
+// limiter is a token bucket shared by all HuggingFace API calls. Like the token,
+// it is initialized lazily so HF_REQUESTS_PER_SECOND can come from .env.
+var (
+	limiter     *rate.Limiter
+	limiterOnce sync.Once
+	limiterMu   sync.Mutex
+)
+
+// SetHTTPClient replaces the HTTP client used for all HuggingFace API calls
+func SetHTTPClient(client *http.Client) {
+	httpClient = client
+}
+
+// SetRateLimit sets the maximum number of HuggingFace API requests per second.
+// A value <= 0 disables rate limiting.
+func SetRateLimit(rps float64) {
+	limiterOnce.Do(func() {}) // explicit configuration takes precedence over the env var
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	limiter = newLimiter(rps)
+}
+
+func newLimiter(rps float64) *rate.Limiter {
+	if rps <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(rps), 1)
+}
+
+func getLimiter() *rate.Limiter {
+	limiterOnce.Do(func() {
+		rps := DefaultRequestsPerSecond
+		if v := os.Getenv("HF_REQUESTS_PER_SECOND"); v != "" {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				rps = parsed
+			} else {
+				log.Printf("Warning: Invalid HF_REQUESTS_PER_SECOND %q, using default %.1f", v, DefaultRequestsPerSecond)
+			}
+		}
+		limiterMu.Lock()
+		limiter = newLimiter(rps)
+		limiterMu.Unlock()
+	})
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	return limiter
+}
+
+// parseRetryAfter parses a Retry-After header given either as seconds or an HTTP date.
+// Returns fallback when the header is missing or malformed.
+func parseRetryAfter(header string, fallback time.Duration) time.Duration {
+	if header == "" {
+		return fallback
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return fallback
+}
+
 // hfToken caches the HuggingFace API token, read lazily on first use via sync.Once.
 // Lazy init is required because main() loads .env before any HuggingFace calls,
 // but after Go's init() functions have already run.
@@ -39,82 +180,207 @@ func getHFToken() string {
 	return hfToken
 }
 
+// SetToken sets the HuggingFace API token, taking precedence over HF_TOKEN
+func SetToken(token string) {
+	hfTokenOnce.Do(func() {})
+	hfToken = token
+}
+
+// HasToken reports whether requests will be authenticated
+func HasToken() bool {
+	return getHFToken() != ""
+}
+
+// ErrGatedModel is returned when HuggingFace denies access to a model (401/403),
+// which for validated collections means the model is gated
+var ErrGatedModel = errors.New("gated model requires an authorized HuggingFace token")
+
+// gatedModelError builds an ErrGatedModel error explaining why access was denied
+func gatedModelError(modelName string, statusCode int) error {
+	if !HasToken() {
+		return fmt.Errorf("%w: %s (status %d, no HF_TOKEN or --hf-token set)", ErrGatedModel, modelName, statusCode)
+	}
+	return fmt.Errorf("%w: %s (status %d, token has not been granted access)", ErrGatedModel, modelName, statusCode)
+}
+
 // doGet performs an authenticated GET request, adding the Bearer header when HF_TOKEN is set.
+// Requests are throttled by the shared rate limiter, and 429 responses are retried after
+// the server-provided Retry-After delay.
 func doGet(url string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	if token := getHFToken(); token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
+	return doGetWith(httpClient, url)
+}
+
+// doGetWith is doGet using the given HTTP client
+func doGetWith(client *http.Client, url string) (*http.Response, error) {
+	return doGetWithHeader(client, url, nil)
+}
+
+// doGetWithHeader is doGetWith sending the given extra request headers (e.g. If-None-Match)
+func doGetWithHeader(client *http.Client, url string, header http.Header) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := getLimiter().Wait(context.Background()); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if requestTimeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		if token := getHFToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := min(parseRetryAfter(resp.Header.Get("Retry-After"), time.Duration(attempt+1)*time.Second), maxRetryAfter)
+		_ = resp.Body.Close()
+		cancel()
+		log.Printf("  HuggingFace rate limit hit for %s, retrying in %v", url, wait)
+		time.Sleep(wait)
 	}
-	return httpClient.Do(req)
 }
 
-// FetchCollections fetches collections from HuggingFace
-func FetchCollections() ([]types.HFCollection, error) {
-	// Fetch collections list from RedHatAI
-	resp, err := doGet("https://huggingface.co/api/collections?search=red-hat-ai-validated-models")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch collections: %v", err)
+// maxPages bounds how many pages getPages follows, guarding against Link headers that loop
+const maxPages = 100
+
+// getPages GETs url and every page linked from it by a `Link: <...>; rel="next"` header,
+// calling handle with each page body in order
+func getPages(client *http.Client, pageURL string, handle func(body []byte) error) error {
+	for page := 0; pageURL != ""; page++ {
+		if page >= maxPages {
+			return fmt.Errorf("stopped after %d pages at %s", maxPages, pageURL)
+		}
+
+		resp, err := doGetWith(client, pageURL)
+		if err != nil {
+			return err
+		}
+		body, err := readBody(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response body from %s: %w", pageURL, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, pageURL)
+		}
+
+		if err := handle(body); err != nil {
+			return err
+		}
+		pageURL = nextPageURL(resp)
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
+// nextPageURL returns the rel="next" target of a response's Link header, resolved against
+// the request URL, or "" on the last page
+func nextPageURL(resp *http.Response) string {
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		target, params, found := strings.Cut(link, ";")
+		if !found || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		if resp.Request == nil || resp.Request.URL == nil {
+			return target
+		}
+		next, err := resp.Request.URL.Parse(target)
+		if err != nil {
+			return ""
+		}
+		return next.String()
 	}
+	return ""
+}
+
+// DefaultCollectionSearch is the collection search term used by FetchCollections
+const DefaultCollectionSearch = "red-hat-ai-validated-models"
 
+// FetchCollections fetches collections from HuggingFace, following pagination
+func FetchCollections() ([]types.HFCollection, error) {
+	return searchCollections(httpClient, DefaultCollectionSearch)
+}
+
+// searchCollections fetches every collection matching a search term
+func searchCollections(client *http.Client, search string) ([]types.HFCollection, error) {
 	var collections []types.HFCollection
-	err = json.Unmarshal(body, &collections)
+	searchURL := fmt.Sprintf("%s/api/collections?search=%s&limit=100", apiBaseURL, url.QueryEscape(search))
+	err := getPages(client, searchURL, func(body []byte) error {
+		var page []types.HFCollection
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to parse collections JSON: %v", err)
+		}
+		collections = append(collections, page...)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse collections JSON: %v", err)
+		return nil, fmt.Errorf("failed to fetch collections: %w", err)
 	}
 
 	return collections, nil
 }
 
-// FetchCollectionDetails fetches detailed information for a specific collection
+// FetchCollectionDetails fetches detailed information for a specific collection. When the
+// items are paginated, items from every page are gathered into the returned collection.
 func FetchCollectionDetails(collectionID string) (*types.HFCollection, error) {
-	url := fmt.Sprintf("https://huggingface.co/api/collections/%s", collectionID)
-	resp, err := doGet(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch collection details: %v", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
-	}
+	return fetchCollectionDetails(httpClient, collectionID)
+}
 
-	var collection types.HFCollection
-	err = json.Unmarshal(body, &collection)
+func fetchCollectionDetails(client *http.Client, collectionID string) (*types.HFCollection, error) {
+	var collection *types.HFCollection
+	err := getPages(client, fmt.Sprintf("%s/api/collections/%s", apiBaseURL, collectionID), func(body []byte) error {
+		var page types.HFCollection
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to parse collection JSON: %v", err)
+		}
+		if collection == nil {
+			collection = &page
+		} else {
+			collection.Items = append(collection.Items, page.Items...)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse collection JSON: %v", err)
+		return nil, fmt.Errorf("failed to fetch collection details: %w", err)
 	}
 
-	return &collection, nil
+	return collection, nil
 }
 
 // DiscoverValidatedModelCollections finds all Red Hat AI validated model collections
 func DiscoverValidatedModelCollections() ([]string, error) {
-	// Fetch collections from RedHatAI user
-	resp, err := doGet("https://huggingface.co/api/users/RedHatAI/collections")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user collections: %v", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
-	}
+	return discoverValidatedModelCollections(httpClient)
+}
 
+func discoverValidatedModelCollections(client *http.Client) ([]string, error) {
+	// Fetch collections from RedHatAI user
 	var collections []types.HFCollection
-	err = json.Unmarshal(body, &collections)
+	err := getPages(client, apiBaseURL+"/api/users/RedHatAI/collections?limit=100", func(body []byte) error {
+		var page []types.HFCollection
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to parse collections JSON: %v", err)
+		}
+		collections = append(collections, page...)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse collections JSON: %v", err)
+		return nil, fmt.Errorf("failed to fetch user collections: %w", err)
 	}
 
 	var validatedModelCollections []string
@@ -140,52 +406,108 @@ func DiscoverValidatedModelCollections() ([]string, error) {
 
 // FetchModelDetails fetches detailed metadata for a specific model
 func FetchModelDetails(modelName string) (*types.HFModelDetails, error) {
-	url := fmt.Sprintf("https://huggingface.co/api/models/%s", modelName)
+	body, cached := getCache().get(cacheEndpointModelDetails, modelName)
+	if !cached {
+		var err error
+		body, err = fetchModelDetailsBody(modelName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var details types.HFModelDetails
+	err := json.Unmarshal(body, &details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse model details JSON: %v", err)
+	}
+
+	// Only cache responses that parsed successfully
+	if !cached {
+		getCache().put(cacheEndpointModelDetails, modelName, body)
+	}
+
+	return &details, nil
+}
+
+// fetchModelDetailsBody fetches the raw model details JSON from the HuggingFace API
+func fetchModelDetailsBody(modelName string) ([]byte, error) {
+	url := fmt.Sprintf("%s/api/models/%s", apiBaseURL, modelName)
 	resp, err := doGet(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch model details: %v", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, gatedModelError(modelName, resp.StatusCode)
+	}
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	var details types.HFModelDetails
-	err = json.Unmarshal(body, &details)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse model details JSON: %v", err)
+		return nil, fmt.Errorf("failed to read model details for %s: %w", modelName, err)
 	}
 
-	return &details, nil
+	return body, nil
 }
 
-// FetchReadme fetches the README content from HuggingFace
+// FetchReadme fetches the README content from HuggingFace. An expired cached README is
+// revalidated with its stored ETag, and a 304 Not Modified response reuses the cached copy.
 func FetchReadme(modelName string) (string, error) {
-	url := fmt.Sprintf("https://huggingface.co/%s/raw/main/README.md", modelName)
-	resp, err := doGet(url)
+	c := getCache()
+	if body, ok := c.get(cacheEndpointReadme, modelName); ok {
+		return string(body), nil
+	}
+
+	var header http.Header
+	cached, etag, hasCached := c.getStale(cacheEndpointReadme, modelName)
+	if hasCached && etag != "" {
+		header = http.Header{"If-None-Match": []string{etag}}
+	}
+
+	url := fmt.Sprintf("%s/%s/raw/main/README.md", apiBaseURL, modelName)
+	resp, err := doGetWithHeader(httpClient, url, header)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch README: %v", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotModified && header != nil {
+		c.touch(cacheEndpointReadme, modelName)
+		return string(cached), nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return "", gatedModelError(modelName, resp.StatusCode)
+	}
 	if resp.StatusCode != 200 {
 		return "", fmt.Errorf("README not found, status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read README body: %v", err)
+		return "", fmt.Errorf("failed to read README for %s: %w", modelName, err)
 	}
 
+	c.putWithETag(cacheEndpointReadme, modelName, body, resp.Header.Get("ETag"))
+
 	return string(body), nil
 }
 
+// RepoIDFromURI returns the "org/model" repository ID of a HuggingFace model URI such as
+// https://huggingface.co/org/model or hf://org/model; bare repository IDs are returned as-is
+func RepoIDFromURI(uri string) string {
+	repoID := strings.TrimSpace(uri)
+	for _, prefix := range []string{"https://huggingface.co/", "http://huggingface.co/", "huggingface.co/", "hf://"} {
+		if strings.HasPrefix(repoID, prefix) {
+			repoID = strings.TrimPrefix(repoID, prefix)
+			break
+		}
+	}
+	return strings.Trim(repoID, "/")
+}
+
 // GetLatestVersionIndexFile finds the latest version index file
 func GetLatestVersionIndexFile() (string, error) {
 	files, err := filepath.Glob(CollectionGlob("v*"))
@@ -202,29 +524,10 @@ func GetLatestVersionIndexFile() (string, error) {
 	return files[len(files)-1], nil
 }
 
-// LoadModelsFromVersionIndex loads models from a version-specific index file
+// LoadModelsFromVersionIndex loads models from a version-specific index file; see
+// config.LoadModelsFromVersionIndex
 func LoadModelsFromVersionIndex(filePath string) ([]string, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	var versionIndex types.VersionIndex
-	err = yaml.Unmarshal(data, &versionIndex)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelRefs []string
-	for _, model := range versionIndex.Models {
-		// Convert HuggingFace model to container registry format
-		// This would need to be mapped to actual container registry URLs
-		// For now, we'll use a placeholder format
-		modelRef := fmt.Sprintf("registry.redhat.io/rhelai1/modelcar-%s", strings.ToLower(strings.ReplaceAll(model.Name, "/", "-")))
-		modelRefs = append(modelRefs, modelRef)
-	}
-
-	return modelRefs, nil
+	return config.LoadModelsFromVersionIndex(filePath)
 }
 
 // ExtractProviderFromReadme extracts provider/developer information from README content
@@ -259,6 +562,50 @@ func ExtractProviderFromReadme(readmeContent string) string {
 	return ""
 }
 
+// baseModelProviders maps HuggingFace organization prefixes to provider display names
+var baseModelProviders = map[string]string{
+	"meta-llama":            "Meta",
+	"facebook":              "Meta",
+	"mistralai":             "Mistral AI",
+	"ibm-granite":           "IBM",
+	"ibm":                   "IBM",
+	"qwen":                  "Alibaba Cloud",
+	"google":                "Google",
+	"microsoft":             "Microsoft",
+	"deepseek-ai":           "DeepSeek",
+	"nvidia":                "NVIDIA",
+	"redhatai":              "Red Hat AI",
+	"neuralmagic":           "Red Hat AI",
+	"openai":                "OpenAI",
+	"moonshotai":            "Moonshot AI",
+	"zai-org":               "Z.ai",
+	"thudm":                 "Z.ai",
+	"tiiuae":                "Technology Innovation Institute",
+	"allenai":               "Allen Institute for AI",
+	"huggingfacetb":         "Hugging Face",
+	"sentence-transformers": "Sentence Transformers",
+}
+
+// ExtractProviderFromBaseModel infers the provider from base_model entries such as
+// "meta-llama/Llama-3.1-8B". The first entry with a known organization wins; when
+// none is known, the organization of the first entry is returned as-is.
+func ExtractProviderFromBaseModel(baseModels []string) string {
+	var fallback string
+	for _, baseModel := range baseModels {
+		org, _, found := strings.Cut(strings.TrimSpace(baseModel), "/")
+		if !found || org == "" {
+			continue
+		}
+		if provider, ok := baseModelProviders[strings.ToLower(org)]; ok {
+			return provider
+		}
+		if fallback == "" {
+			fallback = org
+		}
+	}
+	return fallback
+}
+
 // cliArgsSlice handles the malformed required_cli_args format in HuggingFace YAML
 // where elements like "--config_format: mistral" are parsed as maps instead of strings
 type cliArgsSlice []string
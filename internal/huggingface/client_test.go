@@ -1,14 +1,19 @@
 package huggingface
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
@@ -773,3 +778,384 @@ Content.
 		})
 	}
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	fallback := 2 * time.Second
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{"missing header uses fallback", "", fallback},
+		{"seconds", "7", 7 * time.Second},
+		{"zero seconds", "0", 0},
+		{"malformed uses fallback", "soon", fallback},
+		{"past HTTP date", "Wed, 21 Oct 2015 07:28:00 GMT", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.header, fallback); got != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDoGet_RetriesOnTooManyRequests(t *testing.T) {
+	SetRateLimit(0)
+	defer SetRateLimit(DefaultRequestsPerSecond)
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	resp, err := doGet(srv.URL)
+	if err != nil {
+		t.Fatalf("doGet() error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected final status 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestDoGet_GivesUpAfterMaxRateLimitRetries(t *testing.T) {
+	SetRateLimit(0)
+	defer SetRateLimit(DefaultRequestsPerSecond)
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	resp, err := doGet(srv.URL)
+	if err != nil {
+		t.Fatalf("doGet() error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 to be returned after retries, got %d", resp.StatusCode)
+	}
+	if attempts != maxRateLimitRetries+1 {
+		t.Errorf("Expected %d attempts, got %d", maxRateLimitRetries+1, attempts)
+	}
+}
+
+func TestSetRateLimit(t *testing.T) {
+	defer SetRateLimit(DefaultRequestsPerSecond)
+
+	SetRateLimit(2)
+	if got := getLimiter().Limit(); got != rate.Limit(2) {
+		t.Errorf("Expected limit 2, got %v", got)
+	}
+
+	SetRateLimit(0)
+	if got := getLimiter().Limit(); got != rate.Inf {
+		t.Errorf("Expected unlimited rate, got %v", got)
+	}
+}
+
+func TestGatedModelError(t *testing.T) {
+	defer func() {
+		hfTokenOnce = sync.Once{}
+		hfToken = ""
+	}()
+
+	SetToken("")
+	err := gatedModelError("RedHatAI/gated-model", http.StatusUnauthorized)
+	if !errors.Is(err, ErrGatedModel) {
+		t.Fatalf("Expected ErrGatedModel, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "no HF_TOKEN") {
+		t.Errorf("Expected missing-token hint without token, got %v", err)
+	}
+
+	SetToken("hf_test_token")
+	err = gatedModelError("RedHatAI/gated-model", http.StatusForbidden)
+	if !errors.Is(err, ErrGatedModel) {
+		t.Fatalf("Expected ErrGatedModel, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "not been granted access") {
+		t.Errorf("Expected access hint with token, got %v", err)
+	}
+}
+
+func TestSetToken_OverridesEnv(t *testing.T) {
+	orig := os.Getenv("HF_TOKEN")
+	defer func() {
+		_ = os.Setenv("HF_TOKEN", orig)
+		hfTokenOnce = sync.Once{}
+		hfToken = ""
+	}()
+
+	_ = os.Setenv("HF_TOKEN", "from_env")
+	hfTokenOnce = sync.Once{}
+	hfToken = ""
+
+	SetToken("from_flag")
+	if got := getHFToken(); got != "from_flag" {
+		t.Errorf("getHFToken() = %q, want %q", got, "from_flag")
+	}
+	if !HasToken() {
+		t.Error("Expected HasToken() to be true")
+	}
+}
+
+func TestExtractProviderFromBaseModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseModels []string
+		expected   string
+	}{
+		{
+			name:       "known organization",
+			baseModels: []string{"meta-llama/Llama-3.1-8B"},
+			expected:   "Meta",
+		},
+		{
+			name:       "organization lookup is case-insensitive",
+			baseModels: []string{"Qwen/Qwen2.5-7B-Instruct"},
+			expected:   "Alibaba Cloud",
+		},
+		{
+			name:       "multiple entries use first known organization",
+			baseModels: []string{"some-user/merged-model", "mistralai/Mistral-7B-v0.1", "meta-llama/Llama-3.1-8B"},
+			expected:   "Mistral AI",
+		},
+		{
+			name:       "unknown organization falls back to first org prefix",
+			baseModels: []string{"some-user/merged-model", "other-user/model"},
+			expected:   "some-user",
+		},
+		{
+			name:       "entries without organization are skipped",
+			baseModels: []string{"bert-base-uncased", "ibm-granite/granite-3.1-8b-base"},
+			expected:   "IBM",
+		},
+		{
+			name:       "no usable entries",
+			baseModels: []string{"bert-base-uncased", ""},
+			expected:   "",
+		},
+		{
+			name:       "nil",
+			baseModels: nil,
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractProviderFromBaseModel(tt.baseModels); got != tt.expected {
+				t.Errorf("ExtractProviderFromBaseModel(%v) = %q, want %q", tt.baseModels, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRepoIDFromURI(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+	}{
+		{"https://huggingface.co/ibm-granite/granite-3.1-8b-instruct", "ibm-granite/granite-3.1-8b-instruct"},
+		{"http://huggingface.co/ibm-granite/granite-3.1-8b-instruct/", "ibm-granite/granite-3.1-8b-instruct"},
+		{"huggingface.co/RedHatAI/Llama-3.1-8B-Instruct", "RedHatAI/Llama-3.1-8B-Instruct"},
+		{"hf://RedHatAI/Llama-3.1-8B-Instruct", "RedHatAI/Llama-3.1-8B-Instruct"},
+		{"RedHatAI/Llama-3.1-8B-Instruct", "RedHatAI/Llama-3.1-8B-Instruct"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := RepoIDFromURI(tt.uri); got != tt.expected {
+			t.Errorf("RepoIDFromURI(%q) = %q, want %q", tt.uri, got, tt.expected)
+		}
+	}
+}
+
+// newPaginatedServer serves two pages at path, linking the first to the second with a relative
+// Link header as the HuggingFace API does, and points apiBaseURL at the server
+func newPaginatedServer(t *testing.T, path, firstPage, secondPage string) {
+	t.Helper()
+	SetRateLimit(0)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<`+path+`?cursor=page2>; rel="next"`)
+			_, _ = w.Write([]byte(firstPage))
+			return
+		}
+		_, _ = w.Write([]byte(secondPage))
+	}))
+
+	origBaseURL := apiBaseURL
+	apiBaseURL = srv.URL
+	t.Cleanup(func() {
+		apiBaseURL = origBaseURL
+		srv.Close()
+		SetRateLimit(DefaultRequestsPerSecond)
+	})
+}
+
+func TestDiscoverValidatedModelCollections_Paginated(t *testing.T) {
+	newPaginatedServer(t, "/api/users/RedHatAI/collections",
+		`[{"slug": "RedHatAI/red-hat-ai-validated-models-may-2025", "title": "Red Hat AI validated models - May 2025"},
+		  {"slug": "RedHatAI/other", "title": "Something else"}]`,
+		`[{"slug": "RedHatAI/embedding-models", "title": "Embedding Models"}]`)
+
+	slugs, err := DiscoverValidatedModelCollections()
+	if err != nil {
+		t.Fatalf("DiscoverValidatedModelCollections() error: %v", err)
+	}
+
+	expected := []string{"RedHatAI/red-hat-ai-validated-models-may-2025", "RedHatAI/embedding-models"}
+	if !reflect.DeepEqual(slugs, expected) {
+		t.Errorf("DiscoverValidatedModelCollections() = %v, want %v", slugs, expected)
+	}
+}
+
+func TestFetchCollectionDetails_Paginated(t *testing.T) {
+	newPaginatedServer(t, "/api/collections/RedHatAI/validated",
+		`{"slug": "RedHatAI/validated", "title": "Validated", "items": [{"id": "RedHatAI/model-a", "type": "model"}]}`,
+		`{"slug": "RedHatAI/validated", "title": "Validated", "items": [{"id": "RedHatAI/model-b", "type": "model"}]}`)
+
+	collection, err := FetchCollectionDetails("RedHatAI/validated")
+	if err != nil {
+		t.Fatalf("FetchCollectionDetails() error: %v", err)
+	}
+
+	if collection.Title != "Validated" {
+		t.Errorf("Title = %q, want Validated", collection.Title)
+	}
+	var ids []string
+	for _, item := range collection.Items {
+		ids = append(ids, item.ID)
+	}
+	if expected := []string{"RedHatAI/model-a", "RedHatAI/model-b"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Item IDs = %v, want %v", ids, expected)
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://huggingface.co/api/collections?limit=100", nil)
+	tests := []struct {
+		name     string
+		link     string
+		expected string
+	}{
+		{name: "no Link header", link: "", expected: ""},
+		{name: "relative next link", link: `</api/collections?cursor=abc>; rel="next"`, expected: "https://huggingface.co/api/collections?cursor=abc"},
+		{name: "absolute next link among others", link: `<https://huggingface.co/api/collections?cursor=0>; rel="prev", <https://huggingface.co/api/collections?cursor=2>; rel="next"`, expected: "https://huggingface.co/api/collections?cursor=2"},
+		{name: "no next relation", link: `</api/collections?cursor=0>; rel="prev"`, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}, Request: req}
+			if tt.link != "" {
+				resp.Header.Set("Link", tt.link)
+			}
+			if got := nextPageURL(resp); got != tt.expected {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFetch_ResponseSizeLimit(t *testing.T) {
+	SetRateLimit(0)
+	SetMaxResponseBytes(64)
+	t.Cleanup(func() {
+		SetRateLimit(DefaultRequestsPerSecond)
+		SetMaxResponseBytes(DefaultMaxResponseBytes)
+	})
+
+	small := `{"id": "RedHatAI/small"}`
+	large := `{"id": "RedHatAI/large", "description": "` + strings.Repeat("x", 128) + `"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "large"), strings.HasPrefix(r.URL.Path, "/api/collections"):
+			_, _ = w.Write([]byte(large))
+		default:
+			_, _ = w.Write([]byte(small))
+		}
+	}))
+	origBaseURL := apiBaseURL
+	apiBaseURL = srv.URL
+	t.Cleanup(func() {
+		apiBaseURL = origBaseURL
+		srv.Close()
+	})
+
+	if _, err := FetchModelDetails("RedHatAI/small"); err != nil {
+		t.Errorf("Expected a response under the limit to succeed, got %v", err)
+	}
+
+	calls := map[string]func() error{
+		"FetchModelDetails": func() error { _, err := FetchModelDetails("RedHatAI/large"); return err },
+		"FetchReadme":       func() error { _, err := FetchReadme("RedHatAI/large"); return err },
+		"FetchCollections":  func() error { _, err := FetchCollections(); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("%s: expected ErrResponseTooLarge, got %v", name, err)
+		}
+	}
+
+	SetMaxResponseBytes(0)
+	if _, err := FetchModelDetails("RedHatAI/large"); err != nil {
+		t.Errorf("Expected no limit when set to 0, got %v", err)
+	}
+}
+
+func TestDoGet_RequestTimeout(t *testing.T) {
+	SetRateLimit(0)
+	SetRequestTimeout(50 * time.Millisecond)
+	t.Cleanup(func() {
+		SetRateLimit(DefaultRequestsPerSecond)
+		SetRequestTimeout(DefaultRequestTimeout)
+	})
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		srv.Close()
+	})
+
+	// The client itself has no timeout; the per-request deadline must still apply
+	resp, err := doGetWith(&http.Client{}, srv.URL)
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("Expected the request to time out")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package huggingface
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -84,8 +85,8 @@ func parseVersionFromTitle(title string) string {
 	return ""
 }
 
-// generateVersionIndex creates an index file for a specific version
-func generateVersionIndex(collection *types.HFCollection, version string) error {
+// buildVersionIndex converts a collection's items into a version index
+func buildVersionIndex(collection *types.HFCollection, version string) types.VersionIndex {
 	var models []types.ModelIndex
 
 	for _, model := range collection.Items {
@@ -98,33 +99,20 @@ func generateVersionIndex(collection *types.HFCollection, version string) error
 		models = append(models, modelIndex)
 	}
 
-	versionIndex := types.VersionIndex{
+	return types.VersionIndex{
 		Version: version,
 		Models:  models,
 	}
+}
 
-	// Ensure collections directory exists
-	err := os.MkdirAll(CollectionsDir, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create collections directory: %v", err)
-	}
-
-	// Generate filename
-	filename := CollectionFilePath(strings.ReplaceAll(version, ".", "-"))
-
-	// Marshal to YAML
-	yamlData, err := yaml.Marshal(versionIndex)
-	if err != nil {
-		return fmt.Errorf("failed to marshal version index to YAML: %v", err)
-	}
-
-	// Write to file
-	err = os.WriteFile(filename, yamlData, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write version index file: %v", err)
+// writeVersionIndex writes the version index for a collection to filename
+func writeVersionIndex(collection *types.HFCollection, version, filename string) error {
+	versionIndex := buildVersionIndex(collection, version)
+	if err := SaveVersionIndex(filename, versionIndex); err != nil {
+		return err
 	}
 
-	log.Printf("Generated index file: %s with %d models", filename, len(models))
+	log.Printf("Generated index file: %s with %d models", filename, len(versionIndex.Models))
 	return nil
 }
 
@@ -211,39 +199,74 @@ func generateMergedIndex() error {
 	return nil
 }
 
+// knownCollectionSlugs are processed when collection discovery fails - May, September, October 2025
+// and January through May 2026, plus Granite Quantized and Embedding Models
+var knownCollectionSlugs = []string{
+	"RedHatAI/red-hat-ai-validated-models-may-2025-682613dc19c4a596dbac9437",
+	"RedHatAI/red-hat-ai-validated-models-september-2025-68cc3d7a8a272f6beae3e9a7",
+	"RedHatAI/red-hat-ai-validated-models-october-2025-68ed0a23ec5ce4b0ffc4c60c",
+	"RedHatAI/red-hat-ai-validated-models-january-2026-69652094dc3429e12c32ad49",
+	"RedHatAI/red-hat-ai-validated-models-february-2026-699c6b8ade9c198927302989",
+	"RedHatAI/red-hat-ai-validated-models-march-2026-69b0697e7f157651f5c0f5ac",
+	"RedHatAI/red-hat-ai-validated-models-may-2026",
+	"RedHatAI/granite-quantized",
+	"RedHatAI/embedding-models",
+}
+
+// ProcessOptions configures ProcessCollectionsWithOptions
+type ProcessOptions struct {
+	// SearchTerm selects collections through the HuggingFace collection search instead of
+	// discovering the RedHatAI validated model collections
+	SearchTerm string
+	// Version is the version recorded in the index; empty detects it from each collection title
+	Version string
+	// OutputPath is the version index file to write. When it or Version is set, the items of all
+	// selected collections are written to that single index; otherwise each collection gets its
+	// own file in CollectionsDir and a merged index is generated.
+	OutputPath string
+	// HTTPClient is used for HuggingFace API calls; nil uses the shared client
+	HTTPClient *http.Client
+}
+
 // ProcessCollections processes all HuggingFace collections and generates index files
 func ProcessCollections() error {
-	log.Println("Discovering Red Hat AI validated model collections...")
+	return ProcessCollectionsWithOptions(ProcessOptions{})
+}
 
-	// Try to discover collections automatically
-	collectionSlugs, err := DiscoverValidatedModelCollections()
+// ProcessCollectionsWithOptions processes the HuggingFace collections selected by opts and
+// generates index files
+func ProcessCollectionsWithOptions(opts ProcessOptions) error {
+	client := opts.HTTPClient
+	if client == nil {
+		client = httpClient
+	}
+
+	collectionSlugs, err := selectCollections(client, opts.SearchTerm)
 	if err != nil {
-		log.Printf("Failed to discover collections, using known collections: %v", err)
-		// Fall back to known collections - include May, September, October 2025 and January through May 2026, plus Granite Quantized and Embedding Models
-		collectionSlugs = []string{
-			"RedHatAI/red-hat-ai-validated-models-may-2025-682613dc19c4a596dbac9437",
-			"RedHatAI/red-hat-ai-validated-models-september-2025-68cc3d7a8a272f6beae3e9a7",
-			"RedHatAI/red-hat-ai-validated-models-october-2025-68ed0a23ec5ce4b0ffc4c60c",
-			"RedHatAI/red-hat-ai-validated-models-january-2026-69652094dc3429e12c32ad49",
-			"RedHatAI/red-hat-ai-validated-models-february-2026-699c6b8ade9c198927302989",
-			"RedHatAI/red-hat-ai-validated-models-march-2026-69b0697e7f157651f5c0f5ac",
-			"RedHatAI/red-hat-ai-validated-models-may-2026",
-			"RedHatAI/granite-quantized",
-			"RedHatAI/embedding-models",
-		}
+		return err
 	}
 
 	if len(collectionSlugs) == 0 {
 		return fmt.Errorf("no validated model collections found")
 	}
 
+	outputPath := opts.OutputPath
+	if outputPath == "" && opts.Version != "" {
+		outputPath = CollectionFilePath(strings.ReplaceAll(opts.Version, ".", "-"))
+	}
+
 	var processedCollections []string
 
+	// With a single output path, the index takes the first collection's version (or opts.Version)
+	var combined *types.HFCollection
+	var combinedVersion string
+	seenModels := make(map[string]bool)
+
 	// Process each discovered collection
 	for _, slug := range collectionSlugs {
 		log.Printf("Processing collection: %s", slug)
 
-		collection, err := FetchCollectionDetails(slug)
+		collection, err := fetchCollectionDetails(client, slug)
 		if err != nil {
 			log.Printf("Failed to fetch collection details for %s: %v", slug, err)
 			continue
@@ -252,15 +275,34 @@ func ProcessCollections() error {
 		log.Printf("Found collection: %s", collection.Title)
 
 		// Parse version from title
-		version := parseVersionFromTitle(collection.Title)
+		version := opts.Version
+		if version == "" {
+			version = parseVersionFromTitle(collection.Title)
+		}
 		if version == "" {
 			version = "v1.0" // Default fallback
 		}
 
 		log.Printf("Detected version: %s", version)
 
+		// With a single output path, items from every collection go into one index
+		if outputPath != "" {
+			if combined == nil {
+				combined = &types.HFCollection{Title: collection.Title}
+				combinedVersion = version
+			}
+			for _, item := range collection.Items {
+				if !seenModels[item.ID] {
+					seenModels[item.ID] = true
+					combined.Items = append(combined.Items, item)
+				}
+			}
+			processedCollections = append(processedCollections, version)
+			continue
+		}
+
 		// Generate index file for this version
-		err = generateVersionIndex(collection, version)
+		err = writeVersionIndex(collection, version, CollectionFilePath(strings.ReplaceAll(version, ".", "-")))
 		if err != nil {
 			log.Printf("Failed to generate version index for %s: %v", version, err)
 			continue
@@ -269,6 +311,13 @@ func ProcessCollections() error {
 		processedCollections = append(processedCollections, version)
 	}
 
+	if outputPath != "" {
+		if combined == nil {
+			return fmt.Errorf("no collections could be fetched")
+		}
+		return writeVersionIndex(combined, combinedVersion, outputPath)
+	}
+
 	// Generate merged index from all processed collections
 	if len(processedCollections) > 1 {
 		log.Println("Generating merged index from multiple collections...")
@@ -280,3 +329,30 @@ func ProcessCollections() error {
 
 	return nil
 }
+
+// selectCollections returns the slugs of the collections matching search, or of the discovered
+// validated model collections when search is empty
+func selectCollections(client *http.Client, search string) ([]string, error) {
+	if search != "" {
+		log.Printf("Searching HuggingFace collections for %q...", search)
+		collections, err := searchCollections(client, search)
+		if err != nil {
+			return nil, err
+		}
+		slugs := make([]string, 0, len(collections))
+		for _, collection := range collections {
+			slugs = append(slugs, collection.Slug)
+		}
+		return slugs, nil
+	}
+
+	log.Println("Discovering Red Hat AI validated model collections...")
+
+	// Try to discover collections automatically
+	collectionSlugs, err := discoverValidatedModelCollections(client)
+	if err != nil {
+		log.Printf("Failed to discover collections, using known collections: %v", err)
+		return knownCollectionSlugs, nil
+	}
+	return collectionSlugs, nil
+}
@@ -1,7 +1,16 @@
 package huggingface
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
 func TestParseVersionFromTitle(t *testing.T) {
@@ -106,3 +115,78 @@ func TestParseVersionFromTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildVersionIndex(t *testing.T) {
+	collection := &types.HFCollection{
+		Title: "Red Hat AI validated models - v2.0",
+		Items: []types.HFModel{{ID: "RedHatAI/model-a"}, {ID: "RedHatAI/model-b"}},
+	}
+
+	expected := types.VersionIndex{
+		Version: "v2.0",
+		Models: []types.ModelIndex{
+			{Name: "RedHatAI/model-a", URL: "https://huggingface.co/RedHatAI/model-a", ReadmePath: "/RedHatAI/model-a/README.md"},
+			{Name: "RedHatAI/model-b", URL: "https://huggingface.co/RedHatAI/model-b", ReadmePath: "/RedHatAI/model-b/README.md"},
+		},
+	}
+	if got := buildVersionIndex(collection, "v2.0"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildVersionIndex() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestProcessCollectionsWithOptions(t *testing.T) {
+	SetRateLimit(0)
+	defer SetRateLimit(DefaultRequestsPerSecond)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/collections":
+			if got := r.URL.Query().Get("search"); got != "validated-v2" {
+				t.Errorf("search = %q, want validated-v2", got)
+			}
+			_, _ = w.Write([]byte(`[{"slug": "RedHatAI/first"}, {"slug": "RedHatAI/second"}]`))
+		case "/api/collections/RedHatAI/first":
+			_, _ = w.Write([]byte(`{"title": "First", "items": [{"id": "RedHatAI/model-a"}, {"id": "RedHatAI/model-b"}]}`))
+		case "/api/collections/RedHatAI/second":
+			_, _ = w.Write([]byte(`{"title": "Second", "items": [{"id": "RedHatAI/model-b"}, {"id": "RedHatAI/model-c"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	origBaseURL := apiBaseURL
+	apiBaseURL = srv.URL
+	defer func() { apiBaseURL = origBaseURL }()
+
+	outputPath := filepath.Join(t.TempDir(), "collections", "index-v2-0.yaml")
+	err := ProcessCollectionsWithOptions(ProcessOptions{
+		SearchTerm: "validated-v2",
+		Version:    "v2.0",
+		OutputPath: outputPath,
+		HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("ProcessCollectionsWithOptions() error: %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated index: %v", err)
+	}
+	var index types.VersionIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		t.Fatalf("Failed to parse generated index: %v", err)
+	}
+
+	if index.Version != "v2.0" {
+		t.Errorf("Version = %q, want v2.0", index.Version)
+	}
+	var names []string
+	for _, model := range index.Models {
+		names = append(names, model.Name)
+	}
+	if expected := []string{"RedHatAI/model-a", "RedHatAI/model-b", "RedHatAI/model-c"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Models = %v, want %v", names, expected)
+	}
+}
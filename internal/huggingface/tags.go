@@ -3,6 +3,8 @@ package huggingface
 import (
 	"regexp"
 	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // ParseTagsForStructuredData extracts structured metadata from HuggingFace tags
@@ -29,8 +31,12 @@ func ParseTagsForStructuredData(tags []string) (languages []string, license stri
 		"image-classification":         "image-classification",
 		"image-to-text":                "image-to-text",
 		"text-to-image":                "text-generation",
-		"feature-extraction":           "text-generation",
+		"feature-extraction":           "feature-extraction",
 		"sentence-similarity":          "sentence-similarity",
+		"sentence-transformers":        "sentence-similarity",
+		"embeddings":                   "feature-extraction",
+		"reranker":                     "text-ranking",
+		"cross-encoder":                "text-ranking",
 		"zero-shot-classification":     "text-classification",
 		"token-classification":         "text-classification",
 		"fill-mask":                    "text-generation",
@@ -87,8 +93,12 @@ func ParseTagsForStructuredData(tags []string) (languages []string, license stri
 			continue
 		}
 
-		// Check if it's a task type and normalize it
-		if normalizedTask, exists := taskTypes[tag]; exists {
+		// Check if it's a task type and normalize it; --task-map overrides take precedence
+		normalizedTask, exists := utils.TaskOverride(tag)
+		if !exists {
+			normalizedTask, exists = taskTypes[tag]
+		}
+		if exists {
 			// Avoid duplicates
 			found := false
 			for _, existingTask := range tasks {
@@ -132,8 +142,76 @@ func InferTasksFromReadme(readme string) []string {
 	return tasks
 }
 
+// architectureTaskSuffixes maps transformers architecture class suffixes to the task they imply.
+// Only suffixes with a single unambiguous task are listed (e.g. ForConditionalGeneration is not).
+var architectureTaskSuffixes = []struct {
+	suffix string
+	task   string
+}{
+	{"ForCausalLM", "text-generation"},
+	{"ForSequenceClassification", "text-classification"},
+	{"ForQuestionAnswering", "question-answering"},
+	{"ForTokenClassification", "token-classification"},
+	{"ForMaskedLM", "fill-mask"},
+	{"ForMultipleChoice", "multiple-choice"},
+	{"ForImageClassification", "image-classification"},
+	{"ForCTC", "automatic-speech-recognition"},
+}
+
+// InferTasksFromArchitecture infers model tasks from a config.json architecture class name,
+// e.g. "LlamaForCausalLM" -> ["text-generation"]. Unknown architectures return nil.
+func InferTasksFromArchitecture(arch string) []string {
+	arch = strings.TrimSpace(arch)
+	for _, mapping := range architectureTaskSuffixes {
+		if strings.HasSuffix(arch, mapping.suffix) && len(arch) > len(mapping.suffix) {
+			return []string{mapping.task}
+		}
+	}
+	return nil
+}
+
+// allowedTags and deniedTags hold the lowercased --allow-tags / --deny-tags lists. They are
+// set once at startup, before enrichment workers start.
+var (
+	allowedTags map[string]bool
+	deniedTags  map[string]bool
+)
+
+// SetTagFilter configures tags that are always kept by FilterTagsForCleanTagList (allow) and
+// tags that are always dropped from enriched tag lists (deny). Matching is case-insensitive
+// and deny wins when a tag is in both lists.
+func SetTagFilter(allow, deny []string) {
+	allowedTags = lowerSet(allow)
+	deniedTags = lowerSet(deny)
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
+
+// RemoveDeniedTags returns tags without those in the deny list
+func RemoveDeniedTags(tags []string) []string {
+	if len(deniedTags) == 0 {
+		return tags
+	}
+	kept := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !deniedTags[strings.ToLower(strings.TrimSpace(tag))] {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
 // FilterTagsForCleanTagList filters HuggingFace repository tags to only include clean tags
-// suitable for the tags field, excluding language codes, arxiv references, and other metadata
+// suitable for the tags field, excluding language codes, arxiv references, and other metadata.
+// Tags in the allow list skip this baseline filtering; tags in the deny list are always dropped.
 func FilterTagsForCleanTagList(tags []string) []string {
 	var filteredTags []string
 
@@ -179,6 +257,15 @@ func FilterTagsForCleanTagList(tags []string) []string {
 			continue
 		}
 
+		// Configured tag lists override the baseline filtering below
+		if deniedTags[lowerTag] {
+			continue
+		}
+		if allowedTags[lowerTag] {
+			filteredTags = append(filteredTags, originalTag)
+			continue
+		}
+
 		// Skip language codes
 		if languageCodes[lowerTag] {
 			continue
@@ -3,6 +3,8 @@ package huggingface
 import (
 	"reflect"
 	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestParseTagsForStructuredData(t *testing.T) {
@@ -55,6 +57,20 @@ func TestParseTagsForStructuredData(t *testing.T) {
 			expectedLicense:   "",
 			expectedTasks:     nil,
 		},
+		{
+			name:              "embedding model tags",
+			tags:              []string{"sentence-transformers", "feature-extraction", "embeddings", "en"},
+			expectedLanguages: []string{"en"},
+			expectedLicense:   "",
+			expectedTasks:     []string{"sentence-similarity", "feature-extraction"},
+		},
+		{
+			name:              "reranker model tags",
+			tags:              []string{"cross-encoder", "reranker", "text-ranking"},
+			expectedLanguages: nil,
+			expectedLicense:   "",
+			expectedTasks:     []string{"text-ranking"},
+		},
 		{
 			name:              "case insensitive processing",
 			tags:              []string{"EN", "TEXT-GENERATION", "LICENSE:MIT"},
@@ -82,3 +98,105 @@ func TestParseTagsForStructuredData(t *testing.T) {
 		})
 	}
 }
+
+func TestInferTasksFromArchitecture(t *testing.T) {
+	tests := []struct {
+		arch     string
+		expected []string
+	}{
+		{"LlamaForCausalLM", []string{"text-generation"}},
+		{"GraniteMoeForCausalLM", []string{"text-generation"}},
+		{"BertForSequenceClassification", []string{"text-classification"}},
+		{"RobertaForQuestionAnswering", []string{"question-answering"}},
+		{"DistilBertForTokenClassification", []string{"token-classification"}},
+		{"BertForMaskedLM", []string{"fill-mask"}},
+		{"ViTForImageClassification", []string{"image-classification"}},
+		{"Wav2Vec2ForCTC", []string{"automatic-speech-recognition"}},
+		{" MistralForCausalLM ", []string{"text-generation"}},
+		{"T5ForConditionalGeneration", nil},
+		{"BertModel", nil},
+		{"ForCausalLM", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arch, func(t *testing.T) {
+			got := InferTasksFromArchitecture(tt.arch)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("InferTasksFromArchitecture(%q) = %v, want %v", tt.arch, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterTagsForCleanTagList_AllowDenyLists(t *testing.T) {
+	t.Cleanup(func() { SetTagFilter(nil, nil) })
+
+	tags := []string{"granite", "en", "autotrain", "Endpoints_Compatible", "arxiv:2310.01234", "safetensors"}
+
+	tests := []struct {
+		name     string
+		allow    []string
+		deny     []string
+		expected []string
+	}{
+		{
+			name:     "baseline filtering without lists",
+			expected: []string{"granite", "autotrain", "Endpoints_Compatible", "safetensors"},
+		},
+		{
+			name:     "deny list drops tags case-insensitively",
+			deny:     []string{"autotrain", "endpoints_compatible"},
+			expected: []string{"granite", "safetensors"},
+		},
+		{
+			name:     "allow list keeps tags the baseline filter drops",
+			allow:    []string{"en"},
+			expected: []string{"granite", "en", "autotrain", "Endpoints_Compatible", "safetensors"},
+		},
+		{
+			name:     "deny wins over allow",
+			allow:    []string{"granite"},
+			deny:     []string{"granite"},
+			expected: []string{"autotrain", "Endpoints_Compatible", "safetensors"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTagFilter(tt.allow, tt.deny)
+			got := FilterTagsForCleanTagList(tags)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("FilterTagsForCleanTagList() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveDeniedTags(t *testing.T) {
+	t.Cleanup(func() { SetTagFilter(nil, nil) })
+
+	tags := []string{"validated", "autotrain", "granite"}
+	if got := RemoveDeniedTags(tags); !reflect.DeepEqual(got, tags) {
+		t.Errorf("RemoveDeniedTags() without deny list = %v, want %v", got, tags)
+	}
+
+	SetTagFilter(nil, []string{" AutoTrain "})
+	if got := RemoveDeniedTags(tags); !reflect.DeepEqual(got, []string{"validated", "granite"}) {
+		t.Errorf("RemoveDeniedTags() = %v, want [validated granite]", got)
+	}
+}
+
+func TestParseTagsForStructuredData_TaskMapOverrides(t *testing.T) {
+	utils.SetTaskMapOverrides(map[string]string{
+		"embeddings":         "feature-extraction",
+		"feature-extraction": "feature-extraction",
+	})
+	t.Cleanup(func() { utils.SetTaskMapOverrides(nil) })
+
+	_, _, tasks := ParseTagsForStructuredData([]string{"embeddings", "feature-extraction", "text-generation", "safetensors"})
+	expected := []string{"feature-extraction", "text-generation"}
+	if !reflect.DeepEqual(tasks, expected) {
+		t.Errorf("ParseTagsForStructuredData() tasks = %v, want %v", tasks, expected)
+	}
+}
@@ -0,0 +1,76 @@
+package huggingface
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// MergeVersionIndexes unions the models of two version indexes by name, so models dropped from a
+// newer collection version are carried forward. For a model in both, the overlay's URL and
+// readme path win unless empty. The result takes the overlay's version (or the base's when the
+// overlay has none) and lists models sorted by name.
+func MergeVersionIndexes(base, overlay types.VersionIndex) types.VersionIndex {
+	models := make(map[string]types.ModelIndex, len(base.Models)+len(overlay.Models))
+	for _, model := range base.Models {
+		models[model.Name] = model
+	}
+	for _, model := range overlay.Models {
+		merged := models[model.Name]
+		merged.Name = model.Name
+		if model.URL != "" {
+			merged.URL = model.URL
+		}
+		if model.ReadmePath != "" {
+			merged.ReadmePath = model.ReadmePath
+		}
+		models[model.Name] = merged
+	}
+
+	mergedModels := make([]types.ModelIndex, 0, len(models))
+	for _, model := range models {
+		mergedModels = append(mergedModels, model)
+	}
+	sort.Slice(mergedModels, func(i, j int) bool {
+		return mergedModels[i].Name < mergedModels[j].Name
+	})
+
+	version := overlay.Version
+	if version == "" {
+		version = base.Version
+	}
+	return types.VersionIndex{Version: version, Models: mergedModels}
+}
+
+// LoadVersionIndex reads a version index file
+func LoadVersionIndex(path string) (types.VersionIndex, error) {
+	var index types.VersionIndex
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return index, fmt.Errorf("failed to read version index %s: %v", path, err)
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return index, fmt.Errorf("failed to parse version index %s: %v", path, err)
+	}
+	return index, nil
+}
+
+// SaveVersionIndex writes a version index file, creating its directory if needed
+func SaveVersionIndex(path string, index types.VersionIndex) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create version index directory: %v", err)
+	}
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal version index to YAML: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write version index file: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package huggingface
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestMergeVersionIndexes(t *testing.T) {
+	granite := types.ModelIndex{Name: "RedHatAI/granite-3.1-8b-instruct", URL: "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct", ReadmePath: "/RedHatAI/granite-3.1-8b-instruct/README.md"}
+	llama := types.ModelIndex{Name: "RedHatAI/Llama-3.1-8B-Instruct", URL: "https://huggingface.co/RedHatAI/Llama-3.1-8B-Instruct", ReadmePath: "/RedHatAI/Llama-3.1-8B-Instruct/README.md"}
+	qwen := types.ModelIndex{Name: "RedHatAI/Qwen3-8B", URL: "https://huggingface.co/RedHatAI/Qwen3-8B", ReadmePath: "/RedHatAI/Qwen3-8B/README.md"}
+
+	tests := []struct {
+		name     string
+		base     types.VersionIndex
+		overlay  types.VersionIndex
+		expected types.VersionIndex
+	}{
+		{
+			name:     "disjoint model sets are unioned",
+			base:     types.VersionIndex{Version: "v2025.05", Models: []types.ModelIndex{llama}},
+			overlay:  types.VersionIndex{Version: "v2026.01", Models: []types.ModelIndex{qwen, granite}},
+			expected: types.VersionIndex{Version: "v2026.01", Models: []types.ModelIndex{llama, qwen, granite}},
+		},
+		{
+			name: "overlapping models prefer overlay values",
+			base: types.VersionIndex{Version: "v2025.05", Models: []types.ModelIndex{granite, llama}},
+			overlay: types.VersionIndex{Version: "v2026.01", Models: []types.ModelIndex{
+				{Name: granite.Name, URL: "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct-v2", ReadmePath: "/RedHatAI/granite-3.1-8b-instruct-v2/README.md"},
+			}},
+			expected: types.VersionIndex{Version: "v2026.01", Models: []types.ModelIndex{
+				llama,
+				{Name: granite.Name, URL: "https://huggingface.co/RedHatAI/granite-3.1-8b-instruct-v2", ReadmePath: "/RedHatAI/granite-3.1-8b-instruct-v2/README.md"},
+			}},
+		},
+		{
+			name:     "empty overlay fields keep base values",
+			base:     types.VersionIndex{Version: "v2025.05", Models: []types.ModelIndex{granite}},
+			overlay:  types.VersionIndex{Models: []types.ModelIndex{{Name: granite.Name}}},
+			expected: types.VersionIndex{Version: "v2025.05", Models: []types.ModelIndex{granite}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeVersionIndexes(tt.base, tt.overlay)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MergeVersionIndexes() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSaveAndLoadVersionIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collections", "merged.yaml")
+	index := types.VersionIndex{Version: "v2026.01", Models: []types.ModelIndex{
+		{Name: "RedHatAI/Qwen3-8B", URL: "https://huggingface.co/RedHatAI/Qwen3-8B", ReadmePath: "/RedHatAI/Qwen3-8B/README.md"},
+	}}
+
+	if err := SaveVersionIndex(path, index); err != nil {
+		t.Fatalf("SaveVersionIndex failed: %v", err)
+	}
+	loaded, err := LoadVersionIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVersionIndex failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, index) {
+		t.Errorf("Expected %+v, got %+v", index, loaded)
+	}
+
+	if _, err := LoadVersionIndex(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,114 @@
+// Package logging configures the process-wide leveled logger used by the CLI tools.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Supported log output formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// levels maps the --log-level values to slog levels
+var levels = map[string]slog.Level{
+	"debug":   slog.LevelDebug,
+	"info":    slog.LevelInfo,
+	"warn":    slog.LevelWarn,
+	"warning": slog.LevelWarn,
+	"error":   slog.LevelError,
+}
+
+// ParseLevel converts a level name (debug, info, warn or error) to a slog level
+func ParseLevel(level string) (slog.Level, error) {
+	l, ok := levels[strings.ToLower(strings.TrimSpace(level))]
+	if !ok {
+		return 0, fmt.Errorf("invalid log level %q (expected debug, info, warn or error)", level)
+	}
+	return l, nil
+}
+
+// Setup installs the default slog logger writing to stderr with the given level and
+// format (see SetDefault)
+func Setup(level, format string) error {
+	handler, err := NewHandler(os.Stderr, level, format)
+	if err != nil {
+		return err
+	}
+	SetDefault(handler)
+	return nil
+}
+
+// SetDefault makes handler the default slog handler and routes the standard log package
+// through it. log lines are leveled by their message, as slog.SetDefault would log them all
+// at info and drop "Warning: ..." lines at --log-level=warn: lines starting with "Warning" or
+// "Failed" are warnings, lines starting with "Error" are errors and the rest are info.
+func SetDefault(handler slog.Handler) {
+	slog.SetDefault(slog.New(handler))
+	log.SetOutput(&logWriter{handler: handler})
+	log.SetFlags(0) // the handler adds the time
+}
+
+// logWriter is the standard log package output installed by SetDefault
+type logWriter struct {
+	handler slog.Handler
+}
+
+// Write logs one log package line at the level of its message
+func (w *logWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	level := LogLineLevel(msg)
+	if !w.handler.Enabled(context.Background(), level) {
+		return len(p), nil
+	}
+	return len(p), w.handler.Handle(context.Background(), slog.NewRecord(time.Now(), level, msg, 0))
+}
+
+// LogLineLevel returns the level of a standard log package message, from its leading word
+func LogLineLevel(msg string) slog.Level {
+	msg = strings.TrimSpace(msg)
+	switch {
+	case strings.HasPrefix(msg, "Error"):
+		return slog.LevelError
+	case strings.HasPrefix(msg, "Warning"), strings.HasPrefix(msg, "Failed"):
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// exit ends the process after Fatalf; replaced in tests
+var exit = os.Exit
+
+// Fatalf logs a message at error level, which every --log-level shows, and exits with status 1.
+// CLI tools use it instead of log.Fatalf so the reason for a failed run is never filtered out.
+func Fatalf(format string, args ...any) {
+	slog.Error(fmt.Sprintf(format, args...))
+	exit(1)
+}
+
+// NewHandler creates a slog handler for the given level and format
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: l}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q (expected %s or %s)", format, FormatText, FormatJSON)
+	}
+}
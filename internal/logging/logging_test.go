@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      slog.Level
+		expectErr bool
+	}{
+		{input: "debug", want: slog.LevelDebug},
+		{input: "INFO", want: slog.LevelInfo},
+		{input: "warn", want: slog.LevelWarn},
+		{input: "warning", want: slog.LevelWarn},
+		{input: " error ", want: slog.LevelError},
+		{input: "verbose", expectErr: true},
+		{input: "", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("ParseLevel(%q) expected error, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLevel(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHandler_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, "info", FormatJSON)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	logger := slog.New(handler)
+
+	logger.Debug("layer details", "digest", "sha256:abc")
+	logger.Info("processing model", "model", "registry.example.com/org/model:1.0")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected only the info record, got %d lines: %q", len(lines), buf.String())
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if record["level"] != "INFO" || record["msg"] != "processing model" || record["model"] != "registry.example.com/org/model:1.0" {
+		t.Errorf("Unexpected record: %v", record)
+	}
+}
+
+func TestNewHandler_TextDebug(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, "debug", FormatText)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	slog.New(handler).Debug("layer details", "digest", "sha256:abc")
+
+	if !strings.Contains(buf.String(), "level=DEBUG") || !strings.Contains(buf.String(), "digest=sha256:abc") {
+		t.Errorf("Expected debug record in text output, got %q", buf.String())
+	}
+}
+
+func TestNewHandler_InvalidFormat(t *testing.T) {
+	if _, err := NewHandler(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+	if _, err := NewHandler(&bytes.Buffer{}, "loud", FormatText); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
+
+// useHandler installs handler as the default for one test, restoring the slog and log defaults
+func useHandler(t *testing.T, handler slog.Handler) {
+	previous, previousOutput, previousFlags := slog.Default(), log.Writer(), log.Flags()
+	SetDefault(handler)
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		log.SetOutput(previousOutput)
+		log.SetFlags(previousFlags)
+	})
+}
+
+func TestSetDefault_LogPackageLevels(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+		hide  []string
+	}{
+		{level: "info", want: []string{"Starting processing", "Warning: no modelcard", "Error reading static catalog"}},
+		{level: "warn", want: []string{"Warning: no modelcard", "Failed processing", "Error reading static catalog"}, hide: []string{"Starting processing"}},
+		{level: "error", want: []string{"Error reading static catalog"}, hide: []string{"Starting processing", "Warning: no modelcard", "Failed processing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler, err := NewHandler(&buf, tt.level, FormatText)
+			if err != nil {
+				t.Fatalf("NewHandler failed: %v", err)
+			}
+			useHandler(t, handler)
+
+			log.Printf("Starting processing for: %s", "registry.example.com/org/model:1.0")
+			log.Printf("  Warning: no modelcard for %s", "registry.example.com/org/model:1.0")
+			log.Printf("Failed processing for %s: timeout", "registry.example.com/org/model:1.0")
+			log.Printf("  Error reading static catalog file %s: denied", "static.yaml")
+
+			output := buf.String()
+			for _, msg := range tt.want {
+				if !strings.Contains(output, msg) {
+					t.Errorf("Expected %q at --log-level=%s, got:\n%s", msg, tt.level, output)
+				}
+			}
+			for _, msg := range tt.hide {
+				if strings.Contains(output, msg) {
+					t.Errorf("Expected %q to be filtered at --log-level=%s, got:\n%s", msg, tt.level, output)
+				}
+			}
+		})
+	}
+}
+
+func TestFatalf_ShownAtErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, "error", FormatJSON)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	useHandler(t, handler)
+
+	exitCode := -1
+	exit = func(code int) { exitCode = code }
+	t.Cleanup(func() { exit = os.Exit })
+
+	Fatalf("Invalid --max-concurrent: must be at least 1, got %d", 0)
+
+	if exitCode != 1 {
+		t.Errorf("exit code = %d, want 1", exitCode)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Fatal output is not a JSON record: %v (%q)", err, buf.String())
+	}
+	if record["level"] != "ERROR" || record["msg"] != "Invalid --max-concurrent: must be at least 1, got 0" {
+		t.Errorf("Unexpected fatal record: %v", record)
+	}
+}
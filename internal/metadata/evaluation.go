@@ -0,0 +1,164 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// evaluationHeadingRegex matches the markdown headings of sections holding benchmark results
+	evaluationHeadingRegex = regexp.MustCompile(`(?i)^(#{1,6})\s+.*\b(evaluations?|benchmarks?)\b`)
+	headingRegex           = regexp.MustCompile(`^(#{1,6})\s+`)
+	// tableSeparatorRegex matches the row under a markdown table header, e.g. "|---|:---:|"
+	tableSeparatorRegex = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	// evaluationScoreRegex matches a score cell: a number, optionally a percentage, followed by
+	// anything else in the cell (e.g. "± 0.4")
+	evaluationScoreRegex = regexp.MustCompile(`^([-+]?\d+(?:\.\d+)?)\s*(%?)`)
+	nonAlphanumericRegex = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// evaluationDescriptorColumns are headers of table columns describing how a benchmark was run
+// rather than scoring a model, skipped when looking for the score column
+var evaluationDescriptorColumns = map[string]bool{
+	"shots":   true,
+	"n_shot":  true,
+	"n_shots": true,
+	"shot":    true,
+	"metric":  true,
+	"setting": true,
+	"type":    true,
+}
+
+// ExtractEvaluationResults returns the benchmark scores of markdown tables under "Evaluation" or
+// "Benchmarks" headings, keyed by metric (see EvaluationMetricKey), e.g. "mmlu": "68.2". Tables
+// may list benchmarks as rows or, under a "Model" first column, as columns; when a table compares
+// several models, the column or row named modelName is used. The first score of a metric wins
+// across tables. It returns nil when the card has no evaluation tables.
+func ExtractEvaluationResults(content string, modelName string) map[string]string {
+	lines := strings.Split(content, "\n")
+	results := make(map[string]string)
+
+	sectionLevel := 0
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if match := headingRegex.FindStringSubmatch(line); match != nil {
+			level := len(match[1])
+			if evaluationHeadingRegex.MatchString(line) {
+				if sectionLevel == 0 || level <= sectionLevel {
+					sectionLevel = level
+				}
+			} else if sectionLevel > 0 && level <= sectionLevel {
+				sectionLevel = 0
+			}
+			continue
+		}
+		if sectionLevel == 0 || !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		// Collect the table starting at this line; its second line must be the header separator
+		start := i
+		var table [][]string
+		for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+			table = append(table, splitTableRow(lines[i]))
+		}
+		i--
+		if len(table) < 3 || !tableSeparatorRegex.MatchString(strings.TrimSpace(lines[start+1])) {
+			continue
+		}
+		for metric, score := range tableScores(table[0], table[2:], modelName) {
+			if _, exists := results[metric]; !exists {
+				results[metric] = score
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// tableScores reads the scores of one evaluation table from its header and data rows
+func tableScores(header []string, rows [][]string, modelName string) map[string]string {
+	scores := make(map[string]string)
+	modelKey := EvaluationMetricKey(modelName)
+
+	if strings.Contains(strings.ToLower(header[0]), "model") {
+		// Benchmarks are columns and each row is a model; use the row of this model, or the only row
+		row := -1
+		for r, cells := range rows {
+			if modelKey != "" && EvaluationMetricKey(cells[0]) == modelKey {
+				row = r
+				break
+			}
+		}
+		if row < 0 && len(rows) == 1 {
+			row = 0
+		}
+		if row < 0 {
+			return scores
+		}
+		for c := 1; c < len(header) && c < len(rows[row]); c++ {
+			addScore(scores, header[c], rows[row][c])
+		}
+		return scores
+	}
+
+	// Benchmarks are rows; use the column of this model, or the first score in each row
+	column := -1
+	for c := 1; c < len(header); c++ {
+		if modelKey != "" && EvaluationMetricKey(header[c]) == modelKey {
+			column = c
+			break
+		}
+	}
+	for _, cells := range rows {
+		if column >= 0 {
+			if column < len(cells) {
+				addScore(scores, cells[0], cells[column])
+			}
+			continue
+		}
+		for c := 1; c < len(cells) && c < len(header); c++ {
+			if evaluationDescriptorColumns[EvaluationMetricKey(header[c])] {
+				continue
+			}
+			if evaluationScoreRegex.MatchString(cells[c]) {
+				addScore(scores, cells[0], cells[c])
+				break
+			}
+		}
+	}
+	return scores
+}
+
+// addScore records the score of a metric when the cell holds a number and the metric is new
+func addScore(scores map[string]string, metric, cell string) {
+	key := EvaluationMetricKey(metric)
+	match := evaluationScoreRegex.FindStringSubmatch(cell)
+	if key == "" || match == nil {
+		return
+	}
+	if _, exists := scores[key]; !exists {
+		scores[key] = match[1] + match[2]
+	}
+}
+
+// splitTableRow returns the cells of a markdown table row with surrounding markdown emphasis removed
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(strings.Trim(strings.TrimSpace(cell), "*_`"))
+	}
+	return cells
+}
+
+// EvaluationMetricKey normalizes a benchmark name to a lowercase key of letters, digits and
+// underscores, e.g. "MMLU (5-shot)" -> "mmlu_5_shot"
+func EvaluationMetricKey(name string) string {
+	return strings.Trim(nonAlphanumericRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
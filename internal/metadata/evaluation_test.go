@@ -0,0 +1,154 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractEvaluationResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		modelName string
+		expected  map[string]string
+	}{
+		{
+			name: "benchmark rows with a single score column",
+			content: `# Granite 3.1 8B Instruct
+
+## Evaluation Results
+
+| Benchmark | Score |
+|-----------|------:|
+| MMLU (5-shot) | 65.82 |
+| **GSM8K** | 70.3% |
+| HumanEval | n/a |
+`,
+			modelName: "Granite 3.1 8B Instruct",
+			expected:  map[string]string{"mmlu_5_shot": "65.82", "gsm8k": "70.3%"},
+		},
+		{
+			name: "comparison table uses this model's column and skips shot counts",
+			content: `## Benchmarks
+
+| Benchmark | Shots | Llama 3.1 8B Instruct | Granite 3.1 8B Instruct |
+|---|---|---|---|
+| MMLU | 5 | 69.4 | 65.8 |
+| ARC-Challenge | 25 | 83.4 | 62.6 |
+`,
+			modelName: "Granite-3.1-8B-Instruct",
+			expected:  map[string]string{"mmlu": "65.8", "arc_challenge": "62.6"},
+		},
+		{
+			name: "unknown model falls back to the first score column",
+			content: `## Benchmarks
+
+| Benchmark | Shots | Model A | Model B |
+|---|---|---|---|
+| MMLU | 5 | 69.4 | 65.8 |
+`,
+			modelName: "Other Model",
+			expected:  map[string]string{"mmlu": "69.4"},
+		},
+		{
+			name: "benchmark columns with a row per model",
+			content: `### Evaluation
+
+| Models | ARC | MMLU | TruthfulQA |
+|:-------|:---:|:----:|:----------:|
+| Llama-3.1-8B | 55.1 | 66.7 | 45.2 |
+| Granite-3.1-8B-Base | 63.9 | 65.1 | 52.7 |
+`,
+			modelName: "granite-3.1-8b-base",
+			expected:  map[string]string{"arc": "63.9", "mmlu": "65.1", "truthfulqa": "52.7"},
+		},
+		{
+			name: "multiple tables across subsections keep the first score of each metric",
+			content: `## Evaluation
+
+### Knowledge
+
+| Benchmark | Score |
+| --- | --- |
+| MMLU | 65.8 |
+
+### Math
+
+| Benchmark | Score |
+| --- | --- |
+| GSM8K | 70.3 |
+| MMLU | 10.0 |
+
+## Usage
+
+| Benchmark | Score |
+| --- | --- |
+| Ignored | 1.0 |
+`,
+			expected: map[string]string{"mmlu": "65.8", "gsm8k": "70.3"},
+		},
+		{
+			name: "tables outside evaluation sections are ignored",
+			content: `## Hardware
+
+| GPU | Memory |
+| --- | --- |
+| A100 | 80 |
+`,
+			expected: nil,
+		},
+		{
+			name:     "evaluation section without a table",
+			content:  "## Evaluation\n\nSee the technical report for results.\n",
+			expected: nil,
+		},
+		{
+			name: "comparison table with rows per model and no matching row",
+			content: `## Benchmarks
+
+| Model | MMLU |
+| --- | --- |
+| Model A | 60.1 |
+| Model B | 61.2 |
+`,
+			modelName: "Model C",
+			expected:  nil,
+		},
+		{
+			name:     "table without a header separator",
+			content:  "## Evaluation\n\n| MMLU | 65.8 |\n| GSM8K | 70.3 |\n| ARC | 62.6 |\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractEvaluationResults(tt.content, tt.modelName)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExtractEvaluationResults() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_Evaluations(t *testing.T) {
+	content := "# Granite 3.1 8B Instruct\n\n## Evaluation\n\n| Benchmark | Score |\n|---|---|\n| MMLU | 65.8 |\n\n```\n## Benchmarks\n| Benchmark | Score |\n|---|---|\n| GSM8K | 1.0 |\n```\n"
+	result := ExtractMetadataValues([]byte(content))
+	if want := map[string]string{"mmlu": "65.8"}; !reflect.DeepEqual(result.Evaluations, want) {
+		t.Errorf("Evaluations = %v, want %v", result.Evaluations, want)
+	}
+}
+
+func TestEvaluationMetricKey(t *testing.T) {
+	tests := map[string]string{
+		"MMLU (5-shot)":  "mmlu_5_shot",
+		" ARC-Challenge": "arc_challenge",
+		"GSM8K":          "gsm8k",
+		"---":            "",
+	}
+	for name, expected := range tests {
+		if got := EvaluationMetricKey(name); got != expected {
+			t.Errorf("EvaluationMetricKey(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
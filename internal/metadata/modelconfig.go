@@ -0,0 +1,41 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// ModelConfig holds the fields of a HuggingFace-style config.json that describe the model architecture
+type ModelConfig struct {
+	Architectures []string `json:"architectures"`
+	ModelType     string   `json:"model_type"`
+}
+
+// ParseModelConfig parses the architecture fields of a config.json file
+func ParseModelConfig(data []byte) (*ModelConfig, error) {
+	var config ModelConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config.json: %w", err)
+	}
+	return &config, nil
+}
+
+// ApplyModelConfig copies the architectures and model type from a config.json into the
+// extracted metadata, keeping values the modelcard already provided
+func ApplyModelConfig(extracted *types.ExtractedMetadata, config *ModelConfig) {
+	if len(extracted.Architectures) == 0 {
+		for _, arch := range config.Architectures {
+			if arch = strings.TrimSpace(arch); arch != "" {
+				extracted.Architectures = append(extracted.Architectures, arch)
+			}
+		}
+	}
+	if extracted.ArchitectureType == nil {
+		if modelType := strings.TrimSpace(config.ModelType); modelType != "" {
+			extracted.ArchitectureType = &modelType
+		}
+	}
+}
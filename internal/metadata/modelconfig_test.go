@@ -0,0 +1,67 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestParseAndApplyModelConfig(t *testing.T) {
+	tests := []struct {
+		name                  string
+		config                string
+		existing              types.ExtractedMetadata
+		expectedArchitectures []string
+		expectedType          string
+		expectError           bool
+	}{
+		{
+			name:                  "causal LM config",
+			config:                `{"architectures": ["LlamaForCausalLM"], "model_type": "llama", "hidden_size": 4096}`,
+			expectedArchitectures: []string{"LlamaForCausalLM"},
+			expectedType:          "llama",
+		},
+		{
+			name:         "missing architectures",
+			config:       `{"model_type": "bert"}`,
+			expectedType: "bert",
+		},
+		{
+			name:                  "existing values are kept",
+			config:                `{"architectures": ["GraniteForCausalLM"], "model_type": "granite"}`,
+			existing:              types.ExtractedMetadata{Architectures: []string{"GraniteMoeForCausalLM"}},
+			expectedArchitectures: []string{"GraniteMoeForCausalLM"},
+			expectedType:          "granite",
+		},
+		{
+			name:        "invalid JSON",
+			config:      `{"architectures": [`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseModelConfig([]byte(tt.config))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error for invalid config.json")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			extracted := tt.existing
+			ApplyModelConfig(&extracted, config)
+			if !reflect.DeepEqual(extracted.Architectures, tt.expectedArchitectures) {
+				t.Errorf("Architectures = %v, want %v", extracted.Architectures, tt.expectedArchitectures)
+			}
+			if extracted.ArchitectureType == nil || *extracted.ArchitectureType != tt.expectedType {
+				t.Errorf("ArchitectureType = %v, want %q", extracted.ArchitectureType, tt.expectedType)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package metadata
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
@@ -30,9 +34,11 @@ var (
 	companyRegex = regexp.MustCompile(`(?i)(IBM|Microsoft|Meta|Google|OpenAI|Anthropic|Mistral|Neural Magic|Red Hat|Hugging Face|Facebook)\s+(?:Research|AI|Inc\.?|Corporation|Corp\.?)?`)
 
 	// Description extraction
-	overviewRegex     = regexp.MustCompile(`(?i)(?:## Model Overview|## Overview)\s*\n((?:[^\n]+\n)*?)(?:\n##|\n#|$)`)
-	descInOverviewRe  = regexp.MustCompile(`(?i)(?:^|\n)\s*(.+?(?:model|quantized version|intended for).{20,200}?)(?:\n|$)`)
-	descFallbackRegex = regexp.MustCompile(`(?s)^#[^\n]+\n\n([^\n#]+(?:\n[^\n#]+)*?)(?:\n\n|\n#|$)`)
+	overviewRegex    = regexp.MustCompile(`(?is)(?:## Model Overview|## Overview)[^\n]*\n(.*?)(?:\n#|$)`)
+	descInOverviewRe = regexp.MustCompile(`(?i)(?:^|\n)\s*(.+?(?:model|quantized version|intended for).{20,200}?)(?:\n|$)`)
+	// labelLineRegex matches structured "Key: value" lines such as "**Model Developers:** IBM",
+	// which must never be used as descriptions
+	labelLineRegex = regexp.MustCompile(`^\s*(?:[-*+]\s+)?\*{0,2}[A-Za-z][A-Za-z0-9 ()/&'.-]{0,40}?\*{0,2}:\*{0,2}(?:\s|$)`)
 
 	// License extraction
 	licenseRegex     = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:License(?:\(s\))?|Licensing):\*?\*?\s*(?:\[([^\]]+)\]|\*?([A-Za-z0-9\.\-_]+)\*?)`)
@@ -46,12 +52,21 @@ var (
 	// Task extraction
 	taskRegex = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Intended Use Cases?|Tasks?):\*?\*?\s*(.+)$`)
 
+	// Maturity extraction
+	maturityRegex = regexp.MustCompile(`(?i)^-?\s*\*{0,2}(?:Status|Maturity|Stability)\*{0,2}:\*{0,2}\s*(.+)$`)
+
+	// Parameter count extraction
+	modelSizeFieldRegex = regexp.MustCompile(`(?i)^-?\s*\*{0,2}(?:Parameters|Number of Parameters|Parameter Count|Params|Model Size|Size)\*{0,2}:\*{0,2}\s*(.+)$`)
+	modelSizeValueRegex = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(billion|million|[BM])\b`)
+	modelSizeProseRegex = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(billion|million|[BM])[\s-]+param`)
+
 	// Language extraction
 	supportedLangsRegex = regexp.MustCompile(`(?i)(?:(?:supported\s+languages?|languages?\s+supported):\s*([^.\n]+)|supports\s+\d+\s+languages?\s+in\s+addition\s+to\s+English:\s*([^.]+))`)
 	langFallbackRegex   = regexp.MustCompile(`(?i)(?:language|languages?).*?(?:in\s+)?([A-Z][a-z]+(?:\s+and\s+[A-Z][a-z]+)*)`)
 )
 
-// stringSlice is a helper type that can unmarshal from either a YAML scalar or sequence
+// stringSlice is a helper type that can unmarshal from either a scalar or a list,
+// in YAML, JSON or TOML frontmatter
 type stringSlice []string
 
 func (s *stringSlice) UnmarshalYAML(value *yaml.Node) error {
@@ -61,92 +76,220 @@ func (s *stringSlice) UnmarshalYAML(value *yaml.Node) error {
 		if err := value.Decode(&str); err != nil {
 			return err
 		}
-		trimmed := strings.TrimSpace(str)
-		if trimmed == "" {
-			*s = nil
-		} else {
-			*s = []string{trimmed}
-		}
+		*s = scalarStringSlice(str)
 		return nil
 	case yaml.SequenceNode:
 		var arr []string
 		if err := value.Decode(&arr); err != nil {
 			return err
 		}
-		// trim empties and dedupe
-		seen := map[string]struct{}{}
-		out := make([]string, 0, len(arr))
-		for _, v := range arr {
-			t := strings.TrimSpace(v)
-			if t == "" {
-				continue
-			}
-			if _, ok := seen[t]; ok {
-				continue
+		*s = dedupeStringSlice(arr)
+		return nil
+	default:
+		return fmt.Errorf("expected a string or a list of strings, got YAML node kind %v", value.Kind)
+	}
+}
+
+func (s *stringSlice) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = scalarStringSlice(str)
+		return nil
+	}
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return fmt.Errorf("expected a string or a list of strings: %v", err)
+	}
+	*s = dedupeStringSlice(arr)
+	return nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler
+func (s *stringSlice) UnmarshalTOML(data interface{}) error {
+	switch v := data.(type) {
+	case string:
+		*s = scalarStringSlice(v)
+	case []interface{}:
+		arr := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("expected a list of strings, got element of type %T", item)
 			}
-			seen[t] = struct{}{}
-			out = append(out, t)
+			arr = append(arr, str)
 		}
-		*s = out
+		*s = dedupeStringSlice(arr)
+	default:
+		return fmt.Errorf("expected a string or a list of strings, got %T", data)
+	}
+	return nil
+}
+
+// flexBool is a boolean that also accepts the strings modelcard authors tend to write,
+// e.g. deprecated: "yes", in YAML, JSON or TOML frontmatter. Values it does not recognize, such as
+// a date or "soon", are logged at debug level and read as false rather than failing the whole
+// frontmatter.
+type flexBool bool
+
+func (b *flexBool) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		b.invalid(fmt.Sprintf("YAML node kind %v", value.Kind))
 		return nil
+	}
+	b.set(value.Value)
+	return nil
+}
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		*b = flexBool(v)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		b.invalid(string(data))
+		return nil
+	}
+	b.set(str)
+	return nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler
+func (b *flexBool) UnmarshalTOML(data interface{}) error {
+	switch v := data.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		b.set(v)
+	default:
+		b.invalid(fmt.Sprintf("%v", data))
+	}
+	return nil
+}
+
+// set parses true/false, yes/no, on/off and 1/0, case-insensitively; empty is false
+func (b *flexBool) set(str string) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "true", "yes", "y", "on", "1":
+		*b = true
+	case "false", "no", "n", "off", "0", "":
+		*b = false
 	default:
-		return fmt.Errorf("validated_on: unsupported YAML node kind %v", value.Kind)
+		b.invalid(str)
+	}
+}
+
+// invalid reads an unrecognized value as false
+func (b *flexBool) invalid(value string) {
+	slog.Debug("Ignoring unrecognized boolean in modelcard frontmatter, treating it as false", "value", value)
+	*b = false
+}
+
+// scalarStringSlice wraps a single trimmed value, returning nil when it is empty
+func scalarStringSlice(str string) []string {
+	trimmed := strings.TrimSpace(str)
+	if trimmed == "" {
+		return nil
+	}
+	return []string{trimmed}
+}
+
+// dedupeStringSlice trims values, dropping empties and duplicates
+func dedupeStringSlice(arr []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(arr))
+	for _, v := range arr {
+		t := strings.TrimSpace(v)
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
 	}
+	return out
 }
 
-// ModelCardYAMLFrontmatter represents the YAML frontmatter in modelcard.md files
+// ModelCardYAMLFrontmatter represents the frontmatter in modelcard.md files. Despite the
+// name it is also filled from TOML (+++) and JSON ({...}) frontmatter.
 type ModelCardYAMLFrontmatter struct {
-	Language    []string    `yaml:"language"`
-	BaseModel   []string    `yaml:"base_model"`
-	PipelineTag string      `yaml:"pipeline_tag"`
-	License     string      `yaml:"license"`
-	LicenseName string      `yaml:"license_name"`
-	LicenseLink string      `yaml:"license_link"`
-	Tags        []string    `yaml:"tags"`
-	Name        string      `yaml:"name"`
-	Description string      `yaml:"description"`
-	Tasks       []string    `yaml:"tasks"`
-	Provider    string      `yaml:"provider"`
-	ValidatedOn stringSlice `yaml:"validated_on"`
-	HardwareTag stringSlice `yaml:"hardware_tag"`
+	Language    []string    `yaml:"language" json:"language" toml:"language"`
+	BaseModel   stringSlice `yaml:"base_model" json:"base_model" toml:"base_model"`
+	PipelineTag string      `yaml:"pipeline_tag" json:"pipeline_tag" toml:"pipeline_tag"`
+	License     string      `yaml:"license" json:"license" toml:"license"`
+	LicenseName string      `yaml:"license_name" json:"license_name" toml:"license_name"`
+	LicenseLink string      `yaml:"license_link" json:"license_link" toml:"license_link"`
+	Tags        []string    `yaml:"tags" json:"tags" toml:"tags"`
+	Name        string      `yaml:"name" json:"name" toml:"name"`
+	Description string      `yaml:"description" json:"description" toml:"description"`
+	Tasks       []string    `yaml:"tasks" json:"tasks" toml:"tasks"`
+	Provider    string      `yaml:"provider" json:"provider" toml:"provider"`
+	ValidatedOn stringSlice `yaml:"validated_on" json:"validated_on" toml:"validated_on"`
+	HardwareTag stringSlice `yaml:"hardware_tag" json:"hardware_tag" toml:"hardware_tag"`
+	Maturity    string      `yaml:"maturity" json:"maturity" toml:"maturity"`
+	Deprecated  flexBool    `yaml:"deprecated" json:"deprecated" toml:"deprecated"`
+	ReplacedBy  string      `yaml:"replaced_by" json:"replaced_by" toml:"replaced_by"`
 }
 
-// ExtractYAMLFrontmatterFromModelCard extracts YAML frontmatter from modelcard.md content
+// ExtractYAMLFrontmatterFromModelCard extracts frontmatter from modelcard.md content.
+// The format is detected from the opening delimiter: "---" for YAML (the common case),
+// "+++" for TOML, or a leading "{" for a JSON object.
 func ExtractYAMLFrontmatterFromModelCard(content string) (*ModelCardYAMLFrontmatter, error) {
 	if content == "" {
 		return nil, fmt.Errorf("empty modelcard content")
 	}
 
-	// Check if content starts with YAML frontmatter (---)
-	if !strings.HasPrefix(content, "---") {
-		return nil, fmt.Errorf("no YAML frontmatter found")
+	switch {
+	case strings.HasPrefix(content, "---"):
+		return extractDelimitedFrontmatter(content, "---", "YAML", yaml.Unmarshal)
+	case strings.HasPrefix(content, "+++"):
+		return extractDelimitedFrontmatter(content, "+++", "TOML", toml.Unmarshal)
+	case strings.HasPrefix(strings.TrimLeft(content, " \t\r\n"), "{"):
+		return extractJSONFrontmatter(content)
+	default:
+		return nil, fmt.Errorf("no frontmatter found (expected ---, +++ or a JSON object)")
 	}
+}
 
+// extractDelimitedFrontmatter parses frontmatter enclosed between two delimiter lines
+func extractDelimitedFrontmatter(content, delimiter, format string, unmarshal func([]byte, any) error) (*ModelCardYAMLFrontmatter, error) {
 	// Find the end of the frontmatter
 	lines := strings.Split(content, "\n")
 	endIndex := -1
 	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
+		if strings.TrimSpace(lines[i]) == delimiter {
 			endIndex = i
 			break
 		}
 	}
 
 	if endIndex == -1 {
-		return nil, fmt.Errorf("malformed YAML frontmatter: no closing ---")
+		return nil, fmt.Errorf("malformed %s frontmatter: no closing %s", format, delimiter)
 	}
 
-	// Extract and parse YAML content
-	yamlContent := strings.Join(lines[1:endIndex], "\n")
+	// Extract and parse frontmatter content
+	frontmatterContent := strings.Join(lines[1:endIndex], "\n")
 	var frontmatter ModelCardYAMLFrontmatter
-	err := yaml.Unmarshal([]byte(yamlContent), &frontmatter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse YAML frontmatter: %v", err)
+	if err := unmarshal([]byte(frontmatterContent), &frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to parse %s frontmatter: %v", format, err)
 	}
 
 	return &frontmatter, nil
 }
 
+// extractJSONFrontmatter parses a JSON object at the start of the content; the markdown
+// following the object is ignored
+func extractJSONFrontmatter(content string) (*ModelCardYAMLFrontmatter, error) {
+	var frontmatter ModelCardYAMLFrontmatter
+	if err := json.NewDecoder(strings.NewReader(content)).Decode(&frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON frontmatter: %v", err)
+	}
+	return &frontmatter, nil
+}
+
 // splitTaskString intelligently splits task strings while preserving URLs and markdown links
 func splitTaskString(taskStr string) []string {
 	// First, extract meaningful task-like terms before trying to split
@@ -166,12 +309,24 @@ func splitTaskString(taskStr string) []string {
 		`instruction-following`,
 		`assistant-like chat`,
 		`commercial and research use`,
+		`feature-extraction`,
+		`sentence-similarity`,
+		`text-ranking`,
+		`embedding`,
+		`rerank`,
 	}
+	// Tasks from --task-map are recognized too, normalized to their mapped category
+	taskPatterns = append(taskPatterns, utils.TaskOverrideKeys()...)
 
 	taskStr = strings.ToLower(taskStr)
 	for _, pattern := range taskPatterns {
 		if strings.Contains(taskStr, pattern) {
-			tasks = append(tasks, pattern)
+			if normalized, ok := utils.TaskOverride(pattern); ok {
+				pattern = normalized
+			}
+			if !slices.Contains(tasks, pattern) {
+				tasks = append(tasks, pattern)
+			}
 		}
 	}
 
@@ -233,6 +388,11 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 			metadata.Description = &frontmatter.Description
 		}
 
+		// Base models from YAML, kept for the catalog's lineage ("derived from") property
+		if len(frontmatter.BaseModel) > 0 {
+			metadata.BaseModel = frontmatter.BaseModel
+		}
+
 		// Language from YAML
 		if len(frontmatter.Language) > 0 {
 			metadata.Language = frontmatter.Language
@@ -278,6 +438,15 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		if len(frontmatter.HardwareTag) > 0 {
 			metadata.HardwareTag = []string(frontmatter.HardwareTag)
 		}
+
+		// Maturity from YAML
+		if maturity := NormalizeMaturity(frontmatter.Maturity); maturity != "" {
+			metadata.Maturity = &maturity
+		}
+
+		// Deprecation from YAML; naming a replacement implies the model is deprecated
+		metadata.ReplacedBy = strings.TrimSpace(frontmatter.ReplacedBy)
+		metadata.Deprecated = bool(frontmatter.Deprecated) || metadata.ReplacedBy != ""
 	}
 
 	// Extract name from title - look for model-like headings, not code examples
@@ -287,7 +456,7 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 	titleMatches := titleRegex.FindAllStringSubmatch(contentWithoutCode, -1)
 
 	for _, titleMatch := range titleMatches {
-		name := utils.CleanExtractedValue(titleMatch[1])
+		name := utils.CleanExtractedValue(utils.StripMarkdownFormatting(titleMatch[1]))
 		nameLower := strings.ToLower(name)
 		// Skip obvious code examples, function definitions, generic headings, or code comments
 		if strings.Contains(nameLower, "define") ||
@@ -328,7 +497,7 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 	for _, line := range lines {
 		for _, pattern := range providerPatterns {
 			if providerMatch := pattern.FindStringSubmatch(line); providerMatch != nil {
-				provider := utils.CleanExtractedValue(providerMatch[1])
+				provider := utils.CleanExtractedValue(utils.StripMarkdownFormatting(providerMatch[1]))
 				// More lenient validation for provider names
 				if utils.IsValidValue(provider, 2, 100, []string{`^[A-Za-z0-9\s\\.&,\-()]+$`}) {
 					metadata.Provider = &provider
@@ -350,33 +519,28 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		}
 	}
 
+	// Merge spelling variants of well-known providers (e.g. "IBM Research", "ibm-granite")
+	if metadata.Provider != nil {
+		provider := utils.NormalizeProvider(*metadata.Provider)
+		metadata.Provider = &provider
+	}
+
 	// Extract description from Model Overview or first paragraph after title
-	if overviewMatch := overviewRegex.FindStringSubmatch(contentStr); overviewMatch != nil {
-		// Look for description in overview section
-		overviewText := overviewMatch[1]
+	if overviewMatch := overviewRegex.FindStringSubmatch(contentWithoutCode); overviewMatch != nil {
+		// Look for description in overview section, ignoring structured metadata lines
+		overviewText := strings.Join(proseLines(strings.Split(overviewMatch[1], "\n")), "\n")
 		if descMatch := descInOverviewRe.FindStringSubmatch(overviewText); descMatch != nil {
-			desc := utils.CleanExtractedValue(descMatch[1])
+			desc := utils.CleanExtractedValue(utils.StripMarkdownFormatting(descMatch[1]))
 			if utils.IsValidValue(desc, 20, 500, nil) {
 				metadata.Description = &desc
 			}
 		}
 	}
 
-	// Fallback: first paragraph after title
-	if metadata.Description == nil {
-		if descMatch := descFallbackRegex.FindStringSubmatch(contentStr); descMatch != nil {
-			desc := utils.CleanExtractedValue(descMatch[1])
-			if utils.IsValidValue(desc, 20, 500, nil) {
-				metadata.Description = &desc
-			}
-		}
-	}
-
-	// Final description fallback: generate from model name if still none
-	if metadata.Description == nil && metadata.Name != nil {
-		fallbackDesc := utils.GenerateReadableDescription(*metadata.Name)
-		if fallbackDesc != "" {
-			metadata.Description = &fallbackDesc
+	// Fallback: first prose paragraph after title
+	if metadata.Description == nil && strings.HasPrefix(contentWithoutCode, "#") {
+		if desc := firstProseParagraph(contentWithoutCode); desc != "" {
+			metadata.Description = &desc
 		}
 	}
 
@@ -392,9 +556,9 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 			if licenseMatch := licenseRegex.FindStringSubmatch(line); licenseMatch != nil {
 				var license string
 				if licenseMatch[1] != "" {
-					license = utils.CleanExtractedValue(licenseMatch[1])
+					license = utils.CleanExtractedValue(utils.StripMarkdownFormatting(licenseMatch[1]))
 				} else {
-					license = utils.CleanExtractedValue(licenseMatch[2])
+					license = utils.CleanExtractedValue(utils.StripMarkdownFormatting(licenseMatch[2]))
 				}
 				if utils.IsValidValue(license, 2, 30, []string{`^[A-Za-z0-9\.\-_\s]+$`}) {
 					metadata.License = &license
@@ -497,6 +661,58 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		}
 	}
 
+	// Extract maturity from structured fields (only if not already set by YAML frontmatter)
+	if metadata.Maturity == nil {
+		for _, line := range lines {
+			if maturityMatch := maturityRegex.FindStringSubmatch(line); maturityMatch != nil {
+				if maturity := NormalizeMaturity(maturityMatch[1]); maturity != "" {
+					metadata.Maturity = &maturity
+					break
+				}
+			}
+		}
+	}
+
+	// Extract parameter count, preferring a Parameters/Size field over mentions in prose
+	for _, line := range lines {
+		if sizeMatch := modelSizeFieldRegex.FindStringSubmatch(line); sizeMatch != nil {
+			if valueMatch := modelSizeValueRegex.FindStringSubmatch(sizeMatch[1]); valueMatch != nil {
+				size := normalizeModelSize(valueMatch[1], valueMatch[2])
+				metadata.ModelSize = &size
+				break
+			}
+		}
+	}
+	if metadata.ModelSize == nil {
+		if sizeMatch := modelSizeProseRegex.FindStringSubmatch(contentWithoutCode); sizeMatch != nil {
+			size := normalizeModelSize(sizeMatch[1], sizeMatch[2])
+			metadata.ModelSize = &size
+		}
+	}
+
+	// Quantization scheme encoded in the model name (w4a16, fp8-dynamic, ...)
+	if metadata.Name != nil {
+		if scheme, ok := utils.ParseQuantization(*metadata.Name); ok {
+			metadata.Quantization = &scheme
+		}
+	}
+
+	// Benchmark scores from tables under Evaluation/Benchmarks headings
+	modelName := ""
+	if metadata.Name != nil {
+		modelName = *metadata.Name
+	}
+	metadata.Evaluations = ExtractEvaluationResults(contentWithoutCode, modelName)
+
+	// Final description fallback: generate from model name if still none, after tasks are known
+	// so the description names the right kind of model
+	if metadata.Description == nil && metadata.Name != nil {
+		fallbackDesc := utils.GenerateReadableDescriptionForTasks(*metadata.Name, metadata.Tasks)
+		if fallbackDesc != "" {
+			metadata.Description = &fallbackDesc
+		}
+	}
+
 	// Extract OCI image artifacts and model references
 	// For now, we'll extract from content but we'll populate with registry data later
 	metadata.Artifacts = []types.OCIArtifact{}
@@ -510,3 +726,133 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 
 	return metadata
 }
+
+// maturityLevels maps maturity phrases found in modelcards to the controlled vocabulary
+var maturityLevels = map[string]string{
+	"alpha":                "alpha",
+	"experimental":         "alpha",
+	"early access":         "alpha",
+	"beta":                 "beta",
+	"preview":              "beta",
+	"tech preview":         "beta",
+	"technology preview":   "beta",
+	"developer preview":    "beta",
+	"stable":               "stable",
+	"ga":                   "stable",
+	"generally available":  "stable",
+	"general availability": "stable",
+	"production":           "production",
+	"production ready":     "production",
+	"production-ready":     "production",
+	"deprecated":           "deprecated",
+	"retired":              "deprecated",
+	"end of life":          "deprecated",
+	"eol":                  "deprecated",
+	"no longer maintained": "deprecated",
+	"unmaintained":         "deprecated",
+}
+
+// NormalizeMaturity maps a free-form maturity or status value to one of alpha, beta,
+// stable, production or deprecated. Unknown values and sentences (e.g. Llama's
+// "Status: This is a static model trained on an offline dataset") return "".
+func NormalizeMaturity(value string) string {
+	cleaned := strings.ToLower(utils.CleanExtractedValue(value))
+
+	// Keep only the leading phrase, so "Beta (since 1.2)" and "Stable - ready for use" match
+	if i := strings.IndexAny(cleaned, ".,;:()[]"); i >= 0 {
+		cleaned = cleaned[:i]
+	}
+	cleaned, _, _ = strings.Cut(cleaned, " - ")
+	cleaned = strings.Trim(cleaned, "*_` ")
+
+	return maturityLevels[cleaned]
+}
+
+// normalizeModelSize renders a parameter count as a canonical size string, e.g.
+// ("70", "billion") -> "70B" and ("8.0", "b") -> "8B"
+func normalizeModelSize(number, unit string) string {
+	if strings.Contains(number, ".") {
+		number = strings.TrimRight(strings.TrimRight(number, "0"), ".")
+	}
+	switch strings.ToLower(unit) {
+	case "billion", "b":
+		return number + "B"
+	default:
+		return number + "M"
+	}
+}
+
+// isLabelLine reports whether a line is a structured metadata field (provider, license,
+// dates, tasks, ...) rather than prose
+func isLabelLine(line string) bool {
+	if labelLineRegex.MatchString(line) || licenseRegex.MatchString(line) {
+		return true
+	}
+	for _, pattern := range providerPatterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// isProseLine reports whether a line reads as running text: not a heading, list item,
+// table row, image, HTML tag, blockquote or structured metadata field
+func isProseLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || isLabelLine(trimmed) {
+		return false
+	}
+	for _, prefix := range []string{"#", "|", "<", "![", "[![", ">", "- ", "* ", "+ "} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// proseLines drops structured metadata and other non-prose lines
+func proseLines(lines []string) []string {
+	var prose []string
+	for _, line := range lines {
+		if isProseLine(line) {
+			prose = append(prose, strings.TrimSpace(line))
+		}
+	}
+	return prose
+}
+
+// firstProseParagraph returns the first paragraph after the title made up only of prose
+// lines, skipping headings and metadata blocks such as "**Model Developers:** ...". Long
+// paragraphs are cut to their first sentence. Returns "" when no usable paragraph exists.
+func firstProseParagraph(content string) string {
+	lines := strings.Split(content, "\n")
+
+	// Skip the title line
+	var paragraph []string
+	for i := 1; i <= len(lines); i++ {
+		// Blank lines and headings end a paragraph
+		if i < len(lines) && strings.TrimSpace(lines[i]) != "" && !strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+			paragraph = append(paragraph, lines[i])
+			continue
+		}
+		if len(paragraph) == 0 {
+			continue
+		}
+
+		// A paragraph counts only when every line is prose
+		if prose := proseLines(paragraph); len(prose) == len(paragraph) {
+			desc := utils.CleanExtractedValue(utils.StripMarkdownFormatting(strings.Join(prose, " ")))
+			if len(desc) > 500 {
+				if end := strings.Index(desc, ". "); end > 0 {
+					desc = desc[:end]
+				}
+			}
+			if utils.IsValidValue(desc, 20, 500, nil) {
+				return desc
+			}
+		}
+		paragraph = nil
+	}
+	return ""
+}
@@ -2,9 +2,11 @@ package metadata
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestParseModelCardMetadata(t *testing.T) {
@@ -140,7 +142,7 @@ This model can be deployed efficiently using the [vLLM](https://docs.vllm.ai/en/
 			expected: types.ExtractedMetadata{
 				Name:        stringPtr("granite-3.1-8b-base-quantized.w4a16"),
 				Provider:    stringPtr("Neural Magic"),
-				Description: stringPtr("Model Developers:** Neural Magic"),
+				Description: stringPtr("Quantized version of IBM granite-3.1-8b-base model intended for efficient inference"),
 				License:     stringPtr("Apache-2.0"),
 				LicenseLink: stringPtr("https://www.apache.org/licenses/LICENSE-2.0"),
 				Tasks:       []string{"text-generation"},
@@ -164,7 +166,7 @@ Meta developed and released the Meta Llama 3.2 collection of multilingual large
 			expected: types.ExtractedMetadata{
 				Name:        stringPtr("Meta Llama 3.2 1B Instruct"),
 				Provider:    stringPtr("Meta"),
-				Description: stringPtr("Meta Llama 3.2 1B Instruct - An instruction-tuned language model"),
+				Description: stringPtr("Meta developed and released the Meta Llama 3.2 collection of multilingual large language models"),
 				License:     stringPtr("Llama 3.2 Community License"),
 				LicenseLink: stringPtr("https://github.com/meta-llama/llama-models/blob/main/models/llama3_2/LICENSE"),
 				Tasks:       []string{"text-generation"},
@@ -226,7 +228,7 @@ Basic description here.
 `,
 			expected: types.ExtractedMetadata{
 				Name:        stringPtr("Simple Model"),
-				Description: stringPtr("Simple Model - A large language model"),
+				Description: stringPtr("Basic description here"),
 				Artifacts:   []types.OCIArtifact{},
 			},
 		},
@@ -477,7 +479,544 @@ This is a test model validated on multiple platforms.
 	}
 
 	// Check that provider was also extracted from frontmatter
-	if result.Provider == nil || *result.Provider != "RedHat AI" {
+	if result.Provider == nil || *result.Provider != "Red Hat" {
 		t.Error("Expected provider to be extracted from YAML frontmatter")
 	}
 }
+
+func TestExtractMetadataValues_BaseModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "single base model",
+			content:  "---\nbase_model: ibm-granite/granite-3.1-8b-instruct\n---\n# Granite 3.1 8B Instruct FP8\n",
+			expected: []string{"ibm-granite/granite-3.1-8b-instruct"},
+		},
+		{
+			name:     "multiple base models",
+			content:  "---\nbase_model:\n  - meta-llama/Llama-3.1-8B\n  - meta-llama/Llama-3.1-8B-Instruct\n  - meta-llama/Llama-3.1-8B\n---\n# Llama 3.1 8B Merge\n",
+			expected: []string{"meta-llama/Llama-3.1-8B", "meta-llama/Llama-3.1-8B-Instruct"},
+		},
+		{
+			name:     "TOML frontmatter",
+			content:  "+++\nbase_model = [\"mistralai/Mistral-7B-v0.1\"]\n+++\n# Mistral 7B Instruct\n",
+			expected: []string{"mistralai/Mistral-7B-v0.1"},
+		},
+		{
+			name:    "no base model",
+			content: "---\nlicense: apache-2.0\n---\n# Granite 3.1 8B Instruct\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			if !reflect.DeepEqual(result.BaseModel, tt.expected) {
+				t.Errorf("BaseModel = %v, want %v", result.BaseModel, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_Maturity(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "status field",
+			content:  "# Granite 3.1 8B Instruct\n\n- **Status:** Production\n",
+			expected: "production",
+		},
+		{
+			name:     "maturity field with note",
+			content:  "# Granite 3.1 8B Instruct\n\nMaturity: Beta (since 1.2)\n",
+			expected: "beta",
+		},
+		{
+			name:     "stability field",
+			content:  "# Granite 3.1 8B Instruct\n\n**Stability**: Technology Preview\n",
+			expected: "beta",
+		},
+		{
+			name:     "deprecated status",
+			content:  "# Granite 3.0 8B Instruct\n\nStatus: Deprecated - use Granite 3.1 instead\n",
+			expected: "deprecated",
+		},
+		{
+			name:     "frontmatter wins over body",
+			content:  "---\nmaturity: alpha\n---\n# Granite 3.1 8B Instruct\n\nStatus: Stable\n",
+			expected: "alpha",
+		},
+		{
+			name:     "status sentence is ignored",
+			content:  "# Llama 3.1 8B Instruct\n\n**Status:** This is a static model trained on an offline dataset. Future versions will be released.\n",
+			expected: "",
+		},
+		{
+			name:     "no maturity",
+			content:  "# Granite 3.1 8B Instruct\n\nA model.\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			got := ""
+			if result.Maturity != nil {
+				got = *result.Maturity
+			}
+			if got != tt.expected {
+				t.Errorf("Maturity = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_Deprecation(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		wantDeprecated bool
+		wantReplacedBy string
+	}{
+		{
+			name:           "deprecated with replacement",
+			content:        "---\ndeprecated: true\nreplaced_by: RedHatAI/granite-3.1-8b-instruct\n---\n# Granite 3.0 8B Instruct\n",
+			wantDeprecated: true,
+			wantReplacedBy: "RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:           "deprecated as a yes string",
+			content:        "---\ndeprecated: \"yes\"\n---\n# Granite 3.0 8B Instruct\n",
+			wantDeprecated: true,
+		},
+		{
+			name:           "replacement implies deprecated",
+			content:        "---\nreplaced_by: RedHatAI/granite-3.1-8b-instruct\n---\n# Granite 3.0 8B Instruct\n",
+			wantDeprecated: true,
+			wantReplacedBy: "RedHatAI/granite-3.1-8b-instruct",
+		},
+		{
+			name:    "explicitly not deprecated",
+			content: "---\ndeprecated: false\n---\n# Granite 3.1 8B Instruct\n",
+		},
+		{
+			name:           "TOML frontmatter",
+			content:        "+++\ndeprecated = true\nreplaced_by = \"RedHatAI/Qwen3-8B\"\n+++\n# Qwen2.5 7B Instruct\n",
+			wantDeprecated: true,
+			wantReplacedBy: "RedHatAI/Qwen3-8B",
+		},
+		{
+			name:           "JSON frontmatter with string flag",
+			content:        "{\"deprecated\": \"on\"}\n# Qwen2.5 7B Instruct\n",
+			wantDeprecated: true,
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Granite 3.1 8B Instruct\n\nA model.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			if result.Deprecated != tt.wantDeprecated {
+				t.Errorf("Deprecated = %v, want %v", result.Deprecated, tt.wantDeprecated)
+			}
+			if result.ReplacedBy != tt.wantReplacedBy {
+				t.Errorf("ReplacedBy = %q, want %q", result.ReplacedBy, tt.wantReplacedBy)
+			}
+		})
+	}
+}
+
+func TestFlexBool_InvalidValue(t *testing.T) {
+	tests := map[string]string{
+		"yaml date":   "---\nname: Granite\nlicense: apache-2.0\ndeprecated: 2025-06-01\n---\n# Model\n",
+		"yaml string": "---\nname: Granite\nlicense: apache-2.0\ndeprecated: \"soon\"\n---\n# Model\n",
+		"yaml list":   "---\nname: Granite\nlicense: apache-2.0\ndeprecated: [soon]\n---\n# Model\n",
+		"json number": "{\"name\": \"Granite\", \"license\": \"apache-2.0\", \"deprecated\": 2025}\n# Model\n",
+		"toml date":   "+++\nname = \"Granite\"\nlicense = \"apache-2.0\"\ndeprecated = 2025-06-01\n+++\n# Model\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			frontmatter, err := ExtractYAMLFrontmatterFromModelCard(content)
+			if err != nil {
+				t.Fatalf("Unexpected error for an unrecognized deprecated value: %v", err)
+			}
+			if frontmatter.Deprecated {
+				t.Error("Expected an unrecognized deprecated value to be read as false")
+			}
+			if frontmatter.Name != "Granite" || frontmatter.License != "apache-2.0" {
+				t.Errorf("Name = %q, License = %q, want the other frontmatter fields kept", frontmatter.Name, frontmatter.License)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_Quantization(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "scheme in frontmatter name",
+			content:  "---\nname: RedHatAI/granite-3.1-8b-instruct-quantized.w4a16\n---\nA quantized model.\n",
+			expected: "w4a16",
+		},
+		{
+			name:     "scheme in title",
+			content:  "# Llama-3.1-8B-Instruct-FP8-dynamic\n\nAn FP8 model.\n",
+			expected: "fp8-dynamic",
+		},
+		{
+			name:     "non-quantized model",
+			content:  "# Granite 3.1 8B Instruct\n\nA model.\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			got := ""
+			if result.Quantization != nil {
+				got = *result.Quantization
+			}
+			if got != tt.expected {
+				t.Errorf("Quantization = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeMaturity(t *testing.T) {
+	tests := map[string]string{
+		"Production":            "production",
+		"production-ready":      "production",
+		"**Stable**":            "stable",
+		"GA":                    "stable",
+		"Generally Available.":  "stable",
+		"Experimental":          "alpha",
+		"Developer Preview":     "beta",
+		"End of Life":           "deprecated",
+		"Retired, see 3.1":      "deprecated",
+		"in progress":           "",
+		"":                      "",
+		"static model, offline": "",
+	}
+
+	for input, expected := range tests {
+		if got := NormalizeMaturity(input); got != expected {
+			t.Errorf("NormalizeMaturity(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestExtractMetadataValues_ModelSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "parameters field in billions",
+			content:  "# Llama 3.3 70B Instruct\n\n- **Parameters:** 70 billion\n",
+			expected: "70B",
+		},
+		{
+			name:     "model size field with decimal",
+			content:  "# Granite 3.1 8B Instruct\n\nModel Size: 8.0B\n",
+			expected: "8B",
+		},
+		{
+			name:     "number of parameters in millions",
+			content:  "# Whisper Small\n\n**Number of Parameters**: 244M\n",
+			expected: "244M",
+		},
+		{
+			name:     "prose mention",
+			content:  "# Granite 3.1 8B Instruct\n\nGranite is a 8B parameter long-context instruct model.\n",
+			expected: "8B",
+		},
+		{
+			name:     "field wins over prose",
+			content:  "# Llama 3.1 8B Instruct\n\nCompared to the 70B parameter model, this one is small.\n\nParameters: 8.03B\n",
+			expected: "8.03B",
+		},
+		{
+			name:     "size in bytes is ignored",
+			content:  "# Granite 3.1 8B Instruct\n\nSize: 16GB\n",
+			expected: "",
+		},
+		{
+			name:     "code blocks are ignored",
+			content:  "# Granite 3.1 8B Instruct\n\n```python\n# load the 8B parameter model\n```\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			got := ""
+			if result.ModelSize != nil {
+				got = *result.ModelSize
+			}
+			if got != tt.expected {
+				t.Errorf("ModelSize = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_DescriptionSkipsLabelLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "metadata block before prose",
+			content: `# Granite 3.1 8B Instruct
+
+**Model Developers:** IBM Research
+**License:** Apache-2.0
+
+Granite 3.1 8B Instruct is a long-context model finetuned for instruction following.
+`,
+			expected: "Granite 3.1 8B Instruct is a long-context model finetuned for instruction following",
+		},
+		{
+			name: "overview made of metadata list",
+			content: `# Granite 3.1 8B Instruct
+
+## Model Overview
+- **Model Developers:** Red Hat (Neural Magic)
+- **Intended Use Cases:** This model is intended for assistant-like chat in English.
+
+Granite 3.1 8B Instruct quantized to INT4 weights for faster inference on GPUs.
+`,
+			expected: "Granite 3.1 8B Instruct quantized to INT4 weights for faster inference on GPUs",
+		},
+		{
+			name: "only metadata lines falls back to generated description",
+			content: `# Granite 3.1 8B Instruct
+
+- **Provider:** IBM
+- **Release Date:** 1/8/2025
+`,
+			expected: "Granite 3.1 8B Instruct - An instruction-tuned language model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMetadataValues([]byte(tt.content))
+			if got := derefStringPtr(result.Description); got != tt.expected {
+				t.Errorf("Description = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_StripsMarkdown(t *testing.T) {
+	content := `# Granite 3.1 8B Instruct
+
+- **Model Developers:** [Red Hat](https://www.redhat.com)
+- **License:** [**Apache-2.0**](https://www.apache.org/licenses/LICENSE-2.0)
+
+Granite 3.1 8B Instruct is a ` + "`long-context`" + ` model served with [vLLM](https://docs.vllm.ai/) for **fast** inference.
+`
+	result := ExtractMetadataValues([]byte(content))
+
+	if got := derefStringPtr(result.Provider); got != "Red Hat" {
+		t.Errorf("Provider = %q, want %q", got, "Red Hat")
+	}
+	if got := derefStringPtr(result.License); got != "Apache-2.0" {
+		t.Errorf("License = %q, want %q", got, "Apache-2.0")
+	}
+	if got := derefStringPtr(result.LicenseLink); got != "https://www.apache.org/licenses/LICENSE-2.0" {
+		t.Errorf("LicenseLink = %q, want the URL from the markdown link", got)
+	}
+	expected := "Granite 3.1 8B Instruct is a long-context model served with vLLM for fast inference"
+	if got := derefStringPtr(result.Description); got != expected {
+		t.Errorf("Description = %q, want %q", got, expected)
+	}
+}
+
+func TestExtractYAMLFrontmatterFromModelCard_Formats(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantName    string
+		wantTags    []string
+		wantHW      []string
+		expectError bool
+	}{
+		{
+			name: "YAML frontmatter",
+			content: `---
+name: "YAML Model"
+tags:
+  - text-generation
+hardware_tag: Intel Xeon
+---
+# YAML Model
+`,
+			wantName: "YAML Model",
+			wantTags: []string{"text-generation"},
+			wantHW:   []string{"Intel Xeon"},
+		},
+		{
+			name: "TOML frontmatter",
+			content: `+++
+name = "TOML Model"
+tags = ["text-generation"]
+hardware_tag = ["Intel Xeon", "AMD Zen"]
++++
+# TOML Model
+`,
+			wantName: "TOML Model",
+			wantTags: []string{"text-generation"},
+			wantHW:   []string{"Intel Xeon", "AMD Zen"},
+		},
+		{
+			name: "JSON frontmatter",
+			content: `{
+  "name": "JSON Model",
+  "tags": ["text-generation"],
+  "hardware_tag": "Intel Xeon"
+}
+# JSON Model
+`,
+			wantName: "JSON Model",
+			wantTags: []string{"text-generation"},
+			wantHW:   []string{"Intel Xeon"},
+		},
+		{
+			name:        "no frontmatter",
+			content:     "# Plain Model\n\nJust markdown.\n",
+			expectError: true,
+		},
+		{
+			name:        "TOML without closing delimiter",
+			content:     "+++\nname = \"Broken\"\n# Broken\n",
+			expectError: true,
+		},
+		{
+			name:        "malformed JSON",
+			content:     "{\"name\": \"Broken\"\n# Broken\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, err := ExtractYAMLFrontmatterFromModelCard(tt.content)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got frontmatter %+v", fm)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fm.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", fm.Name, tt.wantName)
+			}
+			if !reflect.DeepEqual(fm.Tags, tt.wantTags) {
+				t.Errorf("Tags = %v, want %v", fm.Tags, tt.wantTags)
+			}
+			if !reflect.DeepEqual([]string(fm.HardwareTag), tt.wantHW) {
+				t.Errorf("HardwareTag = %v, want %v", fm.HardwareTag, tt.wantHW)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_TOMLFrontmatter(t *testing.T) {
+	content := `+++
+name = "TOML Model"
+provider = "Example Org"
+license = "apache-2.0"
++++
+# TOML Model
+`
+	result := ExtractMetadataValues([]byte(content))
+
+	if result.Name == nil || *result.Name != "TOML Model" {
+		t.Errorf("Name = %v, want %q", result.Name, "TOML Model")
+	}
+	if result.Provider == nil || *result.Provider != "Example Org" {
+		t.Errorf("Provider = %v, want %q", result.Provider, "Example Org")
+	}
+}
+
+func TestSplitTaskString_TaskMapOverrides(t *testing.T) {
+	utils.SetTaskMapOverrides(map[string]string{"guard": "text-classification"})
+	t.Cleanup(func() { utils.SetTaskMapOverrides(nil) })
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "custom task is recognized and mapped", input: "Content safety guard", expected: []string{"text-classification"}},
+		{name: "built-in patterns still apply", input: "summarization, guard", expected: []string{"summarization", "text-classification"}},
+		{name: "unknown tasks are split unchanged", input: "weather forecasting; tides", expected: []string{"weather forecasting", " tides"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitTaskString(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("splitTaskString(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitTaskString_RetrievalTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "embedding model", input: "Text embedding for semantic search", expected: []string{"embedding"}},
+		{name: "reranker model", input: "Passage reranking", expected: []string{"rerank"}},
+		{name: "canonical task names", input: "feature-extraction, sentence-similarity", expected: []string{"feature-extraction", "sentence-similarity"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitTaskString(tt.input)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("splitTaskString(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_FallbackDescriptionUsesTasks(t *testing.T) {
+	content := []byte(`---
+name: all-minilm-l6-v2
+pipeline_tag: sentence-similarity
+---
+`)
+
+	result := ExtractMetadataValues(content)
+	if result.Description == nil {
+		t.Fatal("Expected a generated description")
+	}
+	if !strings.HasSuffix(*result.Description, " - A text embedding model") {
+		t.Errorf("Expected an embedding model description, got %q", *result.Description)
+	}
+}
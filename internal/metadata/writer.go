@@ -0,0 +1,160 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Supported metadata output formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatBoth = "both"
+)
+
+// outputFormat controls which files WriteMetadataFile produces
+var outputFormat = FormatYAML
+
+// SetOutputFormat configures the metadata output format ("yaml", "json" or "both").
+// metadata.yaml is always written because enrichment and catalog generation read it;
+// "json" and "both" additionally write metadata.json next to it.
+func SetOutputFormat(format string) error {
+	switch format {
+	case FormatYAML, FormatJSON, FormatBoth:
+		outputFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid metadata format: %q (allowed values: %q, %q, %q)", format, FormatYAML, FormatJSON, FormatBoth)
+	}
+}
+
+// WriteMetadataFile writes extracted metadata to metadataPath (a metadata.yaml file)
+// and, depending on the configured output format, a metadata.json alongside it.
+func WriteMetadataFile(metadataPath string, metadata *types.ExtractedMetadata) error {
+	yamlData, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata to YAML: %v", err)
+	}
+	return writeMetadataOutputs(metadataPath, yamlData, metadata)
+}
+
+// UpdateMetadataFile rewrites an existing metadata.yaml with updated metadata while keeping
+// its structure: existing keys keep their order and comments, unchanged values keep their
+// formatting, keys that are not ExtractedMetadata fields (manual annotations) are kept, and
+// new fields are appended in declared order. Falls back to WriteMetadataFile when the file
+// does not exist or is not a YAML mapping.
+func UpdateMetadataFile(metadataPath string, metadata *types.ExtractedMetadata) error {
+	existingData, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return WriteMetadataFile(metadataPath, metadata)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(existingData, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return WriteMetadataFile(metadataPath, metadata)
+	}
+
+	var updated yaml.Node
+	if err := updated.Encode(metadata); err != nil {
+		return fmt.Errorf("failed to marshal metadata to YAML: %v", err)
+	}
+	mergeMetadataMapping(doc.Content[0], &updated, metadataFieldKeys())
+
+	yamlData, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata to YAML: %v", err)
+	}
+	return writeMetadataOutputs(metadataPath, yamlData, metadata)
+}
+
+// mergeMetadataMapping updates existing in place with the key/value pairs of updated.
+// Known keys missing from updated (omitted empty fields) are removed; unknown keys are kept.
+func mergeMetadataMapping(existing, updated *yaml.Node, known map[string]bool) {
+	updatedValues := make(map[string]*yaml.Node, len(updated.Content)/2)
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		updatedValues[updated.Content[i].Value] = updated.Content[i+1]
+	}
+
+	seen := make(map[string]bool, len(updatedValues))
+	content := make([]*yaml.Node, 0, len(existing.Content))
+	for i := 0; i+1 < len(existing.Content); i += 2 {
+		key, value := existing.Content[i], existing.Content[i+1]
+		newValue, ok := updatedValues[key.Value]
+		if !ok {
+			if !known[key.Value] {
+				content = append(content, key, value)
+			}
+			continue
+		}
+		seen[key.Value] = true
+		content = append(content, key, mergeMetadataValue(value, newValue))
+	}
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		if !seen[updated.Content[i].Value] {
+			content = append(content, updated.Content[i], updated.Content[i+1])
+		}
+	}
+	existing.Content = content
+}
+
+// mergeMetadataValue returns the node to write for a key present in both files: the existing
+// node when the value is unchanged, otherwise the new node carrying the existing comments
+func mergeMetadataValue(existing, updated *yaml.Node) *yaml.Node {
+	if existing.Kind == yaml.ScalarNode && updated.Kind == yaml.ScalarNode &&
+		existing.Value == updated.Value && existing.ShortTag() == updated.ShortTag() {
+		return existing
+	}
+	if updated.HeadComment == "" {
+		updated.HeadComment = existing.HeadComment
+	}
+	if updated.LineComment == "" {
+		updated.LineComment = existing.LineComment
+	}
+	if updated.FootComment == "" {
+		updated.FootComment = existing.FootComment
+	}
+	return updated
+}
+
+// metadataFieldKeys returns the YAML keys of all ExtractedMetadata fields
+func metadataFieldKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(types.ExtractedMetadata{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// writeMetadataOutputs writes the marshaled metadata.yaml and, depending on the configured
+// output format, a metadata.json alongside it
+func writeMetadataOutputs(metadataPath string, yamlData []byte, metadata *types.ExtractedMetadata) error {
+	if err := os.WriteFile(metadataPath, yamlData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", metadataPath, err)
+	}
+
+	if outputFormat == FormatYAML {
+		return nil
+	}
+
+	jsonData, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata to JSON: %v", err)
+	}
+	jsonPath := filepath.Join(filepath.Dir(metadataPath), strings.TrimSuffix(filepath.Base(metadataPath), filepath.Ext(metadataPath))+".json")
+	if err := os.WriteFile(jsonPath, append(jsonData, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", jsonPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package metadata
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestSetOutputFormat(t *testing.T) {
+	defer func() { outputFormat = FormatYAML }()
+
+	for _, format := range []string{FormatYAML, FormatJSON, FormatBoth} {
+		if err := SetOutputFormat(format); err != nil {
+			t.Errorf("SetOutputFormat(%q) unexpected error: %v", format, err)
+		}
+	}
+	if err := SetOutputFormat("xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestWriteMetadataFile(t *testing.T) {
+	defer func() { outputFormat = FormatYAML }()
+
+	name := "granite-3.1-8b-instruct"
+	md := &types.ExtractedMetadata{
+		Name: &name,
+		Tags: []string{"validated"},
+	}
+
+	tests := []struct {
+		format       string
+		expectedJSON bool
+	}{
+		{FormatYAML, false},
+		{FormatJSON, true},
+		{FormatBoth, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if err := SetOutputFormat(tt.format); err != nil {
+				t.Fatalf("SetOutputFormat failed: %v", err)
+			}
+			dir := t.TempDir()
+			metadataPath := filepath.Join(dir, "metadata.yaml")
+
+			if err := WriteMetadataFile(metadataPath, md); err != nil {
+				t.Fatalf("WriteMetadataFile failed: %v", err)
+			}
+
+			if _, err := os.Stat(metadataPath); err != nil {
+				t.Errorf("Expected metadata.yaml to be written: %v", err)
+			}
+
+			jsonData, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+			if !tt.expectedJSON {
+				if err == nil {
+					t.Error("Expected no metadata.json for yaml format")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected metadata.json to be written: %v", err)
+			}
+
+			var decoded map[string]interface{}
+			if err := json.Unmarshal(jsonData, &decoded); err != nil {
+				t.Fatalf("Failed to parse metadata.json: %v", err)
+			}
+			if decoded["name"] != name {
+				t.Errorf("Expected name %q, got %v", name, decoded["name"])
+			}
+			// Nil pointers are marshaled as null, matching the YAML output
+			if v, ok := decoded["provider"]; !ok || v != nil {
+				t.Errorf("Expected provider to be null, got %v (present: %v)", v, ok)
+			}
+		})
+	}
+}
+
+func TestUpdateMetadataFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metadata.yaml")
+
+	// Without an existing file, UpdateMetadataFile writes the metadata as-is
+	name := "granite-3.1-8b-instruct"
+	if err := UpdateMetadataFile(path, &types.ExtractedMetadata{Name: &name}); err != nil {
+		t.Fatalf("UpdateMetadataFile() error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected metadata.yaml to be written: %v", err)
+	}
+
+	existing := `tags:
+    - validated
+# owner comment
+name: granite-3.1-8b-instruct # display name
+customField: keep me
+maturity: Technology Preview
+`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	provider := "IBM"
+	md := &types.ExtractedMetadata{Name: &name, Provider: &provider, Tags: []string{"validated", "granite"}}
+	if err := UpdateMetadataFile(path, md); err != nil {
+		t.Fatalf("UpdateMetadataFile() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read metadata: %v", err)
+	}
+	content := string(data)
+
+	for _, expected := range []string{"# owner comment", "name: granite-3.1-8b-instruct # display name", "customField: keep me", "- granite", "provider: IBM"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+	// maturity is an omitempty field that is now unset, so it is removed
+	if strings.Contains(content, "maturity:") {
+		t.Errorf("Expected unset maturity to be removed, got:\n%s", content)
+	}
+	// Existing keys keep their order; new fields are appended
+	if !(strings.Index(content, "tags:") < strings.Index(content, "name:") && strings.Index(content, "customField:") < strings.Index(content, "provider:")) {
+		t.Errorf("Expected existing key order to be kept, got:\n%s", content)
+	}
+}
@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/oci/layout"
+	containertypes "github.com/containers/image/v5/types"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// OCILayoutPrefix marks a model reference naming an OCI image layout on disk,
+// "oci:<dir>[:<tag>]", instead of a registry image
+const OCILayoutPrefix = "oci:"
+
+// IsOCILayoutRef reports whether a model reference names an OCI image layout on disk. oci://
+// artifact URIs are registry references, not layouts.
+func IsOCILayoutRef(ref string) bool {
+	return strings.HasPrefix(ref, OCILayoutPrefix) && !strings.HasPrefix(ref, "oci://")
+}
+
+// ParseImageReference parses a model reference for the containers/image transport it uses: the
+// oci transport for "oci:<dir>[:<tag>]" layouts and the docker transport for registry references
+func ParseImageReference(ref string) (containertypes.ImageReference, error) {
+	if IsOCILayoutRef(ref) {
+		return layout.ParseReference(strings.TrimPrefix(ref, OCILayoutPrefix))
+	}
+	return docker.ParseReference("//" + ref)
+}
+
+// ociLayoutArtifact is the primary artifact of an OCI layout model. The layout reference itself is
+// the URI, as there is no registry image to point at; the image config supplies timestamps later.
+// The layout is not opened, so artifacts can be rebuilt after it is gone.
+func ociLayoutArtifact(ref string) (types.OCIArtifact, error) {
+	if strings.TrimPrefix(ref, OCILayoutPrefix) == "" {
+		return types.OCIArtifact{}, fmt.Errorf("invalid OCI layout reference %q: no layout directory", ref)
+	}
+	return types.OCIArtifact{
+		URI: ref,
+		CustomProperties: map[string]interface{}{
+			"source": map[string]interface{}{
+				"string_value": "oci-layout",
+			},
+			"type": map[string]interface{}{
+				"string_value": "modelcar",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		ref           string
+		wantTransport string
+		wantLayout    bool
+		wantErr       bool
+	}{
+		{ref: "oci:" + dir + "/granite:1.0", wantTransport: "oci", wantLayout: true},
+		{ref: "oci:./granite", wantTransport: "oci", wantLayout: true},
+		{ref: "registry.redhat.io/rhelai1/modelcar-granite:1.5", wantTransport: "docker"},
+		{ref: "alpine", wantTransport: "docker"},
+		{ref: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5", wantErr: true},
+		{ref: "oci:" + dir + "/granite:Invalid Tag", wantLayout: true, wantErr: true},
+		{ref: "oci:" + dir + "/missing/granite:1.0", wantLayout: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimPrefix(tt.ref, dir), func(t *testing.T) {
+			if got := IsOCILayoutRef(tt.ref); got != tt.wantLayout {
+				t.Errorf("IsOCILayoutRef(%q) = %v, want %v", tt.ref, got, tt.wantLayout)
+			}
+			ref, err := ParseImageReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseImageReference(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+			if err == nil && ref.Transport().Name() != tt.wantTransport {
+				t.Errorf("ParseImageReference(%q) transport = %s, want %s", tt.ref, ref.Transport().Name(), tt.wantTransport)
+			}
+		})
+	}
+}
+
+func TestExtractOCIArtifactsFromRegistryE_OCILayout(t *testing.T) {
+	ref := "oci:/srv/layouts/granite:1.0"
+	artifacts, err := ExtractOCIArtifactsFromRegistryE(ref)
+	if err != nil {
+		t.Fatalf("ExtractOCIArtifactsFromRegistryE failed: %v", err)
+	}
+	if len(artifacts) != 1 || artifacts[0].URI != ref {
+		t.Fatalf("Expected one artifact with URI %q, got %+v", ref, artifacts)
+	}
+	if got := stringProp(artifacts[0].CustomProperties, "source"); got != "oci-layout" {
+		t.Errorf("source = %q, want oci-layout", got)
+	}
+
+	if _, err := ExtractOCIArtifactsFromRegistryE("oci:"); err == nil {
+		t.Error("Expected an error for an OCI layout reference without a directory")
+	}
+}
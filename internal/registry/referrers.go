@@ -0,0 +1,264 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/docker/config"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	containertypes "github.com/containers/image/v5/types"
+	godigest "github.com/opencontainers/go-digest"
+)
+
+// ociIndexMediaType is the media type the referrers API responds with
+const ociIndexMediaType = "application/vnd.oci.image.index.v1+json"
+
+// Referrer is an entry of the referrers API response: an artifact manifest attached to an image
+type Referrer struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// IsModelCardArtifactType reports whether an OCI artifactType identifies a model card,
+// e.g. "application/vnd.redhat.modelcard.v1+markdown" or "application/vnd.model-card+md"
+func IsModelCardArtifactType(artifactType string) bool {
+	t := strings.ToLower(artifactType)
+	return strings.Contains(t, "modelcard") || strings.Contains(t, "model-card") || strings.Contains(t, "model.card")
+}
+
+// ListReferrers queries the OCI referrers API (GET /v2/<name>/referrers/<digest>) for the
+// artifacts attached to a manifest of imageRef's repository. Endpoints come from the same
+// registries.conf configuration containers/image pulls with (sys.SystemRegistriesConfPath):
+// mirrors are tried in order before the registry itself, and endpoints marked insecure, or all of
+// them with sys.DockerInsecureSkipTLSVerify, skip TLS verification and fall back to plain HTTP.
+// Endpoints without the referrers API (404) are skipped; when none has it, there are no referrers
+// and no error. Bearer token challenges are answered with the credentials containers-auth.json
+// holds for the endpoint, or anonymously. OCI layouts on disk have no referrers API and yield no
+// referrers without any network request.
+func ListReferrers(ctx context.Context, imageRef, digest string, sys *containertypes.SystemContext) ([]Referrer, error) {
+	if IsOCILayoutRef(imageRef) {
+		return nil, nil
+	}
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %v", err)
+	}
+	endpoints, err := referrerEndpoints(sys, named, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, endpoint := range endpoints {
+		referrers, err := endpoint.listReferrers(ctx, digest, sys)
+		if err == nil {
+			return referrers, nil
+		}
+		if !errors.Is(err, errNoReferrersAPI) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// errNoReferrersAPI is returned by referrerEndpoint.listReferrers when the endpoint answers 404
+var errNoReferrersAPI = errors.New("referrers API not supported")
+
+// referrerEndpoint is a registry or mirror repository the referrers API is queried at
+type referrerEndpoint struct {
+	domain     string
+	repository string
+	insecure   bool // Skip TLS verification and allow plain HTTP
+}
+
+// referrerEndpoints returns the endpoints to query for the referrers of named's digest, mirrors
+// first, as configured in registries.conf
+func referrerEndpoints(sys *containertypes.SystemContext, named reference.Named, digest string) ([]referrerEndpoint, error) {
+	skipTLSVerify := sys != nil && sys.DockerInsecureSkipTLSVerify == containertypes.OptionalBoolTrue
+	registry, err := sysregistriesv2.FindRegistry(sys, named.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registries configuration: %v", err)
+	}
+	if registry == nil {
+		return []referrerEndpoint{{domain: reference.Domain(named), repository: reference.Path(named), insecure: skipTLSVerify}}, nil
+	}
+	if registry.Blocked {
+		return nil, fmt.Errorf("registry %s is blocked in registries.conf", reference.Domain(named))
+	}
+
+	// Referrers are listed for a manifest digest, so digest-only mirrors apply too
+	ref := named
+	if digested, err := reference.WithDigest(reference.TrimNamed(named), godigest.Digest(digest)); err == nil {
+		ref = digested
+	}
+	sources, err := registry.PullSourcesFromReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve registry mirrors: %v", err)
+	}
+	endpoints := make([]referrerEndpoint, 0, len(sources))
+	for _, source := range sources {
+		endpoints = append(endpoints, referrerEndpoint{
+			domain:     reference.Domain(source.Reference),
+			repository: reference.Path(source.Reference),
+			insecure:   skipTLSVerify || source.Endpoint.Insecure,
+		})
+	}
+	return endpoints, nil
+}
+
+// listReferrers queries the endpoint's referrers API, over plain HTTP when HTTPS fails on an
+// insecure endpoint
+func (e referrerEndpoint) listReferrers(ctx context.Context, digest string, sys *containertypes.SystemContext) ([]Referrer, error) {
+	client := registryClient(e.insecure)
+	referrersURL := fmt.Sprintf("https://%s/v2/%s/referrers/%s", e.domain, e.repository, digest)
+	resp, err := getRegistryJSON(ctx, client, referrersURL, "")
+	if err != nil && e.insecure {
+		referrersURL = fmt.Sprintf("http://%s/v2/%s/referrers/%s", e.domain, e.repository, digest)
+		resp, err = getRegistryJSON(ctx, client, referrersURL, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		token, err := fetchBearerToken(ctx, client, challenge, e.domain, e.repository, sys)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = getRegistryJSON(ctx, client, referrersURL, token); err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errNoReferrersAPI
+	default:
+		return nil, fmt.Errorf("referrers API returned status %d", resp.StatusCode)
+	}
+
+	var index struct {
+		Manifests []Referrer `json:"manifests"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to parse referrers response: %v", err)
+	}
+	return index.Manifests, nil
+}
+
+// registryClient returns the registry HTTP client, or a copy of it that skips TLS certificate
+// verification for insecure endpoints
+func registryClient(insecure bool) *http.Client {
+	if !insecure {
+		return httpClient
+	}
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = true
+	client := *httpClient
+	client.Transport = transport
+	return &client
+}
+
+// getRegistryJSON issues a GET for an OCI index, with a bearer token when one is given
+func getRegistryJSON(ctx context.Context, client *http.Client, rawURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", ociIndexMediaType)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", rawURL, err)
+	}
+	return resp, nil
+}
+
+// fetchBearerToken answers a "Bearer realm=...,service=..." challenge with a pull token for repository
+func fetchBearerToken(ctx context.Context, client *http.Client, challenge, domain, repository string, sys *containertypes.SystemContext) (string, error) {
+	params := parseAuthChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry requires authentication but sent no bearer realm")
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid bearer realm %q: %v", realm, err)
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %v", err)
+	}
+	if creds, err := config.GetCredentials(sys, domain); err == nil && creds.Username != "" {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("token request returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %v", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseAuthChallenge extracts the key="value" parameters of a WWW-Authenticate Bearer header
+func parseAuthChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !strings.EqualFold(scheme, "bearer") {
+		return params
+	}
+	for _, part := range strings.Split(rest, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			params[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return params
+}
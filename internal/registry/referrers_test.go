@@ -0,0 +1,281 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	containertypes "github.com/containers/image/v5/types"
+)
+
+const testManifestDigest = "sha256:5f0c5b2ad3e7dbf1ab7b2e2f0c3b0e7b3c1c2d6f7a8b9c0d1e2f3a4b5c6d7e8f"
+
+func TestIsModelCardArtifactType(t *testing.T) {
+	tests := []struct {
+		artifactType string
+		expected     bool
+	}{
+		{"application/vnd.redhat.modelcard.v1+markdown", true},
+		{"application/vnd.model-card+md", true},
+		{"application/vnd.cncf.model.card.v1+json", true},
+		{"application/vnd.dev.sigstore.bundle.v0.3+json", false},
+		{"application/spdx+json", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.artifactType, func(t *testing.T) {
+			if got := IsModelCardArtifactType(tt.artifactType); got != tt.expected {
+				t.Errorf("IsModelCardArtifactType(%q) = %v, want %v", tt.artifactType, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/model:pull"`)
+	expected := map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:org/model:pull",
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("%s = %q, want %q", key, params[key], value)
+		}
+	}
+
+	if params := parseAuthChallenge(`Basic realm="registry"`); len(params) != 0 {
+		t.Errorf("Basic challenge should yield no bearer params, got %v", params)
+	}
+}
+
+// withTestRegistry points the registry HTTP client at a TLS test server and returns its host
+func withTestRegistry(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	original := httpClient
+	httpClient = server.Client()
+	t.Cleanup(func() { httpClient = original })
+	return strings.TrimPrefix(server.URL, "https://")
+}
+
+func TestListReferrers(t *testing.T) {
+	referrers := []Referrer{
+		{MediaType: ociIndexMediaType, Digest: "sha256:aaa", ArtifactType: "application/vnd.redhat.modelcard.v1+markdown"},
+		{MediaType: ociIndexMediaType, Digest: "sha256:bbb", ArtifactType: "application/spdx+json"},
+	}
+
+	var host string
+	var tokenAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenAuth = r.Header.Get("Authorization")
+		if r.URL.Query().Get("scope") != "repository:org/model:pull" || r.URL.Query().Get("service") != "test-registry" {
+			http.Error(w, "bad scope", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "secret-token"})
+	})
+	mux.HandleFunc("/v2/org/model/referrers/"+testManifestDigest, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="test-registry"`, host))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", ociIndexMediaType)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"schemaVersion": 2, "manifests": referrers})
+	})
+	host = withTestRegistry(t, mux)
+
+	authFile := filepath.Join(t.TempDir(), "auth.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	if err := os.WriteFile(authFile, []byte(fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, host, auth)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sys := &containertypes.SystemContext{AuthFilePath: authFile}
+
+	got, err := ListReferrers(context.Background(), host+"/org/model:1.0", testManifestDigest, sys)
+	if err != nil {
+		t.Fatalf("ListReferrers() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Digest != "sha256:aaa" || got[0].ArtifactType != referrers[0].ArtifactType {
+		t.Errorf("ListReferrers() = %+v, want %+v", got, referrers)
+	}
+	if tokenAuth != "Basic "+auth {
+		t.Errorf("token request Authorization = %q, want basic auth from the auth file", tokenAuth)
+	}
+}
+
+func TestListReferrers_Unsupported(t *testing.T) {
+	host := withTestRegistry(t, http.NotFoundHandler())
+
+	got, err := ListReferrers(context.Background(), host+"/org/model:1.0", testManifestDigest, &containertypes.SystemContext{})
+	if err != nil {
+		t.Fatalf("ListReferrers() error = %v, want nil for a registry without the referrers API", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ListReferrers() = %v, want no referrers", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestListReferrers_OCILayout(t *testing.T) {
+	var requests int
+	original := httpClient
+	httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return nil, fmt.Errorf("unexpected request to %s", r.URL)
+	})}
+	t.Cleanup(func() { httpClient = original })
+
+	got, err := ListReferrers(context.Background(), "oci:/srv/models/granite:1.0", testManifestDigest, &containertypes.SystemContext{})
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListReferrers() = %v, %v, want no referrers and no error for an OCI layout", got, err)
+	}
+	if requests != 0 {
+		t.Errorf("ListReferrers() made %d requests for an OCI layout, want none", requests)
+	}
+}
+
+func TestListReferrers_ServerError(t *testing.T) {
+	host := withTestRegistry(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	if _, err := ListReferrers(context.Background(), host+"/org/model:1.0", testManifestDigest, &containertypes.SystemContext{}); err == nil {
+		t.Error("ListReferrers() expected error for a 500 response")
+	}
+}
+
+// writeRegistriesConf writes a registries.conf for sys.SystemRegistriesConfPath
+func writeRegistriesConf(t *testing.T, conf string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "registries.conf")
+	if err := os.WriteFile(path, []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestListReferrers_Mirrors(t *testing.T) {
+	var requested []string
+	host := withTestRegistry(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path != "/v2/mirror/model/referrers/"+testManifestDigest {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", ociIndexMediaType)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"schemaVersion": 2,
+			"manifests":     []Referrer{{MediaType: ociIndexMediaType, Digest: "sha256:aaa", ArtifactType: "application/vnd.redhat.modelcard.v1+markdown"}},
+		})
+	}))
+	sys := &containertypes.SystemContext{SystemRegistriesConfPath: writeRegistriesConf(t, fmt.Sprintf(`
+[[registry]]
+prefix = "registry.invalid/org"
+location = "registry.invalid/org"
+
+[[registry.mirror]]
+location = "%[1]s/empty"
+
+[[registry.mirror]]
+location = "%[1]s/mirror"
+`, host))}
+
+	got, err := ListReferrers(context.Background(), "registry.invalid/org/model:1.0", testManifestDigest, sys)
+	if err != nil {
+		t.Fatalf("ListReferrers() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Digest != "sha256:aaa" {
+		t.Errorf("ListReferrers() = %+v, want the referrer served by the mirror", got)
+	}
+	expected := []string{"/v2/empty/model/referrers/" + testManifestDigest, "/v2/mirror/model/referrers/" + testManifestDigest}
+	if strings.Join(requested, ",") != strings.Join(expected, ",") {
+		t.Errorf("requested %v, want mirrors in order %v", requested, expected)
+	}
+}
+
+func TestListReferrers_BlockedRegistry(t *testing.T) {
+	sys := &containertypes.SystemContext{SystemRegistriesConfPath: writeRegistriesConf(t, `
+[[registry]]
+location = "registry.invalid"
+blocked = true
+`)}
+	if _, err := ListReferrers(context.Background(), "registry.invalid/org/model:1.0", testManifestDigest, sys); err == nil {
+		t.Error("ListReferrers() expected error for a blocked registry")
+	}
+}
+
+func TestListReferrers_InsecureSkipTLSVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ociIndexMediaType)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"schemaVersion": 2,
+			"manifests":     []Referrer{{MediaType: ociIndexMediaType, Digest: "sha256:aaa", ArtifactType: "application/vnd.redhat.modelcard.v1+markdown"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	host := strings.TrimPrefix(server.URL, "https://")
+	ref := host + "/org/model:1.0"
+
+	tests := []struct {
+		name      string
+		sys       *containertypes.SystemContext
+		expectErr bool
+	}{
+		{"certificate verified", &containertypes.SystemContext{}, true},
+		{"DockerInsecureSkipTLSVerify", &containertypes.SystemContext{DockerInsecureSkipTLSVerify: containertypes.OptionalBoolTrue}, false},
+		{"insecure registry", &containertypes.SystemContext{SystemRegistriesConfPath: writeRegistriesConf(t, fmt.Sprintf(`
+[[registry]]
+location = %q
+insecure = true
+`, host))}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListReferrers(context.Background(), ref, testManifestDigest, tt.sys)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("ListReferrers() expected a certificate error")
+				}
+				return
+			}
+			if err != nil || len(got) != 1 {
+				t.Errorf("ListReferrers() = %v, %v, want one referrer", got, err)
+			}
+		})
+	}
+}
+
+func TestListReferrers_InsecurePlainHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ociIndexMediaType)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"schemaVersion": 2, "manifests": []Referrer{{Digest: "sha256:aaa"}}})
+	}))
+	t.Cleanup(server.Close)
+	ref := strings.TrimPrefix(server.URL, "http://") + "/org/model:1.0"
+
+	if _, err := ListReferrers(context.Background(), ref, testManifestDigest, &containertypes.SystemContext{}); err == nil {
+		t.Error("ListReferrers() expected error for a plain HTTP registry that is not insecure")
+	}
+	sys := &containertypes.SystemContext{DockerInsecureSkipTLSVerify: containertypes.OptionalBoolTrue}
+	if got, err := ListReferrers(context.Background(), ref, testManifestDigest, sys); err != nil || len(got) != 1 {
+		t.Errorf("ListReferrers() = %v, %v, want one referrer over plain HTTP", got, err)
+	}
+}
@@ -3,15 +3,20 @@ package registry
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
 	containertypes "github.com/containers/image/v5/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
@@ -22,6 +27,21 @@ var httpClient = &http.Client{
 	Timeout: 30 * time.Second,
 }
 
+// registryBaseURL, when set, replaces "https://<registry>" in registry API URLs built by
+// FetchRegistryMetadata, so tests can point it at an httptest server
+var registryBaseURL string
+
+// fetchArchitectures looks up an image's architectures; a var so tests can avoid the network
+var fetchArchitectures = FetchImageArchitectures
+
+// registryAPIBase returns the base URL for a registry's v2 API
+func registryAPIBase(registry string) string {
+	if registryBaseURL != "" {
+		return strings.TrimSuffix(registryBaseURL, "/")
+	}
+	return "https://" + registry
+}
+
 // RegistryManifest represents container registry manifest metadata
 type RegistryManifest struct {
 	Config struct {
@@ -33,29 +53,90 @@ type RegistryManifest struct {
 	Annotations map[string]string `json:"annotations"`
 }
 
-// parseRegistryImageRef extracts registry, repository, image name and tag from a registry reference
+// IsTransientError reports whether a registry error is worth retrying.
+// Network timeouts, dropped connections, 429 and 5xx responses are transient;
+// authentication failures, DNS and TLS errors, missing manifests/blobs and
+// context cancellation are not.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// The caller gave up; retrying would only fail again immediately
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, docker.ErrTooManyRequests) {
+		return true
+	}
+
+	// 4xx responses are parsed into errcode errors; only 5xx surface as UnexpectedHTTPStatusError
+	var statusErr docker.UnexpectedHTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// Dropped or refused connections, unreachable hosts and truncated responses mid-transfer
+	for _, transient := range []error{
+		syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, syscall.ETIMEDOUT,
+		syscall.EHOSTUNREACH, syscall.ENETUNREACH, syscall.EPIPE, io.ErrUnexpectedEOF,
+	} {
+		if errors.Is(err, transient) {
+			return true
+		}
+	}
+
+	// Other network errors, such as unknown hosts or failed TLS verification, fail the same way
+	// on every attempt; only timeouts are worth another try
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ValidateImageRef reports whether a registry reference has the registry/repository/image[:tag]
+// form the artifact and manifest code expects
+func ValidateImageRef(imageRef string) error {
+	_, _, _, _, err := parseRegistryImageRef(imageRef)
+	return err
+}
+
+// parseRegistryImageRef extracts registry, repository, image name and tag from a registry reference,
+// normalized the way containers/image resolves it: a reference without a registry is on docker.io,
+// a single-element docker.io path is in the "library" namespace and a missing tag is "latest".
+// For digest references the returned tag is the digest.
 func parseRegistryImageRef(imageRef string) (registry, repository, imageName, tag string, err error) {
-	parts := strings.Split(imageRef, "/")
-	if len(parts) < 3 {
-		return "", "", "", "", fmt.Errorf("invalid image reference format")
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("invalid image reference format: %v", err)
 	}
 
-	registry = parts[0]
-	repository = parts[1]
+	registry = reference.Domain(named)
+	repository, imageName, ok := strings.Cut(reference.Path(named), "/")
+	if !ok {
+		return "", "", "", "", fmt.Errorf("invalid image reference format: %q has no repository namespace", imageRef)
+	}
 
-	// Handle image name and tag
-	imageWithTag := strings.Join(parts[2:], "/")
-	if idx := strings.LastIndex(imageWithTag, ":"); idx != -1 {
-		imageName = imageWithTag[:idx]
-		tag = imageWithTag[idx+1:]
-	} else {
-		imageName = imageWithTag
+	switch ref := named.(type) {
+	case reference.Digested:
+		tag = ref.Digest().String()
+	case reference.Tagged:
+		tag = ref.Tag()
+	default:
 		tag = "latest"
 	}
 
 	return registry, repository, imageName, tag, nil
 }
 
+// ociImageURI builds the oci:// artifact URI of a parsed registry reference, pinning it with "@"
+// when the tag is a digest
+func ociImageURI(registry, repository, imageName, tag string) string {
+	if strings.Contains(tag, ":") {
+		return fmt.Sprintf("oci://%s/%s/%s@%s", registry, repository, imageName, tag)
+	}
+	return fmt.Sprintf("oci://%s/%s/%s:%s", registry, repository, imageName, tag)
+}
+
 // manifestListEntry represents an entry in a Docker/OCI manifest list
 type manifestListEntry struct {
 	Platform struct {
@@ -228,7 +309,7 @@ func addArchitectureToCustomProps(imageRef string, customProps map[string]interf
 	architectures, err := utils.RetryWithExponentialBackoff(
 		utils.DefaultRetryConfig,
 		func() ([]string, error) {
-			return FetchImageArchitectures(imageRef)
+			return fetchArchitectures(imageRef)
 		},
 		fmt.Sprintf("fetch architectures for %s", imageRef),
 	)
@@ -260,13 +341,13 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 	}
 
 	// Create OCI URI format
-	ociURI := fmt.Sprintf("oci://%s/%s/%s:%s", registry, repository, imageName, tag)
+	ociURI := ociImageURI(registry, repository, imageName, tag)
 
 	// For Red Hat registry, we can try to fetch manifest metadata
 	// This is a simplified implementation - in production you'd need proper authentication
 	if strings.Contains(registry, "registry.redhat.io") {
 		// Try to fetch manifest via registry API v2
-		manifestURL := fmt.Sprintf("https://%s/v2/%s/%s/manifests/%s", registry, repository, imageName, tag)
+		manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", registryAPIBase(registry), repository, imageName, tag)
 
 		resp, err := httpClient.Get(manifestURL)
 		if err != nil {
@@ -359,8 +440,118 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 	}, nil
 }
 
-// ExtractOCIArtifactsFromRegistry creates structured OCI artifacts from registry references
+// AddDigestToArtifact records the resolved manifest digest as the "digest" custom property and,
+// when pin is set, rewrites the artifact URI to its immutable digest form (see DigestPinnedURI)
+func AddDigestToArtifact(artifact *types.OCIArtifact, digest string, pin bool) {
+	if digest == "" {
+		return
+	}
+	if artifact.CustomProperties == nil {
+		artifact.CustomProperties = make(map[string]interface{})
+	}
+	artifact.CustomProperties["digest"] = map[string]interface{}{
+		"metadataType": "MetadataStringValue",
+		"string_value": digest,
+	}
+	if pin {
+		artifact.URI = DigestPinnedURI(artifact.URI, digest)
+	}
+}
+
+// AddImageSizeToArtifact records the image's layer count and, when every layer size is known,
+// the total compressed size as the "layer_count" and "total_size_bytes" custom properties
+func AddImageSizeToArtifact(artifact *types.OCIArtifact, layers []containertypes.BlobInfo) {
+	if len(layers) == 0 {
+		return
+	}
+	if artifact.CustomProperties == nil {
+		artifact.CustomProperties = make(map[string]interface{})
+	}
+
+	artifact.CustomProperties["layer_count"] = map[string]interface{}{
+		"metadataType": "MetadataStringValue",
+		"string_value": strconv.Itoa(len(layers)),
+	}
+
+	var totalSize int64
+	for _, layer := range layers {
+		// Size is -1 when the manifest does not declare it; a partial sum would be misleading
+		if layer.Size < 0 {
+			return
+		}
+		totalSize += layer.Size
+	}
+	artifact.CustomProperties["total_size_bytes"] = map[string]interface{}{
+		"metadataType": "MetadataStringValue",
+		"string_value": strconv.FormatInt(totalSize, 10),
+	}
+}
+
+// AddPlatformToArtifact records the os and architecture from the image config as the "os" and
+// "image_architecture" custom properties. Empty values are skipped. "architecture" is left alone,
+// as it lists every architecture the image index offers rather than the one resolved.
+func AddPlatformToArtifact(artifact *types.OCIArtifact, osName, architecture string) {
+	for key, value := range map[string]string{"os": osName, "image_architecture": architecture} {
+		if value == "" {
+			continue
+		}
+		if artifact.CustomProperties == nil {
+			artifact.CustomProperties = make(map[string]interface{})
+		}
+		artifact.CustomProperties[key] = map[string]interface{}{
+			"metadataType": "MetadataStringValue",
+			"string_value": value,
+		}
+	}
+}
+
+// DigestPinnedURI replaces the tag (or existing digest) of an image URI with the given digest,
+// e.g. "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5" -> "oci://registry.redhat.io/rhelai1/modelcar-granite@sha256:..."
+// OCI layout references ("oci:<dir>[:<tag>]") are returned unchanged: the oci transport has no
+// digest form, so "oci:<dir>@sha256:..." would not be a valid reference.
+func DigestPinnedURI(uri, digest string) string {
+	if IsOCILayoutRef(uri) {
+		return uri
+	}
+	// Only the last path segment can carry a tag; the registry host may contain a port
+	lastSlash := strings.LastIndex(uri, "/")
+	name := uri[lastSlash+1:]
+	if at := strings.Index(name, "@"); at >= 0 {
+		name = name[:at]
+	}
+	if colon := strings.Index(name, ":"); colon >= 0 {
+		name = name[:colon]
+	}
+	return uri[:lastSlash+1] + name + "@" + digest
+}
+
+// IsDigestPinned reports whether an image URI references a manifest digest instead of a tag
+func IsDigestPinned(uri string) bool {
+	return strings.Contains(uri[strings.LastIndex(uri, "/")+1:], "@")
+}
+
+// ExtractOCIArtifactsFromRegistry creates structured OCI artifacts from registry references.
+// A reference that cannot be parsed yields an empty slice; use ExtractOCIArtifactsFromRegistryE
+// to get the parse error instead.
 func ExtractOCIArtifactsFromRegistry(manifestRef string) []types.OCIArtifact {
+	artifacts, _ := ExtractOCIArtifactsFromRegistryE(manifestRef)
+	return artifacts
+}
+
+// ExtractOCIArtifactsFromRegistryE is ExtractOCIArtifactsFromRegistry but returns an error
+// (with an empty, non-nil slice) when the reference cannot be parsed
+func ExtractOCIArtifactsFromRegistryE(manifestRef string) ([]types.OCIArtifact, error) {
+	if IsOCILayoutRef(manifestRef) {
+		artifact, err := ociLayoutArtifact(manifestRef)
+		if err != nil {
+			return []types.OCIArtifact{}, fmt.Errorf("failed to parse image reference %q: %w", manifestRef, err)
+		}
+		return []types.OCIArtifact{artifact}, nil
+	}
+	if _, _, _, _, err := parseRegistryImageRef(manifestRef); err != nil {
+		return []types.OCIArtifact{}, fmt.Errorf("failed to parse image reference %q: %w", manifestRef, err)
+	}
+
 	var artifacts []types.OCIArtifact
 
 	// The manifestRef itself is the primary OCI artifact
@@ -369,28 +560,22 @@ func ExtractOCIArtifactsFromRegistry(manifestRef string) []types.OCIArtifact {
 	} else {
 		log.Printf("Warning: Failed to fetch registry metadata for %s: %v", manifestRef, err)
 		// Create basic artifact anyway with nil timestamps
-		registry, repository, imageName, tag, parseErr := parseRegistryImageRef(manifestRef)
-		if parseErr == nil {
-			ociURI := fmt.Sprintf("oci://%s/%s/%s:%s", registry, repository, imageName, tag)
-			artifacts = append(artifacts, types.OCIArtifact{
-				URI:                      ociURI,
-				CreateTimeSinceEpoch:     nil,
-				LastUpdateTimeSinceEpoch: nil,
-				CustomProperties: map[string]interface{}{
-					"source": map[string]interface{}{
-						"string_value": "unknown",
-					},
-					"error": map[string]interface{}{
-						"string_value": err.Error(),
-					},
+		registry, repository, imageName, tag, _ := parseRegistryImageRef(manifestRef)
+		ociURI := ociImageURI(registry, repository, imageName, tag)
+		artifacts = append(artifacts, types.OCIArtifact{
+			URI:                      ociURI,
+			CreateTimeSinceEpoch:     nil,
+			LastUpdateTimeSinceEpoch: nil,
+			CustomProperties: map[string]interface{}{
+				"source": map[string]interface{}{
+					"string_value": "unknown",
 				},
-			})
-		}
+				"error": map[string]interface{}{
+					"string_value": err.Error(),
+				},
+			},
+		})
 	}
 
-	// Ensure we never return nil - always return at least an empty slice
-	if artifacts == nil {
-		artifacts = []types.OCIArtifact{}
-	}
-	return artifacts
+	return artifacts, nil
 }
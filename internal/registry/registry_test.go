@@ -1,9 +1,27 @@
 package registry
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/containers/image/v5/docker"
+	containertypes "github.com/containers/image/v5/types"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
 func TestParseRegistryImageRef(t *testing.T) {
@@ -53,13 +71,77 @@ func TestParseRegistryImageRef(t *testing.T) {
 			expectError:        false,
 		},
 		{
-			name:        "invalid format - too few parts",
+			name:               "bare name defaults to docker.io library and latest",
+			imageRef:           "alpine",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "library",
+			expectedImageName:  "alpine",
+			expectedTag:        "latest",
+		},
+		{
+			name:               "bare name with tag",
+			imageRef:           "alpine:3.20",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "library",
+			expectedImageName:  "alpine",
+			expectedTag:        "3.20",
+		},
+		{
+			name:               "docker hub user repository without registry",
+			imageRef:           "vllm/vllm-openai:v0.8.5",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "vllm",
+			expectedImageName:  "vllm-openai",
+			expectedTag:        "v0.8.5",
+		},
+		{
+			name:               "docker.io library reference",
+			imageRef:           "docker.io/library/alpine",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "library",
+			expectedImageName:  "alpine",
+			expectedTag:        "latest",
+		},
+		{
+			name:               "docker.io reference without namespace",
+			imageRef:           "docker.io/alpine:3.20",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "library",
+			expectedImageName:  "alpine",
+			expectedTag:        "3.20",
+		},
+		{
+			name:               "legacy docker hub registry name",
+			imageRef:           "index.docker.io/library/alpine:3.20",
+			expectedRegistry:   "docker.io",
+			expectedRepository: "library",
+			expectedImageName:  "alpine",
+			expectedTag:        "3.20",
+		},
+		{
+			name:               "ghcr.io reference",
+			imageRef:           "ghcr.io/opendatahub-io/model:1.2",
+			expectedRegistry:   "ghcr.io",
+			expectedRepository: "opendatahub-io",
+			expectedImageName:  "model",
+			expectedTag:        "1.2",
+		},
+		{
+			name:               "digest reference",
+			imageRef:           "quay.io/redhat-ai/granite@sha256:" + strings.Repeat("a", 64),
+			expectedRegistry:   "quay.io",
+			expectedRepository: "redhat-ai",
+			expectedImageName:  "granite",
+			expectedTag:        "sha256:" + strings.Repeat("a", 64),
+		},
+		{
+			name:        "invalid format - no repository namespace",
 			imageRef:    "registry.io/image",
 			expectError: true,
 		},
 		{
-			name:        "invalid format - single part",
-			imageRef:    "image",
+			name:        "invalid format - uppercase repository",
+			imageRef:    "registry.io/Org/Image",
 			expectError: true,
 		},
 		{
@@ -101,225 +183,242 @@ func TestParseRegistryImageRef(t *testing.T) {
 	}
 }
 
+// withManifestServer points FetchRegistryMetadata at a test server and stubs the architecture
+// lookup, so registry metadata can be fetched without network access
+func withManifestServer(t *testing.T, handler http.Handler) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	stubRegistryAPI(t, server.Client(), server.URL)
+}
+
+// stubRegistryAPI swaps the registry HTTP client, base URL and architecture lookup for a test
+func stubRegistryAPI(t *testing.T, client *http.Client, baseURL string) {
+	t.Helper()
+	originalClient, originalBaseURL, originalFetch := httpClient, registryBaseURL, fetchArchitectures
+	httpClient = client
+	registryBaseURL = baseURL
+	fetchArchitectures = func(string) ([]string, error) { return []string{"amd64", "arm64"}, nil }
+	t.Cleanup(func() {
+		httpClient, registryBaseURL, fetchArchitectures = originalClient, originalBaseURL, originalFetch
+	})
+}
+
+// stringProp returns the string_value of a custom property, or "" when absent
+func stringProp(props map[string]interface{}, key string) string {
+	prop, ok := props[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := prop["string_value"].(string)
+	return value
+}
+
 func TestFetchRegistryMetadata(t *testing.T) {
-	t.Skip("Skipping integration test that makes network calls - should be run separately with -integration flag")
+	manifest := `{
+		"config": {"created": "2025-01-15T10:30:00Z"},
+		"history": [
+			{"created": "2025-01-15T10:30:00Z"},
+			{"created": "2025-02-01T08:00:00Z"}
+		],
+		"annotations": {"org.opencontainers.image.title": "granite-3.1-8b-base"}
+	}`
+	var requestedPath string
+	withManifestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		_, _ = w.Write([]byte(manifest))
+	}))
+
+	result, err := FetchRegistryMetadata("registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0")
+	if err != nil {
+		t.Fatalf("FetchRegistryMetadata failed: %v", err)
+	}
+
+	if requestedPath != "/v2/rhelai1/modelcar-granite-3-1-8b-base/manifests/1.0" {
+		t.Errorf("Unexpected manifest path %q", requestedPath)
+	}
+	if result.URI != "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0" {
+		t.Errorf("URI: got %s", result.URI)
+	}
+	wantCreate := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC).UnixMilli()
+	wantUpdate := time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC).UnixMilli()
+	if result.CreateTimeSinceEpoch == nil || *result.CreateTimeSinceEpoch != wantCreate {
+		t.Errorf("CreateTimeSinceEpoch: got %v, want %d", result.CreateTimeSinceEpoch, wantCreate)
+	}
+	if result.LastUpdateTimeSinceEpoch == nil || *result.LastUpdateTimeSinceEpoch != wantUpdate {
+		t.Errorf("LastUpdateTimeSinceEpoch: got %v, want %d", result.LastUpdateTimeSinceEpoch, wantUpdate)
+	}
+
+	expectedProps := map[string]string{
+		"source":                         "registry.redhat.io",
+		"type":                           "modelcar",
+		"org.opencontainers.image.title": "granite-3.1-8b-base",
+		"architecture":                   `["amd64","arm64"]`,
+	}
+	for key, want := range expectedProps {
+		if got := stringProp(result.CustomProperties, key); got != want {
+			t.Errorf("Custom property %s: got %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestFetchRegistryMetadata_NoHistory(t *testing.T) {
+	withManifestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"config": {"created": "2025-01-15T10:30:00Z"}}`))
+	}))
+
+	result, err := FetchRegistryMetadata("registry.redhat.io/rhelai1/test-model:1.0")
+	if err != nil {
+		t.Fatalf("FetchRegistryMetadata failed: %v", err)
+	}
+	if result.CreateTimeSinceEpoch == nil || result.LastUpdateTimeSinceEpoch == nil {
+		t.Fatal("Expected both timestamps to be set from config.created")
+	}
+	if *result.LastUpdateTimeSinceEpoch != *result.CreateTimeSinceEpoch {
+		t.Errorf("Expected update time to fall back to create time, got %d vs %d",
+			*result.LastUpdateTimeSinceEpoch, *result.CreateTimeSinceEpoch)
+	}
+}
+
+func TestFetchRegistryMetadata_NormalizedReferences(t *testing.T) {
+	withManifestServer(t, http.NotFoundHandler())
+
+	digest := "sha256:" + strings.Repeat("b", 64)
 	tests := []struct {
-		name             string
-		imageRef         string
-		expectError      bool
-		expectedURIStart string
-		checkTimestamp   bool
+		imageRef   string
+		wantURI    string
+		wantSource string
 	}{
-		{
-			name:             "valid red hat registry reference",
-			imageRef:         "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0",
-			expectError:      false,
-			expectedURIStart: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0",
-			checkTimestamp:   true,
-		},
-		{
-			name:             "non-red hat registry",
-			imageRef:         "docker.io/library/alpine:latest",
-			expectError:      false,
-			expectedURIStart: "oci://docker.io/library/alpine:latest",
-			checkTimestamp:   true,
-		},
-		{
-			name:        "invalid image reference",
-			imageRef:    "invalid/ref",
-			expectError: true,
-		},
+		{imageRef: "alpine", wantURI: "oci://docker.io/library/alpine:latest", wantSource: "docker.io"},
+		{imageRef: "docker.io/library/alpine:3.20", wantURI: "oci://docker.io/library/alpine:3.20", wantSource: "docker.io"},
+		{imageRef: "ghcr.io/org/model", wantURI: "oci://ghcr.io/org/model:latest", wantSource: "ghcr.io"},
+		{imageRef: "quay.io/org/model@" + digest, wantURI: "oci://quay.io/org/model@" + digest, wantSource: "quay.io"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
+		t.Run(tt.imageRef, func(t *testing.T) {
 			result, err := FetchRegistryMetadata(tt.imageRef)
-
-			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-				}
-				return
-			}
-
 			if err != nil {
-				t.Errorf("Unexpected error: %v", err)
-				return
+				t.Fatalf("FetchRegistryMetadata failed: %v", err)
 			}
-
-			if result == nil {
-				t.Fatal("Result should not be nil")
+			if result.URI != tt.wantURI {
+				t.Errorf("URI: got %s, want %s", result.URI, tt.wantURI)
 			}
-
-			// Check URI format
-			if result.URI != tt.expectedURIStart {
-				t.Errorf("URI: got %s, want %s", result.URI, tt.expectedURIStart)
+			if got := stringProp(result.CustomProperties, "source"); got != tt.wantSource {
+				t.Errorf("source: got %q, want %q", got, tt.wantSource)
 			}
+		})
+	}
+}
 
-			// Check timestamps if required
-			if tt.checkTimestamp {
-				if result.CreateTimeSinceEpoch == nil {
-					t.Error("CreateTimeSinceEpoch should not be nil")
-				}
-				if result.LastUpdateTimeSinceEpoch == nil {
-					t.Error("LastUpdateTimeSinceEpoch should not be nil")
-				}
+func TestFetchRegistryMetadata_ErrorHandling(t *testing.T) {
+	// A closed server makes every request fail at the network level
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
 
-				// Timestamps should be recent (within last hour for test)
-				currentTime := time.Now().Unix() * 1000
-				hourAgo := currentTime - (60 * 60 * 1000)
+	tests := []struct {
+		name       string
+		imageRef   string
+		handler    http.Handler
+		wantSource string
+	}{
+		{name: "network failure", imageRef: "registry.redhat.io/rhelai1/test-model:1.0", wantSource: "registry.redhat.io"},
+		{
+			name:       "non-200 response",
+			imageRef:   "registry.redhat.io/rhelai1/test-model:1.0",
+			handler:    http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusUnauthorized) }),
+			wantSource: "registry.redhat.io",
+		},
+		{
+			name:       "malformed manifest",
+			imageRef:   "registry.redhat.io/rhelai1/test-model:1.0",
+			handler:    http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("not json")) }),
+			wantSource: "registry.redhat.io",
+		},
+		{name: "other registry is not queried", imageRef: "quay.io/test/model:1.0", wantSource: "quay.io"},
+	}
 
-				if result.CreateTimeSinceEpoch != nil && *result.CreateTimeSinceEpoch < hourAgo {
-					// Only warn if the timestamp seems too old (might be from actual registry)
-					t.Logf("Warning: CreateTimeSinceEpoch seems old: %d", *result.CreateTimeSinceEpoch)
-				}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.handler != nil {
+				withManifestServer(t, tt.handler)
+			} else {
+				stubRegistryAPI(t, closed.Client(), closed.URL)
 			}
 
-			// Check custom properties
-			if result.CustomProperties == nil {
-				t.Error("CustomProperties should not be nil")
+			result, err := FetchRegistryMetadata(tt.imageRef)
+			if err != nil {
+				t.Fatalf("FetchRegistryMetadata should not return error, got: %v", err)
 			}
-
-			// Verify source property exists
-			if source, exists := result.CustomProperties["source"]; exists {
-				if sourceMap, ok := source.(map[string]interface{}); ok {
-					if sourceValue, exists := sourceMap["string_value"]; exists {
-						if sourceStr, ok := sourceValue.(string); ok && sourceStr != "" {
-							t.Logf("Source: %s", sourceStr)
-						} else {
-							t.Error("Source string_value should be a non-empty string")
-						}
-					} else {
-						t.Error("Source should have string_value field")
-					}
-				} else {
-					t.Error("Source should be a map")
-				}
-			} else {
-				t.Error("CustomProperties should contain source")
+			if result.URI != "oci://"+tt.imageRef {
+				t.Errorf("URI: got %s, want oci://%s", result.URI, tt.imageRef)
 			}
-
-			// Verify type property exists
-			if typeVal, exists := result.CustomProperties["type"]; exists {
-				if typeMap, ok := typeVal.(map[string]interface{}); ok {
-					if typeValue, exists := typeMap["string_value"]; exists {
-						if typeStr, ok := typeValue.(string); ok && typeStr == "modelcar" {
-							t.Logf("Type: %s", typeStr)
-						} else {
-							t.Errorf("Type string_value should be 'modelcar', got: %v", typeValue)
-						}
-					} else {
-						t.Error("Type should have string_value field")
-					}
-				} else {
-					t.Error("Type should be a map")
-				}
-			} else {
-				t.Error("CustomProperties should contain type")
+			if result.CreateTimeSinceEpoch != nil || result.LastUpdateTimeSinceEpoch != nil {
+				t.Errorf("Expected nil timestamps on fallback, got %v/%v", result.CreateTimeSinceEpoch, result.LastUpdateTimeSinceEpoch)
+			}
+			if got := stringProp(result.CustomProperties, "source"); got != tt.wantSource {
+				t.Errorf("Source: got %q, want %q", got, tt.wantSource)
+			}
+			if got := stringProp(result.CustomProperties, "type"); got != "modelcar" {
+				t.Errorf("Type: got %q, want modelcar", got)
 			}
 		})
 	}
 }
 
 func TestExtractOCIArtifactsFromRegistry(t *testing.T) {
-	t.Skip("Skipping integration test that makes network calls - should be run separately with -integration flag")
+	withManifestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"config": {"created": "2025-01-15T10:30:00Z"}}`))
+	}))
+
 	tests := []struct {
 		name            string
 		manifestRef     string
 		expectArtifacts int
 		checkURI        string
+		expectTimes     bool
 	}{
 		{
-			name:            "valid registry reference",
+			name:            "red hat registry reference",
 			manifestRef:     "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0",
 			expectArtifacts: 1,
 			checkURI:        "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.0",
+			expectTimes:     true,
 		},
 		{
-			name:            "non-red hat registry",
+			name:            "other registry falls back without timestamps",
 			manifestRef:     "docker.io/library/alpine:latest",
 			expectArtifacts: 1,
 			checkURI:        "oci://docker.io/library/alpine:latest",
 		},
 		{
-			name:            "invalid reference - should still create artifact with error",
-			manifestRef:     "invalid/ref",
-			expectArtifacts: 0, // parseRegistryImageRef will fail, so no artifact created
+			name:            "invalid reference",
+			manifestRef:     "invalid ref",
+			expectArtifacts: 0,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := ExtractOCIArtifactsFromRegistry(tt.manifestRef)
-
 			if len(result) != tt.expectArtifacts {
-				t.Errorf("Expected %d artifacts, got %d", tt.expectArtifacts, len(result))
+				t.Fatalf("Expected %d artifacts, got %d", tt.expectArtifacts, len(result))
+			}
+			if tt.expectArtifacts == 0 {
 				return
 			}
 
-			if tt.expectArtifacts > 0 {
-				artifact := result[0]
-
-				// Check URI
-				if artifact.URI != tt.checkURI {
-					t.Errorf("URI: got %s, want %s", artifact.URI, tt.checkURI)
-				}
-
-				// Check timestamps
-				if artifact.CreateTimeSinceEpoch == nil {
-					t.Error("CreateTimeSinceEpoch should not be nil")
-				}
-				if artifact.LastUpdateTimeSinceEpoch == nil {
-					t.Error("LastUpdateTimeSinceEpoch should not be nil")
-				}
-
-				// Check custom properties
-				if artifact.CustomProperties == nil {
-					t.Error("CustomProperties should not be nil")
-				}
+			artifact := result[0]
+			if artifact.URI != tt.checkURI {
+				t.Errorf("URI: got %s, want %s", artifact.URI, tt.checkURI)
 			}
-		})
-	}
-}
-
-func TestFetchRegistryMetadata_ErrorHandling(t *testing.T) {
-	t.Skip("Skipping integration test that makes network calls - should be run separately with -integration flag")
-	// Test with a reference that will definitely fail network call
-	// (using a non-existent domain to ensure network failure)
-	imageRef := "nonexistent.registry.example.com/test/model:1.0"
-
-	result, err := FetchRegistryMetadata(imageRef)
-	if err != nil {
-		t.Errorf("FetchRegistryMetadata should not return error for network failures, got: %v", err)
-		return
-	}
-
-	if result == nil {
-		t.Fatal("Result should not be nil even on network failure")
-	}
-
-	// Should create fallback artifact
-	expectedURI := "oci://nonexistent.registry.example.com/test/model:1.0"
-	if result.URI != expectedURI {
-		t.Errorf("URI: got %s, want %s", result.URI, expectedURI)
-	}
-
-	// Should have timestamps
-	if result.CreateTimeSinceEpoch == nil {
-		t.Error("CreateTimeSinceEpoch should not be nil")
-	}
-
-	// Should have source in custom properties
-	if source, exists := result.CustomProperties["source"]; exists {
-		if sourceMap, ok := source.(map[string]interface{}); ok {
-			if sourceValue, exists := sourceMap["string_value"]; exists {
-				if sourceStr, ok := sourceValue.(string); ok {
-					if sourceStr != "nonexistent.registry.example.com" {
-						t.Errorf("Source should be registry name, got: %s", sourceStr)
-					}
-				}
+			if hasTimes := artifact.CreateTimeSinceEpoch != nil && artifact.LastUpdateTimeSinceEpoch != nil; hasTimes != tt.expectTimes {
+				t.Errorf("Expected timestamps set = %v, got create=%v update=%v", tt.expectTimes, artifact.CreateTimeSinceEpoch, artifact.LastUpdateTimeSinceEpoch)
 			}
-		}
+			if artifact.CustomProperties == nil {
+				t.Error("CustomProperties should not be nil")
+			}
+		})
 	}
 }
 
@@ -372,6 +471,7 @@ func TestRegistryManifest_StructureCompatibility(t *testing.T) {
 }
 
 func TestExtractOCIArtifactsFromRegistry_Properties(t *testing.T) {
+	withManifestServer(t, http.NotFoundHandler())
 	manifestRef := "registry.redhat.io/rhelai1/test-model:1.0"
 	artifacts := ExtractOCIArtifactsFromRegistry(manifestRef)
 
@@ -412,7 +512,7 @@ func TestExtractOCIArtifactsFromRegistry_Properties(t *testing.T) {
 // Test to ensure artifacts slice is never nil
 func TestExtractOCIArtifactsFromRegistry_NeverNil(t *testing.T) {
 	// Even with invalid input, should return empty slice, not nil
-	result := ExtractOCIArtifactsFromRegistry("completely/invalid")
+	result := ExtractOCIArtifactsFromRegistry("completely invalid")
 
 	if result == nil {
 		t.Error("Result should never be nil, should be empty slice instead")
@@ -964,3 +1064,222 @@ func TestArchitectureJSONFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"context deadline exceeded", context.DeadlineExceeded, false},
+		{"wrapped context cancellation", fmt.Errorf("fetching blob: %w", context.Canceled), false},
+		{"too many requests", docker.ErrTooManyRequests, true},
+		{"server error", fmt.Errorf("reading manifest: %w", docker.UnexpectedHTTPStatusError{StatusCode: http.StatusBadGateway}), true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"host unreachable", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)}, true},
+		{"network timeout", &net.OpError{Op: "read", Err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}}, true},
+		{"unknown host", &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "registry.invalid", IsNotFound: true}}, false},
+		{"TLS verification failure", &url.Error{Op: "Get", URL: "https://registry.example.com/v2/", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, false},
+		{"unauthorized", docker.ErrUnauthorizedForCredentials{Err: errors.New("unauthorized")}, false},
+		{"manifest unknown", errors.New("reading manifest 1.0: manifest unknown"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientError(tt.err); got != tt.expected {
+				t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigestPinnedURI(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name     string
+		uri      string
+		expected string
+	}{
+		{
+			name:     "tag is replaced",
+			uri:      "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+			expected: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct@" + digest,
+		},
+		{
+			name:     "registry port is kept",
+			uri:      "oci://localhost:5000/org/model:latest",
+			expected: "oci://localhost:5000/org/model@" + digest,
+		},
+		{
+			name:     "existing digest is replaced",
+			uri:      "oci://quay.io/org/model@sha256:aaaa",
+			expected: "oci://quay.io/org/model@" + digest,
+		},
+		{
+			name:     "untagged reference",
+			uri:      "quay.io/org/model",
+			expected: "quay.io/org/model@" + digest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DigestPinnedURI(tt.uri, digest)
+			if got != tt.expected {
+				t.Errorf("DigestPinnedURI(%q) = %q, want %q", tt.uri, got, tt.expected)
+			}
+			if !IsDigestPinned(got) {
+				t.Errorf("IsDigestPinned(%q) = false, want true", got)
+			}
+		})
+	}
+
+	if IsDigestPinned("oci://localhost:5000/org/model:latest") {
+		t.Error("IsDigestPinned() = true for a tagged URI")
+	}
+
+	// OCI layouts have no digest form and are left as they are
+	if got := DigestPinnedURI("oci:/srv/models/granite:1.0", digest); got != "oci:/srv/models/granite:1.0" {
+		t.Errorf("DigestPinnedURI() = %q for an OCI layout, want it unchanged", got)
+	}
+}
+
+func TestAddDigestToArtifact(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	uri := "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"
+
+	tests := []struct {
+		name        string
+		uri         string
+		digest      string
+		pin         bool
+		expectedURI string
+		expectProp  bool
+	}{
+		{name: "digest recorded without pinning", digest: digest, expectedURI: uri, expectProp: true},
+		{name: "digest recorded and URI pinned", digest: digest, pin: true, expectedURI: "oci://registry.redhat.io/rhelai1/modelcar-granite@" + digest, expectProp: true},
+		{name: "unknown digest leaves artifact untouched", pin: true, expectedURI: uri},
+		{name: "OCI layout URI is not pinned", uri: "oci:/srv/models/granite:1.0", digest: digest, pin: true, expectedURI: "oci:/srv/models/granite:1.0", expectProp: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact := types.OCIArtifact{URI: uri}
+			if tt.uri != "" {
+				artifact.URI = tt.uri
+			}
+			AddDigestToArtifact(&artifact, tt.digest, tt.pin)
+
+			if artifact.URI != tt.expectedURI {
+				t.Errorf("URI = %q, want %q", artifact.URI, tt.expectedURI)
+			}
+			prop, ok := artifact.CustomProperties["digest"].(map[string]interface{})
+			if ok != tt.expectProp {
+				t.Fatalf("digest custom property present = %v, want %v", ok, tt.expectProp)
+			}
+			if ok && prop["string_value"] != tt.digest {
+				t.Errorf("digest string_value = %v, want %s", prop["string_value"], tt.digest)
+			}
+		})
+	}
+}
+
+func TestAddImageSizeToArtifact(t *testing.T) {
+	tests := []struct {
+		name          string
+		layers        []containertypes.BlobInfo
+		expectedCount string
+		expectedSize  string
+	}{
+		{
+			name:          "sizes are summed",
+			layers:        []containertypes.BlobInfo{{Size: 1024}, {Size: 4096}, {Size: 16 << 30}},
+			expectedCount: "3",
+			expectedSize:  "17179874304",
+		},
+		{
+			name:          "unknown layer size omits total",
+			layers:        []containertypes.BlobInfo{{Size: 1024}, {Size: -1}},
+			expectedCount: "2",
+		},
+		{
+			name: "no layers leaves artifact untouched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact := types.OCIArtifact{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"}
+			AddImageSizeToArtifact(&artifact, tt.layers)
+
+			for key, expected := range map[string]string{"layer_count": tt.expectedCount, "total_size_bytes": tt.expectedSize} {
+				prop, ok := artifact.CustomProperties[key].(map[string]interface{})
+				if expected == "" {
+					if ok {
+						t.Errorf("Expected no %s custom property, got %v", key, prop)
+					}
+					continue
+				}
+				if !ok {
+					t.Fatalf("Expected %s custom property", key)
+				}
+				if prop["metadataType"] != "MetadataStringValue" || prop["string_value"] != expected {
+					t.Errorf("%s = %v, want string_value %s", key, prop, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestAddPlatformToArtifact(t *testing.T) {
+	tests := []struct {
+		name         string
+		os           string
+		architecture string
+		expected     map[string]string
+	}{
+		{
+			name:         "os and architecture",
+			os:           "linux",
+			architecture: "amd64",
+			expected:     map[string]string{"os": "linux", "image_architecture": "amd64"},
+		},
+		{
+			name:         "missing os",
+			architecture: "arm64",
+			expected:     map[string]string{"image_architecture": "arm64"},
+		},
+		{
+			name: "absent fields leave artifact untouched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact := types.OCIArtifact{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"}
+			AddPlatformToArtifact(&artifact, tt.os, tt.architecture)
+
+			if len(artifact.CustomProperties) != len(tt.expected) {
+				t.Fatalf("CustomProperties = %v, want keys %v", artifact.CustomProperties, tt.expected)
+			}
+			for key, expected := range tt.expected {
+				prop, ok := artifact.CustomProperties[key].(map[string]interface{})
+				if !ok || prop["metadataType"] != "MetadataStringValue" || prop["string_value"] != expected {
+					t.Errorf("%s = %v, want string_value %s", key, artifact.CustomProperties[key], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractOCIArtifactsFromRegistryE_InvalidReference(t *testing.T) {
+	artifacts, err := ExtractOCIArtifactsFromRegistryE("completely invalid")
+	if err == nil {
+		t.Error("Expected error for unparseable reference")
+	}
+	if artifacts == nil || len(artifacts) != 0 {
+		t.Errorf("Expected empty, non-nil slice on error, got %v", artifacts)
+	}
+}
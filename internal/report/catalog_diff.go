@@ -0,0 +1,258 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// nullValue is how a missing field is shown in the diff
+const nullValue = "null"
+
+// CatalogDiff describes what changed between two catalog snapshots
+type CatalogDiff struct {
+	GeneratedAt time.Time   `yaml:"generated_at"`
+	OldCatalog  string      `yaml:"old_catalog"`
+	NewCatalog  string      `yaml:"new_catalog"`
+	Added       []string    `yaml:"added,omitempty"`
+	Removed     []string    `yaml:"removed,omitempty"`
+	Changed     []ModelDiff `yaml:"changed,omitempty"`
+}
+
+// ModelDiff lists the field changes for a model present in both catalogs
+type ModelDiff struct {
+	Name    string        `yaml:"name"`
+	Changes []FieldChange `yaml:"changes"`
+}
+
+// FieldChange is a single field whose value differs between the snapshots
+type FieldChange struct {
+	Field string `yaml:"field"`
+	Old   string `yaml:"old"`
+	New   string `yaml:"new"`
+}
+
+// GenerateCatalogDiff compares two catalog files and writes catalog-diff.md and
+// catalog-diff.yaml to reportDir
+func GenerateCatalogDiff(oldCatalogPath, newCatalogPath, reportDir string) error {
+	oldCatalog, err := readCatalog(oldCatalogPath)
+	if err != nil {
+		return fmt.Errorf("failed to read old catalog: %w", err)
+	}
+	newCatalog, err := readCatalog(newCatalogPath)
+	if err != nil {
+		return fmt.Errorf("failed to read new catalog: %w", err)
+	}
+
+	diff := CompareCatalogs(oldCatalog, newCatalog)
+	diff.OldCatalog = oldCatalogPath
+	diff.NewCatalog = newCatalogPath
+
+	markdownPath := filepath.Join(reportDir, "catalog-diff.md")
+	if err := writeMarkdownDiff(diff, markdownPath); err != nil {
+		return fmt.Errorf("failed to write markdown diff: %w", err)
+	}
+
+	yamlPath := filepath.Join(reportDir, "catalog-diff.yaml")
+	data, err := yaml.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	if err := os.WriteFile(yamlPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write YAML diff: %w", err)
+	}
+
+	fmt.Printf("Catalog diff generated:\n")
+	fmt.Printf("  Markdown: %s\n", markdownPath)
+	fmt.Printf("  YAML: %s\n", yamlPath)
+	fmt.Printf("  %d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	return nil
+}
+
+// CompareCatalogs matches models by name and reports added, removed and changed models
+func CompareCatalogs(oldCatalog, newCatalog *types.ModelsCatalog) *CatalogDiff {
+	diff := &CatalogDiff{GeneratedAt: time.Now().UTC().Truncate(time.Second)}
+
+	oldModels := modelsByName(oldCatalog)
+	newModels := modelsByName(newCatalog)
+
+	for name, newModel := range newModels {
+		oldModel, exists := oldModels[name]
+		if !exists {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+		if changes := compareModelFields(oldModel, newModel); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, ModelDiff{Name: name, Changes: changes})
+		}
+	}
+	for name := range oldModels {
+		if _, exists := newModels[name]; !exists {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Name < diff.Changed[j].Name
+	})
+
+	return diff
+}
+
+// modelsByName indexes catalog models by name, skipping unnamed entries
+func modelsByName(catalog *types.ModelsCatalog) map[string]types.CatalogMetadata {
+	models := make(map[string]types.CatalogMetadata)
+	for _, model := range catalog.Models {
+		if model.Name != nil && *model.Name != "" {
+			models[*model.Name] = model
+		}
+	}
+	return models
+}
+
+// compareModelFields returns the fields whose comparable values differ, sorted by field name
+func compareModelFields(oldModel, newModel types.CatalogMetadata) []FieldChange {
+	oldValues := comparableFields(oldModel)
+	newValues := comparableFields(newModel)
+
+	// Custom properties may exist on only one side, so compare the union of fields
+	fieldSet := make(map[string]bool)
+	for field := range oldValues {
+		fieldSet[field] = true
+	}
+	for field := range newValues {
+		fieldSet[field] = true
+	}
+	fields := make([]string, 0, len(fieldSet))
+	for field := range fieldSet {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		oldValue, ok := oldValues[field]
+		if !ok {
+			oldValue = nullValue
+		}
+		newValue, ok := newValues[field]
+		if !ok {
+			newValue = nullValue
+		}
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// comparableFields renders each catalog field as a string so values can be compared and shown
+func comparableFields(model types.CatalogMetadata) map[string]string {
+	fields := map[string]string{
+		"provider":                 stringOrNull(model.Provider),
+		"description":              stringOrNull(model.Description),
+		"license":                  stringOrNull(model.License),
+		"licenseLink":              stringOrNull(model.LicenseLink),
+		"language":                 listOrNull(model.Language),
+		"tasks":                    listOrNull(model.Tasks),
+		"validatedTasks":           listOrNull(model.ValidatedTasks),
+		"createTimeSinceEpoch":     stringOrNull(model.CreateTimeSinceEpoch),
+		"lastUpdateTimeSinceEpoch": stringOrNull(model.LastUpdateTimeSinceEpoch),
+		"logo":                     nullValue,
+		"readme":                   nullValue,
+	}
+
+	// Logos are data URIs and READMEs are long, so only their size is compared
+	if model.Logo != nil && *model.Logo != "" {
+		fields["logo"] = fmt.Sprintf("<%d chars>", len(*model.Logo))
+	}
+	if model.Readme != nil && *model.Readme != "" {
+		fields["readme"] = fmt.Sprintf("<%d chars>", len(*model.Readme))
+	}
+
+	var artifactURIs []string
+	for _, artifact := range model.Artifacts {
+		artifactURIs = append(artifactURIs, artifact.URI)
+	}
+	sort.Strings(artifactURIs)
+	fields["artifacts"] = listOrNull(artifactURIs)
+
+	for key, value := range model.CustomProperties {
+		fields["customProperties."+key] = value.StringValue
+	}
+
+	return fields
+}
+
+func stringOrNull(s *string) string {
+	if s == nil || *s == "" {
+		return nullValue
+	}
+	return *s
+}
+
+func listOrNull(values []string) string {
+	if len(values) == 0 {
+		return nullValue
+	}
+	return strings.Join(values, ", ")
+}
+
+// writeMarkdownDiff writes the catalog diff as a markdown summary
+func writeMarkdownDiff(diff *CatalogDiff, outputPath string) error {
+	var md strings.Builder
+
+	md.WriteString("# Model Catalog Diff\n\n")
+	fmt.Fprintf(&md, "**Generated:** %s\n\n", diff.GeneratedAt.Format("2006-01-02 15:04:05 UTC"))
+	fmt.Fprintf(&md, "**Old catalog:** %s\n\n", diff.OldCatalog)
+	fmt.Fprintf(&md, "**New catalog:** %s\n\n", diff.NewCatalog)
+
+	md.WriteString("## Summary\n\n")
+	md.WriteString("| Change | Models |\n")
+	md.WriteString("|--------|--------|\n")
+	fmt.Fprintf(&md, "| Added | %d |\n", len(diff.Added))
+	fmt.Fprintf(&md, "| Removed | %d |\n", len(diff.Removed))
+	fmt.Fprintf(&md, "| Changed | %d |\n", len(diff.Changed))
+
+	if len(diff.Added) > 0 {
+		md.WriteString("\n## Added Models\n\n")
+		for _, name := range diff.Added {
+			fmt.Fprintf(&md, "- %s\n", name)
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		md.WriteString("\n## Removed Models\n\n")
+		for _, name := range diff.Removed {
+			fmt.Fprintf(&md, "- %s\n", name)
+		}
+	}
+
+	if len(diff.Changed) > 0 {
+		md.WriteString("\n## Changed Models\n")
+		for _, model := range diff.Changed {
+			fmt.Fprintf(&md, "\n### %s\n\n", model.Name)
+			md.WriteString("| Field | Old | New |\n")
+			md.WriteString("|-------|-----|-----|\n")
+			for _, change := range model.Changes {
+				fmt.Fprintf(&md, "| %s | %s | %s |\n", change.Field, formatValue(change.Old), formatValue(change.New))
+			}
+		}
+	}
+
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		md.WriteString("\nNo differences found.\n")
+	}
+
+	return os.WriteFile(outputPath, []byte(md.String()), 0644)
+}
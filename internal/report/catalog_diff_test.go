@@ -0,0 +1,110 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestCompareCatalogs(t *testing.T) {
+	oldCatalog := &types.ModelsCatalog{
+		Models: []types.CatalogMetadata{
+			{
+				Name:  stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+				Tasks: []string{"text-generation"},
+				CustomProperties: map[string]types.MetadataValue{
+					"validated": {MetadataType: "MetadataStringValue"},
+				},
+			},
+			{Name: stringPtr("RedHatAI/removed-model")},
+			{Name: stringPtr("RedHatAI/unchanged-model"), License: stringPtr("mit")},
+		},
+	}
+	newCatalog := &types.ModelsCatalog{
+		Models: []types.CatalogMetadata{
+			{
+				Name:    stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+				License: stringPtr("Apache-2.0"),
+				Tasks:   []string{"text-generation"},
+				CustomProperties: map[string]types.MetadataValue{
+					"featured": {MetadataType: "MetadataStringValue"},
+				},
+			},
+			{Name: stringPtr("RedHatAI/added-model")},
+			{Name: stringPtr("RedHatAI/unchanged-model"), License: stringPtr("mit")},
+		},
+	}
+
+	diff := CompareCatalogs(oldCatalog, newCatalog)
+
+	if !reflect.DeepEqual(diff.Added, []string{"RedHatAI/added-model"}) {
+		t.Errorf("Unexpected added models: %v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []string{"RedHatAI/removed-model"}) {
+		t.Errorf("Unexpected removed models: %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Name != "RedHatAI/granite-3.1-8b-instruct" {
+		t.Fatalf("Expected only granite to change, got %+v", diff.Changed)
+	}
+
+	expected := []FieldChange{
+		{Field: "customProperties.featured", Old: "null", New: ""},
+		{Field: "customProperties.validated", Old: "", New: "null"},
+		{Field: "license", Old: "null", New: "Apache-2.0"},
+	}
+	if !reflect.DeepEqual(diff.Changed[0].Changes, expected) {
+		t.Errorf("Unexpected changes:\n got: %+v\nwant: %+v", diff.Changed[0].Changes, expected)
+	}
+}
+
+func TestGenerateCatalogDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog := func(name string, catalog types.ModelsCatalog) string {
+		data, err := yaml.Marshal(catalog)
+		if err != nil {
+			t.Fatalf("Failed to marshal catalog: %v", err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write catalog: %v", err)
+		}
+		return path
+	}
+
+	oldPath := writeCatalog("old.yaml", types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("RedHatAI/granite-3.1-8b-instruct")},
+	}})
+	newPath := writeCatalog("new.yaml", types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("RedHatAI/granite-3.1-8b-instruct"), License: stringPtr("Apache-2.0")},
+	}})
+
+	if err := GenerateCatalogDiff(oldPath, newPath, dir); err != nil {
+		t.Fatalf("GenerateCatalogDiff failed: %v", err)
+	}
+
+	md, err := os.ReadFile(filepath.Join(dir, "catalog-diff.md"))
+	if err != nil {
+		t.Fatalf("Failed to read markdown diff: %v", err)
+	}
+	if !strings.Contains(string(md), "| license | null | Apache-2.0 |") {
+		t.Errorf("Expected license change in markdown diff, got:\n%s", md)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "catalog-diff.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read YAML diff: %v", err)
+	}
+	var diff CatalogDiff
+	if err := yaml.Unmarshal(data, &diff); err != nil {
+		t.Fatalf("Failed to parse YAML diff: %v", err)
+	}
+	if diff.OldCatalog != oldPath || diff.NewCatalog != newPath || len(diff.Changed) != 1 {
+		t.Errorf("Unexpected YAML diff: %+v", diff)
+	}
+}
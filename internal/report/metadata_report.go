@@ -1,72 +1,121 @@
 package report
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // MetadataReport represents a comprehensive report of metadata completeness and sources
 type MetadataReport struct {
-	GeneratedAt time.Time     `yaml:"generated_at"`
-	Summary     ReportSummary `yaml:"summary"`
-	Models      []ModelReport `yaml:"models"`
+	GeneratedAt time.Time     `yaml:"generated_at" json:"generated_at"`
+	Summary     ReportSummary `yaml:"summary" json:"summary"`
+	Models      []ModelReport `yaml:"models" json:"models"`
 }
 
 // ReportSummary provides high-level statistics
 type ReportSummary struct {
-	TotalModels       int                     `yaml:"total_models"`
-	FieldCompleteness map[string]Completeness `yaml:"field_completeness"`
-	DataSources       map[string]int          `yaml:"data_sources"`
+	TotalModels       int                     `yaml:"total_models" json:"total_models"`
+	FieldCompleteness map[string]Completeness `yaml:"field_completeness" json:"field_completeness"`
+	DataSources       map[string]int          `yaml:"data_sources" json:"data_sources"`
+	TagsSources       map[string]int          `yaml:"tags_sources,omitempty" json:"tags_sources,omitempty"`
+	LicenseConflicts  int                     `yaml:"license_conflicts,omitempty" json:"license_conflicts,omitempty"`
 }
 
 // Completeness tracks how many models have data for each field
 type Completeness struct {
-	Populated  int     `yaml:"populated"`
-	Null       int     `yaml:"null"`
-	Percentage float64 `yaml:"percentage"`
+	Populated  int     `yaml:"populated" json:"populated"`
+	Null       int     `yaml:"null" json:"null"`
+	Percentage float64 `yaml:"percentage" json:"percentage"`
 }
 
 // ModelReport contains metadata analysis for a single model
 type ModelReport struct {
-	Name            string                 `yaml:"name"`
-	Provider        string                 `yaml:"provider,omitempty"`
-	Fields          map[string]FieldStatus `yaml:"fields"`
-	MissingFields   []string               `yaml:"missing_fields,omitempty"`
-	DataSources     map[string]int         `yaml:"data_sources"`
-	SourceBreakdown SourceBreakdown        `yaml:"source_breakdown,omitempty"`
+	Name            string                 `yaml:"name" json:"name"`
+	Provider        string                 `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Fields          map[string]FieldStatus `yaml:"fields" json:"fields"`
+	MissingFields   []string               `yaml:"missing_fields,omitempty" json:"missing_fields,omitempty"`
+	DataSources     map[string]int         `yaml:"data_sources" json:"data_sources"`
+	SourceBreakdown SourceBreakdown        `yaml:"source_breakdown,omitempty" json:"source_breakdown,omitempty"`
+	LicenseConflict *LicenseConflict       `yaml:"license_conflict,omitempty" json:"license_conflict,omitempty"`
+}
+
+// LicenseConflict records a modelcard license that disagrees with the HuggingFace one
+type LicenseConflict struct {
+	Modelcard   string `yaml:"modelcard" json:"modelcard"`
+	HuggingFace string `yaml:"huggingface" json:"huggingface"`
 }
 
 // SourceBreakdown provides detailed source analysis
 type SourceBreakdown struct {
-	ModelcardYAML    int `yaml:"modelcard_yaml"`
-	ModelcardRegex   int `yaml:"modelcard_regex"`
-	HuggingfaceYAML  int `yaml:"huggingface_yaml"`
-	HuggingfaceTags  int `yaml:"huggingface_tags"`
-	HuggingfaceRegex int `yaml:"huggingface_regex"`
-	Registry         int `yaml:"registry"`
-	Generated        int `yaml:"generated"`
-	Other            int `yaml:"other"`
+	ModelcardYAML    int `yaml:"modelcard_yaml" json:"modelcard_yaml"`
+	ModelcardRegex   int `yaml:"modelcard_regex" json:"modelcard_regex"`
+	HuggingfaceYAML  int `yaml:"huggingface_yaml" json:"huggingface_yaml"`
+	HuggingfaceTags  int `yaml:"huggingface_tags" json:"huggingface_tags"`
+	HuggingfaceRegex int `yaml:"huggingface_regex" json:"huggingface_regex"`
+	Registry         int `yaml:"registry" json:"registry"`
+	Generated        int `yaml:"generated" json:"generated"`
+	ConfigInferred   int `yaml:"config_inferred" json:"config_inferred"`
+	Other            int `yaml:"other" json:"other"`
 }
 
 // FieldStatus indicates the source and status of a metadata field
 type FieldStatus struct {
-	Value           interface{} `yaml:"value,omitempty"`
-	Source          string      `yaml:"source"`
-	DetectionMethod string      `yaml:"detection_method"`
-	IsNull          bool        `yaml:"is_null"`
-	IsEmpty         bool        `yaml:"is_empty,omitempty"`
+	Value           interface{} `yaml:"value,omitempty" json:"value,omitempty"`
+	Source          string      `yaml:"source" json:"source"`
+	DetectionMethod string      `yaml:"detection_method" json:"detection_method"`
+	IsNull          bool        `yaml:"is_null" json:"is_null"`
+	IsEmpty         bool        `yaml:"is_empty,omitempty" json:"is_empty,omitempty"`
+}
+
+// Supported report output formats
+const (
+	FormatMarkdown = "md"
+	FormatYAML     = "yaml"
+	FormatJSON     = "json"
+	FormatAll      = "all"
+)
+
+// ReportOptions controls which report files GenerateMetadataReport writes
+type ReportOptions struct {
+	// Format is one of FormatMarkdown, FormatYAML, FormatJSON or FormatAll
+	Format string
+	// CSVPath, when set, also writes the field completeness as CSV to this path
+	CSVPath string
+}
+
+// DefaultReportOptions returns options that write every report format
+func DefaultReportOptions() ReportOptions {
+	return ReportOptions{Format: FormatAll}
+}
+
+// ValidateFormat checks that format is a supported report output format
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatMarkdown, FormatYAML, FormatJSON, FormatAll:
+		return nil
+	default:
+		return fmt.Errorf("invalid report format: %q (allowed values: %q, %q, %q, %q)", format, FormatMarkdown, FormatYAML, FormatJSON, FormatAll)
+	}
 }
 
 // GenerateMetadataReport creates a comprehensive metadata report
-func GenerateMetadataReport(catalogPath, outputDir, reportDir string) error {
+func GenerateMetadataReport(catalogPath, outputDir, reportDir string, opts ReportOptions) error {
+	if err := ValidateFormat(opts.Format); err != nil {
+		return err
+	}
+
 	// Read the catalog file
 	catalog, err := readCatalog(catalogPath)
 	if err != nil {
@@ -79,24 +128,48 @@ func GenerateMetadataReport(catalogPath, outputDir, reportDir string) error {
 		return fmt.Errorf("failed to load enrichment data: %w", err)
 	}
 
+	// Load extracted metadata.yaml for fields that only exist there (tags, validatedOn)
+	extractedData := loadExtractedMetadata(outputDir)
+
 	// Generate the report
-	report := generateReport(catalog, enrichmentData)
+	report := generateReport(catalog, enrichmentData, extractedData)
+
+	fmt.Printf("Metadata reports generated:\n")
 
 	// Write markdown report
-	markdownPath := filepath.Join(reportDir, "metadata-report.md")
-	if err := writeMarkdownReport(report, markdownPath); err != nil {
-		return fmt.Errorf("failed to write markdown report: %w", err)
+	if opts.Format == FormatMarkdown || opts.Format == FormatAll {
+		markdownPath := filepath.Join(reportDir, "metadata-report.md")
+		if err := writeMarkdownReport(report, markdownPath); err != nil {
+			return fmt.Errorf("failed to write markdown report: %w", err)
+		}
+		fmt.Printf("  Markdown: %s\n", markdownPath)
 	}
 
 	// Write YAML report for programmatic use
-	yamlPath := filepath.Join(reportDir, "metadata-report.yaml")
-	if err := writeYAMLReport(report, yamlPath); err != nil {
-		return fmt.Errorf("failed to write YAML report: %w", err)
+	if opts.Format == FormatYAML || opts.Format == FormatAll {
+		yamlPath := filepath.Join(reportDir, "metadata-report.yaml")
+		if err := writeYAMLReport(report, yamlPath); err != nil {
+			return fmt.Errorf("failed to write YAML report: %w", err)
+		}
+		fmt.Printf("  YAML: %s\n", yamlPath)
 	}
 
-	fmt.Printf("Metadata reports generated:\n")
-	fmt.Printf("  Markdown: %s\n", markdownPath)
-	fmt.Printf("  YAML: %s\n", yamlPath)
+	// Write JSON report for dashboards
+	if opts.Format == FormatJSON || opts.Format == FormatAll {
+		jsonPath := filepath.Join(reportDir, "metadata-report.json")
+		if err := writeJSONReport(report, jsonPath); err != nil {
+			return fmt.Errorf("failed to write JSON report: %w", err)
+		}
+		fmt.Printf("  JSON: %s\n", jsonPath)
+	}
+
+	// Write CSV completeness export for spreadsheets
+	if opts.CSVPath != "" {
+		if err := WriteCompletenessCSV(report, opts.CSVPath); err != nil {
+			return fmt.Errorf("failed to write CSV report: %w", err)
+		}
+		fmt.Printf("  CSV: %s\n", opts.CSVPath)
+	}
 
 	return nil
 }
@@ -122,21 +195,26 @@ type SimpleEnrichmentData struct {
 	HuggingFaceURL   string            `yaml:"huggingface_url"`
 	MatchConfidence  string            `yaml:"match_confidence"`
 	DataSources      map[string]string `yaml:"data_sources"`
+
+	LicenseConflict    bool   `yaml:"license_conflict"`
+	LicenseModelcard   string `yaml:"license_modelcard"`
+	LicenseHuggingFace string `yaml:"license_huggingface"`
 }
 
 // loadEnrichmentData loads enrichment data for all models
 func loadEnrichmentData(outputDir string, models []types.CatalogMetadata) (map[string]*SimpleEnrichmentData, error) {
 	enrichmentData := make(map[string]*SimpleEnrichmentData)
 
-	// Build a map of model names to enrichment data by scanning all directories
-	modelDirs, err := filepath.Glob(filepath.Join(outputDir, "*"))
+	// Build a map of model names to enrichment data by scanning all model directories
+	modelDirs, err := utils.ModelOutputDirs(outputDir)
 	if err != nil {
 		return enrichmentData, err
 	}
 
 	// Map each enrichment file to its model name from metadata
 	enrichmentFiles := make(map[string]*SimpleEnrichmentData)
-	for _, dir := range modelDirs {
+	for _, name := range modelDirs {
+		dir := filepath.Join(outputDir, name)
 		enrichmentFile := filepath.Join(dir, "models", "enrichment.yaml")
 		metadataFile := filepath.Join(dir, "models", "metadata.yaml")
 
@@ -177,14 +255,40 @@ func loadEnrichmentData(outputDir string, models []types.CatalogMetadata) (map[s
 	return enrichmentData, nil
 }
 
+// loadExtractedMetadata loads each model's metadata.yaml from the output directory, keyed by model name
+func loadExtractedMetadata(outputDir string) map[string]*types.ExtractedMetadata {
+	extracted := make(map[string]*types.ExtractedMetadata)
+
+	modelDirs, err := utils.ModelOutputDirs(outputDir)
+	if err != nil {
+		return extracted
+	}
+
+	for _, dir := range modelDirs {
+		data, err := os.ReadFile(filepath.Join(outputDir, dir, "models", "metadata.yaml"))
+		if err != nil {
+			continue
+		}
+
+		var metadata types.ExtractedMetadata
+		if err := yaml.Unmarshal(data, &metadata); err == nil && metadata.Name != nil {
+			extracted[*metadata.Name] = &metadata
+		}
+	}
+
+	return extracted
+}
+
 // generateReport creates the metadata report
-func generateReport(catalog *types.ModelsCatalog, enrichmentData map[string]*SimpleEnrichmentData) *MetadataReport {
+func generateReport(catalog *types.ModelsCatalog, enrichmentData map[string]*SimpleEnrichmentData, extractedData map[string]*types.ExtractedMetadata) *MetadataReport {
 	report := &MetadataReport{
-		GeneratedAt: time.Now(),
+		// UTC at second precision so JSON renders a plain RFC3339 timestamp
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
 		Summary: ReportSummary{
 			TotalModels:       len(catalog.Models),
 			FieldCompleteness: make(map[string]Completeness),
 			DataSources:       make(map[string]int),
+			TagsSources:       make(map[string]int),
 		},
 		Models: make([]ModelReport, 0, len(catalog.Models)),
 	}
@@ -192,7 +296,7 @@ func generateReport(catalog *types.ModelsCatalog, enrichmentData map[string]*Sim
 	// Field names we want to track
 	trackedFields := []string{
 		"name", "provider", "description", "readme", "language", "license",
-		"licenseLink", "tasks", "artifacts",
+		"licenseLink", "tasks", "tags", "validatedOn", "artifacts",
 		"createTimeSinceEpoch",
 	}
 
@@ -207,11 +311,20 @@ func generateReport(catalog *types.ModelsCatalog, enrichmentData map[string]*Sim
 		if model.Name != nil {
 			modelName = *model.Name
 		}
-		modelReport := analyzeModel(model, enrichmentData[modelName], trackedFields)
+		modelReport := analyzeModel(model, enrichmentData[modelName], extractedData[modelName], trackedFields)
 		report.Models = append(report.Models, modelReport)
 
 		// Update summary statistics
 		updateSummaryStats(&report.Summary, modelReport, trackedFields)
+
+		if modelReport.LicenseConflict != nil {
+			report.Summary.LicenseConflicts++
+		}
+
+		// Count which source each model's tags came from, to explain unexpected tags
+		if tags, ok := modelReport.Fields["tags"]; ok && !tags.IsNull {
+			report.Summary.TagsSources[tags.Source]++
+		}
 	}
 
 	// Calculate percentages
@@ -227,7 +340,7 @@ func generateReport(catalog *types.ModelsCatalog, enrichmentData map[string]*Sim
 }
 
 // analyzeModel analyzes a single model's metadata completeness and sources
-func analyzeModel(model types.CatalogMetadata, enriched *SimpleEnrichmentData, trackedFields []string) ModelReport {
+func analyzeModel(model types.CatalogMetadata, enriched *SimpleEnrichmentData, extracted *types.ExtractedMetadata, trackedFields []string) ModelReport {
 	modelName := ""
 	if model.Name != nil {
 		modelName = *model.Name
@@ -246,9 +359,16 @@ func analyzeModel(model types.CatalogMetadata, enriched *SimpleEnrichmentData, t
 		SourceBreakdown: SourceBreakdown{},
 	}
 
+	if enriched != nil && enriched.LicenseConflict {
+		modelReport.LicenseConflict = &LicenseConflict{
+			Modelcard:   enriched.LicenseModelcard,
+			HuggingFace: enriched.LicenseHuggingFace,
+		}
+	}
+
 	// Analyze each tracked field
 	for _, fieldName := range trackedFields {
-		status := analyzeField(fieldName, model, enriched)
+		status := analyzeField(fieldName, model, enriched, extracted)
 		modelReport.Fields[fieldName] = status
 
 		if status.IsNull {
@@ -280,6 +400,8 @@ func updateSourceBreakdown(breakdown *SourceBreakdown, source string) {
 		breakdown.Registry++
 	case "generated":
 		breakdown.Generated++
+	case "config.inferred":
+		breakdown.ConfigInferred++
 	default:
 		breakdown.Other++
 	}
@@ -296,8 +418,12 @@ func getDetectionMethod(source string) string {
 		return "API call"
 	case strings.HasSuffix(source, ".tags"):
 		return "Tags metadata"
+	case strings.HasSuffix(source, ".inferred"):
+		return "Inferred"
 	case source == "generated":
 		return "Generated"
+	case source == "override":
+		return "Manual override"
 	case source == "registry":
 		return "Registry artifacts"
 	default:
@@ -305,8 +431,9 @@ func getDetectionMethod(source string) string {
 	}
 }
 
-// analyzeField analyzes a specific field for a model
-func analyzeField(fieldName string, model types.CatalogMetadata, enriched *SimpleEnrichmentData) FieldStatus {
+// analyzeField analyzes a specific field for a model. Fields that are not part of the
+// catalog format (tags, validatedOn) are read from the model's extracted metadata.
+func analyzeField(fieldName string, model types.CatalogMetadata, enriched *SimpleEnrichmentData, extracted *types.ExtractedMetadata) FieldStatus {
 	status := FieldStatus{
 		Source:          "unknown",
 		DetectionMethod: "Unknown",
@@ -370,11 +497,28 @@ func analyzeField(fieldName string, model types.CatalogMetadata, enriched *Simpl
 			status.Source = getSourceFromEnriched(enriched, "tasks")
 			status.DetectionMethod = getDetectionMethod(status.Source)
 		}
+	case "tags":
+		if extracted != nil && len(extracted.Tags) > 0 {
+			status.Value = extracted.Tags
+			status.IsNull = false
+			status.Source = getSourceFromEnriched(enriched, "tags")
+			status.DetectionMethod = getDetectionMethod(status.Source)
+		}
+	case "validatedOn":
+		if extracted != nil && len(extracted.ValidatedOn) > 0 {
+			status.Value = extracted.ValidatedOn
+			status.IsNull = false
+			status.Source = getSourceFromEnriched(enriched, "validatedOn")
+			status.DetectionMethod = getDetectionMethod(status.Source)
+		}
 	case "artifacts":
 		if len(model.Artifacts) > 0 {
 			status.Value = len(model.Artifacts)
 			status.IsNull = false
 			status.Source = "registry" // Artifacts typically come from OCI registry
+			if enriched != nil && enriched.DataSources["artifacts"] != "" {
+				status.Source = enriched.DataSources["artifacts"]
+			}
 			status.DetectionMethod = "Registry artifacts"
 		}
 	case "createTimeSinceEpoch":
@@ -428,6 +572,18 @@ func getSourceFromEnriched(enriched *SimpleEnrichmentData, fieldName string) str
 		sourceKey = "language"
 	case "licenseLink":
 		sourceKey = "license_link"
+	case "tags":
+		sourceKey = "tags"
+	case "validatedOn":
+		sourceKey = "validated_on"
+	case "lastUpdateTimeSinceEpoch":
+		sourceKey = "last_modified"
+	case "maturity":
+		sourceKey = "maturity"
+	case "modelSize":
+		sourceKey = "model_size"
+	case "artifacts":
+		sourceKey = "artifacts"
 	default:
 		return "modelcard.regex"
 	}
@@ -518,6 +674,40 @@ func writeMarkdownReport(report *MetadataReport, outputPath string) error {
 		fmt.Fprintf(&md, "| %s | %d | %.1f%% |\n", sc.source, sc.count, percentage)
 	}
 
+	// Tags provenance summary
+	if len(report.Summary.TagsSources) > 0 {
+		md.WriteString("\n### Tags Sources\n\n")
+		md.WriteString("| Tags Source | Models |\n")
+		md.WriteString("|-------------|--------|\n")
+
+		tagsSources := make([]string, 0, len(report.Summary.TagsSources))
+		for source := range report.Summary.TagsSources {
+			tagsSources = append(tagsSources, source)
+		}
+		sort.Slice(tagsSources, func(i, j int) bool {
+			ci, cj := report.Summary.TagsSources[tagsSources[i]], report.Summary.TagsSources[tagsSources[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return tagsSources[i] < tagsSources[j]
+		})
+		for _, source := range tagsSources {
+			fmt.Fprintf(&md, "| %s | %d |\n", source, report.Summary.TagsSources[source])
+		}
+	}
+
+	// License conflicts needing a human decision
+	if report.Summary.LicenseConflicts > 0 {
+		md.WriteString("\n### License Conflicts\n\n")
+		md.WriteString("| Model | Modelcard License | HuggingFace License |\n")
+		md.WriteString("|-------|-------------------|---------------------|\n")
+		for _, model := range report.Models {
+			if model.LicenseConflict != nil {
+				fmt.Fprintf(&md, "| %s | %s | %s |\n", model.Name, model.LicenseConflict.Modelcard, model.LicenseConflict.HuggingFace)
+			}
+		}
+	}
+
 	// Source breakdown summary
 	md.WriteString("\n### Detailed Source Breakdown\n\n")
 	md.WriteString("| Source Type | Count | Percentage |\n")
@@ -532,6 +722,7 @@ func writeMarkdownReport(report *MetadataReport, outputPath string) error {
 		sourceBreakdownSummary["HuggingFace Regex"] += model.SourceBreakdown.HuggingfaceRegex
 		sourceBreakdownSummary["Registry"] += model.SourceBreakdown.Registry
 		sourceBreakdownSummary["Generated"] += model.SourceBreakdown.Generated
+		sourceBreakdownSummary["Config Inferred"] += model.SourceBreakdown.ConfigInferred
 		sourceBreakdownSummary["Other"] += model.SourceBreakdown.Other
 	}
 
@@ -572,11 +763,19 @@ func writeMarkdownReport(report *MetadataReport, outputPath string) error {
 			md.WriteString("\n\n")
 		}
 
+		if model.LicenseConflict != nil {
+			fmt.Fprintf(&md, "**License Conflict:** modelcard `%s` vs HuggingFace `%s`\n\n", model.LicenseConflict.Modelcard, model.LicenseConflict.HuggingFace)
+		}
+
+		if tags, ok := model.Fields["tags"]; ok && !tags.IsNull {
+			fmt.Fprintf(&md, "**Tags Source:** %s\n\n", tags.Source)
+		}
+
 		// Source breakdown for this model
 		yamlFields := model.SourceBreakdown.ModelcardYAML + model.SourceBreakdown.HuggingfaceYAML
 		totalFields := yamlFields + model.SourceBreakdown.ModelcardRegex + model.SourceBreakdown.HuggingfaceTags +
 			model.SourceBreakdown.HuggingfaceRegex + model.SourceBreakdown.Registry +
-			model.SourceBreakdown.Generated + model.SourceBreakdown.Other
+			model.SourceBreakdown.Generated + model.SourceBreakdown.ConfigInferred + model.SourceBreakdown.Other
 
 		if totalFields > 0 {
 			yamlPercentage := float64(yamlFields) / float64(totalFields) * 100
@@ -624,6 +823,55 @@ func writeYAMLReport(report *MetadataReport, outputPath string) error {
 	return os.WriteFile(outputPath, data, 0644)
 }
 
+// writeJSONReport writes the report in JSON format
+func writeJSONReport(report *MetadataReport, outputPath string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, append(data, '\n'), 0644)
+}
+
+// WriteCompletenessCSV writes field completeness as CSV: one row per field
+// (field,populated,null,percentage), then a blank row, then one row per model
+// listing its missing tracked fields separated by semicolons (model,missing_fields).
+func WriteCompletenessCSV(report *MetadataReport, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	w := csv.NewWriter(file)
+
+	fields := make([]string, 0, len(report.Summary.FieldCompleteness))
+	for field := range report.Summary.FieldCompleteness {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	records := [][]string{{"field", "populated", "null", "percentage"}}
+	for _, field := range fields {
+		comp := report.Summary.FieldCompleteness[field]
+		records = append(records, []string{
+			field,
+			strconv.Itoa(comp.Populated),
+			strconv.Itoa(comp.Null),
+			strconv.FormatFloat(comp.Percentage, 'f', 1, 64),
+		})
+	}
+
+	records = append(records, []string{}, []string{"model", "missing_fields"})
+	for _, model := range report.Models {
+		records = append(records, []string{model.Name, strings.Join(model.MissingFields, ";")})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
 // formatValue formats a value for display in the markdown table
 func formatValue(value interface{}) string {
 	if value == nil {
@@ -0,0 +1,406 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// writeTestCatalog writes a small catalog and matching output directory for report tests
+func writeTestCatalog(t *testing.T, dir string) (catalogPath, outputDir string) {
+	t.Helper()
+
+	catalog := types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:     stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+				Provider: stringPtr("IBM"),
+				License:  stringPtr("apache-2.0"),
+				Tasks:    []string{"text-generation"},
+			},
+			{
+				Name: stringPtr("RedHatAI/Llama-3.1-8B-Instruct"),
+			},
+		},
+	}
+	data, err := yaml.Marshal(catalog)
+	if err != nil {
+		t.Fatalf("Failed to marshal catalog: %v", err)
+	}
+	catalogPath = filepath.Join(dir, "models-catalog.yaml")
+	if err := os.WriteFile(catalogPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	outputDir = filepath.Join(dir, "output")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatalf("Failed to create output dir: %v", err)
+	}
+	return catalogPath, outputDir
+}
+
+func TestGenerateMetadataReport_Formats(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected []string
+		missing  []string
+	}{
+		{format: FormatAll, expected: []string{"metadata-report.md", "metadata-report.yaml", "metadata-report.json"}},
+		{format: FormatMarkdown, expected: []string{"metadata-report.md"}, missing: []string{"metadata-report.yaml", "metadata-report.json"}},
+		{format: FormatYAML, expected: []string{"metadata-report.yaml"}, missing: []string{"metadata-report.md", "metadata-report.json"}},
+		{format: FormatJSON, expected: []string{"metadata-report.json"}, missing: []string{"metadata-report.md", "metadata-report.yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			catalogPath, outputDir := writeTestCatalog(t, dir)
+			reportDir := filepath.Join(dir, "reports")
+			if err := os.MkdirAll(reportDir, 0755); err != nil {
+				t.Fatalf("Failed to create report dir: %v", err)
+			}
+
+			if err := GenerateMetadataReport(catalogPath, outputDir, reportDir, ReportOptions{Format: tt.format}); err != nil {
+				t.Fatalf("GenerateMetadataReport failed: %v", err)
+			}
+
+			for _, name := range tt.expected {
+				if _, err := os.Stat(filepath.Join(reportDir, name)); err != nil {
+					t.Errorf("Expected %s to be written: %v", name, err)
+				}
+			}
+			for _, name := range tt.missing {
+				if _, err := os.Stat(filepath.Join(reportDir, name)); err == nil {
+					t.Errorf("Expected %s not to be written for format %q", name, tt.format)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateMetadataReport_InvalidFormat(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath, outputDir := writeTestCatalog(t, dir)
+
+	if err := GenerateMetadataReport(catalogPath, outputDir, dir, ReportOptions{Format: "xml"}); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath, outputDir := writeTestCatalog(t, dir)
+	jsonPath := filepath.Join(dir, "metadata-report.json")
+
+	if err := GenerateMetadataReport(catalogPath, outputDir, dir, ReportOptions{Format: FormatJSON}); err != nil {
+		t.Fatalf("GenerateMetadataReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSON report: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("JSON report is not valid JSON: %v", err)
+	}
+
+	generatedAt, ok := raw["generated_at"].(string)
+	if !ok {
+		t.Fatalf("Expected generated_at string, got %v", raw["generated_at"])
+	}
+	parsed, err := time.Parse(time.RFC3339, generatedAt)
+	if err != nil {
+		t.Fatalf("generated_at %q is not RFC3339: %v", generatedAt, err)
+	}
+	if parsed.Format(time.RFC3339) != generatedAt {
+		t.Errorf("Expected plain RFC3339 timestamp, got %q", generatedAt)
+	}
+
+	var report MetadataReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to decode JSON report: %v", err)
+	}
+	if report.Summary.TotalModels != 2 {
+		t.Errorf("Expected 2 models, got %d", report.Summary.TotalModels)
+	}
+	if comp := report.Summary.FieldCompleteness["license"]; comp.Populated != 1 || comp.Null != 1 {
+		t.Errorf("Unexpected license completeness: %+v", comp)
+	}
+}
+
+func TestWriteCompletenessCSV(t *testing.T) {
+	report := &MetadataReport{
+		Summary: ReportSummary{
+			TotalModels: 2,
+			FieldCompleteness: map[string]Completeness{
+				"name":    {Populated: 2, Null: 0, Percentage: 100},
+				"license": {Populated: 1, Null: 1, Percentage: 50},
+			},
+		},
+		Models: []ModelReport{
+			{Name: "RedHatAI/granite-3.1-8b-instruct"},
+			{Name: "RedHatAI/Llama-3.1-8B-Instruct", MissingFields: []string{"license", "tasks"}},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "completeness.csv")
+	if err := WriteCompletenessCSV(report, path); err != nil {
+		t.Fatalf("WriteCompletenessCSV failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+
+	expected := `field,populated,null,percentage
+license,1,1,50.0
+name,2,0,100.0
+
+model,missing_fields
+RedHatAI/granite-3.1-8b-instruct,
+RedHatAI/Llama-3.1-8B-Instruct,license;tasks
+`
+	if string(data) != expected {
+		t.Errorf("Unexpected CSV output:\n%s\nexpected:\n%s", string(data), expected)
+	}
+}
+
+func TestGenerateReport_TracksTagsAndValidatedOn(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath, outputDir := writeTestCatalog(t, dir)
+
+	// Only the granite model has extracted tags and validatedOn
+	modelDir := filepath.Join(outputDir, "registry.redhat.io_rhelai1_modelcar-granite-3-1-8b-instruct_1.5", "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	if err := utils.WriteManifestRef(filepath.Dir(modelDir), "registry.example.com/"+filepath.Base(filepath.Dir(modelDir))+":1.0"); err != nil {
+		t.Fatal(err)
+	}
+	extracted := types.ExtractedMetadata{
+		Name:        stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+		Tags:        []string{"validated", "featured"},
+		ValidatedOn: []string{"RHOAI 2.20"},
+	}
+	data, err := yaml.Marshal(extracted)
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	enrichment := "data_sources:\n  tags: huggingface.yaml\n  validated_on: huggingface.yaml\n"
+	if err := os.WriteFile(filepath.Join(modelDir, "enrichment.yaml"), []byte(enrichment), 0644); err != nil {
+		t.Fatalf("Failed to write enrichment: %v", err)
+	}
+
+	catalog, err := readCatalog(catalogPath)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	enrichmentData, err := loadEnrichmentData(outputDir, catalog.Models)
+	if err != nil {
+		t.Fatalf("Failed to load enrichment data: %v", err)
+	}
+	report := generateReport(catalog, enrichmentData, loadExtractedMetadata(outputDir))
+
+	for _, field := range []string{"tags", "validatedOn"} {
+		comp, ok := report.Summary.FieldCompleteness[field]
+		if !ok {
+			t.Fatalf("Expected %s in field completeness", field)
+		}
+		if comp.Populated != 1 || comp.Null != 1 || comp.Percentage != 50 {
+			t.Errorf("Unexpected %s completeness: %+v", field, comp)
+		}
+	}
+
+	granite := report.Models[0]
+	if status := granite.Fields["validatedOn"]; status.IsNull || status.Source != "huggingface.yaml" {
+		t.Errorf("Unexpected validatedOn status: %+v", status)
+	}
+
+	llama := report.Models[1]
+	missing := map[string]bool{}
+	for _, field := range llama.MissingFields {
+		missing[field] = true
+	}
+	if !missing["tags"] || !missing["validatedOn"] {
+		t.Errorf("Expected tags and validatedOn to be missing for %s, got %v", llama.Name, llama.MissingFields)
+	}
+}
+
+func TestGenerateReport_TagsSources(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath, outputDir := writeTestCatalog(t, dir)
+
+	models := []struct {
+		dir, name, tagsSource string
+	}{
+		{dir: "granite", name: "RedHatAI/granite-3.1-8b-instruct", tagsSource: "huggingface.tags"},
+		{dir: "llama", name: "RedHatAI/Llama-3.1-8B-Instruct", tagsSource: "modelcard.yaml"},
+	}
+	for _, m := range models {
+		modelDir := filepath.Join(outputDir, m.dir, "models")
+		if err := os.MkdirAll(modelDir, 0755); err != nil {
+			t.Fatalf("Failed to create model dir: %v", err)
+		}
+		if err := utils.WriteManifestRef(filepath.Dir(modelDir), "registry.example.com/"+filepath.Base(filepath.Dir(modelDir))+":1.0"); err != nil {
+			t.Fatal(err)
+		}
+		data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr(m.name), Tags: []string{"validated"}})
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+		enrichment := "data_sources:\n  tags: " + m.tagsSource + "\n"
+		if err := os.WriteFile(filepath.Join(modelDir, "enrichment.yaml"), []byte(enrichment), 0644); err != nil {
+			t.Fatalf("Failed to write enrichment: %v", err)
+		}
+	}
+
+	catalog, err := readCatalog(catalogPath)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	enrichmentData, err := loadEnrichmentData(outputDir, catalog.Models)
+	if err != nil {
+		t.Fatalf("Failed to load enrichment data: %v", err)
+	}
+	report := generateReport(catalog, enrichmentData, loadExtractedMetadata(outputDir))
+
+	want := map[string]int{"huggingface.tags": 1, "modelcard.yaml": 1}
+	if !reflect.DeepEqual(report.Summary.TagsSources, want) {
+		t.Errorf("Expected tags sources %v, got %v", want, report.Summary.TagsSources)
+	}
+
+	markdownPath := filepath.Join(dir, "metadata-report.md")
+	if err := writeMarkdownReport(report, markdownPath); err != nil {
+		t.Fatalf("writeMarkdownReport failed: %v", err)
+	}
+	md, err := os.ReadFile(markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to read markdown report: %v", err)
+	}
+	for _, expected := range []string{"### Tags Sources", "| huggingface.tags | 1 |", "**Tags Source:** modelcard.yaml"} {
+		if !strings.Contains(string(md), expected) {
+			t.Errorf("Expected markdown report to contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateReport_LicenseConflicts(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath, outputDir := writeTestCatalog(t, dir)
+
+	modelDir := filepath.Join(outputDir, "granite", "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	if err := utils.WriteManifestRef(filepath.Dir(modelDir), "registry.example.com/"+filepath.Base(filepath.Dir(modelDir))+":1.0"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr("RedHatAI/granite-3.1-8b-instruct")})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	enrichment := "license_conflict: true\nlicense_modelcard: apache-2.0\nlicense_huggingface: llama3.1\ndata_sources:\n  license: huggingface.yaml\n"
+	if err := os.WriteFile(filepath.Join(modelDir, "enrichment.yaml"), []byte(enrichment), 0644); err != nil {
+		t.Fatalf("Failed to write enrichment: %v", err)
+	}
+
+	catalog, err := readCatalog(catalogPath)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	enrichmentData, err := loadEnrichmentData(outputDir, catalog.Models)
+	if err != nil {
+		t.Fatalf("Failed to load enrichment data: %v", err)
+	}
+	report := generateReport(catalog, enrichmentData, loadExtractedMetadata(outputDir))
+
+	if report.Summary.LicenseConflicts != 1 {
+		t.Errorf("Expected 1 license conflict, got %d", report.Summary.LicenseConflicts)
+	}
+	want := &LicenseConflict{Modelcard: "apache-2.0", HuggingFace: "llama3.1"}
+	if !reflect.DeepEqual(report.Models[0].LicenseConflict, want) {
+		t.Errorf("Expected conflict %+v, got %+v", want, report.Models[0].LicenseConflict)
+	}
+	if report.Models[1].LicenseConflict != nil {
+		t.Errorf("Expected no conflict for %s", report.Models[1].Name)
+	}
+
+	markdownPath := filepath.Join(dir, "metadata-report.md")
+	if err := writeMarkdownReport(report, markdownPath); err != nil {
+		t.Fatalf("writeMarkdownReport failed: %v", err)
+	}
+	md, err := os.ReadFile(markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to read markdown report: %v", err)
+	}
+	for _, expected := range []string{"### License Conflicts", "| RedHatAI/granite-3.1-8b-instruct | apache-2.0 | llama3.1 |", "**License Conflict:** modelcard `apache-2.0` vs HuggingFace `llama3.1`"} {
+		if !strings.Contains(string(md), expected) {
+			t.Errorf("Expected markdown report to contain %q", expected)
+		}
+	}
+}
+
+func TestLoadExtractedMetadata_IgnoresLegacyDirectories(t *testing.T) {
+	outputDir := t.TempDir()
+	for dir, name := range map[string]string{"current": "current-model", "legacy": "legacy-model"} {
+		modelDir := filepath.Join(outputDir, dir, "models")
+		if err := os.MkdirAll(modelDir, 0755); err != nil {
+			t.Fatalf("Failed to create model dir: %v", err)
+		}
+		data, err := yaml.Marshal(types.ExtractedMetadata{Name: stringPtr(name)})
+		if err != nil {
+			t.Fatalf("Failed to marshal metadata: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+			t.Fatalf("Failed to write metadata: %v", err)
+		}
+	}
+	// Only the current layout records the ref next to the models
+	if err := utils.WriteManifestRef(filepath.Join(outputDir, "current"), "registry.example.com/org/current-model:1.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	extracted := loadExtractedMetadata(outputDir)
+	if len(extracted) != 1 || extracted["current-model"] == nil {
+		t.Errorf("loadExtractedMetadata() = %v, want only current-model", extracted)
+	}
+}
+
+func TestUpdateSourceBreakdown(t *testing.T) {
+	var breakdown SourceBreakdown
+	for _, source := range []string{"modelcard.regex", "modelcard.inferred", "config.inferred", "config"} {
+		updateSourceBreakdown(&breakdown, source)
+	}
+
+	// Tasks inferred from config.json architectures are not modelcard extractions
+	expected := SourceBreakdown{ModelcardRegex: 2, ConfigInferred: 1, Other: 1}
+	if breakdown != expected {
+		t.Errorf("updateSourceBreakdown() = %+v, want %+v", breakdown, expected)
+	}
+}
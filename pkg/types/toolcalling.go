@@ -26,11 +26,11 @@ var validToolCallParsers = map[string]bool{
 
 // ToolCallingConfig represents tool-calling configuration from YAML frontmatter
 type ToolCallingConfig struct {
-	Supported        bool     `yaml:"tool_calling_supported"`
-	RequiredCLIArgs  []string `yaml:"required_cli_args"`
-	ChatTemplateFile string   `yaml:"chat_template_file_name"`
-	ChatTemplatePath string   `yaml:"chat_template_path"`
-	ToolCallParser   string   `yaml:"tool_call_parser"`
+	Supported        bool     `yaml:"tool_calling_supported" json:"tool_calling_supported"`
+	RequiredCLIArgs  []string `yaml:"required_cli_args" json:"required_cli_args"`
+	ChatTemplateFile string   `yaml:"chat_template_file_name" json:"chat_template_file_name"`
+	ChatTemplatePath string   `yaml:"chat_template_path" json:"chat_template_path"`
+	ToolCallParser   string   `yaml:"tool_call_parser" json:"tool_call_parser"`
 }
 
 // HasToolCalling returns true if the model supports tool calling
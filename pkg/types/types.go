@@ -22,6 +22,12 @@ type ModelEntry struct {
 	ModelType string   `yaml:"model_type"` // Model type: "generative", "predictive", or "unknown" (defaults to "generative" if omitted)
 }
 
+// Model index entry types
+const (
+	ModelEntryTypeOCI = "oci" // Registry-based modelcar image
+	ModelEntryTypeHF  = "hf"  // Model hosted on HuggingFace
+)
+
 // ModelsConfig represents the configuration of models to process
 type ModelsConfig struct {
 	Models []ModelEntry `yaml:"models"`
@@ -72,30 +78,41 @@ type VersionIndex struct {
 
 // OCIArtifact represents a structured OCI artifact with metadata
 type OCIArtifact struct {
-	URI                      string                 `yaml:"uri"`
-	CreateTimeSinceEpoch     *int64                 `yaml:"createTimeSinceEpoch"`
-	LastUpdateTimeSinceEpoch *int64                 `yaml:"lastUpdateTimeSinceEpoch"`
-	CustomProperties         map[string]interface{} `yaml:"customProperties,omitempty"`
+	URI                      string                 `yaml:"uri" json:"uri"`
+	CreateTimeSinceEpoch     *int64                 `yaml:"createTimeSinceEpoch" json:"createTimeSinceEpoch"`
+	LastUpdateTimeSinceEpoch *int64                 `yaml:"lastUpdateTimeSinceEpoch" json:"lastUpdateTimeSinceEpoch"`
+	CustomProperties         map[string]interface{} `yaml:"customProperties,omitempty" json:"customProperties,omitempty"`
 }
 
 // ExtractedMetadata represents the actual extracted values from the modelcard
 type ExtractedMetadata struct {
-	Name                     *string            `yaml:"name"`
-	Provider                 *string            `yaml:"provider"`
-	Description              *string            `yaml:"description"`
-	Readme                   *string            `yaml:"readme"`
-	Language                 []string           `yaml:"language"`
-	License                  *string            `yaml:"license"`
-	LicenseLink              *string            `yaml:"licenseLink"`
-	Tags                     []string           `yaml:"tags"`
-	Tasks                    []string           `yaml:"tasks"`
-	CreateTimeSinceEpoch     *int64             `yaml:"createTimeSinceEpoch"`
-	LastUpdateTimeSinceEpoch *int64             `yaml:"lastUpdateTimeSinceEpoch"`
-	ValidatedOn              []string           `yaml:"validatedOn"`
-	HardwareTag              []string           `yaml:"hardwareTag"`
-	ValidatedTasks           []string           `yaml:"validatedTasks,omitempty"`
-	ToolCallingConfig        *ToolCallingConfig `yaml:"toolCallingConfig,omitempty"`
-	Artifacts                []OCIArtifact      `yaml:"artifacts"`
+	Name                     *string            `yaml:"name" json:"name"`
+	Provider                 *string            `yaml:"provider" json:"provider"`
+	Description              *string            `yaml:"description" json:"description"`
+	Readme                   *string            `yaml:"readme" json:"readme"`
+	Language                 []string           `yaml:"language" json:"language"`
+	License                  *string            `yaml:"license" json:"license"`
+	LicenseLink              *string            `yaml:"licenseLink" json:"licenseLink"`
+	Tags                     []string           `yaml:"tags" json:"tags"`
+	Tasks                    []string           `yaml:"tasks" json:"tasks"`
+	CreateTimeSinceEpoch     *int64             `yaml:"createTimeSinceEpoch" json:"createTimeSinceEpoch"`
+	LastUpdateTimeSinceEpoch *int64             `yaml:"lastUpdateTimeSinceEpoch" json:"lastUpdateTimeSinceEpoch"`
+	ValidatedOn              []string           `yaml:"validatedOn" json:"validatedOn"`
+	HardwareTag              []string           `yaml:"hardwareTag" json:"hardwareTag"`
+	ValidatedTasks           []string           `yaml:"validatedTasks,omitempty" json:"validatedTasks,omitempty"`
+	BaseModel                []string           `yaml:"baseModel,omitempty" json:"baseModel,omitempty"`
+	Maturity                 *string            `yaml:"maturity,omitempty" json:"maturity,omitempty"`
+	ModelSize                *string            `yaml:"modelSize,omitempty" json:"modelSize,omitempty"`
+	Quantization             *string            `yaml:"quantization,omitempty" json:"quantization,omitempty"`         // e.g. w4a16, fp8-dynamic; parsed from the model name
+	Downloads                *int               `yaml:"downloads,omitempty" json:"downloads,omitempty"`               // HuggingFace download count
+	Likes                    *int               `yaml:"likes,omitempty" json:"likes,omitempty"`                       // HuggingFace like count
+	Architectures            []string           `yaml:"architectures,omitempty" json:"architectures,omitempty"`       // From config.json "architectures"
+	ArchitectureType         *string            `yaml:"architectureType,omitempty" json:"architectureType,omitempty"` // From config.json "model_type"
+	ToolCallingConfig        *ToolCallingConfig `yaml:"toolCallingConfig,omitempty" json:"toolCallingConfig,omitempty"`
+	Deprecated               bool               `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`   // Superseded by a newer model
+	ReplacedBy               string             `yaml:"replacedBy,omitempty" json:"replacedBy,omitempty"`   // Name or ID of the replacement model
+	Evaluations              map[string]string  `yaml:"evaluations,omitempty" json:"evaluations,omitempty"` // Benchmark scores from modelcard evaluation tables, keyed by metric
+	Artifacts                []OCIArtifact      `yaml:"artifacts" json:"artifacts"`
 }
 
 // LegacyExtractedMetadata represents the old format with string artifacts
@@ -185,6 +202,7 @@ type EnrichedModelMetadata struct {
 	ValidatedOn          MetadataSource `yaml:"validated_on"`
 	HardwareTag          MetadataSource `yaml:"hardware_tag"`
 	ValidatedTasks       MetadataSource `yaml:"validated_tasks"`
+	BaseModel            MetadataSource `yaml:"base_model"`
 }
 
 // EnrichmentInfo tracks data sources for metadata fields
@@ -269,12 +287,72 @@ type ModelsCatalog struct {
 	Models []CatalogMetadata `yaml:"models"`
 }
 
-// Config represents the application configuration
+// Config represents the extractor run configuration loaded from a --config file.
+// Keys match the CLI flag names; nil fields are absent from the file and keep the flag value.
 type Config struct {
-	ModelsIndexPath   string
-	OutputDir         string
-	CatalogOutputPath string
-	MaxConcurrent     int
+	ModelsIndexPath          *string        `yaml:"input,omitempty"`
+	Models                   *string        `yaml:"models,omitempty"` // A single --models pattern
+	IndexTimeout             *time.Duration `yaml:"index-timeout,omitempty"`
+	RegistryTemplate         *string        `yaml:"registry-template,omitempty"`
+	InputDir                 *string        `yaml:"input-dir,omitempty"`
+	OutputDir                *string        `yaml:"output-dir,omitempty"`
+	CatalogOutputPath        *string        `yaml:"catalog-output,omitempty"`
+	MaxConcurrent            *int           `yaml:"max-concurrent,omitempty"`
+	MaxRetries               *int           `yaml:"max-retries,omitempty"`
+	MetadataFormat           *string        `yaml:"metadata-format,omitempty"`
+	Platform                 *string        `yaml:"platform,omitempty"`
+	MaxModelcardBytes        *int64         `yaml:"max-modelcard-bytes,omitempty"`
+	FetchTimeout             *time.Duration `yaml:"fetch-timeout,omitempty"`
+	Since                    *string        `yaml:"since,omitempty"`
+	Force                    *bool          `yaml:"force,omitempty"`
+	Progress                 *bool          `yaml:"progress,omitempty"`
+	ProgressJSON             *string        `yaml:"progress-json,omitempty"`
+	ModelcardExtensions      *string        `yaml:"modelcard-extensions,omitempty"`
+	ExtractFiles             *string        `yaml:"extract-files,omitempty"`
+	PinDigests               *bool          `yaml:"pin-digests,omitempty"`
+	SkipHuggingFace          *bool          `yaml:"skip-huggingface,omitempty"`
+	SkipEnrichment           *bool          `yaml:"skip-enrichment,omitempty"`
+	WriteAggregateEnrichment *bool          `yaml:"write-aggregate-enrichment,omitempty"`
+	PreferModelcardName      *bool          `yaml:"prefer-modelcard-name,omitempty"`
+	SourcePriority           *string        `yaml:"source-priority,omitempty"`
+	MinEnrichmentRate        *float64       `yaml:"min-enrichment-rate,omitempty"`
+	MaxConcurrentEnrich      *int           `yaml:"max-concurrent-enrich,omitempty"`
+	MatchThreshold           *float64       `yaml:"match-threshold,omitempty"`
+	HighConfidenceThreshold  *float64       `yaml:"high-confidence-threshold,omitempty"`
+	AmbiguityMargin          *float64       `yaml:"ambiguity-margin,omitempty"`
+	HFMapping                *string        `yaml:"hf-mapping,omitempty"`
+	AllowTags                *string        `yaml:"allow-tags,omitempty"`
+	DenyTags                 *string        `yaml:"deny-tags,omitempty"`
+	TaskMap                  *string        `yaml:"task-map,omitempty"`
+	DescriptionOverrides     *string        `yaml:"description-overrides,omitempty"`
+	SkipCatalog              *bool          `yaml:"skip-catalog,omitempty"`
+	CatalogOnly              *bool          `yaml:"catalog-only,omitempty"`
+	IncludeLabels            *string        `yaml:"include-label,omitempty"`
+	ExcludeLabels            *string        `yaml:"exclude-label,omitempty"`
+	StrictCatalog            *bool          `yaml:"strict,omitempty"`
+	Verify                   *bool          `yaml:"verify,omitempty"`
+	LogoMapPath              *string        `yaml:"logo-map,omitempty"`
+	DedupStrategy            *string        `yaml:"dedup-strategy,omitempty"`
+	CatalogSort              *string        `yaml:"catalog-sort,omitempty"`
+	FacetsOutput             *string        `yaml:"facets-output,omitempty"`
+	SplitByLabel             *string        `yaml:"split-by-label,omitempty"`
+	StaticCatalogFiles       *string        `yaml:"static-catalog-files,omitempty"`
+	SkipDefaultStaticCatalog *bool          `yaml:"skip-default-static-catalog,omitempty"`
+	MCPIndexPath             *string        `yaml:"mcp-index,omitempty"`
+	MCPCatalogOutputPath     *string        `yaml:"mcp-catalog-output,omitempty"`
+	SkipMCPEnrichment        *bool          `yaml:"skip-mcp-enrichment,omitempty"`
+	AgentIndexPath           *string        `yaml:"agent-index,omitempty"`
+	AgentCatalogOutputPath   *string        `yaml:"agent-catalog-output,omitempty"`
+	AgentBranch              *string        `yaml:"agent-branch,omitempty"`
+	SkipAgentEnrichment      *bool          `yaml:"skip-agent-enrichment,omitempty"`
+	CacheDir                 *string        `yaml:"cache-dir,omitempty"`
+	CacheTTL                 *time.Duration `yaml:"cache-ttl,omitempty"`
+	NoCache                  *bool          `yaml:"no-cache,omitempty"`
+	HFToken                  *string        `yaml:"hf-token,omitempty"`
+	HFRequestTimeout         *time.Duration `yaml:"hf-request-timeout,omitempty"`
+	HFMaxResponseBytes       *int64         `yaml:"hf-max-response-bytes,omitempty"`
+	LogLevel                 *string        `yaml:"log-level,omitempty"`
+	LogFormat                *string        `yaml:"log-format,omitempty"`
 }
 
 // ModelMetadata tracks metadata presence in modelcard
@@ -295,14 +373,17 @@ type ModelMetadata struct {
 
 // ModelCard represents a model card structure
 type ModelCard struct {
-	Present  bool          `yaml:"present"`
-	Metadata ModelMetadata `yaml:"metadata"`
+	Present    bool          `yaml:"present"`
+	Empty      bool          `yaml:"empty,omitempty"`      // Modelcard file present but empty or whitespace-only
+	LayerEmpty bool          `yaml:"layerEmpty,omitempty"` // Modelcard layer present but its tar holds no files
+	Metadata   ModelMetadata `yaml:"metadata"`
 }
 
 // ModelManifest represents a model manifest entry
 type ModelManifest struct {
 	Ref       string    `yaml:"ref"`
 	ModelCard ModelCard `yaml:"modelcard"`
+	Error     string    `yaml:"error,omitempty"` // Set when the model failed to process (e.g. fetch timeout)
 }
 
 // ManifestsData represents the collection of all manifests
@@ -310,6 +391,36 @@ type ManifestsData struct {
 	Models []ModelManifest `yaml:"models"`
 }
 
+// ExtractionSummary is the per-run overview written to extraction-summary.yaml
+type ExtractionSummary struct {
+	Total       int                      `yaml:"total"`
+	Succeeded   int                      `yaml:"succeeded"`
+	NoModelCard int                      `yaml:"noModelCard"`
+	Errored     int                      `yaml:"errored"`
+	Models      []ExtractionSummaryEntry `yaml:"models"`
+}
+
+// ExtractionSummaryEntry records the extraction outcome for a single model
+type ExtractionSummaryEntry struct {
+	Ref                 string `yaml:"ref"`
+	ModelCardFound      bool   `yaml:"modelCardFound"`
+	ModelCardEmpty      bool   `yaml:"modelCardEmpty,omitempty"`
+	ModelCardLayerEmpty bool   `yaml:"modelCardLayerEmpty,omitempty"`
+	MetadataWritten     bool   `yaml:"metadataWritten"`
+	Error               string `yaml:"error,omitempty"`
+}
+
+// ModelProgressEvent is one line of the --progress-json stream, written as each model completes
+type ModelProgressEvent struct {
+	Ref             string   `json:"ref"`
+	Success         bool     `json:"success"`
+	ModelCardFound  bool     `json:"modelCardFound"`
+	Reused          bool     `json:"reused"`
+	FieldsExtracted []string `json:"fieldsExtracted"`
+	DurationMs      int64    `json:"durationMs"`
+	Error           string   `json:"error,omitempty"`
+}
+
 // ValidateModelType validates that a model type is one of the allowed values
 func ValidateModelType(modelType string) error {
 	switch modelType {
@@ -1,41 +1,124 @@
 package utils
 
 import (
+	"regexp"
 	"strings"
 )
 
-// getLicenseURL returns the canonical URL for well-known licenses
-func GetLicenseURL(licenseID string) string {
-	licenseID = strings.ToLower(strings.TrimSpace(licenseID))
-
-	licenseURLs := map[string]string{
-		"apache-2.0":            "https://www.apache.org/licenses/LICENSE-2.0",
-		"mit":                   "https://opensource.org/licenses/MIT",
-		"bsd-3-clause":          "https://opensource.org/licenses/BSD-3-Clause",
-		"bsd-2-clause":          "https://opensource.org/licenses/BSD-2-Clause",
-		"gpl-3.0":               "https://www.gnu.org/licenses/gpl-3.0.html",
-		"gpl-2.0":               "https://www.gnu.org/licenses/old-licenses/gpl-2.0.html",
-		"lgpl-3.0":              "https://www.gnu.org/licenses/lgpl-3.0.html",
-		"lgpl-2.1":              "https://www.gnu.org/licenses/old-licenses/lgpl-2.1.html",
-		"cc-by-4.0":             "https://creativecommons.org/licenses/by/4.0/",
-		"cc-by-sa-4.0":          "https://creativecommons.org/licenses/by-sa/4.0/",
-		"cc-by-nc-4.0":          "https://creativecommons.org/licenses/by-nc/4.0/",
-		"cc0-1.0":               "https://creativecommons.org/publicdomain/zero/1.0/",
-		"unlicense":             "https://unlicense.org/",
-		"llama2":                "https://github.com/facebookresearch/llama/blob/main/LICENSE",
-		"llama3":                "https://github.com/meta-llama/llama-models/blob/main/models/llama3/LICENSE",
-		"llama3.1":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_1/LICENSE",
-		"llama3.2":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_2/LICENSE",
-		"llama3.3":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_3/LICENSE",
-		"llama4":                "https://github.com/meta-llama/llama-models/blob/main/models/llama4/LICENSE",
-		"bigscience-openrail-m": "https://huggingface.co/spaces/bigscience/license",
-		"openrail":              "https://www.licenses.ai/ai-licenses",
-		"gemma":                 "https://ai.google.dev/gemma/terms",
+// licenseURLs maps canonical (lowercase SPDX or HuggingFace) license IDs to their license text
+var licenseURLs = map[string]string{
+	"apache-2.0":            "https://www.apache.org/licenses/LICENSE-2.0",
+	"mit":                   "https://opensource.org/licenses/MIT",
+	"bsd-3-clause":          "https://opensource.org/licenses/BSD-3-Clause",
+	"bsd-2-clause":          "https://opensource.org/licenses/BSD-2-Clause",
+	"gpl-3.0":               "https://www.gnu.org/licenses/gpl-3.0.html",
+	"gpl-2.0":               "https://www.gnu.org/licenses/old-licenses/gpl-2.0.html",
+	"agpl-3.0":              "https://www.gnu.org/licenses/agpl-3.0.html",
+	"lgpl-3.0":              "https://www.gnu.org/licenses/lgpl-3.0.html",
+	"lgpl-2.1":              "https://www.gnu.org/licenses/old-licenses/lgpl-2.1.html",
+	"mpl-2.0":               "https://www.mozilla.org/en-US/MPL/2.0/",
+	"cc-by-4.0":             "https://creativecommons.org/licenses/by/4.0/",
+	"cc-by-sa-4.0":          "https://creativecommons.org/licenses/by-sa/4.0/",
+	"cc-by-nc-4.0":          "https://creativecommons.org/licenses/by-nc/4.0/",
+	"cc-by-nc-sa-4.0":       "https://creativecommons.org/licenses/by-nc-sa/4.0/",
+	"cc-by-nc-nd-4.0":       "https://creativecommons.org/licenses/by-nc-nd/4.0/",
+	"cc0-1.0":               "https://creativecommons.org/publicdomain/zero/1.0/",
+	"unlicense":             "https://unlicense.org/",
+	"llama2":                "https://github.com/facebookresearch/llama/blob/main/LICENSE",
+	"llama3":                "https://github.com/meta-llama/llama-models/blob/main/models/llama3/LICENSE",
+	"llama3.1":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_1/LICENSE",
+	"llama3.2":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_2/LICENSE",
+	"llama3.3":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_3/LICENSE",
+	"llama4":                "https://github.com/meta-llama/llama-models/blob/main/models/llama4/LICENSE",
+	"bigscience-openrail-m": "https://huggingface.co/spaces/bigscience/license",
+	"openrail":              "https://www.licenses.ai/ai-licenses",
+	"gemma":                 "https://ai.google.dev/gemma/terms",
+}
+
+// licenseAliases maps license spellings, after licenseKey simplification, to canonical IDs
+var licenseAliases = map[string]string{
+	"apache":                              "apache-2.0",
+	"apache2":                             "apache-2.0",
+	"apache-2":                            "apache-2.0",
+	"apache2.0":                           "apache-2.0",
+	"asl-2.0":                             "apache-2.0",
+	"bsd-3":                               "bsd-3-clause",
+	"new-bsd":                             "bsd-3-clause",
+	"bsd-2":                               "bsd-2-clause",
+	"simplified-bsd":                      "bsd-2-clause",
+	"gplv3":                               "gpl-3.0",
+	"gpl-3":                               "gpl-3.0",
+	"gnu-gpl-v3":                          "gpl-3.0",
+	"gplv2":                               "gpl-2.0",
+	"gpl-2":                               "gpl-2.0",
+	"agplv3":                              "agpl-3.0",
+	"lgplv3":                              "lgpl-3.0",
+	"mpl-2":                               "mpl-2.0",
+	"creative-commons-attribution-4.0":    "cc-by-4.0",
+	"cc-by-sa":                            "cc-by-sa-4.0",
+	"creative-commons-attribution-nc-4.0": "cc-by-nc-4.0",
+	"cc0":                                 "cc0-1.0",
+	"llama-2":                             "llama2",
+	"meta-llama-2":                        "llama2",
+	"gemma-terms":                         "gemma",
+	"openrail-m":                          "openrail",
+}
+
+var (
+	// licenseFillerWords are dropped when matching license spellings, e.g. "Apache License, Version 2.0"
+	licenseFillerWords = map[string]bool{
+		"license": true, "licence": true, "licensed": true, "agreement": true, "version": true, "the": true,
+		"community": true, "terms": true, "of": true, "use": true, "v": true,
 	}
+	licenseWordRegex  = regexp.MustCompile(`[a-z0-9.+]+`)
+	llamaVersionRegex = regexp.MustCompile(`^(?:meta-)?llama-?(\d(?:\.\d)?)$`)
+	versionSuffixV    = regexp.MustCompile(`^v(\d+(?:\.\d+)?)$`)
+)
 
-	if url, exists := licenseURLs[licenseID]; exists {
-		return url
+// licenseKey lowercases a license name, drops filler words and joins the remaining words with "-"
+func licenseKey(license string) string {
+	var words []string
+	for _, word := range licenseWordRegex.FindAllString(strings.ToLower(license), -1) {
+		word = strings.Trim(word, ".")
+		if word == "" || licenseFillerWords[word] {
+			continue
+		}
+		// "v2.0" -> "2.0", but keep IDs such as "gplv3" intact
+		if m := versionSuffixV.FindStringSubmatch(word); m != nil {
+			word = m[1]
+		}
+		words = append(words, word)
 	}
+	return strings.Join(words, "-")
+}
 
-	return ""
+// NormalizeLicenseSPDX maps common spellings of a license (e.g. "Apache License 2.0", "apache 2.0",
+// "Llama 3.1 Community License") to its canonical lowercase SPDX or HuggingFace ID ("apache-2.0",
+// "llama3.1"). Unrecognized licenses are returned trimmed but otherwise unchanged.
+func NormalizeLicenseSPDX(license string) string {
+	trimmed := strings.TrimSpace(license)
+	lower := strings.ToLower(trimmed)
+	if _, known := licenseURLs[lower]; known {
+		return lower
+	}
+
+	key := licenseKey(trimmed)
+	if _, known := licenseURLs[key]; known {
+		return key
+	}
+	if canonical, ok := licenseAliases[key]; ok {
+		return canonical
+	}
+	if m := llamaVersionRegex.FindStringSubmatch(key); m != nil {
+		if _, known := licenseURLs["llama"+m[1]]; known {
+			return "llama" + m[1]
+		}
+	}
+	return trimmed
+}
+
+// GetLicenseURL returns the canonical URL for well-known licenses, accepting any spelling
+// NormalizeLicenseSPDX recognizes
+func GetLicenseURL(licenseID string) string {
+	return licenseURLs[NormalizeLicenseSPDX(licenseID)]
 }
@@ -40,6 +40,21 @@ func TestGetLicenseURL(t *testing.T) {
 			licenseID: "llama3.1",
 			expected:  "https://github.com/meta-llama/llama-models/blob/main/models/llama3_1/LICENSE",
 		},
+		{
+			name:      "license name spelling",
+			licenseID: "Apache License, Version 2.0",
+			expected:  "https://www.apache.org/licenses/LICENSE-2.0",
+		},
+		{
+			name:      "creative commons non-commercial",
+			licenseID: "cc-by-nc-4.0",
+			expected:  "https://creativecommons.org/licenses/by-nc/4.0/",
+		},
+		{
+			name:      "gemma terms",
+			licenseID: "Gemma Terms of Use",
+			expected:  "https://ai.google.dev/gemma/terms",
+		},
 		{
 			name:      "empty string",
 			licenseID: "",
@@ -56,3 +71,40 @@ func TestGetLicenseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeLicenseSPDX(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"apache-2.0", "apache-2.0"},
+		{"Apache-2.0", "apache-2.0"},
+		{"apache 2.0", "apache-2.0"},
+		{"Apache License 2.0", "apache-2.0"},
+		{"Apache License, Version 2.0", "apache-2.0"},
+		{"Apache 2", "apache-2.0"},
+		{"MIT License", "mit"},
+		{"BSD 3-Clause", "bsd-3-clause"},
+		{"GPLv3", "gpl-3.0"},
+		{"GPL v3.0", "gpl-3.0"},
+		{"CC BY 4.0", "cc-by-4.0"},
+		{"CC-BY-NC-4.0", "cc-by-nc-4.0"},
+		{"Creative Commons Attribution 4.0", "cc-by-4.0"},
+		{"Llama 3.1 Community License", "llama3.1"},
+		{"Llama 3.2 Community License", "llama3.2"},
+		{"llama2", "llama2"},
+		{"Gemma Terms of Use", "gemma"},
+		{"  mit  ", "mit"},
+		{"Proprietary License", "Proprietary License"},
+		{"other", "other"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeLicenseSPDX(tt.input); got != tt.expected {
+				t.Errorf("NormalizeLicenseSPDX(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
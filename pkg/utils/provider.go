@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// providerAliases maps provider spellings, after providerKey simplification, to canonical names
+var providerAliases = map[string]string{
+	"ibm":                           "IBM",
+	"ibmresearch":                   "IBM",
+	"ibmgranite":                    "IBM",
+	"internationalbusinessmachines": "IBM",
+	"meta":                          "Meta",
+	"metaai":                        "Meta",
+	"metallama":                     "Meta",
+	"metaplatforms":                 "Meta",
+	"facebook":                      "Meta",
+	"facebookai":                    "Meta",
+	"facebookresearch":              "Meta",
+	"microsoft":                     "Microsoft",
+	"microsoftresearch":             "Microsoft",
+	"mistral":                       "Mistral AI",
+	"mistralai":                     "Mistral AI",
+	"neuralmagic":                   "Neural Magic",
+	"redhat":                        "Red Hat",
+	"redhatai":                      "Red Hat",
+}
+
+// providerSuffixes are company suffixes dropped before the alias lookup
+var providerSuffixes = []string{"corporation", "corp", "inc", "llc", "ltd"}
+
+// providerKey lowercases s and keeps only letters and digits, dropping a trailing company suffix
+func providerKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	key := b.String()
+	for _, suffix := range providerSuffixes {
+		if trimmed := strings.TrimSuffix(key, suffix); trimmed != key && trimmed != "" {
+			return trimmed
+		}
+	}
+	return key
+}
+
+// NormalizeProvider maps common spellings of well-known model providers (e.g. "IBM Research",
+// "ibm-granite") to a single canonical name. Unknown providers are returned trimmed but
+// otherwise unchanged.
+func NormalizeProvider(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if canonical, ok := providerAliases[providerKey(trimmed)]; ok {
+		return canonical
+	}
+	return trimmed
+}
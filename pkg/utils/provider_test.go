@@ -0,0 +1,47 @@
+package utils
+
+import "testing"
+
+func TestNormalizeProvider(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"IBM", "IBM"},
+		{"IBM Research", "IBM"},
+		{"ibm-granite", "IBM"},
+		{"ibm", "IBM"},
+		{"Meta", "Meta"},
+		{"Meta AI", "Meta"},
+		{"meta-llama", "Meta"},
+		{"Meta Platforms, Inc.", "Meta"},
+		{"facebook", "Meta"},
+		{"Microsoft", "Microsoft"},
+		{"Microsoft Research", "Microsoft"},
+		{"Microsoft Corporation", "Microsoft"},
+		{"Mistral", "Mistral AI"},
+		{"mistralai", "Mistral AI"},
+		{"Mistral AI", "Mistral AI"},
+		{"Neural Magic", "Neural Magic"},
+		{"neuralmagic", "Neural Magic"},
+		{"Red Hat", "Red Hat"},
+		{"RedHat", "Red Hat"},
+		{"RedHat AI", "Red Hat"},
+		{"RedHatAI", "Red Hat"},
+		{"Red Hat, Inc.", "Red Hat"},
+		{"  IBM Research  ", "IBM"},
+		{"Red Hat (Neural Magic)", "Red Hat (Neural Magic)"},
+		{"Google", "Google"},
+		{"Test Company", "Test Company"},
+		{"  Example Org ", "Example Org"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeProvider(tt.input); got != tt.expected {
+				t.Errorf("NormalizeProvider(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
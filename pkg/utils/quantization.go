@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// quantizationTokenSplitter splits model names into tokens, e.g. "granite-3.1-8b-instruct-quantized.w4a16"
+var quantizationTokenSplitter = regexp.MustCompile(`[\s\-_./:]+`)
+
+// weightActivationRegex matches weight/activation bit-width schemes such as w4a16 and w8a8
+var weightActivationRegex = regexp.MustCompile(`^w(4|8|16)a(4|8|16)$`)
+
+// quantizationSchemes are precision tokens reported as-is
+var quantizationSchemes = map[string]bool{
+	"fp8":   true,
+	"fp4":   true,
+	"nvfp4": true,
+	"mxfp4": true,
+	"int4":  true,
+	"int8":  true,
+}
+
+// ParseQuantization returns the lowercase quantization scheme encoded in a model name, e.g.
+// "w4a16" for "granite-3.1-8b-instruct-quantized.w4a16" or "fp8-dynamic" for
+// "Llama-3.1-8B-Instruct-FP8-dynamic". ok is false for names without a recognized scheme.
+func ParseQuantization(name string) (scheme string, ok bool) {
+	tokens := quantizationTokenSplitter.Split(strings.ToLower(name), -1)
+	for i, token := range tokens {
+		if weightActivationRegex.MatchString(token) {
+			return token, true
+		}
+		if !quantizationSchemes[token] {
+			continue
+		}
+		// FP8 checkpoints come with static or dynamic activation scales
+		if token == "fp8" && i+1 < len(tokens) && tokens[i+1] == "dynamic" {
+			return "fp8-dynamic", true
+		}
+		return token, true
+	}
+	return "", false
+}
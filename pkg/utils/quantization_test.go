@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestParseQuantization(t *testing.T) {
+	tests := []struct {
+		name       string
+		modelName  string
+		wantScheme string
+		wantOK     bool
+	}{
+		{name: "w4a16", modelName: "RedHatAI/granite-3.1-8b-instruct-quantized.w4a16", wantScheme: "w4a16", wantOK: true},
+		{name: "w8a8", modelName: "RedHatAI/Qwen2.5-7B-Instruct-quantized.w8a8", wantScheme: "w8a8", wantOK: true},
+		{name: "fp8 dynamic", modelName: "RedHatAI/Llama-3.1-8B-Instruct-FP8-dynamic", wantScheme: "fp8-dynamic", wantOK: true},
+		{name: "fp8 dynamic with underscore", modelName: "Mistral-Small-24B-Instruct-2501_FP8_Dynamic", wantScheme: "fp8-dynamic", wantOK: true},
+		{name: "fp8 static", modelName: "RedHatAI/Llama-3.3-70B-Instruct-FP8", wantScheme: "fp8", wantOK: true},
+		{name: "nvfp4", modelName: "RedHatAI/Llama-3.3-70B-Instruct-NVFP4", wantScheme: "nvfp4", wantOK: true},
+		{name: "int4", modelName: "gemma-2-9b-it-int4", wantScheme: "int4", wantOK: true},
+		{name: "display name with spaces", modelName: "Granite 3.1 8B Instruct quantized w4a16", wantScheme: "w4a16", wantOK: true},
+		{name: "registry image name", modelName: "modelcar-llama-3-1-8b-instruct-quantized-w8a16", wantScheme: "w8a16", wantOK: true},
+		{name: "non-quantized", modelName: "ibm-granite/granite-3.1-8b-instruct"},
+		{name: "size token is not a scheme", modelName: "RedHatAI/Llama-3.1-8B-Instruct"},
+		{name: "scheme embedded in a word", modelName: "afp8model-7b"},
+		{name: "empty", modelName: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, ok := ParseQuantization(tt.modelName)
+			if scheme != tt.wantScheme || ok != tt.wantOK {
+				t.Errorf("ParseQuantization(%q) = (%q, %v), want (%q, %v)", tt.modelName, scheme, ok, tt.wantScheme, tt.wantOK)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand/v2"
 	"time"
 )
 
@@ -14,7 +15,9 @@ type RetryConfig struct {
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
 	Multiplier     float64
-	OverallTimeout time.Duration // Maximum total time for all retries
+	OverallTimeout time.Duration    // Maximum total time for all retries
+	Jitter         float64          // Fraction of each backoff to randomize (e.g. 0.2 = ±20%); 0 disables jitter
+	ShouldRetry    func(error) bool // Optional classifier; when set, errors it rejects are returned immediately
 }
 
 // DefaultRetryConfig provides sensible defaults for registry operations
@@ -29,11 +32,17 @@ var DefaultRetryConfig = RetryConfig{
 // RetryWithExponentialBackoff retries a function with exponential backoff
 // Returns the result of the function or the last error encountered
 func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T, error), operationName string) (T, error) {
+	return RetryWithExponentialBackoffContext(context.Background(), config, operation, operationName)
+}
+
+// RetryWithExponentialBackoffContext is like RetryWithExponentialBackoff but stops
+// retrying as soon as the parent context is cancelled or its deadline expires.
+func RetryWithExponentialBackoffContext[T any](parent context.Context, config RetryConfig, operation func() (T, error), operationName string) (T, error) {
 	var result T
 	var err error
 
 	// Create context with overall timeout if configured
-	ctx := context.Background()
+	ctx := parent
 	var cancel context.CancelFunc
 	if config.OverallTimeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, config.OverallTimeout)
@@ -44,13 +53,8 @@ func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T,
 		// Check if context has been cancelled (timeout exceeded)
 		select {
 		case <-ctx.Done():
-			log.Printf("  Retry timeout exceeded for %s: %v", operationName, ctx.Err())
-			// Wrap ctx.Err() instead of err to avoid wrapping nil if timeout occurs before first operation
-			errToReturn := err
-			if errToReturn == nil {
-				errToReturn = ctx.Err()
-			}
-			return result, fmt.Errorf("retry timeout exceeded after %v: %w", config.OverallTimeout, errToReturn)
+			log.Printf("  Stopped retrying %s: %v", operationName, ctx.Err())
+			return result, retryStoppedError(parent, ctx, config, err)
 		default:
 			// Continue with retry attempt
 		}
@@ -63,6 +67,9 @@ func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T,
 			// Cap at max backoff
 			backoffDuration = min(backoffDuration, config.MaxBackoff)
 
+			// Spread retries from concurrent callers so they don't hit the server in lockstep
+			backoffDuration = applyJitter(backoffDuration, config.Jitter)
+
 			log.Printf("  Retry %d/%d for %s after %v backoff", attempt, config.MaxRetries, operationName, backoffDuration)
 
 			// Use context-aware sleep
@@ -70,13 +77,8 @@ func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T,
 			case <-time.After(backoffDuration):
 				// Sleep completed normally
 			case <-ctx.Done():
-				log.Printf("  Retry timeout exceeded during backoff for %s: %v", operationName, ctx.Err())
-				// Wrap ctx.Err() instead of err to avoid wrapping nil if timeout occurs before first operation
-				errToReturn := err
-				if errToReturn == nil {
-					errToReturn = ctx.Err()
-				}
-				return result, fmt.Errorf("retry timeout exceeded after %v: %w", config.OverallTimeout, errToReturn)
+				log.Printf("  Stopped retrying %s during backoff: %v", operationName, ctx.Err())
+				return result, retryStoppedError(parent, ctx, config, err)
 			}
 		}
 
@@ -88,6 +90,12 @@ func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T,
 			return result, nil
 		}
 
+		// Permanent failures (e.g. 401/404) are not worth retrying
+		if config.ShouldRetry != nil && !config.ShouldRetry(err) {
+			log.Printf("  Non-retryable error for %s: %v", operationName, err)
+			return result, err
+		}
+
 		// Log the error (except on last attempt where we'll return it)
 		if attempt < config.MaxRetries {
 			log.Printf("  Attempt %d/%d failed for %s: %v", attempt+1, config.MaxRetries+1, operationName, err)
@@ -98,3 +106,28 @@ func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T,
 	log.Printf("  All %d retry attempts exhausted for %s: %v", config.MaxRetries+1, operationName, err)
 	return result, err
 }
+
+// retryStoppedError describes why retrying stopped once ctx is done, wrapping the last attempt's
+// error (or ctx.Err() if no attempt ran). Only the config's OverallTimeout is reported as a retry
+// timeout; when the parent context ended first, its own error is reported instead.
+func retryStoppedError(parent, ctx context.Context, config RetryConfig, lastErr error) error {
+	if parent.Err() != nil {
+		if lastErr == nil {
+			return fmt.Errorf("retry stopped: %w", parent.Err())
+		}
+		return fmt.Errorf("retry stopped: %w: %w", parent.Err(), lastErr)
+	}
+	if lastErr == nil {
+		lastErr = ctx.Err()
+	}
+	return fmt.Errorf("retry timeout exceeded after %v: %w", config.OverallTimeout, lastErr)
+}
+
+// applyJitter randomizes d by up to ±fraction of its value
+func applyJitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	delta := float64(d) * fraction * (2*rand.Float64() - 1)
+	return time.Duration(float64(d) + delta)
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -164,3 +166,92 @@ func TestRetryWithExponentialBackoff_TimeoutErrorWrapping(t *testing.T) {
 		t.Errorf("Expected descriptive timeout error, got: %v", err)
 	}
 }
+
+func TestRetryWithExponentialBackoff_ShouldRetryStopsOnPermanentError(t *testing.T) {
+	permanent := errors.New("not found")
+	attempts := 0
+	config := RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		Multiplier:     2.0,
+		ShouldRetry: func(err error) bool {
+			return !errors.Is(err, permanent)
+		},
+	}
+
+	_, err := RetryWithExponentialBackoff(config, func() (string, error) {
+		attempts++
+		return "", permanent
+	}, "test operation")
+
+	if !errors.Is(err, permanent) {
+		t.Errorf("Expected permanent error to be returned, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt for non-retryable error, got: %d", attempts)
+	}
+}
+
+func TestRetryWithExponentialBackoffContext_ParentCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	config := RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		Multiplier:     2.0,
+	}
+
+	_, err := RetryWithExponentialBackoffContext(ctx, config, func() (string, error) {
+		attempts++
+		cancel()
+		return "", errors.New("transient failure")
+	}, "test operation")
+
+	if err == nil {
+		t.Fatal("Expected error after parent cancellation, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected retries to stop after cancellation, got %d attempts", attempts)
+	}
+}
+
+func TestRetryWithExponentialBackoffContext_ParentDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	failure := errors.New("transient failure")
+	config := RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		Multiplier:     2.0,
+	}
+
+	_, err := RetryWithExponentialBackoffContext(ctx, config, func() (string, error) {
+		return "", failure
+	}, "test operation")
+
+	// Without an OverallTimeout of its own, the parent's deadline is what stopped the retries
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, failure) {
+		t.Errorf("Expected error wrapping the deadline and the last failure, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "timeout exceeded after") {
+		t.Errorf("Expected the parent's error, not a retry timeout, got: %v", err)
+	}
+}
+
+func TestApplyJitter(t *testing.T) {
+	base := 100 * time.Millisecond
+
+	if got := applyJitter(base, 0); got != base {
+		t.Errorf("Expected no jitter with fraction 0, got %v", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		got := applyJitter(base, 0.2)
+		if got < 80*time.Millisecond || got > 120*time.Millisecond {
+			t.Fatalf("Jittered backoff %v outside ±20%% of %v", got, base)
+		}
+	}
+}
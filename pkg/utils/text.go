@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"maps"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -54,41 +57,146 @@ func StripYAMLFrontmatter(content string) string {
 	return content
 }
 
-// parseLanguageNames converts language names to locale codes
-func ParseLanguageNames(langStr string) []string {
-	// Language name to locale code mapping
-	languageMap := map[string]string{
-		"english":    "en",
-		"spanish":    "es",
-		"french":     "fr",
-		"german":     "de",
-		"italian":    "it",
-		"portuguese": "pt",
-		"russian":    "ru",
-		"chinese":    "zh",
-		"japanese":   "ja",
-		"korean":     "ko",
-		"arabic":     "ar",
-		"hindi":      "hi",
-		"dutch":      "nl",
-		"swedish":    "sv",
-		"danish":     "da",
-		"norwegian":  "no",
-		"finnish":    "fi",
-		"polish":     "pl",
-		"czech":      "cs",
-		"hungarian":  "hu",
-		"turkish":    "tr",
-		"hebrew":     "he",
-		"thai":       "th",
-		"vietnamese": "vi",
-		"indonesian": "id",
-		"malay":      "ms",
-		"tagalog":    "tl",
-		"swahili":    "sw",
+// Markdown patterns removed by StripMarkdownFormatting
+var (
+	markdownImageRegex = regexp.MustCompile(`!\[([^\[\]]*)\]\([^()\s]*\)`)
+	markdownLinkRegex  = regexp.MustCompile(`\[([^\[\]]*)\]\([^()\s]*\)`)
+	markdownCodeRegex  = regexp.MustCompile("`([^`]*)`")
+	markdownBoldRegex  = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	multiSpaceRegex    = regexp.MustCompile(`\s+`)
+)
+
+// StripMarkdownFormatting removes inline markdown from an extracted value: **bold** and
+// __bold__ markers, [text](url) links and images (keeping the text) and `code` spans.
+// Nested markup such as [**Apache-2.0**](url) is unwrapped; stray markers left by
+// malformed markdown are dropped. Extract URLs (e.g. for license links) before calling this.
+func StripMarkdownFormatting(s string) string {
+	// Code spans first so their content is not treated as markup
+	s = markdownCodeRegex.ReplaceAllString(s, "$1")
+
+	// Unwrap links from the inside out, e.g. [![badge](img)](link)
+	for {
+		stripped := markdownLinkRegex.ReplaceAllString(markdownImageRegex.ReplaceAllString(s, "$1"), "$1")
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+
+	s = markdownBoldRegex.ReplaceAllString(s, "$1$2")
+
+	// Drop markers left unpaired by malformed markdown
+	s = strings.ReplaceAll(s, "**", "")
+	s = strings.ReplaceAll(s, "`", "")
+
+	return strings.TrimSpace(multiSpaceRegex.ReplaceAllString(s, " "))
+}
+
+// languageMap maps lowercase language names to locale codes
+var languageMap = map[string]string{
+	"english":              "en",
+	"spanish":              "es",
+	"french":               "fr",
+	"german":               "de",
+	"italian":              "it",
+	"portuguese":           "pt",
+	"brazilian portuguese": "pt-BR",
+	"russian":              "ru",
+	"chinese":              "zh",
+	"simplified chinese":   "zh-Hans",
+	"traditional chinese":  "zh-Hant",
+	"mandarin":             "zh",
+	"cantonese":            "yue",
+	"japanese":             "ja",
+	"korean":               "ko",
+	"arabic":               "ar",
+	"hindi":                "hi",
+	"bengali":              "bn",
+	"urdu":                 "ur",
+	"persian":              "fa",
+	"farsi":                "fa",
+	"dutch":                "nl",
+	"swedish":              "sv",
+	"danish":               "da",
+	"norwegian":            "no",
+	"finnish":              "fi",
+	"icelandic":            "is",
+	"polish":               "pl",
+	"czech":                "cs",
+	"slovak":               "sk",
+	"slovenian":            "sl",
+	"croatian":             "hr",
+	"serbian":              "sr",
+	"bulgarian":            "bg",
+	"ukrainian":            "uk",
+	"belarusian":           "be",
+	"romanian":             "ro",
+	"hungarian":            "hu",
+	"greek":                "el",
+	"lithuanian":           "lt",
+	"latvian":              "lv",
+	"estonian":             "et",
+	"catalan":              "ca",
+	"basque":               "eu",
+	"galician":             "gl",
+	"welsh":                "cy",
+	"irish":                "ga",
+	"turkish":              "tr",
+	"hebrew":               "he",
+	"thai":                 "th",
+	"vietnamese":           "vi",
+	"indonesian":           "id",
+	"malay":                "ms",
+	"tagalog":              "tl",
+	"filipino":             "tl",
+	"swahili":              "sw",
+	"tamil":                "ta",
+	"telugu":               "te",
+	"marathi":              "mr",
+	"gujarati":             "gu",
+	"kannada":              "kn",
+	"malayalam":            "ml",
+	"punjabi":              "pa",
+}
+
+// knownLanguageCodes holds the base codes from languageMap, used to pass through
+// inputs that are already locale codes (e.g. "en", "pt-BR", "zh-Hans")
+var knownLanguageCodes = func() map[string]bool {
+	codes := make(map[string]bool)
+	for _, code := range languageMap {
+		base, _, _ := strings.Cut(code, "-")
+		codes[base] = true
+	}
+	return codes
+}()
+
+// localeCodeRegex matches a language code with an optional script or region subtag
+var localeCodeRegex = regexp.MustCompile(`^([a-z]{2,3})(?:[-_]([a-z]{4}|[a-z]{2}))?$`)
+
+// normalizeLocaleCode returns the canonical form of a locale code such as "pt_br" → "pt-BR"
+// or "zh-hans" → "zh-Hans", or "" if lang is not a known locale code
+func normalizeLocaleCode(lang string) string {
+	matches := localeCodeRegex.FindStringSubmatch(lang)
+	if matches == nil || !knownLanguageCodes[matches[1]] {
+		return ""
+	}
+	switch subtag := matches[2]; len(subtag) {
+	case 0:
+		return matches[1]
+	case 2:
+		// Region subtags are upper case (pt-BR, zh-CN)
+		return matches[1] + "-" + strings.ToUpper(subtag)
+	default:
+		// Script subtags are title case (zh-Hans)
+		return matches[1] + "-" + strings.ToUpper(subtag[:1]) + subtag[1:]
 	}
+}
 
+// parseLanguageNames converts language names to locale codes.
+// Inputs that are already locale codes are passed through, and duplicates are removed.
+func ParseLanguageNames(langStr string) []string {
 	var locales []string
+	seen := make(map[string]bool)
 	langStr = strings.ToLower(langStr)
 
 	// Split by common delimiters
@@ -98,7 +206,12 @@ func ParseLanguageNames(langStr string) []string {
 		lang = strings.TrimSpace(lang)
 		lang = strings.Trim(lang, ".,")
 
-		if locale, exists := languageMap[lang]; exists {
+		locale, exists := languageMap[lang]
+		if !exists {
+			locale = normalizeLocaleCode(lang)
+		}
+		if locale != "" && !seen[locale] {
+			seen[locale] = true
 			locales = append(locales, locale)
 		}
 	}
@@ -258,28 +371,33 @@ func CalculateSimilarity(s1, s2 string) float64 {
 
 	// Track which s2 tokens have been matched to ensure symmetric results
 	// Each token in s2 can only be matched once
+	usedS1 := make(map[int]bool)
 	usedS2 := make(map[int]bool)
-	commonTokens := 0
-	for _, token1 := range s1Tokens {
+	commonTokens := 0.0
+	for i, token1 := range s1Tokens {
 		if token1 == "" {
 			continue
 		}
 		for j, token2 := range s2Tokens {
 			if token1 == token2 && !usedS2[j] {
 				commonTokens++
+				usedS1[i] = true
 				usedS2[j] = true
 				break
 			}
 		}
 	}
 
+	// Near-miss tokens (e.g. "quantised" vs "quantized") count as partial matches
+	commonTokens += fuzzyTokenMatches(s1Tokens, s2Tokens, usedS1, usedS2)
+
 	maxTokens := max(len(s2Tokens), len(s1Tokens))
 
 	if maxTokens == 0 {
 		return 0.0
 	}
 
-	tokenScore := float64(commonTokens) / float64(maxTokens)
+	tokenScore := commonTokens / float64(maxTokens)
 
 	// Boost score if one string contains the other (indicates close relationship)
 	// but don't override token-based matching which provides better specificity
@@ -292,8 +410,115 @@ func CalculateSimilarity(s1, s2 string) float64 {
 	return tokenScore
 }
 
+const (
+	// maxFuzzyTokenDistance is the largest edit distance treated as a near-miss token
+	maxFuzzyTokenDistance = 2
+	// minFuzzyTokenLength avoids fuzzy matching short tokens like "it" or "fp8"
+	minFuzzyTokenLength = 4
+)
+
+// fuzzyTokenMatches pairs up tokens left unmatched by the exact pass whose
+// Levenshtein distance is at most maxFuzzyTokenDistance, and returns the sum of
+// their similarities (1 - distance/length). Tokens containing digits are never
+// fuzzy-matched, so versions and sizes (3v1 vs 3v3, 8b vs 7b) must match exactly.
+// Candidates are assigned best-first with a deterministic tie-break so the result
+// is symmetric in its arguments.
+func fuzzyTokenMatches(s1Tokens, s2Tokens []string, usedS1, usedS2 map[int]bool) float64 {
+	type candidate struct {
+		i, j       int
+		similarity float64
+	}
+
+	var candidates []candidate
+	for i, token1 := range s1Tokens {
+		if usedS1[i] || !isFuzzyMatchable(token1) {
+			continue
+		}
+		for j, token2 := range s2Tokens {
+			if usedS2[j] || !isFuzzyMatchable(token2) {
+				continue
+			}
+			distance := levenshteinDistance(token1, token2)
+			if distance == 0 || distance > maxFuzzyTokenDistance {
+				continue
+			}
+			longest := max(len([]rune(token1)), len([]rune(token2)))
+			candidates = append(candidates, candidate{i, j, 1.0 - float64(distance)/float64(longest)})
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		ca, cb := candidates[a], candidates[b]
+		if ca.similarity != cb.similarity {
+			return ca.similarity > cb.similarity
+		}
+		lowA, highA := orderedPair(s1Tokens[ca.i], s2Tokens[ca.j])
+		lowB, highB := orderedPair(s1Tokens[cb.i], s2Tokens[cb.j])
+		if lowA != lowB {
+			return lowA < lowB
+		}
+		return highA < highB
+	})
+
+	total := 0.0
+	for _, c := range candidates {
+		if usedS1[c.i] || usedS2[c.j] {
+			continue
+		}
+		usedS1[c.i] = true
+		usedS2[c.j] = true
+		total += c.similarity
+	}
+	return total
+}
+
+// isFuzzyMatchable reports whether a token is long enough and free of digits
+func isFuzzyMatchable(token string) bool {
+	if len([]rune(token)) < minFuzzyTokenLength {
+		return false
+	}
+	return !strings.ContainsAny(token, "0123456789")
+}
+
+// orderedPair returns a and b in lexicographic order
+func orderedPair(a, b string) (string, string) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// levenshteinDistance returns the number of single-rune edits needed to turn a into b
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
 // GenerateReadableDescription creates a human-readable description from a model name
 func GenerateReadableDescription(modelName string) string {
+	return GenerateReadableDescriptionForTasks(modelName, nil)
+}
+
+// GenerateReadableDescriptionForTasks creates a human-readable description from a model name,
+// describing the kind of model from its tasks and name keywords (e.g. whisper, embedding, clip)
+// and only defaulting to a language model when neither indicates another modality
+func GenerateReadableDescriptionForTasks(modelName string, tasks []string) string {
 	if modelName == "" {
 		return ""
 	}
@@ -373,21 +598,207 @@ func GenerateReadableDescription(modelName string) string {
 
 	description := strings.Join(result, " ")
 
-	// Add appropriate suffix based on model type
-	if strings.Contains(strings.ToLower(description), "instruct") {
-		description += " - An instruction-tuned language model"
-	} else if strings.Contains(strings.ToLower(description), "chat") {
-		description += " - A conversational AI model"
-	} else if strings.Contains(strings.ToLower(description), "base") {
-		description += " - A foundation language model"
-	} else {
-		description += " - A large language model"
+	return description + modelTypeSuffix(description, tasks)
+}
+
+// Tasks that identify non-language models for modelTypeSuffix
+var (
+	speechTasks    = []string{"automatic-speech-recognition", "text-to-speech", "audio-classification"}
+	embeddingTasks = []string{"feature-extraction", "sentence-similarity"}
+	rankingTasks   = []string{"text-ranking"}
+	visionTasks    = []string{"image-classification", "image-to-text", "image-text-to-text", "image-to-image", "object-detection", "zero-shot-image-classification"}
+)
+
+// modelTypeSuffix returns the " - A ... model" suffix describing a model, checking speech,
+// embedding, reranking and vision models before the language model variants
+func modelTypeSuffix(description string, tasks []string) string {
+	words := strings.Fields(strings.ToLower(description))
+	hasWord := func(prefixes ...string) bool {
+		for _, word := range words {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(word, prefix) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	hasTask := func(candidates []string) bool {
+		for _, task := range tasks {
+			if slices.Contains(candidates, strings.ToLower(strings.TrimSpace(task))) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case hasTask(speechTasks) || hasWord("whisper", "speech", "wav2vec"):
+		return " - A speech model"
+	case hasTask(embeddingTasks) || hasWord("embed"):
+		return " - A text embedding model"
+	case hasTask(rankingTasks) || hasWord("rerank"):
+		return " - A text reranking model"
+	case hasTask(visionTasks) || hasWord("clip", "vision", "siglip"):
+		return " - A vision model"
+	case hasWord("instruct"):
+		return " - An instruction-tuned language model"
+	case hasWord("chat"):
+		return " - A conversational AI model"
+	case hasWord("base"):
+		return " - A foundation language model"
+	default:
+		return " - A large language model"
 	}
+}
 
-	return description
+// defaultTaskMap maps lowercase task descriptions to standard task categories
+var defaultTaskMap = map[string]string{
+	// Text generation tasks
+	"text generation":   "text-generation",
+	"text-generation":   "text-generation",
+	"language modeling": "text-generation",
+	"conversation":      "text-generation",
+	"conversational":    "text-generation",
+	"chat":              "text-generation",
+	"chatbot":           "text-generation",
+	"dialogue":          "text-generation",
+	"code generation":   "text-generation",
+	"coding":            "text-generation",
+	"programming":       "text-generation",
+	"completion":        "text-generation",
+	"writing":           "text-generation",
+	"creative writing":  "text-generation",
+	"storytelling":      "text-generation",
+
+	// Classification tasks
+	"text classification": "text-classification",
+	"text-classification": "text-classification",
+	"classification":      "text-classification",
+	"sentiment analysis":  "text-classification",
+	"sentiment":           "text-classification",
+	"categorization":      "text-classification",
+	"labeling":            "text-classification",
+
+	// Question answering
+	"question answering":    "question-answering",
+	"question-answering":    "question-answering",
+	"qa":                    "question-answering",
+	"q&a":                   "question-answering",
+	"question and answer":   "question-answering",
+	"information retrieval": "question-answering",
+	"search":                "question-answering",
+
+	// Image tasks
+	"image classification":      "image-classification",
+	"image-classification":      "image-classification",
+	"image captioning":          "image-to-text",
+	"image-to-text":             "image-to-text",
+	"image description":         "image-to-text",
+	"visual question answering": "image-text-to-text",
+	"image-text-to-text":        "image-text-to-text",
+	"image-to-image":            "image-to-image",
+
+	// Embedding and reranking tasks (retrieval models)
+	"feature extraction":    "feature-extraction",
+	"feature-extraction":    "feature-extraction",
+	"embedding":             "feature-extraction",
+	"embeddings":            "feature-extraction",
+	"text embedding":        "feature-extraction",
+	"sentence embedding":    "feature-extraction",
+	"sentence-transformers": "sentence-similarity",
+	"sentence similarity":   "sentence-similarity",
+	"sentence-similarity":   "sentence-similarity",
+	"text ranking":          "text-ranking",
+	"text-ranking":          "text-ranking",
+	"ranking":               "text-ranking",
+	"rerank":                "text-ranking",
+	"reranking":             "text-ranking",
+	"re-ranking":            "text-ranking",
+	"reranker":              "text-ranking",
+	"cross-encoder":         "text-ranking",
+	"cross encoder":         "text-ranking",
+
+	// Other specific tasks
+	"any-to-any":     "any-to-any",
+	"text-to-video":  "text-to-video",
+	"video-to-video": "video-to-video",
 }
 
-// NormalizeTask normalizes a task description to standard task categories
+// taskMap is defaultTaskMap merged with any --task-map overrides; taskPatterns holds its keys
+// longest first so partial matches prefer the most specific pattern
+var (
+	taskMap       = defaultTaskMap
+	taskOverrides = map[string]string{}
+	taskPatterns  = sortedTaskPatterns(defaultTaskMap)
+)
+
+// DefaultTaskMap returns a copy of the built-in task normalization map
+func DefaultTaskMap() map[string]string {
+	return maps.Clone(defaultTaskMap)
+}
+
+// SetTaskMapOverrides merges custom task mappings over the built-in defaults for NormalizeTask.
+// Keys are matched case-insensitively; passing nil restores the defaults.
+func SetTaskMapOverrides(overrides map[string]string) {
+	merged := maps.Clone(defaultTaskMap)
+	normalized := make(map[string]string, len(overrides))
+	for key, value := range overrides {
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			continue
+		}
+		merged[key] = value
+		normalized[key] = value
+	}
+	taskMap = merged
+	taskOverrides = normalized
+	taskPatterns = sortedTaskPatterns(merged)
+}
+
+// TaskOverride returns the custom mapping for a task, if one was configured with SetTaskMapOverrides
+func TaskOverride(task string) (string, bool) {
+	normalized, ok := taskOverrides[strings.ToLower(strings.TrimSpace(task))]
+	return normalized, ok
+}
+
+// TaskOverrideKeys returns the task descriptions that have custom mappings, sorted
+func TaskOverrideKeys() []string {
+	return slices.Sorted(maps.Keys(taskOverrides))
+}
+
+// ApplyTaskOverrides replaces tasks that have a custom mapping and drops duplicates,
+// leaving other tasks unchanged
+func ApplyTaskOverrides(tasks []string) []string {
+	if len(taskOverrides) == 0 {
+		return tasks
+	}
+	result := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if normalized, ok := TaskOverride(task); ok {
+			task = normalized
+		}
+		if !slices.Contains(result, task) {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+func sortedTaskPatterns(m map[string]string) []string {
+	patterns := slices.Collect(maps.Keys(m))
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	return patterns
+}
+
+// NormalizeTask normalizes a task description to standard task categories using the
+// built-in mapping merged with any --task-map overrides
 func NormalizeTask(task string) string {
 	if task == "" {
 		return ""
@@ -395,73 +806,15 @@ func NormalizeTask(task string) string {
 
 	taskLower := strings.ToLower(strings.TrimSpace(task))
 
-	// Task normalization mapping
-	taskMap := map[string]string{
-		// Text generation tasks
-		"text generation":   "text-generation",
-		"text-generation":   "text-generation",
-		"language modeling": "text-generation",
-		"conversation":      "text-generation",
-		"conversational":    "text-generation",
-		"chat":              "text-generation",
-		"chatbot":           "text-generation",
-		"dialogue":          "text-generation",
-		"code generation":   "text-generation",
-		"coding":            "text-generation",
-		"programming":       "text-generation",
-		"completion":        "text-generation",
-		"writing":           "text-generation",
-		"creative writing":  "text-generation",
-		"storytelling":      "text-generation",
-
-		// Classification tasks
-		"text classification": "text-classification",
-		"text-classification": "text-classification",
-		"classification":      "text-classification",
-		"sentiment analysis":  "text-classification",
-		"sentiment":           "text-classification",
-		"categorization":      "text-classification",
-		"labeling":            "text-classification",
-
-		// Question answering
-		"question answering":    "question-answering",
-		"question-answering":    "question-answering",
-		"qa":                    "question-answering",
-		"q&a":                   "question-answering",
-		"question and answer":   "question-answering",
-		"information retrieval": "question-answering",
-		"search":                "question-answering",
-
-		// Image tasks
-		"image classification":      "image-classification",
-		"image-classification":      "image-classification",
-		"image captioning":          "image-to-text",
-		"image-to-text":             "image-to-text",
-		"image description":         "image-to-text",
-		"visual question answering": "image-text-to-text",
-		"image-text-to-text":        "image-text-to-text",
-		"image-to-image":            "image-to-image",
-
-		// Other specific tasks
-		"sentence similarity": "sentence-similarity",
-		"sentence-similarity": "sentence-similarity",
-		"text ranking":        "text-ranking",
-		"text-ranking":        "text-ranking",
-		"ranking":             "text-ranking",
-		"any-to-any":          "any-to-any",
-		"text-to-video":       "text-to-video",
-		"video-to-video":      "video-to-video",
-	}
-
 	// Check exact matches first
 	if normalized, exists := taskMap[taskLower]; exists {
 		return normalized
 	}
 
-	// Check for partial matches
-	for pattern, normalized := range taskMap {
+	// Check for partial matches, most specific pattern first
+	for _, pattern := range taskPatterns {
 		if strings.Contains(taskLower, pattern) {
-			return normalized
+			return taskMap[pattern]
 		}
 	}
 
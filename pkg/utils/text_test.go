@@ -109,6 +109,31 @@ func TestParseLanguageNames(t *testing.T) {
 			input:    "ENGLISH, Spanish",
 			expected: []string{"en", "es"},
 		},
+		{
+			name:     "mixed names and ISO codes",
+			input:    "English, es, Japanese",
+			expected: []string{"en", "es", "ja"},
+		},
+		{
+			name:     "additional languages",
+			input:    "Ukrainian, Greek and Romanian",
+			expected: []string{"uk", "el", "ro"},
+		},
+		{
+			name:     "regional and script variants",
+			input:    "pt-BR, zh-Hans, zh_cn",
+			expected: []string{"pt-BR", "zh-Hans", "zh-CN"},
+		},
+		{
+			name:     "duplicates removed",
+			input:    "English, en, english; EN",
+			expected: []string{"en"},
+		},
+		{
+			name:     "unknown codes ignored",
+			input:    "xx, en-gibberish, fr",
+			expected: []string{"fr"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -447,6 +472,11 @@ func TestCalculateSimilarity_Symmetry(t *testing.T) {
 			s1:   "registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct-quantized-w4a16:1.5",
 			s2:   "RedHatAI/Meta-Llama-3.1-8B-Instruct-quantized.w4a16",
 		},
+		{
+			name: "fuzzy tokens competing for the same match",
+			s1:   "model-quantised-quantise",
+			s2:   "model-quantized",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -462,6 +492,111 @@ func TestCalculateSimilarity_Symmetry(t *testing.T) {
 	}
 }
 
+func TestCalculateSimilarity_FuzzyTokens(t *testing.T) {
+	// oldScore is the exact-token-only score before near-miss tokens were
+	// counted as partial matches
+	tests := []struct {
+		name     string
+		s1       string
+		s2       string
+		oldScore float64
+		minScore float64
+		maxScore float64
+	}{
+		{
+			name:     "spelling variant of quantized",
+			s1:       "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct-quantised-w8a8:1.5",
+			s2:       "RedHatAI/granite-3.1-8b-instruct-quantized.w8a8",
+			oldScore: 0.8333,
+			minScore: 0.95,
+			maxScore: 0.99,
+		},
+		{
+			name:     "distil vs distill",
+			s1:       "registry.redhat.io/rhai/modelcar-deepseek-r1-distil-llama-8b:3.0",
+			s2:       "RedHatAI/DeepSeek-R1-Distill-Llama-8B",
+			oldScore: 0.8,
+			minScore: 0.95,
+			maxScore: 0.99,
+		},
+		{
+			name:     "typo in HuggingFace name",
+			s1:       "registry.redhat.io/rhai/modelcar-phi-4-reasoning-plus:3.0",
+			s2:       "microsoft/Phi-4-reasonning-plus",
+			oldScore: 0.5,
+			minScore: 0.7,
+			maxScore: 0.75,
+		},
+		{
+			name:     "exact match stays 1.0",
+			s1:       "registry.redhat.io/rhai/modelcar-mistral-small-24b-instruct-2501:3.0",
+			s2:       "mistralai/Mistral-Small-24B-Instruct-2501",
+			oldScore: 1.0,
+			minScore: 1.0,
+			maxScore: 1.0,
+		},
+		{
+			name:     "dotted vs hyphenated version already normalizes to exact",
+			s1:       "granite-3-1-8b",
+			s2:       "granite-3.1-8b",
+			oldScore: 1.0,
+			minScore: 1.0,
+			maxScore: 1.0,
+		},
+		{
+			name:     "version tokens are never fuzzy matched",
+			s1:       "registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.5",
+			s2:       "RedHatAI/Llama-3.3-8B-Instruct",
+			oldScore: 0.75,
+			minScore: 0.75,
+			maxScore: 0.75,
+		},
+		{
+			name:     "parameter size tokens are never fuzzy matched",
+			s1:       "registry.redhat.io/rhai/modelcar-gemma-2-9b-it:3.0",
+			s2:       "RedHatAI/gemma-2-2b-it",
+			oldScore: 0.6667,
+			minScore: 0.66,
+			maxScore: 0.67,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := CalculateSimilarity(tt.s1, tt.s2)
+			if score < tt.minScore || score > tt.maxScore {
+				t.Errorf("CalculateSimilarity(%q, %q) = %f (was %f), expected between %f and %f",
+					tt.s1, tt.s2, score, tt.oldScore, tt.minScore, tt.maxScore)
+			}
+			if score < tt.oldScore-0.0001 {
+				t.Errorf("CalculateSimilarity(%q, %q) = %f, should not score lower than before (%f)",
+					tt.s1, tt.s2, score, tt.oldScore)
+			}
+		})
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"quantized", "quantized", 0},
+		{"quantised", "quantized", 1},
+		{"distil", "distill", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshteinDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
 func TestCalculateSimilarity_VersionNumberDisambiguation(t *testing.T) {
 	// Test that version numbers are properly distinguished
 	// This addresses the bug where granite-3.3 was incorrectly matched to granite-3.1
@@ -542,3 +677,183 @@ func TestCalculateSimilarity_VersionNumberDisambiguation(t *testing.T) {
 		})
 	}
 }
+
+func TestStripMarkdownFormatting(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "Neural Magic", "Neural Magic"},
+		{"bold", "**Neural Magic**", "Neural Magic"},
+		{"underscore bold", "__Neural Magic__", "Neural Magic"},
+		{"link keeps text", "[Apache-2.0](https://www.apache.org/licenses/LICENSE-2.0)", "Apache-2.0"},
+		{"inline link in sentence", "Deploy with [vLLM](https://docs.vllm.ai/) for fast inference.", "Deploy with vLLM for fast inference."},
+		{"code span", "Use `granite-3.1-8b` for chat", "Use granite-3.1-8b for chat"},
+		{"bold inside link", "[**Apache-2.0**](https://www.apache.org/licenses/LICENSE-2.0)", "Apache-2.0"},
+		{"link inside bold", "**[Red Hat](https://www.redhat.com)**", "Red Hat"},
+		{"badge link", "[![License](https://img.shields.io/badge/license-apache-blue)](LICENSE) Granite", "License Granite"},
+		{"code inside link", "[`vllm serve`](https://docs.vllm.ai/)", "vllm serve"},
+		{"unclosed bold", "**Neural Magic", "Neural Magic"},
+		{"unclosed code span", "Use `granite for chat", "Use granite for chat"},
+		{"unclosed link is left alone", "[Apache-2.0](https://www.apache.org", "[Apache-2.0](https://www.apache.org"},
+		{"brackets without url", "[Apache-2.0] license", "[Apache-2.0] license"},
+		{"collapses whitespace", "  Granite \n **3.1**  ", "Granite 3.1"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripMarkdownFormatting(tt.input); got != tt.expected {
+				t.Errorf("StripMarkdownFormatting(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeTask_Overrides(t *testing.T) {
+	SetTaskMapOverrides(map[string]string{
+		"Embedding": "feature-extraction",
+		"guard":     "text-classification",
+		"chat":      "conversational",
+	})
+	t.Cleanup(func() { SetTaskMapOverrides(nil) })
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "custom task exact match", input: "embedding", expected: "feature-extraction"},
+		{name: "custom task is case-insensitive", input: "Guard", expected: "text-classification"},
+		{name: "custom task partial match", input: "safety guard model", expected: "text-classification"},
+		{name: "override replaces default", input: "chat", expected: "conversational"},
+		{name: "defaults still apply", input: "text generation", expected: "text-generation"},
+		{name: "keyword fallback still applies", input: "answering questions", expected: "question-answering"},
+		{name: "unknown task passes through unchanged", input: "Weather Forecasting", expected: "Weather Forecasting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTask(tt.input); got != tt.expected {
+				t.Errorf("NormalizeTask(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetTaskMapOverrides_NilRestoresDefaults(t *testing.T) {
+	SetTaskMapOverrides(map[string]string{"chat": "conversational"})
+	SetTaskMapOverrides(nil)
+
+	if got := NormalizeTask("chat"); got != "text-generation" {
+		t.Errorf("NormalizeTask(chat) = %q, want text-generation", got)
+	}
+	if keys := TaskOverrideKeys(); len(keys) != 0 {
+		t.Errorf("TaskOverrideKeys() = %v, want none", keys)
+	}
+	if got := DefaultTaskMap()["chat"]; got != "text-generation" {
+		t.Errorf("DefaultTaskMap()[chat] = %q, want text-generation", got)
+	}
+}
+
+func TestApplyTaskOverrides(t *testing.T) {
+	SetTaskMapOverrides(map[string]string{"embedding": "feature-extraction", "embeddings": "feature-extraction"})
+	t.Cleanup(func() { SetTaskMapOverrides(nil) })
+
+	got := ApplyTaskOverrides([]string{"embedding", "embeddings", "text-generation"})
+	expected := []string{"feature-extraction", "text-generation"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ApplyTaskOverrides() = %v, want %v", got, expected)
+	}
+}
+
+func TestNormalizeTask_RetrievalTasks(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "embedding", expected: "feature-extraction"},
+		{input: "Text Embeddings", expected: "feature-extraction"},
+		{input: "feature extraction", expected: "feature-extraction"},
+		{input: "sentence-transformers", expected: "sentence-similarity"},
+		{input: "sentence similarity", expected: "sentence-similarity"},
+		{input: "reranker", expected: "text-ranking"},
+		{input: "passage re-ranking", expected: "text-ranking"},
+		{input: "cross-encoder", expected: "text-ranking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeTask(tt.input); got != tt.expected {
+				t.Errorf("NormalizeTask(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateReadableDescriptionForTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		modelName string
+		tasks     []string
+		expected  string
+	}{
+		{
+			name:      "instruct language model",
+			modelName: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+			expected:  "Granite 3 1 8b Instruct - An instruction-tuned language model",
+		},
+		{
+			name:      "unknown model defaults to language model",
+			modelName: "granite-3-1-8b",
+			expected:  "Granite 3 1 8b - A large language model",
+		},
+		{
+			name:      "whisper is a speech model",
+			modelName: "whisper-large-v3",
+			expected:  "Whisper Large V3 - A speech model",
+		},
+		{
+			name:      "embedding keyword",
+			modelName: "granite-embedding-125m-english",
+			expected:  "Granite Embedding 125m English - A text embedding model",
+		},
+		{
+			name:      "embedding task without keyword",
+			modelName: "all-minilm-l6-v2",
+			tasks:     []string{"sentence-similarity"},
+			expected:  "All Minilm L6 V2 - A text embedding model",
+		},
+		{
+			name:      "reranker keyword",
+			modelName: "bge-reranker-v2-m3",
+			expected:  "Bge Reranker V2 M3 - A text reranking model",
+		},
+		{
+			name:      "clip is a vision model",
+			modelName: "clip-vit-base-patch32",
+			expected:  "Clip Vit Base Patch32 - A vision model",
+		},
+		{
+			name:      "vision task wins over instruct keyword",
+			modelName: "pixtral-12b-instruct",
+			tasks:     []string{"image-text-to-text"},
+			expected:  "Pixtral 12b Instruct - A vision model",
+		},
+		{
+			name:      "speech task",
+			modelName: "canary-1b",
+			tasks:     []string{"automatic-speech-recognition"},
+			expected:  "Canary 1b - A speech model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateReadableDescriptionForTasks(tt.modelName, tt.tasks); got != tt.expected {
+				t.Errorf("GenerateReadableDescriptionForTasks(%q, %v) = %q, want %q", tt.modelName, tt.tasks, got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -54,7 +60,15 @@ func ContainsMetadataField(content string, indicators []string) bool {
 	return false
 }
 
-// sanitizeManifestRef creates a valid directory name from manifestRef
+// manifestRefHashLength is the number of hex digits of the ref's sha256 appended by SanitizeManifestRef
+const manifestRefHashLength = 8
+
+// SanitizeManifestRef creates a unique directory name from manifestRef: the ref with characters
+// invalid in file names (/ \ : * ? " < > |) collapsed to single underscores, followed by "_" and
+// a short sha256 of the original ref, e.g. "registry.redhat.io/rhelai1/granite:1.0" becomes
+// "registry.redhat.io_rhelai1_granite_1.0_<hash>". The readable part alone is lossy ("a/b:c" and
+// "a/b/c" both become "a_b_c"); the hash keeps such refs apart. WriteManifestRef records the
+// original ref inside the directory, since the name cannot be reversed.
 func SanitizeManifestRef(manifestRef string) string {
 	// Replace invalid filesystem characters with underscores
 	// Invalid characters: / \ : * ? " < > |
@@ -68,7 +82,73 @@ func SanitizeManifestRef(manifestRef string) string {
 	// Remove leading/trailing underscores
 	sanitized = strings.Trim(sanitized, "_")
 
-	return sanitized
+	sum := sha256.Sum256([]byte(manifestRef))
+	hash := hex.EncodeToString(sum[:])[:manifestRefHashLength]
+	if sanitized == "" {
+		return hash
+	}
+	return sanitized + "_" + hash
+}
+
+// ManifestRefFile is the file in each model output directory that records the manifest ref the
+// directory was created for
+const ManifestRefFile = "manifest-ref.txt"
+
+// WriteManifestRef records manifestRef in modelDir/manifest-ref.txt, creating modelDir if needed
+func WriteManifestRef(modelDir, manifestRef string) error {
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", modelDir, err)
+	}
+	path := filepath.Join(modelDir, ManifestRefFile)
+	if err := os.WriteFile(path, []byte(manifestRef+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// ReadManifestRef returns the manifest ref recorded in modelDir by WriteManifestRef
+func ReadManifestRef(modelDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(modelDir, ManifestRefFile))
+	if err != nil {
+		return "", err
+	}
+	ref := strings.TrimSpace(string(data))
+	if ref == "" {
+		return "", fmt.Errorf("%s in %s is empty", ManifestRefFile, modelDir)
+	}
+	return ref, nil
+}
+
+// ModelOutputDirs returns the sorted names of the model directories under outputDir that hold a
+// models/metadata.yaml. Directories without a manifest-ref.txt are skipped: they were written
+// before directory names carried a hash of the ref, and would otherwise duplicate the model now
+// kept in its hashed directory.
+func ModelOutputDirs(outputDir string) ([]string, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	legacy := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		modelDir := filepath.Join(outputDir, entry.Name())
+		if _, err := os.Stat(filepath.Join(modelDir, "models", "metadata.yaml")); err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(modelDir, ManifestRefFile)); err != nil {
+			legacy++
+			continue
+		}
+		dirs = append(dirs, entry.Name())
+	}
+	if legacy > 0 {
+		log.Printf("  Warning: Ignoring %d output directories in %s without %s (written before the current layout; safe to delete)", legacy, outputDir, ManifestRefFile)
+	}
+	return dirs, nil
 }
 
 // parseDateToEpoch converts a date string to Unix epoch timestamp in milliseconds
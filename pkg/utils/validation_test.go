@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -103,37 +106,127 @@ func TestCleanExtractedValue(t *testing.T) {
 
 func TestSanitizeManifestRef(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    string
-		expected string
+		name           string
+		input          string
+		expectedPrefix string
 	}{
 		{
-			name:     "basic registry reference",
-			input:    "registry.redhat.io/rhelai1/modelcar-granite:1.0",
-			expected: "registry.redhat.io_rhelai1_modelcar-granite_1.0",
+			name:           "basic registry reference",
+			input:          "registry.redhat.io/rhelai1/modelcar-granite:1.0",
+			expectedPrefix: "registry.redhat.io_rhelai1_modelcar-granite_1.0_",
 		},
 		{
-			name:     "complex reference with multiple special chars",
-			input:    "registry.io/path/with:colon/and\\backslash?question",
-			expected: "registry.io_path_with_colon_and_backslash_question",
+			name:           "complex reference with multiple special chars",
+			input:          "registry.io/path/with:colon/and\\backslash?question",
+			expectedPrefix: "registry.io_path_with_colon_and_backslash_question_",
 		},
 		{
-			name:     "multiple underscores cleanup",
-			input:    "test///multiple\\\\\\slashes",
-			expected: "test_multiple_slashes",
+			name:           "multiple underscores cleanup",
+			input:          "test///multiple\\\\\\slashes",
+			expectedPrefix: "test_multiple_slashes_",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := SanitizeManifestRef(tt.input)
-			if result != tt.expected {
-				t.Errorf("SanitizeManifestRef() = %q, expected %q", result, tt.expected)
+			if !strings.HasPrefix(result, tt.expectedPrefix) || len(result) != len(tt.expectedPrefix)+manifestRefHashLength {
+				t.Errorf("SanitizeManifestRef() = %q, expected %q followed by a %d-digit hash", result, tt.expectedPrefix, manifestRefHashLength)
+			}
+			if again := SanitizeManifestRef(tt.input); again != result {
+				t.Errorf("SanitizeManifestRef() is not stable: %q then %q", result, again)
 			}
 		})
 	}
 }
 
+func TestSanitizeManifestRef_Collisions(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []string
+	}{
+		{
+			name: "tag separator versus path separator",
+			refs: []string{"registry.example.com/org/model:1.0", "registry.example.com/org/model/1.0"},
+		},
+		{
+			name: "different tags that share a readable form",
+			refs: []string{"registry.example.com/org/model:1.0", "registry.example.com/org/model_1.0", "registry.example.com/org/model::1.0"},
+		},
+		{
+			name: "registry port versus repository path",
+			refs: []string{"registry.example.com:5000/org/model:1.0", "registry.example.com/5000/org/model:1.0"},
+		},
+		{
+			name: "different registries with the same repository",
+			refs: []string{"registry.redhat.io/rhelai1/granite:1.0", "quay.io/rhelai1/granite:1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[string]string)
+			for _, ref := range tt.refs {
+				dir := SanitizeManifestRef(ref)
+				if other, ok := seen[dir]; ok {
+					t.Errorf("SanitizeManifestRef(%q) and SanitizeManifestRef(%q) both = %q", ref, other, dir)
+				}
+				seen[dir] = ref
+			}
+		})
+	}
+}
+
+func TestManifestRefFile(t *testing.T) {
+	ref := "registry.example.com/org/model:1.0"
+	modelDir := filepath.Join(t.TempDir(), SanitizeManifestRef(ref))
+
+	if _, err := ReadManifestRef(modelDir); err == nil {
+		t.Error("ReadManifestRef() expected error before the ref is written")
+	}
+	if err := WriteManifestRef(modelDir, ref); err != nil {
+		t.Fatalf("WriteManifestRef() error = %v", err)
+	}
+	got, err := ReadManifestRef(modelDir)
+	if err != nil {
+		t.Fatalf("ReadManifestRef() error = %v", err)
+	}
+	if got != ref {
+		t.Errorf("ReadManifestRef() = %q, want %q", got, ref)
+	}
+}
+
+func TestModelOutputDirs(t *testing.T) {
+	outputDir := t.TempDir()
+	current := SanitizeManifestRef("registry.example.com/org/model:1.0")
+	for _, dir := range []string{current, "registry.example.com_org_model_1.0", "no-metadata"} {
+		if err := os.MkdirAll(filepath.Join(outputDir, dir, "models"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := WriteManifestRef(filepath.Join(outputDir, current), "registry.example.com/org/model:1.0"); err != nil {
+		t.Fatal(err)
+	}
+	// The legacy directory has metadata but no manifest-ref.txt; no-metadata has neither
+	for _, dir := range []string{current, "registry.example.com_org_model_1.0"} {
+		if err := os.WriteFile(filepath.Join(outputDir, dir, "models", "metadata.yaml"), []byte("name: model\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dirs, err := ModelOutputDirs(outputDir)
+	if err != nil {
+		t.Fatalf("ModelOutputDirs() error = %v", err)
+	}
+	if len(dirs) != 1 || dirs[0] != current {
+		t.Errorf("ModelOutputDirs() = %v, want [%s]", dirs, current)
+	}
+
+	if _, err := ModelOutputDirs(filepath.Join(outputDir, "missing")); err == nil {
+		t.Error("ModelOutputDirs() expected error for a missing output directory")
+	}
+}
+
 func TestParseDateToEpoch(t *testing.T) {
 	tests := []struct {
 		name     string